@@ -9,17 +9,15 @@ import (
 	"syscall"
 
 	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/trace"
 	"github.com/comerc/budva43/app/util"
 )
 
 // TODO: вместо append использовать выделение памяти и назначение по индексу, когда размер массива известен
-// TODO: copy-once просто отклоняет редактирование (как и при форвардинге), а хотелось бы отправлять копию со ссылкой на сообщение предыдущей редакции (только для копирования, т.к. при форвардинге невозможно добавить ссылку)
-// TODO: вместо copy-once -> save-revision; и выполнять не только при копировании, но и при форвардинге (или удалять старое и вставлять новое, или не удалять старое и вставлять новое)
 // TODO: сделать task init - для установки всех зависимостей
 // TODO: pkg/tdlib-ubuntu - в какой папке лучше держать?
 // TODO: при старте проверять новые необработанные сообщения в чатах
 // TODO: реализовать InlineKeyboardButton (см. README.md -> examples )
-// TODO: реализовать storage.BackupEnabled
 // TODO: проверить на Race Condition
 // TODO: заменить примитивы синхронизации на [CSP](../go-secrets/README_V2/Communicating Sequential Processes (CSP) и потокобезопасный счетчик.md)
 // TODO: проверить весь перенесённый код на early return
@@ -65,6 +63,14 @@ func (a *App) Run(runFunc RunFunc) error { //nolint:error_log_or_return
 	// Настраиваем обработку сигналов остановки
 	a.setupSignalHandler(cancel)
 
+	// Включаем экспорт спанов (если задан коллектор)
+	tracing, err := trace.Setup(ctx)
+	if err != nil {
+		err = log.WrapError(err) // внешняя ошибка
+		return err
+	}
+	defer a.gracefulShutdown(tracing)
+
 	// Запускаем компоненты приложения
 	err = runFunc(ctx, cancel, a.gracefulShutdown, func() {
 		// Ожидаем завершения контекста
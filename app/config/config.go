@@ -16,8 +16,10 @@ type (
 		Telegram  telegram
 		Web       web
 		Grpc      grpc
+		Metrics   metrics
+		Tracing   tracing
 		Engine    domain.EngineConfig
-		// Reports reports
+		Reports   reports
 	}
 
 	// Общие настройки приложения
@@ -36,10 +38,25 @@ type (
 	// Настройки хранилища данных
 	storage struct {
 		Log               storageLog
+		Backend           string // badger | sqlite
 		DatabaseDirectory string
-		// BackupEnabled     bool
-		// BackupDirectory string
-		// BackupFrequency string
+		SQLiteDirectory   string
+		Retention         time.Duration // 0 - бессрочно
+		SweepInterval     time.Duration // 0 - без очистки осиротевших ключей
+		StatsRetention    time.Duration // срок хранения почасовой статистики (0 - бессрочно)
+		JournalRetention  time.Duration // срок хранения журнала решений по сообщениям (0 - без журнала)
+		BackupEnabled     bool
+		BackupDirectory   string
+		BackupFrequency   time.Duration
+		BackupCount       int  // количество хранимых копий (0 - все)
+		BackupCompress    bool // gzip
+		// ключ шифрования в hex (16, 24 или 32 байта) - только из переменной окружения
+		// BUDVA43__STORAGE__ENCRYPTION_KEY или из файла, но не из конфигурации
+		EncryptionKey         string
+		EncryptionKeyFile     string
+		PrevEncryptionKey     string // прежний ключ: база перешифровывается текущим при запуске
+		PrevEncryptionKeyFile string
+		IndexCacheSize        int // MB, кэш индексов обязателен при шифровании
 	}
 
 	// Настройки логирования хранилища данных
@@ -56,6 +73,8 @@ type (
 		PhoneNumber         string
 		DatabaseDirectory   string
 		FilesDirectory      string
+		MediaCacheDirectory string
+		MediaCacheMaxSize   int // MB
 		SystemLanguageCode  string
 		DeviceModel         string
 		SystemVersion       string
@@ -74,6 +93,7 @@ type (
 	web struct {
 		Host            string
 		Port            string
+		EnginePort      string // пусто - engine не запускает GraphQL (статистика)
 		ReadTimeout     time.Duration
 		WriteTimeout    time.Duration
 		ShutdownTimeout time.Duration
@@ -83,14 +103,32 @@ type (
 	grpc struct {
 		Host              string
 		Port              string
+		EnginePort        string // пусто - engine не запускает gRPC (whence)
 		ConnectionTimeout time.Duration
 	}
 
-	// Настройки отчетов
-	// report struct {
-	// 	Template string
-	// 	For      []domain.ChatId
-	// }
+	// Настройки Prometheus (/metrics)
+	metrics struct {
+		Host       string
+		Port       string // пусто - facade не отдаёт метрики
+		EnginePort string // пусто - engine не отдаёт метрики
+	}
+
+	// Настройки трассировки OpenTelemetry
+	tracing struct {
+		Endpoint    string // OTLP/gRPC коллектора; пусто - идентификаторы трасс только в логах
+		Insecure    bool
+		ServiceName string
+	}
+
+	// Настройки отчетов по статистике пересылок
+	reports struct {
+		Schedule string        // cron: "минуты часы дни месяцы дни-недели"; пусто - без отчетов
+		Period   time.Duration // период статистики, заканчивающийся временем отправки
+		Template string        // text/template в Markdown V2, данные - domain.ReportData
+		TopCount int           // количество источников в топе
+		For      []domain.ChatId
+	}
 )
 
 var (
@@ -101,6 +139,8 @@ var (
 	Telegram  = &cfg.Telegram
 	Web       = &cfg.Web
 	Grpc      = &cfg.Grpc
+	Metrics   = &cfg.Metrics
+	Tracing   = &cfg.Tracing
 	Engine    = &cfg.Engine
-	// Reports = &cfg.Reports
+	Reports   = &cfg.Reports
 )
@@ -50,14 +50,25 @@ func setDefaultConfig(config *config) {
 	config.Telegram.LogDirectory = logDir
 	config.Telegram.DatabaseDirectory = filepath.Join(util.ProjectRoot, ".data", subproject, "telegram", "db")
 	config.Telegram.FilesDirectory = filepath.Join(util.ProjectRoot, ".data", subproject, "telegram", "files")
+	config.Telegram.MediaCacheDirectory = filepath.Join(util.ProjectRoot, ".data", subproject, "telegram", "media_cache")
+	config.Telegram.MediaCacheMaxSize = 512 // MB
 
 	config.Storage.Log.Level = slog.LevelInfo
 	config.Storage.Log.Directory = logDir
 	config.Storage.Log.MaxFileSize = 10 // MB
+	config.Storage.Backend = "badger"
 	config.Storage.DatabaseDirectory = filepath.Join(util.ProjectRoot, ".data", subproject, "badger", "db")
-	// config.Storage.BackupEnabled = false
-	// config.Storage.BackupDirectory = filepath.Join(projectRoot, ".data", subproject, "badger", "backup")
-	// config.Storage.BackupFrequency = "daily"
+	config.Storage.SQLiteDirectory = filepath.Join(util.ProjectRoot, ".data", subproject, "sqlite")
+	config.Storage.Retention = 0
+	config.Storage.SweepInterval = time.Hour
+	config.Storage.StatsRetention = 90 * 24 * time.Hour
+	config.Storage.JournalRetention = 7 * 24 * time.Hour
+	config.Storage.BackupEnabled = false
+	config.Storage.BackupDirectory = filepath.Join(util.ProjectRoot, ".data", subproject, "badger", "backup")
+	config.Storage.BackupFrequency = 24 * time.Hour
+	config.Storage.BackupCount = 7
+	config.Storage.BackupCompress = true
+	config.Storage.IndexCacheSize = 100 // MB
 
 	config.Web.Host = "localhost" // V6 supported
 	config.Web.Port = "7070"
@@ -65,7 +76,53 @@ func setDefaultConfig(config *config) {
 	config.Web.WriteTimeout = 15 * time.Second
 	config.Web.ShutdownTimeout = 5 * time.Second
 
+	config.Reports.Schedule = ""
+	config.Reports.Period = 24 * time.Hour
+	config.Reports.Template = defaultReportTemplate
+	config.Reports.TopCount = 5
+
 	config.Grpc.Host = "localhost" // V6 supported
 	config.Grpc.Port = "50051"
 	config.Grpc.ConnectionTimeout = 15 * time.Second
+
+	config.Metrics.Host = "localhost" // V6 supported
+	config.Metrics.Port = "9430"
+	config.Metrics.EnginePort = "9431"
+
+	config.Tracing.Endpoint = ""
+	config.Tracing.Insecure = true
+	config.Tracing.ServiceName = "budva43-" + subproject
 }
+
+// defaultReportTemplate шаблон отчета по умолчанию (Markdown V2), данные - domain.ReportData
+const defaultReportTemplate = `📊 *Статистика {{ .From.Format "02.01 15:04" | escape }} – {{ .To.Format "02.01 15:04" | escape }}*
+Отобрал: *{{ .Forwarded }}* из *{{ .Viewed }}*
+{{- if .Destinations }}
+
+*Получатели*
+{{- range .Destinations }}
+{{ escape .ChatId }}: {{ .Forwarded }} из {{ .Viewed }}
+{{- end }}
+{{- end }}
+{{- if .TopSources }}
+
+*Топ источников*
+{{- range .TopSources }}
+{{ escape .ChatId }}: {{ .Forwarded }}
+{{- end }}
+{{- end }}
+{{- if .Rules }}
+
+*Фильтры*
+{{- range .Rules }}
+{{ escape .ForwardRuleId }}: прошло {{ printf "%.0f" .HitRate }}% \({{ .Ok }}\), отсеяно {{ .Filtered }}
+{{- if .Protected }}, защищённых пропущено {{ .Protected }}{{ end }}
+{{- end }}
+{{- end }}
+{{- if .Failures }}
+
+*Ошибки доставки*
+{{- range .Failures }}
+{{ escape .ChatId }}: {{ .Failed }}
+{{- end }}
+{{- end }}`
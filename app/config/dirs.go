@@ -32,8 +32,10 @@ var dirPtrs = []*string{
 	&General.Log.Directory,
 	&Storage.Log.Directory,
 	&Storage.DatabaseDirectory,
-	// &Storage.BackupDirectory,
+	&Storage.SQLiteDirectory,
+	&Storage.BackupDirectory,
 	&Telegram.LogDirectory,
 	&Telegram.DatabaseDirectory,
 	&Telegram.FilesDirectory,
+	&Telegram.MediaCacheDirectory,
 }
@@ -1,13 +1,18 @@
 package config
 
 import (
+	"errors"
+	"io"
 	"log"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/robfig/cron/v3"
 	"github.com/spf13/viper"
 
+	"github.com/comerc/budva43/app/domain"
 	"github.com/comerc/budva43/app/util"
 )
 
@@ -39,12 +44,21 @@ func load() *config {
 	_ = viper.BindEnv("telegram.api-hash")
 	_ = viper.BindEnv("telegram.phone-number")
 	_ = viper.BindEnv("general.engine-config-file")
+	_ = viper.BindEnv("storage.encryption-key")
+	_ = viper.BindEnv("storage.prev-encryption-key")
 
 	// Читаем конфигурацию из файла
 	if err := viper.ReadInConfig(); err != nil {
 		log.Panic("ошибка чтения конфигурации: ", err)
 	}
 
+	// Ключи шифрования не должны попадать в файлы конфигурации под git
+	for _, key := range []string{"storage.encryption-key", "storage.prev-encryption-key"} {
+		if viper.InConfig(key) {
+			log.Panic("ключ шифрования задаётся через переменную окружения или файл ключа: ", key)
+		}
+	}
+
 	// Создаем конфигурацию с дефолтными значениями
 	config := &config{}
 	setDefaultConfig(config)
@@ -54,8 +68,56 @@ func load() *config {
 		log.Panic("ошибка разбора конфигурации: ", err)
 	}
 
+	// SQLite хранит базу без шифрования: ключ шифрования с ним - ошибка настройки
+	if err := validateStorage(&config.Storage); err != nil {
+		log.Panic("ошибка настройки хранилища: ", err)
+	}
+
+	// Шаблон и расписание отчетов проверяются при загрузке, а не при первой отправке
+	if err := validateReports(&config.Reports); err != nil {
+		log.Panic("ошибка настройки отчетов: ", err)
+	}
+
 	// Преобразуем относительные пути в абсолютные
 	transformDirs()
 
 	return config
 }
+
+// validateStorage проверяет, что выбранное хранилище поддерживает заданные настройки
+func validateStorage(storage *storage) error {
+	if storage.Backend != "sqlite" {
+		return nil
+	}
+	if storage.EncryptionKey != "" || storage.EncryptionKeyFile != "" ||
+		storage.PrevEncryptionKey != "" || storage.PrevEncryptionKeyFile != "" {
+		return errors.New("sqlite хранит базу без шифрования: уберите ключ шифрования или выберите backend badger")
+	}
+	return nil
+}
+
+// validateReports проверяет расписание и шаблон отчетов на пробных данных
+func validateReports(reports *reports) error {
+	if reports.Schedule == "" {
+		return nil
+	}
+	if _, err := cron.ParseStandard(reports.Schedule); err != nil {
+		return err
+	}
+	if reports.Period <= 0 {
+		return errors.New("период отчета должен быть положительным")
+	}
+	tmpl, err := util.NewMarkdownTemplate("report", reports.Template)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	return tmpl.Execute(io.Discard, &domain.ReportData{
+		From:         now.Add(-reports.Period),
+		To:           now,
+		Destinations: []*domain.ReportDestination{{}},
+		TopSources:   []*domain.ReportSource{{}},
+		Rules:        []*domain.ReportRule{{}},
+		Failures:     []*domain.ReportFailure{{}},
+	})
+}
@@ -0,0 +1,14 @@
+package domain
+
+type BridgeId = string
+
+// Bridge представляет правило обратной пересылки (мост):
+// ответы на копии в получателе-зеркале отправляются в источник ответами на оригиналы
+type Bridge struct {
+	// Id уникальный идентификатор моста - обогощаем при загрузке
+	Id BridgeId
+	// From идентификатор получателя-зеркала, в котором отвечают
+	From ChatId
+	// To идентификатор источника, в который отправляются ответы
+	To ChatId
+}
@@ -5,3 +5,13 @@ type ChatId = int64
 
 // MediaAlbumKey ключ для пересылаемого медиа-альбома
 type MediaAlbumKey = string
+
+// ChatMessage ссылка на сообщение в чате
+type ChatMessage struct {
+	// ForwardRuleId правило, по которому отправлено сообщение (пусто, если неизвестно)
+	ForwardRuleId ForwardRuleId
+	// ChatId идентификатор чата
+	ChatId ChatId
+	// MessageId идентификатор сообщения
+	MessageId int64
+}
@@ -7,6 +7,13 @@ type Destination struct {
 	ReplaceMyselfLinks *ReplaceMyselfLinks
 	// ReplaceFragments настройки для замены фрагментов текста
 	ReplaceFragments []*ReplaceFragment
+	// EditDiff настройки публикации изменений текста при редактировании оригинала
+	EditDiff *EditDiff
+	// Media настройки качества медиа при копировании
+	Media *MediaPolicy
+	// AutoTopics если true, то для каждого источника создаётся тема форума с его названием
+	// (для получателей без явно заданной темы)
+	AutoTopics bool
 }
 
 // ReplaceMyselfLinks настройки для замены ссылок на текущего бота
@@ -29,3 +36,36 @@ type ReplaceFragment struct {
 	// To текст для замены
 	To string
 }
+
+// EditDiff настройки публикации изменений текста при редактировании оригинала
+type EditDiff struct {
+	// Run если true, то при редактировании оригинала публикуется ответ с изменениями текста
+	Run bool
+	// Title заголовок ответа (markdown)
+	Title string
+	// InsteadOfEdit если true, то копия не редактируется, публикуется только ответ с изменениями
+	InsteadOfEdit bool
+}
+
+// EDIT_DIFF_TITLE заголовок ответа с изменениями текста по умолчанию
+const EDIT_DIFF_TITLE = "*Edited:*"
+
+// MediaPolicy настройки качества медиа при копировании
+type MediaPolicy struct {
+	// PhotoSize выбор размера фото
+	PhotoSize PhotoSizeMode
+	// MaxSide ограничение по большей стороне фото в пикселях (0 - без ограничения)
+	MaxSide int32
+	// MaxFileSize ограничение размера фото в байтах (0 - без ограничения)
+	MaxFileSize int64
+}
+
+// PhotoSizeMode выбор размера фото
+type PhotoSizeMode = string
+
+const (
+	// PhotoSizeSmallest наименьший размер (по умолчанию)
+	PhotoSizeSmallest PhotoSizeMode = "smallest"
+	// PhotoSizeLargest наибольший размер в пределах ограничений
+	PhotoSizeLargest PhotoSizeMode = "largest"
+)
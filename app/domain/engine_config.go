@@ -8,6 +8,8 @@ type EngineConfig struct {
 	Destinations map[ChatId]*Destination
 	// Правила форвардинга
 	ForwardRules map[ForwardRuleId]*ForwardRule
+	// Мосты для обратной пересылки ответов из получателей в источники
+	Bridges map[BridgeId]*Bridge
 	// Уникальные источники
 	UniqueSources map[ChatId]struct{} `mapstructure:"-"`
 	// Уникальные получатели
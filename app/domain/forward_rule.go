@@ -1,6 +1,9 @@
 package domain
 
-import "regexp"
+import (
+	"regexp"
+	"time"
+)
 
 type ForwardRuleId = string
 type FiltersMode = string
@@ -15,16 +18,39 @@ const (
 type ForwardRule struct {
 	// Id уникальный идентификатор правила - обогощаем при загрузке
 	Id ForwardRuleId
-	// From идентификатор чата-источника
+	// From идентификатор чата-источника (или chat_id/thread_id для фильтра по теме форума)
 	From ChatId
-	// To список идентификаторов чатов-получателей
+	// FromThreads если не пусто, то пересылаются только сообщения из указанных тем форума источника
+	FromThreads []int64
+	// To список идентификаторов чатов-получателей (или chat_id/thread_id для темы форума)
 	To []ChatId
+	// Threads темы форума для получателей To, Check и Other - обогощаем при загрузке;
+	// тема одна на чат, поэтому чат в разных ролях с разными темами отвергается при загрузке
+	Threads map[ChatId]int64
 	// SendCopy если true, то отправляет копию сообщения вместо пересылки
 	SendCopy bool
 	// CopyOnce если true, то сообщение копируется однократно без синхронизации при редактировании
+	// (устарело: вместо CopyOnce следует применять Revisions)
 	CopyOnce bool
+	// Revisions настройки сохранения редакций сообщения
+	Revisions *Revisions
+	// SyncForwards если true, то при редактировании оригинала пересланное сообщение
+	// удаляется и пересылается заново (только для форвардинга, без SendCopy)
+	SyncForwards bool
+	// Fallback политика для защищённого содержимого (без SendCopy и без CanBeSaved):
+	// skip (по умолчанию), text, note или check
+	Fallback FallbackPolicy
 	// Indelible если true, то сообщение не удаляется при удалении оригинала
 	Indelible bool
+	// OnDelete действие с копиями при удалении оригинала: delete (по умолчанию) или tombstone
+	OnDelete OnDeleteAction
+	// Tombstone настройки пометки копий удалённого оригинала (для OnDelete: tombstone)
+	Tombstone *Tombstone
+	// ReplyContext настройки контекста ответа, если исходное сообщение не связано с копией в получателе
+	ReplyContext *ReplyContext
+	// Retention срок хранения связей сообщений правила (0 - как в config.Storage.Retention);
+	// общие ключи источника и получателей хранятся по наибольшему сроку среди правил
+	Retention time.Duration
 	// Exclude регулярное выражение для исключения сообщений
 	Exclude string
 	// Include регулярное выражение для включения сообщений
@@ -37,6 +63,101 @@ type ForwardRule struct {
 	Check ChatId
 }
 
+type RevisionsMode = string
+
+const (
+	// RevisionsKeep при форвардинге старая редакция остаётся, новая пересылается
+	RevisionsKeep RevisionsMode = "keep"
+	// RevisionsReplace при форвардинге старая редакция удаляется, новая пересылается
+	RevisionsReplace RevisionsMode = "replace"
+)
+
+// Revisions представляет настройки сохранения редакций сообщения
+type Revisions struct {
+	// Run если true, то каждая редакция оригинала отправляется новым сообщением
+	Run bool
+	// Title метка редакции (с поддержкой разметки), где %d - номер редакции
+	Title string
+	// Mode режим для форвардинга (без копирования): keep или replace
+	Mode RevisionsMode
+}
+
+// REVISION_TITLE метка редакции сообщения
+const REVISION_TITLE = "Revision \\#%d"
+
+type FallbackPolicy = string
+
+const (
+	// FallbackSkip защищённое сообщение пропускается (учитывается в статистике)
+	FallbackSkip FallbackPolicy = "skip"
+	// FallbackText копируется только текст защищённого сообщения
+	FallbackText FallbackPolicy = "text"
+	// FallbackNote копируется текст с пометкой о защищённом содержимом
+	FallbackNote FallbackPolicy = "note"
+	// FallbackCheck текст с пометкой о защищённом содержимом отправляется в Check
+	FallbackCheck FallbackPolicy = "check"
+)
+
+// PROTECTED_CONTENT_NOTE пометка о защищённом содержимом
+const PROTECTED_CONTENT_NOTE = "\\[protected content\\]"
+
+type OnDeleteAction = string
+
+const (
+	// OnDeleteDelete копии удаляются вместе с оригиналом
+	OnDeleteDelete OnDeleteAction = "delete"
+	// OnDeleteTombstone копии помечаются как удалённые источником (форварды удаляются)
+	OnDeleteTombstone OnDeleteAction = "tombstone"
+)
+
+type TombstoneMode = string
+
+const (
+	// TombstoneMarker перед текстом копии добавляется пометка
+	TombstoneMarker TombstoneMode = "marker"
+	// TombstoneStrike текст копии зачёркивается
+	TombstoneStrike TombstoneMode = "strike"
+)
+
+// Tombstone представляет настройки пометки копий удалённого оригинала
+type Tombstone struct {
+	// Mode способ пометки: marker (по умолчанию) или strike
+	Mode TombstoneMode
+	// Title пометка (с поддержкой разметки) для Mode: marker
+	Title string
+}
+
+// TOMBSTONE_TITLE пометка копии удалённого оригинала
+const TOMBSTONE_TITLE = "🗑 _deleted by source_"
+
+type ReplyContextMode = string
+
+const (
+	// ReplyContextQuote перед текстом добавляется цитата исходного сообщения (и ссылка, если доступна)
+	ReplyContextQuote ReplyContextMode = "quote"
+	// ReplyContextLink перед текстом добавляется только ссылка (цитата, если ссылка недоступна)
+	ReplyContextLink ReplyContextMode = "link"
+)
+
+// ReplyContext представляет настройки контекста ответа:
+// для копии контекст добавляется перед текстом, для форварда отправляется отдельным сообщением
+type ReplyContext struct {
+	// Run если true, то контекст ответа сохраняется
+	Run bool
+	// Mode способ: quote (по умолчанию) или link
+	Mode ReplyContextMode
+	// Length максимальная длина цитаты в символах
+	Length int
+	// Title заголовок контекста (с поддержкой разметки)
+	Title string
+}
+
+// REPLY_CONTEXT_TITLE заголовок контекста ответа
+const REPLY_CONTEXT_TITLE = "↩️ _in reply to_"
+
+// REPLY_CONTEXT_LENGTH максимальная длина цитаты по умолчанию
+const REPLY_CONTEXT_LENGTH = 200
+
 // SubmatchRule представляет правило для работы с подстроками в сообщениях
 type SubmatchRule struct {
 	// Regexp регулярное выражение для поиска подстрок
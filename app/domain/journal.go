@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// Journal журнал решений по исходному сообщению (для медиа-альбома - по первому сообщению)
+type Journal struct {
+	ChatId    ChatId
+	MessageId int64
+	Date      time.Time // время первой записи
+	Rules     []*JournalRule
+}
+
+// JournalVerdict решение по правилу для сообщения из его источника
+type JournalVerdict = string
+
+const (
+	// JournalEvaluated сообщение проверено фильтрами правила
+	JournalEvaluated JournalVerdict = "evaluated"
+	// JournalOtherThread сообщение не из отслеживаемой темы форума (ForwardRule.FromThreads)
+	JournalOtherThread JournalVerdict = "other_thread"
+	// JournalProtected защищённое содержимое пропущено (ForwardRule.Fallback = skip)
+	JournalProtected JournalVerdict = "protected"
+)
+
+// JournalRule решение правила
+type JournalRule struct {
+	ForwardRuleId ForwardRuleId
+	Verdict       JournalVerdict
+	FiltersMode   FiltersMode
+	Pattern       string // сработавший фильтр, например "exclude: реклама"; пусто - без фильтра
+	Destinations  []*JournalDestination
+}
+
+// JournalDestination исход для получателя правила
+type JournalDestination struct {
+	ChatId     ChatId
+	Outcome    StatsOutcome
+	MessageIds []int64 // отправленные сообщения: постоянные, если отправка подтверждена, иначе временные
+	Error      string
+}
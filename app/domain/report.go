@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// ReportData данные шаблона отчета за период From..To
+type ReportData struct {
+	From         time.Time
+	To           time.Time
+	Forwarded    int64 // сумма по получателям
+	Viewed       int64 // сумма по получателям
+	Destinations []*ReportDestination
+	TopSources   []*ReportSource
+	Rules        []*ReportRule
+	Failures     []*ReportFailure
+}
+
+// ReportDestination счетчики получателя за период
+type ReportDestination struct {
+	ChatId    ChatId
+	Forwarded int64 // доставлено (ok, check, other)
+	Viewed    int64 // все исходы для получателя
+}
+
+// ReportSource источник с количеством доставленных сообщений
+type ReportSource struct {
+	ChatId    ChatId
+	Forwarded int64
+}
+
+// ReportRule исходы правила за период
+type ReportRule struct {
+	ForwardRuleId ForwardRuleId
+	Ok            int64
+	Check         int64
+	Other         int64
+	Filtered      int64
+	Deduped       int64
+	Protected     int64
+	Failed        int64
+	HitRate       float64 // доля прошедших фильтры, % от ok + filtered
+}
+
+// ReportFailure ошибки доставки получателю за период
+type ReportFailure struct {
+	ChatId ChatId
+	Failed int64
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // Source представляет настройки источника сообщений
 type Source struct {
 	// Id идентификатор чата-источника - обогощаем при загрузке
@@ -14,10 +16,16 @@ type Source struct {
 	AutoAnswer bool
 	// DeleteSystemMessages настройки удаления системных сообщений
 	DeleteSystemMessages bool
+	// SyncPins если true, то закрепление и открепление сообщений повторяется для копий в получателях
+	SyncPins bool
+	// PinNotification если true, то закрепление копий сопровождается уведомлением (по умолчанию без уведомления)
+	PinNotification bool
 	// Prev настройки ссылки на предыдущую версию сообщения
 	Prev *Prev
 	// Next настройки ссылки на следующую версию сообщения
 	Next *Next
+	// MediaAlbumWait время ожидания следующей части медиа-альбома (0 - по умолчанию)
+	MediaAlbumWait time.Duration
 }
 
 // Translate представляет настройки перевода сообщений
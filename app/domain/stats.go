@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// StatsOutcome исход обработки сообщения правилом для получателя
+type StatsOutcome = string
+
+const (
+	// StatsOk сообщение прошло фильтры и доставлено получателю
+	StatsOk StatsOutcome = FiltersOK
+	// StatsCheck сообщение доставлено в чат проверки (Check)
+	StatsCheck StatsOutcome = FiltersCheck
+	// StatsOther сообщение доставлено в чат остального (Other)
+	StatsOther StatsOutcome = FiltersOther
+	// StatsFiltered сообщение не прошло фильтры правила и не доставлено получателю
+	StatsFiltered StatsOutcome = "filtered"
+	// StatsFailed доставка завершилась ошибкой
+	StatsFailed StatsOutcome = "failed"
+	// StatsDeduped получатель уже получил сообщение по другому правилу
+	StatsDeduped StatsOutcome = "deduped"
+	// StatsProtected защищённое содержимое пропущено по политике Fallback (skip)
+	StatsProtected StatsOutcome = "protected"
+)
+
+// StatsGroupBy измерение группировки статистики для top-N
+type StatsGroupBy = string
+
+const (
+	StatsGroupByRule        StatsGroupBy = "rule"
+	StatsGroupBySource      StatsGroupBy = "source"
+	StatsGroupByDestination StatsGroupBy = "destination"
+	StatsGroupByOutcome     StatsGroupBy = "outcome"
+)
+
+// Stats счетчик сообщений правило × источник × получатель × исход за час;
+// в top-N заполнены только измерение группировки и Count
+type Stats struct {
+	Hour          time.Time // начало часа в UTC
+	ForwardRuleId ForwardRuleId
+	SrcChatId     ChatId
+	DstChatId     ChatId
+	Outcome       StatsOutcome
+	Count         int64
+}
+
+// StatsFilter отбор статистики; пустые поля - без отбора
+type StatsFilter struct {
+	From          time.Time // включительно
+	To            time.Time // не включительно; нулевое - до текущего часа включительно
+	ForwardRuleId ForwardRuleId
+	SrcChatId     ChatId
+	DstChatId     ChatId
+	Outcome       StatsOutcome
+}
@@ -0,0 +1,35 @@
+package domain
+
+// StorageIssueKind категория несогласованной связи сообщений
+type StorageIssueKind string
+
+const (
+	// StorageIssueCopiedWithoutNew копия в copiedMsgIds с временным Id, для которого нет newMsgId
+	StorageIssueCopiedWithoutNew StorageIssueKind = "copiedWithoutNewMsgId"
+	// StorageIssueTmpWithoutCopied tmpMsgId копии, на которую не ссылается ни одна прямая связь
+	StorageIssueTmpWithoutCopied StorageIssueKind = "tmpMsgIdWithoutCopied"
+	// StorageIssueNewWithoutTmp newMsgId без обратного tmpMsgId
+	StorageIssueNewWithoutTmp StorageIssueKind = "newMsgIdWithoutTmpMsgId"
+)
+
+// StorageRepair исправление несогласованной связи по данным Telegram
+type StorageRepair string
+
+const (
+	// StorageRepairDelete сообщения-копии нет - связь удаляется
+	StorageRepairDelete StorageRepair = "delete"
+	// StorageRepairRestore сообщение-копия есть - недостающая связь восстанавливается
+	StorageRepairRestore StorageRepair = "restore"
+	// StorageRepairKeep сообщение-копия есть, но восстановить связь нечем
+	StorageRepairKeep StorageRepair = "keep"
+)
+
+// StorageIssue несогласованная связь сообщений
+type StorageIssue struct {
+	Kind         StorageIssueKind
+	Source       *ChatMessage // оригинал; nil, если неизвестен
+	ChatId       int64        // чат получателя
+	TmpMessageId int64
+	NewMessageId int64         // 0, если неизвестен
+	Repair       StorageRepair // пусто, если исправление не выполнялось
+}
@@ -0,0 +1,13 @@
+package domain
+
+// Whence представляет происхождение сообщения в получателе
+type Whence struct {
+	// ForwardRuleId правило, по которому отправлено сообщение (пусто, если найдено по ForwardInfo)
+	ForwardRuleId ForwardRuleId
+	// ChatId идентификатор чата-источника
+	ChatId ChatId
+	// MessageId идентификатор исходного сообщения
+	MessageId int64
+	// Link ссылка на исходное сообщение (пусто, если недоступна)
+	Link string
+}
@@ -2,12 +2,38 @@
 
 package dto
 
+import (
+	"time"
+)
+
 type Chat struct {
 	Id       string     `json:"id"`
 	Name     string     `json:"name"`
 	Messages []*Message `json:"messages"`
 }
 
+type Journal struct {
+	ChatId    int64          `json:"chatId"`
+	MessageId int64          `json:"messageId"`
+	Date      time.Time      `json:"date"`
+	Rules     []*JournalRule `json:"rules"`
+}
+
+type JournalDestination struct {
+	ChatId     int64   `json:"chatId"`
+	Outcome    string  `json:"outcome"`
+	MessageIds []int64 `json:"messageIds"`
+	Error      string  `json:"error"`
+}
+
+type JournalRule struct {
+	ForwardRuleId string                `json:"forwardRuleId"`
+	Verdict       string                `json:"verdict"`
+	FiltersMode   string                `json:"filtersMode"`
+	Pattern       string                `json:"pattern"`
+	Destinations  []*JournalDestination `json:"destinations"`
+}
+
 type Message struct {
 	Id   string `json:"id"`
 	Text string `json:"text"`
@@ -25,6 +51,24 @@ type NewMessage struct {
 type Query struct {
 }
 
+type Stats struct {
+	Hour          *time.Time `json:"hour,omitempty"`
+	ForwardRuleId string     `json:"forwardRuleId"`
+	SrcChatId     int64      `json:"srcChatId"`
+	DstChatId     int64      `json:"dstChatId"`
+	Outcome       string     `json:"outcome"`
+	Count         int64      `json:"count"`
+}
+
+type StatsFilter struct {
+	From          *time.Time `json:"from,omitempty"`
+	To            *time.Time `json:"to,omitempty"`
+	ForwardRuleId *string    `json:"forwardRuleId,omitempty"`
+	SrcChatId     *int64     `json:"srcChatId,omitempty"`
+	DstChatId     *int64     `json:"dstChatId,omitempty"`
+	Outcome       *string    `json:"outcome,omitempty"`
+}
+
 type Status struct {
 	ReleaseVersion string `json:"releaseVersion"`
 	TdlibVersion   string `json:"tdlibVersion"`
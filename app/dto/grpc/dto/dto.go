@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type Chat struct {
 	Id       int64
 	Name     string
@@ -14,6 +16,59 @@ type Message struct {
 	FilePath string
 }
 
+type Whence struct {
+	ForwardRuleId string
+	ChatId        int64
+	MessageId     int64
+	Link          string
+}
+
+type Journal struct {
+	ChatId    int64
+	MessageId int64
+	Date      time.Time
+	Rules     []*JournalRule
+}
+
+type JournalRule struct {
+	ForwardRuleId string
+	Verdict       string
+	FiltersMode   string
+	Pattern       string
+	Destinations  []*JournalDestination
+}
+
+type JournalDestination struct {
+	ChatId     int64
+	Outcome    string
+	MessageIds []int64
+	Error      string
+}
+
+type Backup struct {
+	Name      string
+	Size      int64
+	CreatedAt time.Time
+}
+
+type Stats struct {
+	Hour          time.Time
+	ForwardRuleId string
+	SrcChatId     int64
+	DstChatId     int64
+	Outcome       string
+	Count         int64
+}
+
+type StatsFilter struct {
+	From          time.Time
+	To            time.Time
+	ForwardRuleId string
+	SrcChatId     int64
+	DstChatId     int64
+	Outcome       string
+}
+
 type NewMessage struct {
 	ChatId           int64
 	Text             string
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// Usage занимаемое место по префиксу ключа
+type Usage struct {
+	Prefix string
+	Keys   int64
+	Size   int64 // байт, оценка без учёта сжатия
+}
+
+// Backup резервная копия хранилища
+type Backup struct {
+	Name      string
+	Size      int64 // байт
+	CreatedAt time.Time
+}
+
+// Entry значение хранилища как есть, для переноса между реализациями
+type Entry struct {
+	Key       string
+	Value     []byte
+	ExpiresAt uint64 // unix-время, 0 - бессрочно
+}
@@ -0,0 +1,449 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: app/dto/storage/dto/record.proto
+
+package dto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Record - значение в хранилище; заполняются поля, соответствующие префиксу ключа
+type Record struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatMessages  []*ChatMessage         `protobuf:"bytes,1,rep,name=chat_messages,json=chatMessages,proto3" json:"chat_messages,omitempty"`
+	MessageIds    []int64                `protobuf:"varint,2,rep,packed,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
+	MessageId     int64                  `protobuf:"varint,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	Journal       *Journal               `protobuf:"bytes,5,opt,name=journal,proto3" json:"journal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Record) Reset() {
+	*x = Record{}
+	mi := &file_app_dto_storage_dto_record_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record) ProtoMessage() {}
+
+func (x *Record) ProtoReflect() protoreflect.Message {
+	mi := &file_app_dto_storage_dto_record_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Record.ProtoReflect.Descriptor instead.
+func (*Record) Descriptor() ([]byte, []int) {
+	return file_app_dto_storage_dto_record_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Record) GetChatMessages() []*ChatMessage {
+	if x != nil {
+		return x.ChatMessages
+	}
+	return nil
+}
+
+func (x *Record) GetMessageIds() []int64 {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+func (x *Record) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *Record) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Record) GetJournal() *Journal {
+	if x != nil {
+		return x.Journal
+	}
+	return nil
+}
+
+// ChatMessage - ссылка на сообщение в чате (forward_rule_id пуст, если правило неизвестно)
+type ChatMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ForwardRuleId string                 `protobuf:"bytes,1,opt,name=forward_rule_id,json=forwardRuleId,proto3" json:"forward_rule_id,omitempty"`
+	ChatId        int64                  `protobuf:"varint,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     int64                  `protobuf:"varint,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatMessage) Reset() {
+	*x = ChatMessage{}
+	mi := &file_app_dto_storage_dto_record_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessage) ProtoMessage() {}
+
+func (x *ChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_app_dto_storage_dto_record_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
+func (*ChatMessage) Descriptor() ([]byte, []int) {
+	return file_app_dto_storage_dto_record_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ChatMessage) GetForwardRuleId() string {
+	if x != nil {
+		return x.ForwardRuleId
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+// Journal - журнал решений по исходному сообщению
+type Journal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          int64                  `protobuf:"varint,1,opt,name=date,proto3" json:"date,omitempty"` // unix-время первой записи
+	Rules         []*JournalRule         `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Journal) Reset() {
+	*x = Journal{}
+	mi := &file_app_dto_storage_dto_record_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Journal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Journal) ProtoMessage() {}
+
+func (x *Journal) ProtoReflect() protoreflect.Message {
+	mi := &file_app_dto_storage_dto_record_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Journal.ProtoReflect.Descriptor instead.
+func (*Journal) Descriptor() ([]byte, []int) {
+	return file_app_dto_storage_dto_record_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Journal) GetDate() int64 {
+	if x != nil {
+		return x.Date
+	}
+	return 0
+}
+
+func (x *Journal) GetRules() []*JournalRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// JournalRule - решение правила по исходному сообщению
+type JournalRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ForwardRuleId string                 `protobuf:"bytes,1,opt,name=forward_rule_id,json=forwardRuleId,proto3" json:"forward_rule_id,omitempty"`
+	Verdict       string                 `protobuf:"bytes,2,opt,name=verdict,proto3" json:"verdict,omitempty"`
+	FiltersMode   string                 `protobuf:"bytes,3,opt,name=filters_mode,json=filtersMode,proto3" json:"filters_mode,omitempty"`
+	Pattern       string                 `protobuf:"bytes,4,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Destinations  []*JournalDestination  `protobuf:"bytes,5,rep,name=destinations,proto3" json:"destinations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JournalRule) Reset() {
+	*x = JournalRule{}
+	mi := &file_app_dto_storage_dto_record_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JournalRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JournalRule) ProtoMessage() {}
+
+func (x *JournalRule) ProtoReflect() protoreflect.Message {
+	mi := &file_app_dto_storage_dto_record_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JournalRule.ProtoReflect.Descriptor instead.
+func (*JournalRule) Descriptor() ([]byte, []int) {
+	return file_app_dto_storage_dto_record_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *JournalRule) GetForwardRuleId() string {
+	if x != nil {
+		return x.ForwardRuleId
+	}
+	return ""
+}
+
+func (x *JournalRule) GetVerdict() string {
+	if x != nil {
+		return x.Verdict
+	}
+	return ""
+}
+
+func (x *JournalRule) GetFiltersMode() string {
+	if x != nil {
+		return x.FiltersMode
+	}
+	return ""
+}
+
+func (x *JournalRule) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *JournalRule) GetDestinations() []*JournalDestination {
+	if x != nil {
+		return x.Destinations
+	}
+	return nil
+}
+
+// JournalDestination - исход для получателя (message_ids - временные идентификаторы отправленных сообщений)
+type JournalDestination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Outcome       string                 `protobuf:"bytes,2,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	MessageIds    []int64                `protobuf:"varint,3,rep,packed,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JournalDestination) Reset() {
+	*x = JournalDestination{}
+	mi := &file_app_dto_storage_dto_record_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JournalDestination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JournalDestination) ProtoMessage() {}
+
+func (x *JournalDestination) ProtoReflect() protoreflect.Message {
+	mi := &file_app_dto_storage_dto_record_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JournalDestination.ProtoReflect.Descriptor instead.
+func (*JournalDestination) Descriptor() ([]byte, []int) {
+	return file_app_dto_storage_dto_record_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *JournalDestination) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *JournalDestination) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *JournalDestination) GetMessageIds() []int64 {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+func (x *JournalDestination) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_app_dto_storage_dto_record_proto protoreflect.FileDescriptor
+
+const file_app_dto_storage_dto_record_proto_rawDesc = "" +
+	"\n" +
+	" app/dto/storage/dto/record.proto\x12\astorage\"\xc3\x01\n" +
+	"\x06Record\x129\n" +
+	"\rchat_messages\x18\x01 \x03(\v2\x14.storage.ChatMessageR\fchatMessages\x12\x1f\n" +
+	"\vmessage_ids\x18\x02 \x03(\x03R\n" +
+	"messageIds\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x03 \x01(\x03R\tmessageId\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12*\n" +
+	"\ajournal\x18\x05 \x01(\v2\x10.storage.JournalR\ajournal\"m\n" +
+	"\vChatMessage\x12&\n" +
+	"\x0fforward_rule_id\x18\x01 \x01(\tR\rforwardRuleId\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\x03R\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x03 \x01(\x03R\tmessageId\"I\n" +
+	"\aJournal\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\x03R\x04date\x12*\n" +
+	"\x05rules\x18\x02 \x03(\v2\x14.storage.JournalRuleR\x05rules\"\xcd\x01\n" +
+	"\vJournalRule\x12&\n" +
+	"\x0fforward_rule_id\x18\x01 \x01(\tR\rforwardRuleId\x12\x18\n" +
+	"\averdict\x18\x02 \x01(\tR\averdict\x12!\n" +
+	"\ffilters_mode\x18\x03 \x01(\tR\vfiltersMode\x12\x18\n" +
+	"\apattern\x18\x04 \x01(\tR\apattern\x12?\n" +
+	"\fdestinations\x18\x05 \x03(\v2\x1b.storage.JournalDestinationR\fdestinations\"~\n" +
+	"\x12JournalDestination\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x18\n" +
+	"\aoutcome\x18\x02 \x01(\tR\aoutcome\x12\x1f\n" +
+	"\vmessage_ids\x18\x03 \x03(\x03R\n" +
+	"messageIds\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05errorB/Z-github.com/comerc/budva43/app/dto/storage/dtob\x06proto3"
+
+var (
+	file_app_dto_storage_dto_record_proto_rawDescOnce sync.Once
+	file_app_dto_storage_dto_record_proto_rawDescData []byte
+)
+
+func file_app_dto_storage_dto_record_proto_rawDescGZIP() []byte {
+	file_app_dto_storage_dto_record_proto_rawDescOnce.Do(func() {
+		file_app_dto_storage_dto_record_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_app_dto_storage_dto_record_proto_rawDesc), len(file_app_dto_storage_dto_record_proto_rawDesc)))
+	})
+	return file_app_dto_storage_dto_record_proto_rawDescData
+}
+
+var file_app_dto_storage_dto_record_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_app_dto_storage_dto_record_proto_goTypes = []any{
+	(*Record)(nil),             // 0: storage.Record
+	(*ChatMessage)(nil),        // 1: storage.ChatMessage
+	(*Journal)(nil),            // 2: storage.Journal
+	(*JournalRule)(nil),        // 3: storage.JournalRule
+	(*JournalDestination)(nil), // 4: storage.JournalDestination
+}
+var file_app_dto_storage_dto_record_proto_depIdxs = []int32{
+	1, // 0: storage.Record.chat_messages:type_name -> storage.ChatMessage
+	2, // 1: storage.Record.journal:type_name -> storage.Journal
+	3, // 2: storage.Journal.rules:type_name -> storage.JournalRule
+	4, // 3: storage.JournalRule.destinations:type_name -> storage.JournalDestination
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_app_dto_storage_dto_record_proto_init() }
+func file_app_dto_storage_dto_record_proto_init() {
+	if File_app_dto_storage_dto_record_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_app_dto_storage_dto_record_proto_rawDesc), len(file_app_dto_storage_dto_record_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_app_dto_storage_dto_record_proto_goTypes,
+		DependencyIndexes: file_app_dto_storage_dto_record_proto_depIdxs,
+		MessageInfos:      file_app_dto_storage_dto_record_proto_msgTypes,
+	}.Build()
+	File_app_dto_storage_dto_record_proto = out.File
+	file_app_dto_storage_dto_record_proto_goTypes = nil
+	file_app_dto_storage_dto_record_proto_depIdxs = nil
+}
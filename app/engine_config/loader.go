@@ -3,10 +3,14 @@ package engine_config
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"path/filepath"
-	"regexp"
+	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 
 	"github.com/comerc/budva43/app/config"
@@ -63,7 +67,7 @@ func load() (*domain.EngineConfig, error) {
 	}
 
 	engineConfig := &domain.EngineConfig{}
-	if err := engineViper.Unmarshal(engineConfig, util.GetConfigOptions()); err != nil {
+	if err := engineViper.Unmarshal(engineConfig, util.GetConfigOptions(forwardRuleThreadsHookFunc())); err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
 
@@ -84,6 +88,78 @@ func load() (*domain.EngineConfig, error) {
 	return engineConfig, nil
 }
 
+// forwardRuleThreadsHookFunc разбирает чаты вида chat_id/thread_id в правиле форвардинга:
+// тема форума получателя переносится в Threads, тема источника - в FromThreads;
+// Threads задаёт одну тему на чат, поэтому чат в разных ролях (To, Check, Other)
+// с разными темами отвергается
+func forwardRuleThreadsHookFunc() mapstructure.DecodeHookFunc {
+	forwardRuleType := reflect.TypeOf(domain.ForwardRule{})
+	return func(_ reflect.Type, to reflect.Type, data any) (any, error) {
+		if to != forwardRuleType {
+			return data, nil
+		}
+		m, ok := data.(map[string]any)
+		if !ok {
+			return data, nil
+		}
+
+		threads := map[string]any{}
+		if val, ok := m["Threads"].(map[string]any); ok {
+			maps.Copy(threads, val)
+		}
+		roleThreads := map[string]string{} // chatId -> тема (пусто - без темы) по ролям получателя
+		cutThread := func(val any) (any, error) {
+			chatId, threadId, _ := strings.Cut(fmt.Sprint(val), "/")
+			if prevThreadId, ok := roleThreads[chatId]; ok && prevThreadId != threadId {
+				return nil, log.NewError("получатель в разных ролях правила должен быть в одной теме форума",
+					"chatId", chatId,
+					"threadIds", []string{prevThreadId, threadId},
+				)
+			}
+			roleThreads[chatId] = threadId
+			if threadId == "" {
+				return val, nil
+			}
+			threads[chatId] = threadId
+			return chatId, nil
+		}
+
+		if to, ok := m["To"].([]any); ok {
+			a := make([]any, 0, len(to))
+			for _, val := range to {
+				val, err := cutThread(val)
+				if err != nil {
+					return nil, err
+				}
+				a = append(a, val)
+			}
+			m["To"] = a
+		}
+		for _, key := range []string{"Check", "Other"} {
+			if val, ok := m[key]; ok {
+				val, err := cutThread(val)
+				if err != nil {
+					return nil, err
+				}
+				m[key] = val
+			}
+		}
+		if len(threads) > 0 {
+			m["Threads"] = threads
+		}
+
+		if s, ok := m["From"].(string); ok {
+			if chatId, threadId, ok := strings.Cut(s, "/"); ok {
+				m["From"] = chatId
+				fromThreads, _ := m["FromThreads"].([]any)
+				m["FromThreads"] = append(fromThreads, threadId)
+			}
+		}
+
+		return m, nil
+	}
+}
+
 // Initialize инициализирует конфигурацию
 func Initialize(engineConfig *domain.EngineConfig) {
 	if engineConfig.Sources == nil {
@@ -95,6 +171,9 @@ func Initialize(engineConfig *domain.EngineConfig) {
 	if engineConfig.ForwardRules == nil {
 		engineConfig.ForwardRules = make(map[domain.ForwardRuleId]*domain.ForwardRule)
 	}
+	if engineConfig.Bridges == nil {
+		engineConfig.Bridges = make(map[domain.BridgeId]*domain.Bridge)
+	}
 	engineConfig.UniqueSources = make(map[domain.ChatId]struct{})
 	engineConfig.UniqueDestinations = make(map[domain.ChatId]struct{})
 }
@@ -153,6 +232,11 @@ func validate(engineConfig *domain.EngineConfig) error {
 				}
 			}
 		}
+		if src.MediaAlbumWait < 0 {
+			return log.NewError("время ожидания медиа-альбома не может быть отрицательным",
+				"path", fmt.Sprintf("config.Engine.Sources[%d].MediaAlbumWait", srcChatId),
+				"value", src.MediaAlbumWait)
+		}
 	}
 
 	for dstChatId, dsc := range engineConfig.Destinations {
@@ -171,17 +255,20 @@ func validate(engineConfig *domain.EngineConfig) error {
 				)
 			}
 		}
+		if dsc.Media != nil {
+			if !slices.Contains([]domain.PhotoSizeMode{"", domain.PhotoSizeSmallest, domain.PhotoSizeLargest}, dsc.Media.PhotoSize) {
+				return log.NewError("недопустимый выбор размера фото (valid: smallest, largest)",
+					"path", fmt.Sprintf("config.Engine.Destinations[%d].Media.PhotoSize", dstChatId),
+					"value", dsc.Media.PhotoSize)
+			}
+			if dsc.Media.MaxSide < 0 || dsc.Media.MaxFileSize < 0 {
+				return log.NewError("ограничения размера фото не могут быть отрицательными",
+					"path", fmt.Sprintf("config.Engine.Destinations[%d].Media", dstChatId))
+			}
+		}
 	}
 
-	re := regexp.MustCompile("[:,]") // TODO: зачем нужна эта проверка? (предположительно для badger)
 	for forwardRuleId, forwardRule := range engineConfig.ForwardRules {
-		if re.FindString(forwardRuleId) != "" {
-			return log.NewError("нельзя использовать [:,] в идентификаторе",
-				"path", "config.Engine.ForwardRules",
-				"value", forwardRuleId,
-			)
-		}
-
 		// viper читает именные ключи в PascalCase
 		// if cases.Title(language.English).String(forwardRuleId) != forwardRuleId {
 		// 	return log.NewError("идентификатор должен быть в PascalCase",
@@ -209,6 +296,33 @@ func validate(engineConfig *domain.EngineConfig) error {
 			}
 		}
 
+		for i, dstChatId := range forwardRule.To {
+			if slices.Index(forwardRule.To, dstChatId) != i {
+				return log.NewError("получатель не может повторяться (в том числе в разных темах форума)",
+					"path", fmt.Sprintf("config.Engine.ForwardRules[%s].To[%d]", forwardRuleId, i),
+					"value", dstChatId)
+			}
+		}
+		for dstChatId, threadId := range forwardRule.Threads {
+			if threadId <= 0 {
+				return log.NewError("идентификатор темы форума должен быть положительным",
+					"path", fmt.Sprintf("config.Engine.ForwardRules[%s].Threads[%d]", forwardRuleId, dstChatId),
+					"value", threadId)
+			}
+			if !slices.Contains(forwardRule.To, dstChatId) && forwardRule.Check != dstChatId && forwardRule.Other != dstChatId {
+				return log.NewError("тема форума задана для чата, который не является получателем",
+					"path", fmt.Sprintf("config.Engine.ForwardRules[%s].Threads[%d]", forwardRuleId, dstChatId),
+					"value", threadId)
+			}
+		}
+		for i, threadId := range forwardRule.FromThreads {
+			if threadId <= 0 {
+				return log.NewError("идентификатор темы форума должен быть положительным",
+					"path", fmt.Sprintf("config.Engine.ForwardRules[%s].FromThreads[%d]", forwardRuleId, i),
+					"value", threadId)
+			}
+		}
+
 		if forwardRule.Check < 0 {
 			return log.NewError("идентификатор не может быть отрицательным",
 				"path", fmt.Sprintf("config.Engine.ForwardRules[%s].Check", forwardRuleId),
@@ -220,8 +334,109 @@ func validate(engineConfig *domain.EngineConfig) error {
 				"path", fmt.Sprintf("config.Engine.ForwardRules[%s].Other", forwardRuleId),
 				"value", forwardRule.Other)
 		}
+		if forwardRule.Revisions != nil {
+			if !slices.Contains([]domain.RevisionsMode{"", domain.RevisionsKeep, domain.RevisionsReplace}, forwardRule.Revisions.Mode) {
+				return log.NewError("недопустимый режим редакций (valid: keep, replace)",
+					"path", fmt.Sprintf("config.Engine.ForwardRules[%s].Revisions.Mode", forwardRuleId),
+					"value", forwardRule.Revisions.Mode)
+			}
+			if forwardRule.Revisions.Title != "" && strings.Count(forwardRule.Revisions.Title, "%d") != 1 {
+				return log.NewError("метка редакции должна содержать один %d",
+					"path", fmt.Sprintf("config.Engine.ForwardRules[%s].Revisions.Title", forwardRuleId),
+					"value", forwardRule.Revisions.Title)
+			}
+		}
+		fallbackPolicies := []domain.FallbackPolicy{"",
+			domain.FallbackSkip, domain.FallbackText, domain.FallbackNote, domain.FallbackCheck}
+		if !slices.Contains(fallbackPolicies, forwardRule.Fallback) {
+			return log.NewError("недопустимая политика для защищённого содержимого (valid: skip, text, note, check)",
+				"path", fmt.Sprintf("config.Engine.ForwardRules[%s].Fallback", forwardRuleId),
+				"value", forwardRule.Fallback)
+		}
+		if forwardRule.Fallback == domain.FallbackCheck && forwardRule.Check == 0 {
+			return log.NewError("для политики check не задан чат Check",
+				"path", fmt.Sprintf("config.Engine.ForwardRules[%s].Check", forwardRuleId))
+		}
+		if !slices.Contains([]domain.OnDeleteAction{"", domain.OnDeleteDelete, domain.OnDeleteTombstone}, forwardRule.OnDelete) {
+			return log.NewError("недопустимое действие при удалении оригинала (valid: delete, tombstone)",
+				"path", fmt.Sprintf("config.Engine.ForwardRules[%s].OnDelete", forwardRuleId),
+				"value", forwardRule.OnDelete)
+		}
+		if forwardRule.Tombstone != nil &&
+			!slices.Contains([]domain.TombstoneMode{"", domain.TombstoneMarker, domain.TombstoneStrike}, forwardRule.Tombstone.Mode) {
+			return log.NewError("недопустимый способ пометки удалённого оригинала (valid: marker, strike)",
+				"path", fmt.Sprintf("config.Engine.ForwardRules[%s].Tombstone.Mode", forwardRuleId),
+				"value", forwardRule.Tombstone.Mode)
+		}
+		if forwardRule.ReplyContext != nil {
+			if !slices.Contains([]domain.ReplyContextMode{"", domain.ReplyContextQuote, domain.ReplyContextLink}, forwardRule.ReplyContext.Mode) {
+				return log.NewError("недопустимый способ контекста ответа (valid: quote, link)",
+					"path", fmt.Sprintf("config.Engine.ForwardRules[%s].ReplyContext.Mode", forwardRuleId),
+					"value", forwardRule.ReplyContext.Mode)
+			}
+			if forwardRule.ReplyContext.Length < 0 {
+				return log.NewError("длина цитаты не может быть отрицательной",
+					"path", fmt.Sprintf("config.Engine.ForwardRules[%s].ReplyContext.Length", forwardRuleId),
+					"value", forwardRule.ReplyContext.Length)
+			}
+		}
+		if forwardRule.Retention < 0 {
+			return log.NewError("срок хранения не может быть отрицательным",
+				"path", fmt.Sprintf("config.Engine.ForwardRules[%s].Retention", forwardRuleId),
+				"value", forwardRule.Retention)
+		}
 	}
 
+	err := validateRetention(engineConfig)
+	if err != nil {
+		return err
+	}
+
+	for bridgeId, bridge := range engineConfig.Bridges {
+		if bridge.From <= 0 || bridge.To <= 0 {
+			return log.NewError("идентификатор должен быть положительным",
+				"path", fmt.Sprintf("config.Engine.Bridges[%s]", bridgeId),
+				"value", fmt.Sprintf("%d -> %d", bridge.From, bridge.To))
+		}
+		// мост возможен только в обратном направлении существующего правила
+		isMirror := false
+		for _, forwardRule := range engineConfig.ForwardRules {
+			if forwardRule.From == bridge.To && slices.Contains(forwardRule.To, bridge.From) {
+				isMirror = true
+				break
+			}
+		}
+		if !isMirror {
+			return log.NewError("нет правила пересылки из To в From",
+				"path", fmt.Sprintf("config.Engine.Bridges[%s]", bridgeId),
+				"value", fmt.Sprintf("%d -> %d", bridge.From, bridge.To))
+		}
+	}
+
+	return nil
+}
+
+// validateRetention запрещает смешивать бессрочное и ограниченное хранение связей:
+// ключи источников и получателей общие для правил и хранятся по наибольшему сроку,
+// поэтому одно бессрочное правило сделало бы бессрочными ключи всех правил
+func validateRetention(engineConfig *domain.EngineConfig) error {
+	var finiteRuleId, foreverRuleId domain.ForwardRuleId
+	for forwardRuleId, forwardRule := range engineConfig.ForwardRules {
+		retention := forwardRule.Retention
+		if retention == 0 {
+			retention = config.Storage.Retention
+		}
+		if retention > 0 {
+			finiteRuleId = forwardRuleId
+		} else {
+			foreverRuleId = forwardRuleId
+		}
+	}
+	if finiteRuleId != "" && foreverRuleId != "" {
+		return log.NewError("срок хранения задан не для всех правил: задайте storage.retention или retention каждого правила",
+			"path", fmt.Sprintf("config.Engine.ForwardRules[%s].Retention", foreverRuleId),
+			"finite", fmt.Sprintf("config.Engine.ForwardRules[%s].Retention", finiteRuleId))
+	}
 	return nil
 }
 
@@ -296,6 +511,18 @@ func transform(engineConfig *domain.EngineConfig) {
 		}
 		forwardRule.Check = -forwardRule.Check
 		forwardRule.Other = -forwardRule.Other
+		if forwardRule.Threads != nil {
+			threads := make(map[domain.ChatId]int64, len(forwardRule.Threads))
+			for dstChatId, threadId := range forwardRule.Threads {
+				threads[-dstChatId] = threadId
+			}
+			forwardRule.Threads = threads
+		}
+	}
+
+	for _, bridge := range engineConfig.Bridges {
+		bridge.From = -bridge.From
+		bridge.To = -bridge.To
 	}
 }
 
@@ -327,6 +554,10 @@ func enrich(engineConfig *domain.EngineConfig) {
 	}
 
 	engineConfig.OrderedForwardRules = util.Distinct(tmpOrderedForwardRules)
+
+	for key, bridge := range engineConfig.Bridges {
+		bridge.Id = key
+	}
 }
 
 var ErrEmptyConfigData = errors.New("отсутствуют данные")
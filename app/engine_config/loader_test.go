@@ -2,10 +2,17 @@ package engine_config
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/spf13/viper"
+
+	"github.com/comerc/budva43/app/config"
 	"github.com/comerc/budva43/app/domain"
 	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,3 +38,139 @@ func TestCheck(t *testing.T) {
 		"config.Engine.OrderedForwardRules",
 	})
 }
+
+func TestForwardRuleThreadsHookFunc(t *testing.T) {
+	t.Parallel()
+
+	data := `
+forward-rules:
+  Topics:
+    from: 1001/7
+    to: [2002, "3003/15"]
+    check: 4004/21
+    other: 5005
+`
+	v := viper.New()
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(strings.NewReader(data)))
+
+	engineConfig := &domain.EngineConfig{}
+	require.NoError(t, v.Unmarshal(engineConfig, util.GetConfigOptions(forwardRuleThreadsHookFunc())))
+
+	forwardRule := engineConfig.ForwardRules["Topics"]
+	require.NotNil(t, forwardRule)
+	assert.Equal(t, domain.ChatId(1001), forwardRule.From)
+	assert.Equal(t, []int64{7}, forwardRule.FromThreads)
+	assert.Equal(t, []domain.ChatId{2002, 3003}, forwardRule.To)
+	assert.Equal(t, domain.ChatId(4004), forwardRule.Check)
+	assert.Equal(t, domain.ChatId(5005), forwardRule.Other)
+	assert.Equal(t, map[domain.ChatId]int64{3003: 15, 4004: 21}, forwardRule.Threads)
+
+	Initialize(engineConfig)
+	require.NoError(t, validate(engineConfig))
+	transform(engineConfig)
+	assert.Equal(t, map[domain.ChatId]int64{-3003: 15, -4004: 21}, forwardRule.Threads)
+}
+
+func TestForwardRuleThreadsHookFuncConflict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rule    string
+		isError bool
+	}{
+		{
+			name: "same_thread_in_to_and_check",
+			rule: `{from: 1001, to: ["2002/15"], check: 2002/15}`,
+		},
+		{
+			name:    "different_threads_in_to_and_check",
+			rule:    `{from: 1001, to: ["2002/15"], check: 2002/21}`,
+			isError: true,
+		},
+		{
+			name:    "thread_only_in_other",
+			rule:    `{from: 1001, to: [2002], other: 2002/21}`,
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			v := viper.New()
+			v.SetConfigType("yaml")
+			require.NoError(t, v.ReadConfig(strings.NewReader("forward-rules:\n  Topics: "+test.rule+"\n")))
+
+			engineConfig := &domain.EngineConfig{}
+			err := v.Unmarshal(engineConfig, util.GetConfigOptions(forwardRuleThreadsHookFunc()))
+			if test.isError {
+				assert.ErrorContains(t, err, "получатель в разных ролях правила должен быть в одной теме форума")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, map[domain.ChatId]int64{2002: 15}, engineConfig.ForwardRules["Topics"].Threads)
+		})
+	}
+}
+
+func TestValidateRetention(t *testing.T) {
+	// t.Parallel() // !! нельзя параллелить, тестирую с подменой глобальных переменных
+
+	retention := config.Storage.Retention
+	t.Cleanup(func() {
+		config.Storage.Retention = retention
+	})
+
+	tests := []struct {
+		name       string
+		retention  time.Duration // config.Storage.Retention
+		retentions []time.Duration
+		isError    bool
+	}{
+		{
+			name:       "forever",
+			retentions: []time.Duration{0, 0},
+		},
+		{
+			name:       "finite_rules",
+			retentions: []time.Duration{24 * time.Hour, 720 * time.Hour},
+		},
+		{
+			name:       "finite_default_with_rule",
+			retention:  24 * time.Hour,
+			retentions: []time.Duration{0, 720 * time.Hour},
+		},
+		{
+			name:       "forever_default_with_rule",
+			retentions: []time.Duration{0, 720 * time.Hour},
+			isError:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config.Storage.Retention = test.retention
+
+			engineConfig := &domain.EngineConfig{
+				ForwardRules: make(map[domain.ForwardRuleId]*domain.ForwardRule),
+			}
+			for i, retention := range test.retentions {
+				forwardRuleId := fmt.Sprintf("Rule%d", i)
+				engineConfig.ForwardRules[forwardRuleId] = &domain.ForwardRule{
+					Id:        forwardRuleId,
+					Retention: retention,
+				}
+			}
+
+			err := validateRetention(engineConfig)
+			if test.isError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"strings"
 
 	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/comerc/budva43/app/trace"
 )
 
 // TODO: linter: `var err error` must be used
@@ -30,18 +32,40 @@ func newLogger(loggerName string) *Logger {
 }
 
 func (l *Logger) ErrorOrDebug(err error, message string, args ...any) {
-	l.logWithError(slog.LevelDebug, err, message, args...)
+	l.logWithError(context.Background(), slog.LevelDebug, err, message, args...)
 }
 
 func (l *Logger) ErrorOrInfo(err error, message string, args ...any) {
-	l.logWithError(slog.LevelInfo, err, message, args...)
+	l.logWithError(context.Background(), slog.LevelInfo, err, message, args...)
 }
 
 func (l *Logger) ErrorOrWarn(err error, message string, args ...any) {
-	l.logWithError(slog.LevelWarn, err, message, args...)
+	l.logWithError(context.Background(), slog.LevelWarn, err, message, args...)
+}
+
+// ErrorOrDebugContext как ErrorOrDebug, но с идентификатором трассы из ctx
+func (l *Logger) ErrorOrDebugContext(ctx context.Context, err error, message string, args ...any) {
+	l.logWithError(ctx, slog.LevelDebug, err, message, args...)
+}
+
+// ErrorOrInfoContext как ErrorOrInfo, но с идентификатором трассы из ctx
+func (l *Logger) ErrorOrInfoContext(ctx context.Context, err error, message string, args ...any) {
+	l.logWithError(ctx, slog.LevelInfo, err, message, args...)
+}
+
+// ErrorOrWarnContext как ErrorOrWarn, но с идентификатором трассы из ctx
+func (l *Logger) ErrorOrWarnContext(ctx context.Context, err error, message string, args ...any) {
+	l.logWithError(ctx, slog.LevelWarn, err, message, args...)
 }
 
-func (l *Logger) logWithError(level slog.Level, err error, message string, args ...any) { //nolint:error_log_or_return
+func (l *Logger) logWithError(ctx context.Context, level slog.Level, err error, message string, args ...any) { //nolint:error_log_or_return
+	if err != nil {
+		level = slog.LevelError
+	}
+	// отфильтрованные записи не попадают ни в лог, ни в спан трассы
+	if !l.Enabled(ctx, level) {
+		return
+	}
 	count := 0
 	for _, arg := range args {
 		if attr, ok := arg.(slog.Attr); ok {
@@ -61,7 +85,6 @@ func (l *Logger) logWithError(level slog.Level, err error, message string, args
 			args = append(args, "source", stack[0].String())
 		}
 	} else {
-		level = slog.LevelError
 		message = err.Error()
 		var customError *CustomError
 		if errors.As(err, &customError) {
@@ -80,7 +103,11 @@ func (l *Logger) logWithError(level slog.Level, err error, message string, args
 		}
 		args = append(args, slog.Group("source", groupArgs...))
 	}
-	l.Log(context.Background(), level, message, args...)
+	if traceId := trace.Id(ctx); traceId != "" {
+		trace.Annotate(ctx, err, message)
+		args = append(args, "traceId", traceId)
+	}
+	l.Log(ctx, level, message, args...)
 }
 
 func NewWriter(filePath string, maxSize int) io.Writer {
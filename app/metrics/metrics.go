@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/domain"
+)
+
+const namespace = "budva43"
+
+// отправки без UpdateMessageSendSucceeded забываются через pendingSendsTTL,
+// когда их накопилось pendingSendsLimit
+const (
+	pendingSendsLimit = 10000
+	pendingSendsTTL   = time.Hour
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	updatesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "updates_total",
+		Help:      "Обновления TDLib по типам",
+	}, []string{"type"})
+
+	forwardsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "forwards_total",
+		Help:      "Исходы обработки сообщений правилами по получателям",
+	}, []string{"rule", "destination", "outcome"})
+
+	tdlibErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tdlib_errors_total",
+		Help:      "Ошибки вызовов TDLib по кодам",
+	}, []string{"method", "code"})
+
+	tdlibCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "tdlib_call_duration_seconds",
+		Help:      "Длительность вызовов TDLib",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	forwardLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "forward_latency_seconds",
+		Help:      "Задержка от даты исходного сообщения до успешной отправки получателю",
+		Buckets:   []float64{0.5, 1, 2, 5, 10, 30, 60, 120, 300, 600},
+	})
+
+	queueLength = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "queue_length",
+		Help:      "Количество задач в очереди",
+	})
+
+	mediaAlbumsPending = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "media_albums_pending",
+		Help:      "Количество медиа-альбомов в ожидании последнего сообщения",
+	})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		updatesTotal,
+		forwardsTotal,
+		tdlibErrorsTotal,
+		tdlibCallDuration,
+		forwardLatency,
+		queueLength,
+		mediaAlbumsPending,
+	)
+}
+
+// Handler возвращает HTTP-обработчик для /metrics
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// AddUpdate учитывает обновление TDLib
+func AddUpdate(update client.Type) {
+	updatesTotal.WithLabelValues(update.GetType()).Inc()
+}
+
+// AddForward учитывает исход обработки сообщения правилом для получателя
+func AddForward(forwardRuleId string, dstChatId int64, outcome domain.StatsOutcome) {
+	forwardsTotal.WithLabelValues(forwardRuleId, strconv.FormatInt(dstChatId, 10), outcome).Inc()
+}
+
+// ObserveTdlibCall учитывает длительность вызова TDLib, начатого в start, и код ошибки
+func ObserveTdlibCall(method string, start time.Time, err error) {
+	tdlibCallDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err == nil {
+		return
+	}
+	code := "unknown"
+	var responseError client.ResponseError
+	if errors.As(err, &responseError) && responseError.Err != nil {
+		code = strconv.Itoa(int(responseError.Err.Code))
+	}
+	tdlibErrorsTotal.WithLabelValues(method, code).Inc()
+}
+
+// SetQueueLength задаёт длину очереди
+func SetQueueLength(length int) {
+	queueLength.Set(float64(length))
+}
+
+// SetMediaAlbumsPending задаёт количество ожидающих медиа-альбомов
+func SetMediaAlbumsPending(count int) {
+	mediaAlbumsPending.Set(float64(count))
+}
+
+type pendingSendKey struct {
+	chatId       int64
+	tmpMessageId int64
+}
+
+var (
+	pendingSendsMu sync.Mutex
+	pendingSends   = make(map[pendingSendKey]time.Time)
+)
+
+// StartSend запоминает дату исходного сообщения для отправленного временного сообщения,
+// задержка учитывается в FinishSend по UpdateMessageSendSucceeded
+func StartSend(chatId, tmpMessageId int64, srcDate int32) {
+	pendingSendsMu.Lock()
+	defer pendingSendsMu.Unlock()
+	if len(pendingSends) >= pendingSendsLimit {
+		for key, date := range pendingSends {
+			if time.Since(date) > pendingSendsTTL {
+				delete(pendingSends, key)
+			}
+		}
+	}
+	pendingSends[pendingSendKey{chatId, tmpMessageId}] = time.Unix(int64(srcDate), 0)
+}
+
+// FinishSend учитывает задержку доставки временного сообщения, начатой в StartSend
+func FinishSend(chatId, tmpMessageId int64) {
+	pendingSendsMu.Lock()
+	key := pendingSendKey{chatId, tmpMessageId}
+	date, ok := pendingSends[key]
+	delete(pendingSends, key)
+	pendingSendsMu.Unlock()
+	if ok {
+		forwardLatency.Observe(time.Since(date).Seconds())
+	}
+}
@@ -0,0 +1,123 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/comerc/budva43/app/config"
+)
+
+// Трасса сопровождает обработку одного обновления TDLib: engine открывает корневой спан,
+// этапы (очередь, фильтры, преобразование, пересылка) открывают дочерние спаны;
+// текущий спан передаётся между этапами через context.Context.
+
+var (
+	provider = sdktrace.NewTracerProvider() // без экспорта: только идентификаторы для логов
+	tracer   = provider.Tracer("budva43")
+)
+
+// Setup включает экспорт спанов в коллектор OTLP, если задан config.Tracing.Endpoint
+func Setup(ctx context.Context) (*Provider, error) {
+	var options []sdktrace.TracerProviderOption
+	options = append(options, sdktrace.WithResource(resource.NewSchemaless(
+		semconv.ServiceName(config.Tracing.ServiceName),
+	)))
+	if config.Tracing.Endpoint != "" {
+		exporterOptions := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(config.Tracing.Endpoint),
+		}
+		if config.Tracing.Insecure {
+			exporterOptions = append(exporterOptions, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(ctx, exporterOptions...)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+	provider = sdktrace.NewTracerProvider(options...)
+	tracer = provider.Tracer("budva43")
+	return &Provider{provider}, nil
+}
+
+// Provider завершает экспорт спанов при остановке приложения
+type Provider struct {
+	provider *sdktrace.TracerProvider
+}
+
+// Close отправляет накопленные спаны и останавливает экспорт
+func (p *Provider) Close() error {
+	return p.provider.Shutdown(context.Background())
+}
+
+// Start открывает спан name: дочерний к спану из ctx, а без него - корневой спан новой трассы;
+// args - пары ключ-значение, как для логов (только идентификаторы, без текста сообщений).
+// Возвращает ctx со спаном и функцию, которая закрывает спан с ошибкой err (или без неё)
+func Start(ctx context.Context, name string, args ...any) (context.Context, func(err error)) {
+	ctx, span := tracer.Start(ctx, name, oteltrace.WithAttributes(toAttributes(args)...))
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// Id возвращает идентификатор трассы из ctx или пустую строку
+func Id(ctx context.Context) string {
+	spanContext := oteltrace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// Annotate добавляет в спан из ctx событие message или ошибку err;
+// аргументы записи лога не передаются: в них бывает текст сообщений
+func Annotate(ctx context.Context, err error, message string) {
+	span := oteltrace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+	} else {
+		span.AddEvent(message)
+	}
+}
+
+// toAttributes преобразует пары ключ-значение в атрибуты спана (slog.Attr пропускаются)
+func toAttributes(args []any) []attribute.KeyValue {
+	result := make([]attribute.KeyValue, 0, len(args)/2)
+	for i := 0; i < len(args); i++ {
+		key, ok := args[i].(string)
+		if !ok || i+1 == len(args) {
+			continue
+		}
+		i++
+		switch value := args[i].(type) {
+		case string:
+			result = append(result, attribute.String(key, value))
+		case int:
+			result = append(result, attribute.Int(key, value))
+		case int32:
+			result = append(result, attribute.Int64(key, int64(value)))
+		case int64:
+			result = append(result, attribute.Int64(key, value))
+		case bool:
+			result = append(result, attribute.Bool(key, value))
+		default:
+			result = append(result, attribute.String(key, fmt.Sprint(value)))
+		}
+	}
+	return result
+}
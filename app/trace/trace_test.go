@@ -0,0 +1,65 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStart(t *testing.T) {
+	// t.Parallel() // !! нельзя параллелить, тестирую с подменой глобальных переменных
+
+	recorder := tracetest.NewSpanRecorder()
+	prevProvider, prevTracer := provider, tracer
+	provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer = provider.Tracer("test")
+	t.Cleanup(func() {
+		provider, tracer = prevProvider, prevTracer
+	})
+
+	assert.Empty(t, Id(context.Background()))
+	Annotate(context.Background(), nil, "outside")
+
+	ctx, end := Start(context.Background(), "updateNewMessage", "chatId", int64(-1001))
+	traceId := Id(ctx)
+	require.NotEmpty(t, traceId)
+
+	forwardCtx, endForward := Start(ctx, "forward")
+	assert.Equal(t, traceId, Id(forwardCtx))
+	Annotate(forwardCtx, nil, "sent")
+	endForward(errors.New("failed"))
+
+	// задача очереди выполняется в другой горутине с ctx из обработчика
+	done := make(chan string)
+	go func() {
+		queueCtx, endQueue := Start(ctx, "queue")
+		defer endQueue(nil)
+		done <- Id(queueCtx)
+	}()
+	assert.Equal(t, traceId, <-done)
+
+	end(nil)
+
+	// другая трасса для следующего обновления
+	nextCtx, end := Start(context.Background(), "updateMessageEdited")
+	assert.NotEqual(t, traceId, Id(nextCtx))
+	end(nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+	root := spans[2]
+	assert.Equal(t, "updateNewMessage", root.Name())
+	assert.Equal(t, "forward", spans[0].Name())
+	assert.Equal(t, root.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, "sent", spans[0].Events()[0].Name)
+	assert.Empty(t, spans[0].Events()[0].Attributes)
+	assert.Equal(t, "failed", spans[0].Status().Description)
+	assert.Equal(t, "queue", spans[1].Name())
+	assert.Equal(t, root.SpanContext().SpanID(), spans[1].Parent().SpanID())
+	assert.False(t, spans[3].Parent().IsValid())
+}
@@ -8,13 +8,16 @@ import (
 	"github.com/spf13/viper"
 )
 
-// GetConfigOptions Настраиваем декодирование
-func GetConfigOptions() viper.DecoderConfigOption {
+// GetConfigOptions Настраиваем декодирование;
+// дополнительные хуки применяются после преобразования ключей в PascalCase
+func GetConfigOptions(hooks ...mapstructure.DecodeHookFunc) viper.DecoderConfigOption {
 	return viper.DecodeHook(
 		mapstructure.ComposeDecodeHookFunc(
-			mapstructure.StringToTimeDurationHookFunc(),
-			mapstructure.StringToSliceHookFunc(","),
-			kebabCaseKeyHookFunc(),
+			append([]mapstructure.DecodeHookFunc{
+				mapstructure.StringToTimeDurationHookFunc(),
+				mapstructure.StringToSliceHookFunc(","),
+				kebabCaseKeyHookFunc(),
+			}, hooks...)...,
 		),
 	)
 }
@@ -0,0 +1,117 @@
+package util
+
+import "strings"
+
+// DiffOp тип операции фрагмента разницы
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffDelete
+	DiffInsert
+)
+
+// DiffPart фрагмент разницы между двумя текстами
+type DiffPart struct {
+	Op   DiffOp
+	Text string
+}
+
+// maxDiffCells ограничение на размер таблицы LCS (кол-во слов старого текста * нового)
+const maxDiffCells = 1_000_000
+
+// DiffWords возвращает разницу между двумя текстами на уровне слов;
+// соседние слова с одинаковой операцией объединяются через пробел
+func DiffWords(oldText, newText string) []DiffPart {
+	a := strings.Fields(oldText)
+	b := strings.Fields(newText)
+
+	// Отбрасываем общие префикс и суффикс, чтобы уменьшить таблицу
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var words []DiffPart
+	for _, word := range a[:prefix] {
+		words = append(words, DiffPart{Op: DiffEqual, Text: word})
+	}
+	words = append(words, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, word := range a[len(a)-suffix:] {
+		words = append(words, DiffPart{Op: DiffEqual, Text: word})
+	}
+
+	return joinDiffParts(words)
+}
+
+// diffMiddle возвращает пословную разницу через наибольшую общую подпоследовательность
+func diffMiddle(a, b []string) []DiffPart {
+	var result []DiffPart
+	if len(a)*len(b) > maxDiffCells {
+		// Слишком большой текст - считаем, что он заменён целиком
+		for _, word := range a {
+			result = append(result, DiffPart{Op: DiffDelete, Text: word})
+		}
+		for _, word := range b {
+			result = append(result, DiffPart{Op: DiffInsert, Text: word})
+		}
+		return result
+	}
+
+	// lcs[i][j] - длина общей подпоследовательности для a[i:] и b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, DiffPart{Op: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, DiffPart{Op: DiffDelete, Text: a[i]})
+			i++
+		default:
+			result = append(result, DiffPart{Op: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		result = append(result, DiffPart{Op: DiffDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		result = append(result, DiffPart{Op: DiffInsert, Text: b[j]})
+	}
+	return result
+}
+
+// joinDiffParts объединяет соседние слова с одинаковой операцией
+func joinDiffParts(words []DiffPart) []DiffPart {
+	var result []DiffPart
+	for _, word := range words {
+		last := len(result) - 1
+		if last >= 0 && result[last].Op == word.Op {
+			result[last].Text += " " + word.Text
+			continue
+		}
+		result = append(result, word)
+	}
+	return result
+}
@@ -0,0 +1,72 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffWords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		oldText string
+		newText string
+		want    []DiffPart
+	}{
+		{
+			name:    "equal",
+			oldText: "a b  c",
+			newText: "a b\nc",
+			want: []DiffPart{
+				{Op: DiffEqual, Text: "a b c"},
+			},
+		},
+		{
+			name:    "replace_in_middle",
+			oldText: "one two three four",
+			newText: "one 2 three four",
+			want: []DiffPart{
+				{Op: DiffEqual, Text: "one"},
+				{Op: DiffDelete, Text: "two"},
+				{Op: DiffInsert, Text: "2"},
+				{Op: DiffEqual, Text: "three four"},
+			},
+		},
+		{
+			name:    "insert_and_delete",
+			oldText: "a b c d",
+			newText: "a x c",
+			want: []DiffPart{
+				{Op: DiffEqual, Text: "a"},
+				{Op: DiffDelete, Text: "b"},
+				{Op: DiffInsert, Text: "x"},
+				{Op: DiffEqual, Text: "c"},
+				{Op: DiffDelete, Text: "d"},
+			},
+		},
+		{
+			name:    "from_empty",
+			oldText: "",
+			newText: "new text",
+			want: []DiffPart{
+				{Op: DiffInsert, Text: "new text"},
+			},
+		},
+		{
+			name:    "both_empty",
+			oldText: "",
+			newText: "",
+			want:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.want, DiffWords(test.oldText, test.newText))
+		})
+	}
+}
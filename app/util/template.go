@@ -0,0 +1,19 @@
+package util
+
+import (
+	"fmt"
+	"text/template"
+)
+
+// NewMarkdownTemplate разбирает text/template для Markdown V2;
+// функция escape экранирует значения из данных шаблона
+func NewMarkdownTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"escape": func(v any) string {
+				return EscapeMarkdown(fmt.Sprint(v))
+			},
+		}).
+		Parse(text)
+}
@@ -6,8 +6,11 @@ import (
 	"os"
 
 	app "github.com/comerc/budva43/app"
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/util"
 	updateDeleteMessagesHandler "github.com/comerc/budva43/handler/update_delete_messages"
 	updateMessageEditedHandler "github.com/comerc/budva43/handler/update_message_edited"
+	updateMessageIsPinnedHandler "github.com/comerc/budva43/handler/update_message_is_pinned"
 	updateMessageSendHandler "github.com/comerc/budva43/handler/update_message_send"
 	updateNewMessageHandler "github.com/comerc/budva43/handler/update_new_message"
 	queueRepo "github.com/comerc/budva43/repo/queue"
@@ -15,17 +18,27 @@ import (
 	telegramRepo "github.com/comerc/budva43/repo/telegram"
 	termRepo "github.com/comerc/budva43/repo/term"
 	authService "github.com/comerc/budva43/service/auth"
+	bridgeService "github.com/comerc/budva43/service/bridge"
 	engineService "github.com/comerc/budva43/service/engine"
+	explainService "github.com/comerc/budva43/service/explain"
+	facadeGQL "github.com/comerc/budva43/service/facade_gql"
+	facadeGRPC "github.com/comerc/budva43/service/facade_grpc"
 	filtersModeService "github.com/comerc/budva43/service/filters_mode"
 	forwardedToService "github.com/comerc/budva43/service/forwarded_to"
 	forwarderService "github.com/comerc/budva43/service/forwarder"
 	loaderService "github.com/comerc/budva43/service/loader"
 	mediaAlbumService "github.com/comerc/budva43/service/media_album"
+	mediaCacheService "github.com/comerc/budva43/service/media_cache"
 	messageService "github.com/comerc/budva43/service/message"
 	rateLimiterService "github.com/comerc/budva43/service/rate_limiter"
+	reportService "github.com/comerc/budva43/service/report"
 	storageService "github.com/comerc/budva43/service/storage"
 	transformService "github.com/comerc/budva43/service/transform"
+	whenceService "github.com/comerc/budva43/service/whence"
+	grpcTransport "github.com/comerc/budva43/transport/grpc"
+	metricsTransport "github.com/comerc/budva43/transport/metrics"
 	termTransport "github.com/comerc/budva43/transport/term"
+	webTransport "github.com/comerc/budva43/transport/web"
 )
 
 // Engine - это сервис, который выполняет пересылку сообщений.
@@ -46,7 +59,17 @@ func runEngine(
 	var err error
 
 	// - Инициализация репозиториев
-	storageRepo := storageRepo.New()
+	storageRepo, err := storageRepo.NewBackend(config.Storage.Backend)
+	if err != nil {
+		return err
+	}
+	// восстановление из резервной копии до открытия базы: -restore=<файл>
+	if path := util.GetFlag("restore"); path != nil {
+		err = storageRepo.Restore(*path)
+		if err != nil {
+			return err
+		}
+	}
 	err = storageRepo.StartContext(ctx)
 	if err != nil {
 		return err
@@ -73,9 +96,15 @@ func runEngine(
 
 	// - Инициализация вспомогательных сервисов
 	storageService := storageService.New(storageRepo)
+	err = storageService.StartContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer gracefulShutdown(storageService)
 	loaderService := loaderService.New(telegramRepo)
 	messageService := messageService.New()
 	mediaAlbumService := mediaAlbumService.New()
+	mediaCacheService := mediaCacheService.New(telegramRepo)
 	transformService := transformService.New(
 		telegramRepo,
 		storageService,
@@ -89,6 +118,7 @@ func runEngine(
 		storageService,
 		messageService,
 		transformService,
+		mediaCacheService,
 		rateLimiterService,
 	)
 	err = forwarderService.StartContext(ctx)
@@ -96,6 +126,19 @@ func runEngine(
 		return err
 	}
 	defer gracefulShutdown(forwarderService)
+	bridgeService := bridgeService.New(
+		telegramRepo,
+		storageService,
+		messageService,
+	)
+	whenceService := whenceService.New(
+		telegramRepo,
+		storageService,
+	)
+	explainService := explainService.New(
+		telegramRepo,
+		storageService,
+	)
 	authService := authService.New(
 		telegramRepo,
 		loaderService,
@@ -116,6 +159,7 @@ func runEngine(
 		filtersModeService,
 		forwardedToService,
 		forwarderService,
+		bridgeService,
 	)
 	updateMessageEditedHandler := updateMessageEditedHandler.New(
 		telegramRepo,
@@ -130,17 +174,25 @@ func runEngine(
 		telegramRepo,
 		queueRepo,
 		storageService,
+		messageService,
+		transformService,
 	)
 	updateMessageSendHandler := updateMessageSendHandler.New(
 		queueRepo,
 		storageService,
 	)
+	updateMessageIsPinnedHandler := updateMessageIsPinnedHandler.New(
+		telegramRepo,
+		queueRepo,
+		storageService,
+	)
 	engineService := engineService.New(
 		telegramRepo,
 		updateNewMessageHandler,
 		updateMessageEditedHandler,
 		updateDeleteMessagesHandler,
 		updateMessageSendHandler,
+		updateMessageIsPinnedHandler,
 	)
 	err = engineService.StartContext(ctx)
 	if err != nil {
@@ -149,42 +201,74 @@ func runEngine(
 	defer gracefulShutdown(engineService)
 
 	// - Инициализация фасадов
-	// facadeGQL := facadeGQL.New(
-	// 	telegramRepo,
-	// )
-	// facadeGRPC := facadeGRPC.New(
-	// 	telegramRepo,
-	// 	messageService,
-	// )
+	facadeGQL := facadeGQL.New(
+		telegramRepo,
+		explainService,
+		storageService,
+	)
+	facadeGRPC := facadeGRPC.New(
+		telegramRepo,
+		messageService,
+		mediaAlbumService,
+		whenceService,
+		explainService,
+		storageService,
+	)
+	reportService := reportService.New(
+		storageService,
+		facadeGRPC,
+	)
+	err = reportService.StartContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer gracefulShutdown(reportService)
 
 	// - Инициализация транспортных адаптеров
 	termTransport := termTransport.New(
 		telegramRepo,
 		termRepo,
 		authService,
+		whenceService,
+		explainService,
+		storageService,
 	)
 	err = termTransport.StartContext(ctx, cancel)
 	if err != nil {
 		return err
 	}
 	defer gracefulShutdown(termTransport)
-	// webTransport := webTransport.New(
-	// 	authService,
-	// 	facadeGQL,
-	// )
-	// err = webTransport.StartContext(ctx, cancel)
-	// if err != nil {
-	// 	return err
-	// }
-	// defer gracefulShutdown(webTransport)
-	// grpcTransport := grpcTransport.New(
-	// 	facadeGRPC,
-	// )
-	// err = grpcTransport.Start()
-	// if err != nil {
-	// 	return err
-	// }
-	// defer gracefulShutdown(grpcTransport)
+	// GraphQL в engine нужен для статистики: хранилище доступно только здесь
+	if config.Web.EnginePort != "" {
+		webTransport := webTransport.New(
+			authService,
+			facadeGQL,
+		).WithPort(config.Web.EnginePort)
+		err = webTransport.StartContext(ctx, cancel)
+		if err != nil {
+			return err
+		}
+		defer gracefulShutdown(webTransport)
+	}
+	// gRPC в engine нужен для whence, резервных копий и статистики: хранилище доступно только здесь
+	if config.Grpc.EnginePort != "" {
+		grpcTransport := grpcTransport.New(
+			facadeGRPC,
+		).WithPort(config.Grpc.EnginePort)
+		err = grpcTransport.Start()
+		if err != nil {
+			return err
+		}
+		defer gracefulShutdown(grpcTransport)
+	}
+	if config.Metrics.EnginePort != "" {
+		metricsTransport := metricsTransport.New().WithPort(config.Metrics.EnginePort)
+		err = metricsTransport.Start()
+		if err != nil {
+			return err
+		}
+		defer gracefulShutdown(metricsTransport)
+	}
 
 	wait()
 
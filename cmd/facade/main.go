@@ -6,6 +6,7 @@ import (
 	"os"
 
 	app "github.com/comerc/budva43/app"
+	"github.com/comerc/budva43/app/config"
 	telegramRepo "github.com/comerc/budva43/repo/telegram"
 	termRepo "github.com/comerc/budva43/repo/term"
 	authService "github.com/comerc/budva43/service/auth"
@@ -15,6 +16,7 @@ import (
 	mediaAlbumService "github.com/comerc/budva43/service/media_album"
 	messageService "github.com/comerc/budva43/service/message"
 	grpcTransport "github.com/comerc/budva43/transport/grpc"
+	metricsTransport "github.com/comerc/budva43/transport/metrics"
 	termTransport "github.com/comerc/budva43/transport/term"
 	webTransport "github.com/comerc/budva43/transport/web"
 )
@@ -80,6 +82,7 @@ func runFacade(
 	// 	storageService,
 	// 	messageService,
 	// 	transformService,
+	// 	mediaCacheService,
 	// 	rateLimiterService,
 	// )
 	// err = forwarderService.StartContext(ctx)
@@ -87,6 +90,11 @@ func runFacade(
 	// 	return err
 	// }
 	// defer gracefulShutdown(forwarderService)
+	// bridgeService := bridgeService.New(
+	// 	telegramRepo,
+	// 	storageService,
+	// 	messageService,
+	// )
 
 	// - Инициализация сервиса авторизации
 	authService := authService.New(
@@ -109,6 +117,7 @@ func runFacade(
 	// 	filtersModeService,
 	// 	forwardedToService,
 	// 	forwarderService,
+	// 	bridgeService,
 	// )
 	// updateMessageEditedHandler := updateMessageEditedHandler.New(
 	// 	telegramRepo,
@@ -128,12 +137,18 @@ func runFacade(
 	// 	queueRepo,
 	// 	storageService,
 	// )
+	// updateMessageIsPinnedHandler := updateMessageIsPinnedHandler.New(
+	// 	telegramRepo,
+	// 	queueRepo,
+	// 	storageService,
+	// )
 	// engineService := engineService.New(
 	// 	telegramRepo,
 	// 	updateNewMessageHandler,
 	// 	updateMessageEditedHandler,
 	// 	updateDeleteMessagesHandler,
 	// 	updateMessageSendHandler,
+	// 	updateMessageIsPinnedHandler,
 	// )
 	// err = engineService.StartContext(ctx)
 	// if err != nil {
@@ -144,11 +159,16 @@ func runFacade(
 	// - Инициализация фасадов
 	facadeGQL := facadeGQL.New(
 		telegramRepo,
+		nil, // explainService требует хранилища (только в engine)
+		nil, // storageService требует хранилища (только в engine)
 	)
 	facadeGRPC := facadeGRPC.New(
 		telegramRepo,
 		messageService,
 		mediaAlbumService,
+		nil, // whenceService требует хранилища (только в engine)
+		nil, // explainService требует хранилища (только в engine)
+		nil, // storageService требует хранилища (только в engine)
 	)
 
 	// - Инициализация транспортных адаптеров
@@ -156,6 +176,9 @@ func runFacade(
 		telegramRepo,
 		termRepo,
 		authService,
+		nil, // whenceService требует хранилища (только в engine)
+		nil, // explainService требует хранилища (только в engine)
+		nil, // storageService требует хранилища (только в engine)
 	)
 	err = termTransport.StartContext(ctx, cancel)
 	if err != nil {
@@ -179,6 +202,14 @@ func runFacade(
 		return err
 	}
 	defer gracefulShutdown(grpcTransport)
+	if config.Metrics.Port != "" {
+		metricsTransport := metricsTransport.New()
+		err = metricsTransport.Start()
+		if err != nil {
+			return err
+		}
+		defer gracefulShutdown(metricsTransport)
+	}
 
 	wait()
 
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	app "github.com/comerc/budva43/app"
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/util"
+	storageRepo "github.com/comerc/budva43/repo/storage"
+	telegramRepo "github.com/comerc/budva43/repo/telegram"
+	authService "github.com/comerc/budva43/service/auth"
+	loaderService "github.com/comerc/budva43/service/loader"
+	storageService "github.com/comerc/budva43/service/storage"
+	storageCheckService "github.com/comerc/budva43/service/storage_check"
+)
+
+// Storage - обслуживание хранилища engine (запускать при остановленном engine):
+// copy -from=badger -to=sqlite - копирование данных между реализациями хранилища
+// check [-repair] [-dry-run] - проверка согласованности связей сообщений; исправление
+// сверяется с Telegram (нужна авторизованная сессия engine), -dry-run только показывает его
+
+func main() {
+	if err := app.NewApp().Run(runStorage); err != nil {
+		os.Exit(1)
+	}
+}
+
+func runStorage(
+	ctx context.Context,
+	cancel func(),
+	gracefulShutdown func(closer io.Closer),
+	wait func(),
+) error {
+	if len(os.Args) < 2 {
+		return log.NewError("usage: storage copy|check")
+	}
+	switch os.Args[1] {
+	case "copy":
+		return runCopy(ctx, gracefulShutdown)
+	case "check":
+		return runCheck(ctx, gracefulShutdown)
+	}
+	return log.NewError("unknown command", "cmd", os.Args[1])
+}
+
+// runCopy копирует данные между реализациями хранилища
+func runCopy(ctx context.Context, gracefulShutdown func(closer io.Closer)) error {
+	var err error
+
+	from := util.GetFlag("from")
+	to := util.GetFlag("to")
+	if from == nil || to == nil || *from == *to {
+		return log.NewError("usage: storage copy -from=<backend> -to=<backend>",
+			"backends", storageRepo.GetBackendNames(),
+		)
+	}
+
+	var fromRepo, toRepo storageRepo.Backend
+	fromRepo, err = storageRepo.NewBackend(*from)
+	if err != nil {
+		return err
+	}
+	toRepo, err = storageRepo.NewBackend(*to)
+	if err != nil {
+		return err
+	}
+	err = fromRepo.StartContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer gracefulShutdown(fromRepo)
+	err = toRepo.StartContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer gracefulShutdown(toRepo)
+
+	var result int
+	result, err = storageRepo.Copy(fromRepo, toRepo)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Скопировано значений: %d (%s -> %s)\n", result, *from, *to)
+
+	return nil
+}
+
+// runCheck проверяет согласованность связей сообщений и, при -repair, исправляет их
+func runCheck(ctx context.Context, gracefulShutdown func(closer io.Closer)) error {
+	var err error
+
+	isRepair := util.HasFlag("repair")
+	isDryRun := util.HasFlag("dry-run")
+
+	var repo storageRepo.Backend
+	repo, err = storageRepo.NewBackend(config.Storage.Backend)
+	if err != nil {
+		return err
+	}
+	err = repo.StartContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer gracefulShutdown(repo)
+
+	// Telegram нужен только для исправления: проверка наличия сообщений-копий
+	telegramRepo := telegramRepo.New()
+	if isRepair {
+		err = telegramRepo.Start()
+		if err != nil {
+			return err
+		}
+		defer gracefulShutdown(telegramRepo)
+		loaderService := loaderService.New(telegramRepo)
+		authService := authService.New(
+			telegramRepo,
+			loaderService,
+		)
+		err = authService.StartContext(ctx)
+		if err != nil {
+			return err
+		}
+		defer gracefulShutdown(authService)
+	}
+
+	storageService := storageService.New(repo)
+	storageCheckService := storageCheckService.New(
+		telegramRepo,
+		storageService,
+	)
+
+	var issues []*domain.StorageIssue
+	issues, err = storageCheckService.Run(isRepair, isDryRun)
+	if err != nil {
+		return err
+	}
+	printIssues(issues)
+
+	return nil
+}
+
+// printIssues выводит несогласованные связи по категориям
+func printIssues(issues []*domain.StorageIssue) {
+	if len(issues) == 0 {
+		fmt.Println("Несогласованных связей нет")
+		return
+	}
+	var kind domain.StorageIssueKind
+	for _, issue := range issues {
+		if issue.Kind != kind {
+			kind = issue.Kind
+			fmt.Printf("%s:\n", kind)
+		}
+		fmt.Printf("  %d:%d", issue.ChatId, issue.TmpMessageId)
+		if issue.NewMessageId != 0 {
+			fmt.Printf(" (new %d)", issue.NewMessageId)
+		}
+		if issue.Source != nil {
+			fmt.Printf(" <- %d:%d [%s]", issue.Source.ChatId, issue.Source.MessageId, issue.Source.ForwardRuleId)
+		}
+		if issue.Repair != "" {
+			fmt.Printf(" %s", issue.Repair)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("Всего: %d\n", len(issues))
+}
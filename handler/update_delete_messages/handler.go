@@ -1,8 +1,9 @@
 package update_delete_messages
 
 import (
+	"context"
 	"fmt"
-	"strings"
+	"slices"
 
 	"github.com/zelenin/go-tdlib/client"
 
@@ -16,47 +17,82 @@ import (
 type telegramRepo interface {
 	// tdlibClient methods
 	DeleteMessages(*client.DeleteMessagesRequest) (*client.Ok, error)
+	GetMessage(*client.GetMessageRequest) (*client.Message, error)
+	EditMessageText(*client.EditMessageTextRequest) (*client.Message, error)
+	EditMessageCaption(*client.EditMessageCaptionRequest) (*client.Message, error)
 }
 
 //go:generate mockery --name=queueRepo --exported
 type queueRepo interface {
-	Add(fn func())
+	Add(ctx context.Context, fn func(ctx context.Context))
 }
 
 //go:generate mockery --name=storageService --exported
 type storageService interface {
-	GetCopiedMessageIds(chatId, messageId int64) []string
+	GetCopiedMessageIds(chatId, messageId int64) []*domain.ChatMessage
 	DeleteCopiedMessageIds(chatId, messageId int64)
+	GetRevisionMessageIds(chatId, messageId int64) []*domain.ChatMessage
+	DeleteRevisionMessageIds(chatId, messageId int64)
+	DeleteTextSnapshot(chatId, messageId int64)
 	GetNewMessageId(chatId, tmpMessageId int64) int64
 	DeleteNewMessageId(chatId, tmpMessageId int64)
 	DeleteTmpMessageId(chatId, newMessageId int64)
 	DeleteAnswerMessageId(dstChatId, tmpMessageId int64)
+	DeleteOriginMessageId(dstChatId, dstMessageId int64)
+	SetTombstone(dstChatId, newMessageId int64)
+	GetMediaAlbumMessageIds(chatId, messageId int64) []int64
+	SetMediaAlbumMessageIds(chatId int64, messageIds []int64)
+	DeleteMediaAlbumMessageIds(chatId, messageId int64)
+	GetOverflowMessageIds(dstChatId, tmpMessageId int64) []int64
+	DeleteOverflowMessageIds(dstChatId, tmpMessageId int64)
+	GetEditDiffMessageIds(dstChatId, tmpMessageId int64) []int64
+	DeleteEditDiffMessageIds(dstChatId, tmpMessageId int64)
+	GetReplyContextMessageId(dstChatId, tmpMessageId int64) int64
+	DeleteReplyContextMessageId(dstChatId, tmpMessageId int64)
+}
+
+//go:generate mockery --name=messageService --exported
+type messageService interface {
+	GetFormattedText(message *client.Message) *client.FormattedText
+	GetInputMessageContent(message *client.Message, formattedText *client.FormattedText) client.InputMessageContent
+}
+
+//go:generate mockery --name=transformService --exported
+type transformService interface {
+	Transform(ctx context.Context, formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId, prevMessageId int64, engineConfig *domain.EngineConfig)
+	AddTombstone(formattedText *client.FormattedText, forwardRule *domain.ForwardRule)
 }
 
 type Handler struct {
 	log *log.Logger
 	//
-	telegramRepo   telegramRepo
-	queueRepo      queueRepo
-	storageService storageService
+	telegramRepo     telegramRepo
+	queueRepo        queueRepo
+	storageService   storageService
+	messageService   messageService
+	transformService transformService
 }
 
 func New(
 	telegramRepo telegramRepo,
 	queueRepo queueRepo,
 	storageService storageService,
+	messageService messageService,
+	transformService transformService,
 ) *Handler {
 	return &Handler{
 		log: log.NewLogger(),
 		//
-		telegramRepo:   telegramRepo,
-		queueRepo:      queueRepo,
-		storageService: storageService,
+		telegramRepo:     telegramRepo,
+		queueRepo:        queueRepo,
+		storageService:   storageService,
+		messageService:   messageService,
+		transformService: transformService,
 	}
 }
 
 // Run выполняет обрабатку обновления об удалении сообщений
-func (h *Handler) Run(update *client.UpdateDeleteMessages) {
+func (h *Handler) Run(ctx context.Context, update *client.UpdateDeleteMessages) {
 	if !update.IsPermanent {
 		return
 	}
@@ -72,11 +108,11 @@ func (h *Handler) Run(update *client.UpdateDeleteMessages) {
 	const maxRetries = 3
 	retryCount := 0
 
-	var fn func()
-	fn = func() {
+	var fn func(ctx context.Context)
+	fn = func(ctx context.Context) {
 		var err error
 		defer func() {
-			h.log.ErrorOrDebug(err, "",
+			h.log.ErrorOrDebugContext(ctx, err, "",
 				"retryCount", retryCount,
 				"chatId", chatId,
 				"messageIds", messageIds,
@@ -90,39 +126,46 @@ func (h *Handler) Run(update *client.UpdateDeleteMessages) {
 				err = log.NewError("max retries reached for message deletion")
 				return
 			}
-			h.queueRepo.Add(fn) // переставляем в конец очереди
+			h.queueRepo.Add(ctx, fn) // переставляем в конец очереди
 			return
 		}
 
-		h.deleteMessages(chatId, messageIds, data, engineConfig)
+		h.deleteMessages(ctx, chatId, messageIds, data, engineConfig)
+		h.updateMediaAlbums(ctx, chatId, messageIds, engineConfig)
 	}
 
-	h.queueRepo.Add(fn)
+	h.queueRepo.Add(ctx, fn)
 }
 
 type data struct {
 	needRepeat       bool
-	copiedMessageIds map[string][]string // fromChatMessageId -> []toChatMessageId
-	newMessageIds    map[string]int64    // tmpChatMessageId -> newMessageId
+	copiedMessageIds map[string][]*domain.ChatMessage // fromChatMessageId -> []toChatMessage
+	newMessageIds    map[string]int64                 // tmpChatMessageId -> newMessageId
 }
 
 // collectData собирает данные для удаления сообщений
 func (h *Handler) collectData(chatId int64, messageIds []int64) *data {
 	result := &data{
-		copiedMessageIds: make(map[string][]string),
+		copiedMessageIds: make(map[string][]*domain.ChatMessage),
 		newMessageIds:    make(map[string]int64),
 	}
 
 	for _, messageId := range messageIds {
-		toChatMessageIds := h.storageService.GetCopiedMessageIds(chatId, messageId)
+		toChatMessages := h.storageService.GetCopiedMessageIds(chatId, messageId)
+		// цепочка редакций содержит все копии, включая предыдущие редакции
+		for _, revisionMessage := range h.storageService.GetRevisionMessageIds(chatId, messageId) {
+			if !slices.ContainsFunc(toChatMessages, func(toChatMessage *domain.ChatMessage) bool {
+				return *toChatMessage == *revisionMessage
+			}) {
+				toChatMessages = append(toChatMessages, revisionMessage)
+			}
+		}
 		fromChatMessageId := fmt.Sprintf("%d:%d", chatId, messageId)
-		result.copiedMessageIds[fromChatMessageId] = toChatMessageIds
+		result.copiedMessageIds[fromChatMessageId] = toChatMessages
 
-		for _, toChatMessageId := range toChatMessageIds {
-			a := strings.Split(toChatMessageId, ":")
-			// forwardRuleId := a[0]
-			dstChatId := util.ConvertToInt[int64](a[1])
-			tmpMessageId := util.ConvertToInt[int64](a[2])
+		for _, toChatMessage := range toChatMessages {
+			dstChatId := toChatMessage.ChatId
+			tmpMessageId := toChatMessage.MessageId
 
 			newMessageId := h.storageService.GetNewMessageId(dstChatId, tmpMessageId)
 			if newMessageId == 0 {
@@ -139,11 +182,11 @@ func (h *Handler) collectData(chatId int64, messageIds []int64) *data {
 }
 
 // deleteMessages удаляет сообщения
-func (h *Handler) deleteMessages(chatId int64, messageIds []int64, data *data, engineConfig *domain.EngineConfig) {
+func (h *Handler) deleteMessages(ctx context.Context, chatId int64, messageIds []int64, data *data, engineConfig *domain.EngineConfig) {
 	var err error
 	result := []string{}
 	defer func() {
-		h.log.ErrorOrDebug(err, "",
+		h.log.ErrorOrDebugContext(ctx, err, "",
 			"chatId", chatId,
 			"messageIds", messageIds,
 			"result", result,
@@ -152,25 +195,24 @@ func (h *Handler) deleteMessages(chatId int64, messageIds []int64, data *data, e
 
 	for _, messageId := range messageIds {
 		fromChatMessageId := fmt.Sprintf("%d:%d", chatId, messageId)
-		toChatMessageIds := data.copiedMessageIds[fromChatMessageId]
+		toChatMessages := data.copiedMessageIds[fromChatMessageId]
 
-		for _, toChatMessageId := range toChatMessageIds {
+		for _, toChatMessage := range toChatMessages {
 			func() {
 				var err error
 				forwardRuleId := ""
 				defer func() {
-					h.log.ErrorOrDebug(err, "",
+					h.log.ErrorOrDebugContext(ctx, err, "",
 						"chatId", chatId,
 						"messageId", messageId,
-						"toChatMessageId", toChatMessageId,
+						"toChatMessage", toChatMessage,
 						"forwardRuleId", forwardRuleId,
 					)
 				}()
 
-				a := strings.Split(toChatMessageId, ":")
-				forwardRuleId = a[0]
-				dstChatId := util.ConvertToInt[int64](a[1])
-				tmpMessageId := util.ConvertToInt[int64](a[2])
+				forwardRuleId = toChatMessage.ForwardRuleId
+				dstChatId := toChatMessage.ChatId
+				tmpMessageId := toChatMessage.MessageId
 
 				forwardRule, ok := engineConfig.ForwardRules[forwardRuleId]
 				if !ok {
@@ -186,17 +228,44 @@ func (h *Handler) deleteMessages(chatId int64, messageIds []int64, data *data, e
 				tmpChatMessageId := fmt.Sprintf("%d:%d", dstChatId, tmpMessageId)
 				newMessageId := data.newMessageIds[tmpChatMessageId]
 
+				isTombstone := forwardRule.OnDelete == domain.OnDeleteTombstone
+				if isTombstone {
+					isTombstone = h.addTombstone(ctx, dstChatId, newMessageId, forwardRule)
+				}
+
 				// TODO: может лучше удалять индексы _после_ удаления сообщения?
 				h.storageService.DeleteTmpMessageId(dstChatId, newMessageId)
 				h.storageService.DeleteNewMessageId(dstChatId, tmpMessageId)
+				// помеченная копия остаётся в получателе, поэтому обратная связь с оригиналом сохраняется;
+				// до успешной отправки обратная связь хранится по временному идентификатору
+				if isTombstone {
+					h.storageService.SetTombstone(dstChatId, newMessageId)
+				} else if newMessageId != 0 {
+					h.storageService.DeleteOriginMessageId(dstChatId, newMessageId)
+				} else {
+					h.storageService.DeleteOriginMessageId(dstChatId, tmpMessageId)
+				}
+				attachedMessageIds := h.popOverflowMessageIds(dstChatId, tmpMessageId)
+				attachedMessageIds = append(attachedMessageIds, h.popEditDiffMessageIds(dstChatId, tmpMessageId)...)
+				if contextMessageId := h.popReplyContextMessageId(dstChatId, tmpMessageId); contextMessageId != 0 {
+					attachedMessageIds = append(attachedMessageIds, contextMessageId)
+				}
 
-				_, err = h.telegramRepo.DeleteMessages(&client.DeleteMessagesRequest{
-					ChatId:     dstChatId,
-					MessageIds: []int64{newMessageId},
-					Revoke:     true,
-				})
-				if err != nil {
-					return
+				// без пометки копия удаляется, иначе она останется без связи с оригиналом;
+				// продолжение текста, изменения текста и контекст ответа удаляются в любом случае, т.к. их связи удалены
+				messageIds := attachedMessageIds
+				if !isTombstone {
+					messageIds = append([]int64{newMessageId}, attachedMessageIds...)
+				}
+				if len(messageIds) > 0 {
+					_, err = h.telegramRepo.DeleteMessages(&client.DeleteMessagesRequest{
+						ChatId:     dstChatId,
+						MessageIds: messageIds,
+						Revoke:     true,
+					})
+					if err != nil {
+						return
+					}
 				}
 
 				result = append(result,
@@ -204,8 +273,226 @@ func (h *Handler) deleteMessages(chatId int64, messageIds []int64, data *data, e
 			}()
 		}
 
-		if len(toChatMessageIds) > 0 {
+		if len(toChatMessages) > 0 {
 			h.storageService.DeleteCopiedMessageIds(chatId, messageId)
+			h.storageService.DeleteRevisionMessageIds(chatId, messageId)
+			h.storageService.DeleteTextSnapshot(chatId, messageId)
+		}
+	}
+}
+
+// popOverflowMessageIds возвращает идентификаторы ответов с продолжением текста копии
+// и удаляет связанные с ними индексы
+func (h *Handler) popOverflowMessageIds(dstChatId, tmpMessageId int64) []int64 {
+	overflowTmpMessageIds := h.storageService.GetOverflowMessageIds(dstChatId, tmpMessageId)
+	if len(overflowTmpMessageIds) == 0 {
+		return nil
+	}
+	h.storageService.DeleteOverflowMessageIds(dstChatId, tmpMessageId)
+
+	result := make([]int64, 0, len(overflowTmpMessageIds))
+	for _, overflowTmpMessageId := range overflowTmpMessageIds {
+		newMessageId := h.storageService.GetNewMessageId(dstChatId, overflowTmpMessageId)
+		h.storageService.DeleteNewMessageId(dstChatId, overflowTmpMessageId)
+		if newMessageId == 0 {
+			h.storageService.DeleteOriginMessageId(dstChatId, overflowTmpMessageId)
+			continue
+		}
+		h.storageService.DeleteTmpMessageId(dstChatId, newMessageId)
+		h.storageService.DeleteOriginMessageId(dstChatId, newMessageId)
+		result = append(result, newMessageId)
+	}
+	return result
+}
+
+// popEditDiffMessageIds возвращает идентификаторы ответов с изменениями текста копии
+// и удаляет связанные с ними индексы
+func (h *Handler) popEditDiffMessageIds(dstChatId, tmpMessageId int64) []int64 {
+	editDiffTmpMessageIds := h.storageService.GetEditDiffMessageIds(dstChatId, tmpMessageId)
+	if len(editDiffTmpMessageIds) == 0 {
+		return nil
+	}
+	h.storageService.DeleteEditDiffMessageIds(dstChatId, tmpMessageId)
+
+	result := make([]int64, 0, len(editDiffTmpMessageIds))
+	for _, editDiffTmpMessageId := range editDiffTmpMessageIds {
+		newMessageId := h.storageService.GetNewMessageId(dstChatId, editDiffTmpMessageId)
+		h.storageService.DeleteNewMessageId(dstChatId, editDiffTmpMessageId)
+		if newMessageId == 0 {
+			continue
+		}
+		h.storageService.DeleteTmpMessageId(dstChatId, newMessageId)
+		result = append(result, newMessageId)
+	}
+	return result
+}
+
+// popReplyContextMessageId возвращает идентификатор сообщения с контекстом ответа для форварда
+// и удаляет связанные с ним индексы
+func (h *Handler) popReplyContextMessageId(dstChatId, tmpMessageId int64) int64 {
+	contextTmpMessageId := h.storageService.GetReplyContextMessageId(dstChatId, tmpMessageId)
+	if contextTmpMessageId == 0 {
+		return 0
+	}
+	h.storageService.DeleteReplyContextMessageId(dstChatId, tmpMessageId)
+
+	newMessageId := h.storageService.GetNewMessageId(dstChatId, contextTmpMessageId)
+	h.storageService.DeleteNewMessageId(dstChatId, contextTmpMessageId)
+	if newMessageId != 0 {
+		h.storageService.DeleteTmpMessageId(dstChatId, newMessageId)
+		h.storageService.DeleteOriginMessageId(dstChatId, newMessageId)
+	} else {
+		h.storageService.DeleteOriginMessageId(dstChatId, contextTmpMessageId)
+	}
+	return newMessageId
+}
+
+// addTombstone помечает копию удалённого оригинала вместо удаления;
+// возвращает false, если копию невозможно пометить: форвард, содержимое без подписи или ошибка
+func (h *Handler) addTombstone(ctx context.Context, dstChatId, newMessageId int64, forwardRule *domain.ForwardRule) bool {
+	var (
+		err    error
+		result bool
+	)
+	defer func() {
+		h.log.ErrorOrDebugContext(ctx, err, "",
+			"dstChatId", dstChatId,
+			"newMessageId", newMessageId,
+			"result", result,
+		)
+	}()
+
+	var dst *client.Message
+	dst, err = h.telegramRepo.GetMessage(&client.GetMessageRequest{
+		ChatId:    dstChatId,
+		MessageId: newMessageId,
+	})
+	if err != nil {
+		return false
+	}
+	if dst.ForwardInfo != nil {
+		return false
+	}
+
+	formattedText := h.messageService.GetFormattedText(dst)
+	if formattedText == nil {
+		return false // стикеры, опросы и т.п.
+	}
+	h.transformService.AddTombstone(formattedText, forwardRule)
+
+	if _, ok := dst.Content.(*client.MessageText); ok {
+		_, err = h.telegramRepo.EditMessageText(&client.EditMessageTextRequest{
+			ChatId:              dstChatId,
+			MessageId:           newMessageId,
+			InputMessageContent: h.messageService.GetInputMessageContent(dst, formattedText),
+		})
+	} else {
+		_, err = h.telegramRepo.EditMessageCaption(&client.EditMessageCaptionRequest{
+			ChatId:    dstChatId,
+			MessageId: newMessageId,
+			Caption:   formattedText,
+		})
+	}
+	if err != nil {
+		return false
+	}
+	result = true
+	return result
+}
+
+// updateMediaAlbums обновляет состав медиа-альбомов после удаления части сообщений;
+// если удалено первое сообщение, то подпись и ссылки на источник переносятся на следующее
+func (h *Handler) updateMediaAlbums(ctx context.Context, chatId int64, messageIds []int64, engineConfig *domain.EngineConfig) {
+	mediaAlbums := make(map[int64][]int64) // first messageId -> mediaAlbumMessageIds
+	for _, messageId := range messageIds {
+		mediaAlbumMessageIds := h.storageService.GetMediaAlbumMessageIds(chatId, messageId)
+		if len(mediaAlbumMessageIds) == 0 {
+			continue
+		}
+		mediaAlbums[mediaAlbumMessageIds[0]] = mediaAlbumMessageIds
+		h.storageService.DeleteMediaAlbumMessageIds(chatId, messageId)
+	}
+
+	for firstMessageId, mediaAlbumMessageIds := range mediaAlbums {
+		remainingMessageIds := slices.DeleteFunc(slices.Clone(mediaAlbumMessageIds), func(messageId int64) bool {
+			return slices.Contains(messageIds, messageId)
+		})
+		if len(remainingMessageIds) == 0 {
+			continue
+		}
+		h.storageService.SetMediaAlbumMessageIds(chatId, remainingMessageIds)
+		if remainingMessageIds[0] != firstMessageId {
+			h.moveSources(ctx, chatId, remainingMessageIds[0], engineConfig)
+		}
+	}
+}
+
+// moveSources добавляет подпись и ссылки на источник в копии нового первого сообщения медиа-альбома
+func (h *Handler) moveSources(ctx context.Context, chatId, messageId int64, engineConfig *domain.EngineConfig) {
+	var (
+		err    error
+		result []*domain.ChatMessage
+	)
+	defer func() {
+		h.log.ErrorOrDebugContext(ctx, err, "",
+			"chatId", chatId,
+			"messageId", messageId,
+			"result", result,
+		)
+	}()
+
+	var src *client.Message
+	src, err = h.telegramRepo.GetMessage(&client.GetMessageRequest{
+		ChatId:    chatId,
+		MessageId: messageId,
+	})
+	if err != nil {
+		return
+	}
+	srcFormattedText := h.messageService.GetFormattedText(src)
+	if srcFormattedText == nil {
+		err = log.NewError("unsupported message content")
+		return
+	}
+
+	for _, toChatMessage := range h.storageService.GetCopiedMessageIds(chatId, messageId) {
+		forwardRuleId := toChatMessage.ForwardRuleId
+		dstChatId := toChatMessage.ChatId
+		tmpMessageId := toChatMessage.MessageId
+
+		forwardRule, ok := engineConfig.ForwardRules[forwardRuleId]
+		if !ok {
+			continue
+		}
+		if forwardRule.Indelible || forwardRule.OnDelete == domain.OnDeleteTombstone {
+			continue // копия первого сообщения осталась в целевом чате
+		}
+		isSendCopy := forwardRule.SendCopy || dstChatId == forwardRule.Other || !src.CanBeSaved
+		if !isSendCopy {
+			continue // пересланное сообщение невозможно отредактировать
+		}
+		newMessageId := h.storageService.GetNewMessageId(dstChatId, tmpMessageId)
+		if newMessageId == 0 {
+			continue
+		}
+
+		var formattedText *client.FormattedText
+		formattedText, err = util.DeepCopy(srcFormattedText)
+		if err != nil {
+			err = log.WrapError(err) // внешняя ошибка
+			return
+		}
+		const withSources = true
+		h.transformService.Transform(ctx, formattedText, withSources, src, dstChatId, 0, engineConfig)
+
+		_, err = h.telegramRepo.EditMessageCaption(&client.EditMessageCaptionRequest{
+			ChatId:    dstChatId,
+			MessageId: newMessageId,
+			Caption:   formattedText,
+		})
+		if err != nil {
+			return
 		}
+		result = append(result, toChatMessage)
 	}
 }
@@ -0,0 +1,70 @@
+package update_delete_messages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/handler/update_delete_messages/mocks"
+)
+
+func TestDeleteMessagesWithEditDiff(t *testing.T) {
+	t.Parallel()
+
+	const (
+		srcChatId    = int64(-1001)
+		srcMessageId = int64(10)
+		dstChatId    = int64(-1002)
+		tmpMessageId = int64(20)
+		newMessageId = int64(21)
+	)
+
+	forwardRule := &domain.ForwardRule{
+		Id:       "Rule1",
+		From:     srcChatId,
+		To:       []domain.ChatId{dstChatId},
+		SendCopy: true,
+	}
+	engineConfig := &domain.EngineConfig{
+		ForwardRules: map[domain.ForwardRuleId]*domain.ForwardRule{forwardRule.Id: forwardRule},
+	}
+	data := &data{
+		copiedMessageIds: map[string][]*domain.ChatMessage{
+			"-1001:10": {{ForwardRuleId: forwardRule.Id, ChatId: dstChatId, MessageId: tmpMessageId}},
+		},
+		newMessageIds: map[string]int64{
+			"-1002:20": newMessageId,
+		},
+	}
+
+	telegramRepo := mocks.NewTelegramRepo(t)
+	storageService := mocks.NewStorageService(t)
+
+	storageService.EXPECT().DeleteAnswerMessageId(dstChatId, tmpMessageId).Once()
+	storageService.EXPECT().DeleteTmpMessageId(dstChatId, newMessageId).Once()
+	storageService.EXPECT().DeleteNewMessageId(dstChatId, tmpMessageId).Once()
+	storageService.EXPECT().DeleteOriginMessageId(dstChatId, newMessageId).Once()
+	storageService.EXPECT().GetOverflowMessageIds(dstChatId, tmpMessageId).Return(nil)
+	storageService.EXPECT().GetReplyContextMessageId(dstChatId, tmpMessageId).Return(0)
+	// ответы с изменениями текста: второй ещё не отправлен
+	storageService.EXPECT().GetEditDiffMessageIds(dstChatId, tmpMessageId).Return([]int64{30, 32})
+	storageService.EXPECT().DeleteEditDiffMessageIds(dstChatId, tmpMessageId).Once()
+	storageService.EXPECT().GetNewMessageId(dstChatId, int64(30)).Return(31)
+	storageService.EXPECT().GetNewMessageId(dstChatId, int64(32)).Return(0)
+	storageService.EXPECT().DeleteNewMessageId(dstChatId, int64(30)).Once()
+	storageService.EXPECT().DeleteNewMessageId(dstChatId, int64(32)).Once()
+	storageService.EXPECT().DeleteTmpMessageId(dstChatId, int64(31)).Once()
+	telegramRepo.EXPECT().DeleteMessages(&client.DeleteMessagesRequest{
+		ChatId:     dstChatId,
+		MessageIds: []int64{newMessageId, 31},
+		Revoke:     true,
+	}).Return(&client.Ok{}, nil).Once()
+	storageService.EXPECT().DeleteCopiedMessageIds(srcChatId, srcMessageId).Once()
+	storageService.EXPECT().DeleteRevisionMessageIds(srcChatId, srcMessageId).Once()
+	storageService.EXPECT().DeleteTextSnapshot(srcChatId, srcMessageId).Once()
+
+	h := New(telegramRepo, nil, storageService, nil, nil)
+	h.deleteMessages(context.Background(), srcChatId, []int64{srcMessageId}, data, engineConfig)
+}
@@ -2,7 +2,10 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	domain "github.com/comerc/budva43/app/domain"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // StorageService is an autogenerated mock type for the storageService type
 type StorageService struct {
@@ -85,6 +88,74 @@ func (_c *StorageService_DeleteCopiedMessageIds_Call) RunAndReturn(run func(int6
 	return _c
 }
 
+// DeleteEditDiffMessageIds provides a mock function with given fields: dstChatId, tmpMessageId
+func (_m *StorageService) DeleteEditDiffMessageIds(dstChatId int64, tmpMessageId int64) {
+	_m.Called(dstChatId, tmpMessageId)
+}
+
+// StorageService_DeleteEditDiffMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEditDiffMessageIds'
+type StorageService_DeleteEditDiffMessageIds_Call struct {
+	*mock.Call
+}
+
+// DeleteEditDiffMessageIds is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+func (_e *StorageService_Expecter) DeleteEditDiffMessageIds(dstChatId interface{}, tmpMessageId interface{}) *StorageService_DeleteEditDiffMessageIds_Call {
+	return &StorageService_DeleteEditDiffMessageIds_Call{Call: _e.mock.On("DeleteEditDiffMessageIds", dstChatId, tmpMessageId)}
+}
+
+func (_c *StorageService_DeleteEditDiffMessageIds_Call) Run(run func(dstChatId int64, tmpMessageId int64)) *StorageService_DeleteEditDiffMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteEditDiffMessageIds_Call) Return() *StorageService_DeleteEditDiffMessageIds_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteEditDiffMessageIds_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteEditDiffMessageIds_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteMediaAlbumMessageIds provides a mock function with given fields: chatId, messageId
+func (_m *StorageService) DeleteMediaAlbumMessageIds(chatId int64, messageId int64) {
+	_m.Called(chatId, messageId)
+}
+
+// StorageService_DeleteMediaAlbumMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMediaAlbumMessageIds'
+type StorageService_DeleteMediaAlbumMessageIds_Call struct {
+	*mock.Call
+}
+
+// DeleteMediaAlbumMessageIds is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+func (_e *StorageService_Expecter) DeleteMediaAlbumMessageIds(chatId interface{}, messageId interface{}) *StorageService_DeleteMediaAlbumMessageIds_Call {
+	return &StorageService_DeleteMediaAlbumMessageIds_Call{Call: _e.mock.On("DeleteMediaAlbumMessageIds", chatId, messageId)}
+}
+
+func (_c *StorageService_DeleteMediaAlbumMessageIds_Call) Run(run func(chatId int64, messageId int64)) *StorageService_DeleteMediaAlbumMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteMediaAlbumMessageIds_Call) Return() *StorageService_DeleteMediaAlbumMessageIds_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteMediaAlbumMessageIds_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteMediaAlbumMessageIds_Call {
+	_c.Run(run)
+	return _c
+}
+
 // DeleteNewMessageId provides a mock function with given fields: chatId, tmpMessageId
 func (_m *StorageService) DeleteNewMessageId(chatId int64, tmpMessageId int64) {
 	_m.Called(chatId, tmpMessageId)
@@ -119,6 +190,176 @@ func (_c *StorageService_DeleteNewMessageId_Call) RunAndReturn(run func(int64, i
 	return _c
 }
 
+// DeleteOriginMessageId provides a mock function with given fields: dstChatId, dstMessageId
+func (_m *StorageService) DeleteOriginMessageId(dstChatId int64, dstMessageId int64) {
+	_m.Called(dstChatId, dstMessageId)
+}
+
+// StorageService_DeleteOriginMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOriginMessageId'
+type StorageService_DeleteOriginMessageId_Call struct {
+	*mock.Call
+}
+
+// DeleteOriginMessageId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - dstMessageId int64
+func (_e *StorageService_Expecter) DeleteOriginMessageId(dstChatId interface{}, dstMessageId interface{}) *StorageService_DeleteOriginMessageId_Call {
+	return &StorageService_DeleteOriginMessageId_Call{Call: _e.mock.On("DeleteOriginMessageId", dstChatId, dstMessageId)}
+}
+
+func (_c *StorageService_DeleteOriginMessageId_Call) Run(run func(dstChatId int64, dstMessageId int64)) *StorageService_DeleteOriginMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteOriginMessageId_Call) Return() *StorageService_DeleteOriginMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteOriginMessageId_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteOriginMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteOverflowMessageIds provides a mock function with given fields: dstChatId, tmpMessageId
+func (_m *StorageService) DeleteOverflowMessageIds(dstChatId int64, tmpMessageId int64) {
+	_m.Called(dstChatId, tmpMessageId)
+}
+
+// StorageService_DeleteOverflowMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOverflowMessageIds'
+type StorageService_DeleteOverflowMessageIds_Call struct {
+	*mock.Call
+}
+
+// DeleteOverflowMessageIds is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+func (_e *StorageService_Expecter) DeleteOverflowMessageIds(dstChatId interface{}, tmpMessageId interface{}) *StorageService_DeleteOverflowMessageIds_Call {
+	return &StorageService_DeleteOverflowMessageIds_Call{Call: _e.mock.On("DeleteOverflowMessageIds", dstChatId, tmpMessageId)}
+}
+
+func (_c *StorageService_DeleteOverflowMessageIds_Call) Run(run func(dstChatId int64, tmpMessageId int64)) *StorageService_DeleteOverflowMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteOverflowMessageIds_Call) Return() *StorageService_DeleteOverflowMessageIds_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteOverflowMessageIds_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteOverflowMessageIds_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteReplyContextMessageId provides a mock function with given fields: dstChatId, tmpMessageId
+func (_m *StorageService) DeleteReplyContextMessageId(dstChatId int64, tmpMessageId int64) {
+	_m.Called(dstChatId, tmpMessageId)
+}
+
+// StorageService_DeleteReplyContextMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReplyContextMessageId'
+type StorageService_DeleteReplyContextMessageId_Call struct {
+	*mock.Call
+}
+
+// DeleteReplyContextMessageId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+func (_e *StorageService_Expecter) DeleteReplyContextMessageId(dstChatId interface{}, tmpMessageId interface{}) *StorageService_DeleteReplyContextMessageId_Call {
+	return &StorageService_DeleteReplyContextMessageId_Call{Call: _e.mock.On("DeleteReplyContextMessageId", dstChatId, tmpMessageId)}
+}
+
+func (_c *StorageService_DeleteReplyContextMessageId_Call) Run(run func(dstChatId int64, tmpMessageId int64)) *StorageService_DeleteReplyContextMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteReplyContextMessageId_Call) Return() *StorageService_DeleteReplyContextMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteReplyContextMessageId_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteReplyContextMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteRevisionMessageIds provides a mock function with given fields: chatId, messageId
+func (_m *StorageService) DeleteRevisionMessageIds(chatId int64, messageId int64) {
+	_m.Called(chatId, messageId)
+}
+
+// StorageService_DeleteRevisionMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRevisionMessageIds'
+type StorageService_DeleteRevisionMessageIds_Call struct {
+	*mock.Call
+}
+
+// DeleteRevisionMessageIds is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+func (_e *StorageService_Expecter) DeleteRevisionMessageIds(chatId interface{}, messageId interface{}) *StorageService_DeleteRevisionMessageIds_Call {
+	return &StorageService_DeleteRevisionMessageIds_Call{Call: _e.mock.On("DeleteRevisionMessageIds", chatId, messageId)}
+}
+
+func (_c *StorageService_DeleteRevisionMessageIds_Call) Run(run func(chatId int64, messageId int64)) *StorageService_DeleteRevisionMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteRevisionMessageIds_Call) Return() *StorageService_DeleteRevisionMessageIds_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteRevisionMessageIds_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteRevisionMessageIds_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteTextSnapshot provides a mock function with given fields: chatId, messageId
+func (_m *StorageService) DeleteTextSnapshot(chatId int64, messageId int64) {
+	_m.Called(chatId, messageId)
+}
+
+// StorageService_DeleteTextSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTextSnapshot'
+type StorageService_DeleteTextSnapshot_Call struct {
+	*mock.Call
+}
+
+// DeleteTextSnapshot is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+func (_e *StorageService_Expecter) DeleteTextSnapshot(chatId interface{}, messageId interface{}) *StorageService_DeleteTextSnapshot_Call {
+	return &StorageService_DeleteTextSnapshot_Call{Call: _e.mock.On("DeleteTextSnapshot", chatId, messageId)}
+}
+
+func (_c *StorageService_DeleteTextSnapshot_Call) Run(run func(chatId int64, messageId int64)) *StorageService_DeleteTextSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteTextSnapshot_Call) Return() *StorageService_DeleteTextSnapshot_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteTextSnapshot_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteTextSnapshot_Call {
+	_c.Run(run)
+	return _c
+}
+
 // DeleteTmpMessageId provides a mock function with given fields: chatId, newMessageId
 func (_m *StorageService) DeleteTmpMessageId(chatId int64, newMessageId int64) {
 	_m.Called(chatId, newMessageId)
@@ -154,19 +395,19 @@ func (_c *StorageService_DeleteTmpMessageId_Call) RunAndReturn(run func(int64, i
 }
 
 // GetCopiedMessageIds provides a mock function with given fields: chatId, messageId
-func (_m *StorageService) GetCopiedMessageIds(chatId int64, messageId int64) []string {
+func (_m *StorageService) GetCopiedMessageIds(chatId int64, messageId int64) []*domain.ChatMessage {
 	ret := _m.Called(chatId, messageId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCopiedMessageIds")
 	}
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func(int64, int64) []string); ok {
+	var r0 []*domain.ChatMessage
+	if rf, ok := ret.Get(0).(func(int64, int64) []*domain.ChatMessage); ok {
 		r0 = rf(chatId, messageId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]*domain.ChatMessage)
 		}
 	}
 
@@ -192,12 +433,110 @@ func (_c *StorageService_GetCopiedMessageIds_Call) Run(run func(chatId int64, me
 	return _c
 }
 
-func (_c *StorageService_GetCopiedMessageIds_Call) Return(_a0 []string) *StorageService_GetCopiedMessageIds_Call {
+func (_c *StorageService_GetCopiedMessageIds_Call) Return(_a0 []*domain.ChatMessage) *StorageService_GetCopiedMessageIds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetCopiedMessageIds_Call) RunAndReturn(run func(int64, int64) []*domain.ChatMessage) *StorageService_GetCopiedMessageIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEditDiffMessageIds provides a mock function with given fields: dstChatId, tmpMessageId
+func (_m *StorageService) GetEditDiffMessageIds(dstChatId int64, tmpMessageId int64) []int64 {
+	ret := _m.Called(dstChatId, tmpMessageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEditDiffMessageIds")
+	}
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(int64, int64) []int64); ok {
+		r0 = rf(dstChatId, tmpMessageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	return r0
+}
+
+// StorageService_GetEditDiffMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEditDiffMessageIds'
+type StorageService_GetEditDiffMessageIds_Call struct {
+	*mock.Call
+}
+
+// GetEditDiffMessageIds is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+func (_e *StorageService_Expecter) GetEditDiffMessageIds(dstChatId interface{}, tmpMessageId interface{}) *StorageService_GetEditDiffMessageIds_Call {
+	return &StorageService_GetEditDiffMessageIds_Call{Call: _e.mock.On("GetEditDiffMessageIds", dstChatId, tmpMessageId)}
+}
+
+func (_c *StorageService_GetEditDiffMessageIds_Call) Run(run func(dstChatId int64, tmpMessageId int64)) *StorageService_GetEditDiffMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetEditDiffMessageIds_Call) Return(_a0 []int64) *StorageService_GetEditDiffMessageIds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetEditDiffMessageIds_Call) RunAndReturn(run func(int64, int64) []int64) *StorageService_GetEditDiffMessageIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMediaAlbumMessageIds provides a mock function with given fields: chatId, messageId
+func (_m *StorageService) GetMediaAlbumMessageIds(chatId int64, messageId int64) []int64 {
+	ret := _m.Called(chatId, messageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMediaAlbumMessageIds")
+	}
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(int64, int64) []int64); ok {
+		r0 = rf(chatId, messageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	return r0
+}
+
+// StorageService_GetMediaAlbumMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMediaAlbumMessageIds'
+type StorageService_GetMediaAlbumMessageIds_Call struct {
+	*mock.Call
+}
+
+// GetMediaAlbumMessageIds is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+func (_e *StorageService_Expecter) GetMediaAlbumMessageIds(chatId interface{}, messageId interface{}) *StorageService_GetMediaAlbumMessageIds_Call {
+	return &StorageService_GetMediaAlbumMessageIds_Call{Call: _e.mock.On("GetMediaAlbumMessageIds", chatId, messageId)}
+}
+
+func (_c *StorageService_GetMediaAlbumMessageIds_Call) Run(run func(chatId int64, messageId int64)) *StorageService_GetMediaAlbumMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetMediaAlbumMessageIds_Call) Return(_a0 []int64) *StorageService_GetMediaAlbumMessageIds_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *StorageService_GetCopiedMessageIds_Call) RunAndReturn(run func(int64, int64) []string) *StorageService_GetCopiedMessageIds_Call {
+func (_c *StorageService_GetMediaAlbumMessageIds_Call) RunAndReturn(run func(int64, int64) []int64) *StorageService_GetMediaAlbumMessageIds_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -249,6 +588,219 @@ func (_c *StorageService_GetNewMessageId_Call) RunAndReturn(run func(int64, int6
 	return _c
 }
 
+// GetOverflowMessageIds provides a mock function with given fields: dstChatId, tmpMessageId
+func (_m *StorageService) GetOverflowMessageIds(dstChatId int64, tmpMessageId int64) []int64 {
+	ret := _m.Called(dstChatId, tmpMessageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverflowMessageIds")
+	}
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(int64, int64) []int64); ok {
+		r0 = rf(dstChatId, tmpMessageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	return r0
+}
+
+// StorageService_GetOverflowMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOverflowMessageIds'
+type StorageService_GetOverflowMessageIds_Call struct {
+	*mock.Call
+}
+
+// GetOverflowMessageIds is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+func (_e *StorageService_Expecter) GetOverflowMessageIds(dstChatId interface{}, tmpMessageId interface{}) *StorageService_GetOverflowMessageIds_Call {
+	return &StorageService_GetOverflowMessageIds_Call{Call: _e.mock.On("GetOverflowMessageIds", dstChatId, tmpMessageId)}
+}
+
+func (_c *StorageService_GetOverflowMessageIds_Call) Run(run func(dstChatId int64, tmpMessageId int64)) *StorageService_GetOverflowMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetOverflowMessageIds_Call) Return(_a0 []int64) *StorageService_GetOverflowMessageIds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetOverflowMessageIds_Call) RunAndReturn(run func(int64, int64) []int64) *StorageService_GetOverflowMessageIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReplyContextMessageId provides a mock function with given fields: dstChatId, tmpMessageId
+func (_m *StorageService) GetReplyContextMessageId(dstChatId int64, tmpMessageId int64) int64 {
+	ret := _m.Called(dstChatId, tmpMessageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReplyContextMessageId")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(dstChatId, tmpMessageId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// StorageService_GetReplyContextMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReplyContextMessageId'
+type StorageService_GetReplyContextMessageId_Call struct {
+	*mock.Call
+}
+
+// GetReplyContextMessageId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+func (_e *StorageService_Expecter) GetReplyContextMessageId(dstChatId interface{}, tmpMessageId interface{}) *StorageService_GetReplyContextMessageId_Call {
+	return &StorageService_GetReplyContextMessageId_Call{Call: _e.mock.On("GetReplyContextMessageId", dstChatId, tmpMessageId)}
+}
+
+func (_c *StorageService_GetReplyContextMessageId_Call) Run(run func(dstChatId int64, tmpMessageId int64)) *StorageService_GetReplyContextMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetReplyContextMessageId_Call) Return(_a0 int64) *StorageService_GetReplyContextMessageId_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetReplyContextMessageId_Call) RunAndReturn(run func(int64, int64) int64) *StorageService_GetReplyContextMessageId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRevisionMessageIds provides a mock function with given fields: chatId, messageId
+func (_m *StorageService) GetRevisionMessageIds(chatId int64, messageId int64) []*domain.ChatMessage {
+	ret := _m.Called(chatId, messageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisionMessageIds")
+	}
+
+	var r0 []*domain.ChatMessage
+	if rf, ok := ret.Get(0).(func(int64, int64) []*domain.ChatMessage); ok {
+		r0 = rf(chatId, messageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ChatMessage)
+		}
+	}
+
+	return r0
+}
+
+// StorageService_GetRevisionMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevisionMessageIds'
+type StorageService_GetRevisionMessageIds_Call struct {
+	*mock.Call
+}
+
+// GetRevisionMessageIds is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+func (_e *StorageService_Expecter) GetRevisionMessageIds(chatId interface{}, messageId interface{}) *StorageService_GetRevisionMessageIds_Call {
+	return &StorageService_GetRevisionMessageIds_Call{Call: _e.mock.On("GetRevisionMessageIds", chatId, messageId)}
+}
+
+func (_c *StorageService_GetRevisionMessageIds_Call) Run(run func(chatId int64, messageId int64)) *StorageService_GetRevisionMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetRevisionMessageIds_Call) Return(_a0 []*domain.ChatMessage) *StorageService_GetRevisionMessageIds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetRevisionMessageIds_Call) RunAndReturn(run func(int64, int64) []*domain.ChatMessage) *StorageService_GetRevisionMessageIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMediaAlbumMessageIds provides a mock function with given fields: chatId, messageIds
+func (_m *StorageService) SetMediaAlbumMessageIds(chatId int64, messageIds []int64) {
+	_m.Called(chatId, messageIds)
+}
+
+// StorageService_SetMediaAlbumMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMediaAlbumMessageIds'
+type StorageService_SetMediaAlbumMessageIds_Call struct {
+	*mock.Call
+}
+
+// SetMediaAlbumMessageIds is a helper method to define mock.On call
+//   - chatId int64
+//   - messageIds []int64
+func (_e *StorageService_Expecter) SetMediaAlbumMessageIds(chatId interface{}, messageIds interface{}) *StorageService_SetMediaAlbumMessageIds_Call {
+	return &StorageService_SetMediaAlbumMessageIds_Call{Call: _e.mock.On("SetMediaAlbumMessageIds", chatId, messageIds)}
+}
+
+func (_c *StorageService_SetMediaAlbumMessageIds_Call) Run(run func(chatId int64, messageIds []int64)) *StorageService_SetMediaAlbumMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_SetMediaAlbumMessageIds_Call) Return() *StorageService_SetMediaAlbumMessageIds_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_SetMediaAlbumMessageIds_Call) RunAndReturn(run func(int64, []int64)) *StorageService_SetMediaAlbumMessageIds_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetTombstone provides a mock function with given fields: dstChatId, newMessageId
+func (_m *StorageService) SetTombstone(dstChatId int64, newMessageId int64) {
+	_m.Called(dstChatId, newMessageId)
+}
+
+// StorageService_SetTombstone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTombstone'
+type StorageService_SetTombstone_Call struct {
+	*mock.Call
+}
+
+// SetTombstone is a helper method to define mock.On call
+//   - dstChatId int64
+//   - newMessageId int64
+func (_e *StorageService_Expecter) SetTombstone(dstChatId interface{}, newMessageId interface{}) *StorageService_SetTombstone_Call {
+	return &StorageService_SetTombstone_Call{Call: _e.mock.On("SetTombstone", dstChatId, newMessageId)}
+}
+
+func (_c *StorageService_SetTombstone_Call) Run(run func(dstChatId int64, newMessageId int64)) *StorageService_SetTombstone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_SetTombstone_Call) Return() *StorageService_SetTombstone_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_SetTombstone_Call) RunAndReturn(run func(int64, int64)) *StorageService_SetTombstone_Call {
+	_c.Run(run)
+	return _c
+}
+
 // NewStorageService creates a new instance of StorageService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStorageService(t interface {
@@ -0,0 +1,111 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/comerc/budva43/app/domain"
+	mock "github.com/stretchr/testify/mock"
+	client "github.com/zelenin/go-tdlib/client"
+)
+
+// TransformService is an autogenerated mock type for the transformService type
+type TransformService struct {
+	mock.Mock
+}
+
+type TransformService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TransformService) EXPECT() *TransformService_Expecter {
+	return &TransformService_Expecter{mock: &_m.Mock}
+}
+
+// AddTombstone provides a mock function with given fields: formattedText, forwardRule
+func (_m *TransformService) AddTombstone(formattedText *client.FormattedText, forwardRule *domain.ForwardRule) {
+	_m.Called(formattedText, forwardRule)
+}
+
+// TransformService_AddTombstone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTombstone'
+type TransformService_AddTombstone_Call struct {
+	*mock.Call
+}
+
+// AddTombstone is a helper method to define mock.On call
+//   - formattedText *client.FormattedText
+//   - forwardRule *domain.ForwardRule
+func (_e *TransformService_Expecter) AddTombstone(formattedText interface{}, forwardRule interface{}) *TransformService_AddTombstone_Call {
+	return &TransformService_AddTombstone_Call{Call: _e.mock.On("AddTombstone", formattedText, forwardRule)}
+}
+
+func (_c *TransformService_AddTombstone_Call) Run(run func(formattedText *client.FormattedText, forwardRule *domain.ForwardRule)) *TransformService_AddTombstone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.FormattedText), args[1].(*domain.ForwardRule))
+	})
+	return _c
+}
+
+func (_c *TransformService_AddTombstone_Call) Return() *TransformService_AddTombstone_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *TransformService_AddTombstone_Call) RunAndReturn(run func(*client.FormattedText, *domain.ForwardRule)) *TransformService_AddTombstone_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Transform provides a mock function with given fields: ctx, formattedText, withSources, src, dstChatId, prevMessageId, engineConfig
+func (_m *TransformService) Transform(ctx context.Context, formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId int64, prevMessageId int64, engineConfig *domain.EngineConfig) {
+	_m.Called(ctx, formattedText, withSources, src, dstChatId, prevMessageId, engineConfig)
+}
+
+// TransformService_Transform_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Transform'
+type TransformService_Transform_Call struct {
+	*mock.Call
+}
+
+// Transform is a helper method to define mock.On call
+//   - ctx context.Context
+//   - formattedText *client.FormattedText
+//   - withSources bool
+//   - src *client.Message
+//   - dstChatId int64
+//   - prevMessageId int64
+//   - engineConfig *domain.EngineConfig
+func (_e *TransformService_Expecter) Transform(ctx interface{}, formattedText interface{}, withSources interface{}, src interface{}, dstChatId interface{}, prevMessageId interface{}, engineConfig interface{}) *TransformService_Transform_Call {
+	return &TransformService_Transform_Call{Call: _e.mock.On("Transform", ctx, formattedText, withSources, src, dstChatId, prevMessageId, engineConfig)}
+}
+
+func (_c *TransformService_Transform_Call) Run(run func(ctx context.Context, formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId int64, prevMessageId int64, engineConfig *domain.EngineConfig)) *TransformService_Transform_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*client.FormattedText), args[2].(bool), args[3].(*client.Message), args[4].(int64), args[5].(int64), args[6].(*domain.EngineConfig))
+	})
+	return _c
+}
+
+func (_c *TransformService_Transform_Call) Return() *TransformService_Transform_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *TransformService_Transform_Call) RunAndReturn(run func(context.Context, *client.FormattedText, bool, *client.Message, int64, int64, *domain.EngineConfig)) *TransformService_Transform_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewTransformService creates a new instance of TransformService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTransformService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TransformService {
+	mock := &TransformService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
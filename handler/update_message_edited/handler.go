@@ -1,8 +1,8 @@
 package update_message_edited
 
 import (
+	"context"
 	"fmt"
-	"strings"
 
 	"github.com/zelenin/go-tdlib/client"
 
@@ -18,41 +18,65 @@ type telegramRepo interface {
 	GetMessage(*client.GetMessageRequest) (*client.Message, error)
 	EditMessageText(*client.EditMessageTextRequest) (*client.Message, error)
 	EditMessageCaption(*client.EditMessageCaptionRequest) (*client.Message, error)
+	DeleteMessages(*client.DeleteMessagesRequest) (*client.Ok, error)
+	SendMessage(*client.SendMessageRequest) (*client.Message, error)
+	EditMessageLiveLocation(*client.EditMessageLiveLocationRequest) (*client.Message, error)
+	StopPoll(*client.StopPollRequest) (*client.Ok, error)
 }
 
 //go:generate mockery --name=queueRepo --exported
 type queueRepo interface {
-	Add(fn func())
+	Add(ctx context.Context, fn func(ctx context.Context))
 }
 
 //go:generate mockery --name=storageService --exported
 type storageService interface {
-	GetCopiedMessageIds(chatId, messageId int64) []string
+	GetCopiedMessageIds(chatId, messageId int64) []*domain.ChatMessage
 	GetNewMessageId(chatId, tmpMessageId int64) int64
+	DeleteNewMessageId(chatId, tmpMessageId int64)
+	DeleteTmpMessageId(chatId, newMessageId int64)
 	SetAnswerMessageId(dstChatId, tmpMessageId, chatId, messageId int64)
 	DeleteAnswerMessageId(dstChatId, tmpMessageId int64)
+	DeleteRevisionMessageId(chatId, messageId int64, toChatMessage *domain.ChatMessage)
+	GetTextSnapshot(chatId, messageId int64) (string, bool)
+	SetTextSnapshot(chatId, messageId int64, text string)
+	GetMediaAlbumMessageIds(chatId, messageId int64) []int64
+	GetOverflowMessageIds(dstChatId, tmpMessageId int64) []int64
+	SetOverflowMessageIds(dstChatId, tmpMessageId int64, overflowTmpMessageIds []int64)
+	DeleteOverflowMessageIds(dstChatId, tmpMessageId int64)
+	AddEditDiffMessageIds(dstChatId, tmpMessageId int64, editDiffTmpMessageIds []int64)
+	GetReplyContextMessageId(dstChatId, tmpMessageId int64) int64
+	DeleteReplyContextMessageId(dstChatId, tmpMessageId int64)
+	SetOriginMessageId(dstChatId, dstMessageId int64, forwardRuleId string, chatId, messageId int64)
+	DeleteOriginMessageId(dstChatId, dstMessageId int64)
 }
 
 //go:generate mockery --name=messageService --exported
 type messageService interface {
 	GetFormattedText(message *client.Message) *client.FormattedText
 	GetInputMessageContent(message *client.Message, formattedText *client.FormattedText) client.InputMessageContent
+	ApplyMediaPolicy(content client.InputMessageContent, message *client.Message, mediaPolicy *domain.MediaPolicy)
 	GetReplyMarkupData(message *client.Message) []byte
+	SplitOverflow(content client.InputMessageContent) []*client.FormattedText
 }
 
 //go:generate mockery --name=transformService --exported
 type transformService interface {
-	Transform(formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId, prevMessageId int64, engineConfig *domain.EngineConfig)
+	Transform(ctx context.Context, formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId, prevMessageId int64, engineConfig *domain.EngineConfig)
+	FormatEditDiff(oldText, newText string, dstChatId int64, engineConfig *domain.EngineConfig) *client.FormattedText
+	AddProtectedContentNote(formattedText *client.FormattedText, forwardRule *domain.ForwardRule)
 }
 
 //go:generate mockery --name=filtersModeService --exported
 type filtersModeService interface {
-	Map(formattedText *client.FormattedText, forwardRule *domain.ForwardRule) domain.FiltersMode
+	Map(ctx context.Context, formattedText *client.FormattedText, forwardRule *domain.ForwardRule) domain.FiltersMode
 }
 
 //go:generate mockery --name=forwarderService --exported
 type forwarderService interface {
-	ForwardMessages(messages []*client.Message, filtersMode domain.FiltersMode, srcChatId, dstChatId, prevMessageId int64, isSendCopy bool, forwardRuleId string, engineConfig *domain.EngineConfig)
+	AddReplyContext(ctx context.Context, formattedText *client.FormattedText, src *client.Message, dstChatId int64, forwardRule *domain.ForwardRule)
+	GetMessageThreadId(ctx context.Context, srcChatId, dstChatId int64, forwardRule *domain.ForwardRule, engineConfig *domain.EngineConfig) int64
+	ForwardMessages(ctx context.Context, messages []*client.Message, filtersMode domain.FiltersMode, srcChatId, dstChatId, prevMessageId int64, isSendCopy bool, forwardRuleId string, engineConfig *domain.EngineConfig)
 }
 
 type Handler struct {
@@ -90,7 +114,7 @@ func New(
 }
 
 // Run выполняет обрабатку обновления о редактировании сообщения
-func (h *Handler) Run(update *client.UpdateMessageEdited) {
+func (h *Handler) Run(ctx context.Context, update *client.UpdateMessageEdited) {
 	engineConfig := config.Engine // копируем, см. WATCH-CONFIG.md
 
 	chatId := update.ChatId
@@ -102,11 +126,11 @@ func (h *Handler) Run(update *client.UpdateMessageEdited) {
 	const maxRetries = 3
 	retryCount := 0
 
-	var fn func()
-	fn = func() {
+	var fn func(ctx context.Context)
+	fn = func(ctx context.Context) {
 		var err error
 		defer func() {
-			h.log.ErrorOrDebug(err, "",
+			h.log.ErrorOrDebugContext(ctx, err, "",
 				"retryCount", retryCount,
 				"chatId", chatId,
 				"messageId", messageId,
@@ -120,35 +144,33 @@ func (h *Handler) Run(update *client.UpdateMessageEdited) {
 				err = log.NewError("max retries reached for message edit")
 				return
 			}
-			h.queueRepo.Add(fn) // переставляем в конец очереди
+			h.queueRepo.Add(ctx, fn) // переставляем в конец очереди
 			return
 		}
 
-		h.editMessages(chatId, messageId, data, engineConfig)
+		h.editMessages(ctx, chatId, messageId, data, engineConfig)
 	}
 
-	h.queueRepo.Add(fn)
+	h.queueRepo.Add(ctx, fn)
 }
 
 type data struct {
 	needRepeat       bool
-	copiedMessageIds []string         // []toChatMessageId
-	newMessageIds    map[string]int64 // tmpChatMessageId -> newMessageId
+	copiedMessageIds []*domain.ChatMessage // []toChatMessage
+	newMessageIds    map[string]int64      // tmpChatMessageId -> newMessageId
 }
 
 // collectData собирает данные для редактирования сообщений
 func (h *Handler) collectData(chatId, messageId int64) *data {
-	toChatMessageIds := h.storageService.GetCopiedMessageIds(chatId, messageId)
+	toChatMessages := h.storageService.GetCopiedMessageIds(chatId, messageId)
 	result := &data{
-		copiedMessageIds: toChatMessageIds,
+		copiedMessageIds: toChatMessages,
 		newMessageIds:    make(map[string]int64),
 	}
 
-	for _, toChatMessageId := range toChatMessageIds {
-		a := strings.Split(toChatMessageId, ":")
-		// forwardRuleId := a[0]
-		dstChatId := util.ConvertToInt[int64](a[1])
-		tmpMessageId := util.ConvertToInt[int64](a[2])
+	for _, toChatMessage := range toChatMessages {
+		dstChatId := toChatMessage.ChatId
+		tmpMessageId := toChatMessage.MessageId
 
 		newMessageId := h.storageService.GetNewMessageId(dstChatId, tmpMessageId)
 		if newMessageId == 0 {
@@ -164,14 +186,14 @@ func (h *Handler) collectData(chatId, messageId int64) *data {
 }
 
 // editMessages редактирует сообщения
-func (h *Handler) editMessages(chatId, messageId int64, data *data, engineConfig *domain.EngineConfig) {
+func (h *Handler) editMessages(ctx context.Context, chatId, messageId int64, data *data, engineConfig *domain.EngineConfig) {
 	var (
 		err          error
 		mediaAlbumId int64
 		result       []string
 	)
 	defer func() {
-		h.log.ErrorOrDebug(err, "",
+		h.log.ErrorOrDebugContext(ctx, err, "",
 			"chatId", chatId,
 			"messageId", messageId,
 			"mediaAlbumId", mediaAlbumId,
@@ -179,7 +201,7 @@ func (h *Handler) editMessages(chatId, messageId int64, data *data, engineConfig
 		)
 	}()
 
-	toChatMessageIds := data.copiedMessageIds
+	toChatMessages := data.copiedMessageIds
 
 	var src *client.Message
 	src, err = h.telegramRepo.GetMessage(&client.GetMessageRequest{
@@ -194,25 +216,36 @@ func (h *Handler) editMessages(chatId, messageId int64, data *data, engineConfig
 	srcFormattedText := h.messageService.GetFormattedText(src)
 	mediaAlbumId = int64(src.MediaAlbumId)
 
+	oldText, hasTextSnapshot := h.storageService.GetTextSnapshot(chatId, messageId)
+
+	// подпись и ссылки на источник остаются только на первом сообщении медиа-альбома
+	withSources := true
+	if mediaAlbumId != 0 {
+		mediaAlbumMessageIds := h.storageService.GetMediaAlbumMessageIds(chatId, messageId)
+		if len(mediaAlbumMessageIds) > 0 {
+			withSources = mediaAlbumMessageIds[0] == messageId
+		}
+	}
+	hasEditDiff := false
+
 	checkFns := make(map[int64]func())
 
-	for _, toChatMessageId := range toChatMessageIds {
+	for _, toChatMessage := range toChatMessages {
 		func() {
 			var err error
 			forwardRuleId := ""
 			defer func() {
-				h.log.ErrorOrDebug(err, "",
+				h.log.ErrorOrDebugContext(ctx, err, "",
 					"chatId", chatId,
 					"messageId", messageId,
-					"toChatMessageId", toChatMessageId,
+					"toChatMessage", toChatMessage,
 					"forwardRuleId", forwardRuleId,
 				)
 			}()
 
-			a := strings.Split(toChatMessageId, ":")
-			forwardRuleId = a[0]
-			dstChatId := util.ConvertToInt[int64](a[1])
-			tmpMessageId := util.ConvertToInt[int64](a[2])
+			forwardRuleId = toChatMessage.ForwardRuleId
+			dstChatId := toChatMessage.ChatId
+			tmpMessageId := toChatMessage.MessageId
 
 			tmpChatMessageId := fmt.Sprintf("%d:%d", dstChatId, tmpMessageId)
 			newMessageId := data.newMessageIds[tmpChatMessageId]
@@ -224,10 +257,15 @@ func (h *Handler) editMessages(chatId, messageId int64, data *data, engineConfig
 				return
 			}
 
+			if forwardRule.Revisions != nil && forwardRule.Revisions.Run {
+				h.addRevision(ctx, src, toChatMessage, dstChatId, tmpMessageId, newMessageId, forwardRule, engineConfig)
+				return
+			}
+
 			if forwardRule.CopyOnce {
 				prevMessageId := newMessageId
 				const isSendCopy = true
-				h.forwarderService.ForwardMessages(
+				h.forwarderService.ForwardMessages(ctx,
 					[]*client.Message{src},
 					"",
 					chatId,
@@ -241,12 +279,12 @@ func (h *Handler) editMessages(chatId, messageId int64, data *data, engineConfig
 			}
 
 			if (forwardRule.SendCopy || src.CanBeSaved) &&
-				h.filtersModeService.Map(srcFormattedText, forwardRule) == domain.FiltersCheck {
+				h.filtersModeService.Map(ctx, srcFormattedText, forwardRule) == domain.FiltersCheck {
 				_, ok := checkFns[forwardRule.Check]
 				if !ok {
 					checkFns[forwardRule.Check] = func() {
 						const isSendCopy = false // обязательно надо форвардить, иначе не видно текущего сообщения
-						h.forwarderService.ForwardMessages(
+						h.forwarderService.ForwardMessages(ctx,
 							[]*client.Message{src},
 							"",
 							chatId,
@@ -261,6 +299,31 @@ func (h *Handler) editMessages(chatId, messageId int64, data *data, engineConfig
 				return
 			}
 
+			// защищённое содержимое скопировано только текстом, см. ForwardRule.Fallback
+			isProtected := !forwardRule.SendCopy && !src.CanBeSaved
+
+			isForward := !forwardRule.SendCopy && dstChatId != forwardRule.Other && !isProtected
+			if isForward {
+				if forwardRule.SyncForwards {
+					h.resendForward(ctx, src, dstChatId, tmpMessageId, newMessageId, forwardRule, engineConfig)
+				}
+				return // пересланное сообщение невозможно отредактировать
+			}
+
+			// ответы на копию отправляются в ту же тему форума, что и копия
+			messageThreadId := h.forwarderService.GetMessageThreadId(ctx, src.ChatId, dstChatId, forwardRule, engineConfig)
+
+			destination := engineConfig.Destinations[dstChatId]
+			if destination != nil && destination.EditDiff != nil && destination.EditDiff.Run {
+				hasEditDiff = true
+				if hasTextSnapshot {
+					h.sendEditDiff(ctx, oldText, srcFormattedText.Text, dstChatId, messageThreadId, tmpMessageId, newMessageId, engineConfig)
+				}
+				if destination.EditDiff.InsteadOfEdit {
+					return
+				}
+			}
+
 			// TODO: почему не используется?
 			// hasFiltersCheck := false
 			// testChatId := dstChatId
@@ -294,8 +357,29 @@ func (h *Handler) editMessages(chatId, messageId int64, data *data, engineConfig
 				return
 			}
 
-			withSources := true
-			h.transformService.Transform(formattedText, withSources, src, dstChatId, 0, engineConfig)
+			h.transformService.Transform(ctx, formattedText, withSources, src, dstChatId, 0, engineConfig)
+			// контекст ответа, добавленный при отправке, не должен пропасть при правке
+			if withSources {
+				h.forwarderService.AddReplyContext(ctx, formattedText, src, dstChatId, forwardRule)
+			}
+
+			if isProtected {
+				h.transformService.AddProtectedContentNote(formattedText, forwardRule)
+				content := &client.InputMessageText{
+					Text: formattedText,
+				}
+				overflow := h.messageService.SplitOverflow(content)
+				_, err = h.telegramRepo.EditMessageText(&client.EditMessageTextRequest{
+					ChatId:              dstChatId,
+					MessageId:           newMessageId,
+					InputMessageContent: content,
+				})
+				if err != nil {
+					return
+				}
+				h.syncOverflow(ctx, dstChatId, messageThreadId, tmpMessageId, newMessageId, overflow, forwardRuleId, src)
+				return
+			}
 
 			switch src.Content.(type) {
 			case
@@ -306,6 +390,10 @@ func (h *Handler) editMessages(chatId, messageId int64, data *data, engineConfig
 				*client.MessageVideo,
 				*client.MessagePhoto:
 				content := h.messageService.GetInputMessageContent(src, formattedText)
+				if destination != nil {
+					h.messageService.ApplyMediaPolicy(content, src, destination.Media)
+				}
+				overflow := h.messageService.SplitOverflow(content)
 				_, err = h.telegramRepo.EditMessageText(&client.EditMessageTextRequest{
 					ChatId:              dstChatId,
 					MessageId:           newMessageId,
@@ -320,6 +408,9 @@ func (h *Handler) editMessages(chatId, messageId int64, data *data, engineConfig
 				// if err != nil {
 				//   //ничего не делаем, просто логируем ошибку
 				// }
+				if err == nil {
+					h.syncOverflow(ctx, dstChatId, messageThreadId, tmpMessageId, newMessageId, overflow, forwardRuleId, src)
+				}
 			case *client.MessageVoiceNote:
 				_, err = h.telegramRepo.EditMessageCaption(&client.EditMessageCaptionRequest{
 					ChatId:    dstChatId,
@@ -329,6 +420,38 @@ func (h *Handler) editMessages(chatId, messageId int64, data *data, engineConfig
 				// if err != nil {
 				//   //ничего не делаем, просто логируем ошибку
 				// }
+			case *client.MessageLocation:
+				location := src.Content.(*client.MessageLocation)
+				if location.LivePeriod == 0 {
+					return // статичная геопозиция не редактируется
+				}
+				_, err = h.telegramRepo.EditMessageLiveLocation(&client.EditMessageLiveLocationRequest{
+					ChatId:    dstChatId,
+					MessageId: newMessageId,
+					Location: func() *client.Location {
+						if location.ExpiresIn == 0 {
+							return nil // трансляция оригинала остановлена
+						}
+						return location.Location
+					}(),
+					Heading: location.Heading,
+				})
+			case *client.MessagePoll:
+				poll := src.Content.(*client.MessagePoll).Poll
+				if !poll.IsClosed {
+					return // вопрос и варианты ответа не редактируются
+				}
+				_, err = h.telegramRepo.StopPoll(&client.StopPollRequest{
+					ChatId:    dstChatId,
+					MessageId: newMessageId,
+				})
+			case
+				*client.MessageVideoNote,
+				*client.MessageSticker,
+				*client.MessageVenue,
+				*client.MessageContact,
+				*client.MessageDice:
+				return // содержимое не редактируется
 			default:
 				return
 			}
@@ -341,7 +464,285 @@ func (h *Handler) editMessages(chatId, messageId int64, data *data, engineConfig
 		}()
 	}
 
+	if hasEditDiff {
+		h.storageService.SetTextSnapshot(chatId, messageId, srcFormattedText.Text)
+	}
+
 	for _, fn := range checkFns {
 		fn()
 	}
 }
+
+// addRevision отправляет новую редакцию сообщения, сохраняя цепочку редакций
+func (h *Handler) addRevision(ctx context.Context, src *client.Message, toChatMessage *domain.ChatMessage,
+	dstChatId, tmpMessageId, newMessageId int64,
+	forwardRule *domain.ForwardRule, engineConfig *domain.EngineConfig,
+) {
+	var err error
+	defer func() {
+		h.log.ErrorOrDebugContext(ctx, err, "",
+			"chatId", src.ChatId,
+			"messageId", src.Id,
+			"toChatMessage", toChatMessage,
+			"newMessageId", newMessageId,
+			"mode", forwardRule.Revisions.Mode,
+		)
+	}()
+
+	isSendCopy := forwardRule.SendCopy || dstChatId == forwardRule.Other || !src.CanBeSaved
+	prevMessageId := newMessageId
+	if !isSendCopy {
+		prevMessageId = 0 // при форвардинге невозможно добавить ссылку на предыдущую редакцию
+	}
+
+	h.forwarderService.ForwardMessages(ctx,
+		[]*client.Message{src},
+		"",
+		src.ChatId,
+		dstChatId,
+		prevMessageId,
+		isSendCopy,
+		forwardRule.Id,
+		engineConfig,
+	)
+
+	if isSendCopy || forwardRule.Revisions.Mode != domain.RevisionsReplace {
+		return
+	}
+	// прежняя редакция удаляется только после того, как новая получила связь
+	if !h.isForwardResent(src, dstChatId, tmpMessageId, forwardRule.Id) {
+		err = log.NewError("forward is not resent")
+		return
+	}
+	err = h.deleteForward(dstChatId, tmpMessageId, newMessageId)
+	if err != nil {
+		return
+	}
+	h.storageService.DeleteRevisionMessageId(src.ChatId, src.Id, toChatMessage)
+}
+
+// isForwardResent проверяет, что связь правила с получателем указывает на новое сообщение
+func (h *Handler) isForwardResent(src *client.Message, dstChatId, tmpMessageId int64, forwardRuleId string) bool {
+	for _, toChatMessage := range h.storageService.GetCopiedMessageIds(src.ChatId, src.Id) {
+		if toChatMessage.ForwardRuleId == forwardRuleId && toChatMessage.ChatId == dstChatId {
+			return toChatMessage.MessageId != tmpMessageId
+		}
+	}
+	return false
+}
+
+// resendForward пересылает новую редакцию оригинала и удаляет прежнее пересланное сообщение;
+// если пересылка не удалась, прежнее сообщение и связь с ним остаются
+func (h *Handler) resendForward(ctx context.Context, src *client.Message,
+	dstChatId, tmpMessageId, newMessageId int64,
+	forwardRule *domain.ForwardRule, engineConfig *domain.EngineConfig,
+) {
+	var err error
+	defer func() {
+		h.log.ErrorOrDebugContext(ctx, err, "",
+			"chatId", src.ChatId,
+			"messageId", src.Id,
+			"dstChatId", dstChatId,
+			"newMessageId", newMessageId,
+			"forwardRuleId", forwardRule.Id,
+		)
+	}()
+
+	// связь с новым сообщением перезапишется в ForwardMessages (по префиксу forwardRuleId:dstChatId:)
+	const isSendCopy = false
+	h.forwarderService.ForwardMessages(ctx,
+		[]*client.Message{src},
+		"",
+		src.ChatId,
+		dstChatId,
+		0, // prevMessageId
+		isSendCopy,
+		forwardRule.Id,
+		engineConfig,
+	)
+
+	if !h.isForwardResent(src, dstChatId, tmpMessageId, forwardRule.Id) {
+		err = log.NewError("forward is not resent")
+		return
+	}
+
+	err = h.deleteForward(dstChatId, tmpMessageId, newMessageId)
+}
+
+// deleteForward удаляет пересланное сообщение (вместе с контекстом ответа) и его временный/постоянный Id
+func (h *Handler) deleteForward(dstChatId, tmpMessageId, newMessageId int64) error {
+	messageIds := []int64{newMessageId}
+	contextTmpMessageId := h.storageService.GetReplyContextMessageId(dstChatId, tmpMessageId)
+	var contextMessageId int64
+	if contextTmpMessageId != 0 {
+		contextMessageId = h.storageService.GetNewMessageId(dstChatId, contextTmpMessageId)
+	}
+	if contextMessageId != 0 {
+		messageIds = append(messageIds, contextMessageId)
+	}
+	_, err := h.telegramRepo.DeleteMessages(&client.DeleteMessagesRequest{
+		ChatId:     dstChatId,
+		MessageIds: messageIds,
+		Revoke:     true,
+	})
+	if err != nil {
+		return err
+	}
+	h.storageService.DeleteTmpMessageId(dstChatId, newMessageId)
+	h.storageService.DeleteNewMessageId(dstChatId, tmpMessageId)
+	// до успешной отправки обратная связь хранится по временному идентификатору
+	if newMessageId != 0 {
+		h.storageService.DeleteOriginMessageId(dstChatId, newMessageId)
+	} else {
+		h.storageService.DeleteOriginMessageId(dstChatId, tmpMessageId)
+	}
+	if contextTmpMessageId != 0 {
+		h.storageService.DeleteReplyContextMessageId(dstChatId, tmpMessageId)
+		h.storageService.DeleteNewMessageId(dstChatId, contextTmpMessageId)
+		if contextMessageId != 0 {
+			h.storageService.DeleteTmpMessageId(dstChatId, contextMessageId)
+			h.storageService.DeleteOriginMessageId(dstChatId, contextMessageId)
+		} else {
+			h.storageService.DeleteOriginMessageId(dstChatId, contextTmpMessageId)
+		}
+	}
+	return nil
+}
+
+// syncOverflow приводит ответы с продолжением текста копии в соответствие с новым текстом:
+// существующие ответы редактируются, недостающие отправляются, лишние удаляются
+func (h *Handler) syncOverflow(ctx context.Context, dstChatId, messageThreadId, tmpMessageId, newMessageId int64, overflow []*client.FormattedText,
+	forwardRuleId string, src *client.Message,
+) {
+	var (
+		err    error
+		result []int64
+	)
+	overflowTmpMessageIds := h.storageService.GetOverflowMessageIds(dstChatId, tmpMessageId)
+	defer func() {
+		// связь сохраняется даже при частичной синхронизации
+		if len(result) > 0 {
+			h.storageService.SetOverflowMessageIds(dstChatId, tmpMessageId, result)
+		} else if len(overflowTmpMessageIds) > 0 {
+			h.storageService.DeleteOverflowMessageIds(dstChatId, tmpMessageId)
+		}
+		h.log.ErrorOrDebugContext(ctx, err, "",
+			"dstChatId", dstChatId,
+			"tmpMessageId", tmpMessageId,
+			"overflowTmpMessageIds", overflowTmpMessageIds,
+			"result", result,
+		)
+	}()
+
+	for i, formattedText := range overflow {
+		content := &client.InputMessageText{
+			Text: formattedText,
+		}
+		if i < len(overflowTmpMessageIds) {
+			result = append(result, overflowTmpMessageIds[i])
+			overflowNewMessageId := h.storageService.GetNewMessageId(dstChatId, overflowTmpMessageIds[i])
+			if overflowNewMessageId == 0 {
+				continue // ответ ещё не отправлен
+			}
+			_, err = h.telegramRepo.EditMessageText(&client.EditMessageTextRequest{
+				ChatId:              dstChatId,
+				MessageId:           overflowNewMessageId,
+				InputMessageContent: content,
+			})
+			if err != nil {
+				result = append(result, overflowTmpMessageIds[i+1:]...)
+				return
+			}
+			continue
+		}
+		var message *client.Message
+		message, err = h.telegramRepo.SendMessage(&client.SendMessageRequest{
+			ChatId:              dstChatId,
+			MessageThreadId:     messageThreadId,
+			InputMessageContent: content,
+			ReplyTo: &client.InputMessageReplyToMessage{
+				MessageId: newMessageId,
+			},
+		})
+		if err != nil {
+			return
+		}
+		result = append(result, message.Id)
+		h.storageService.SetOriginMessageId(dstChatId, message.Id, forwardRuleId, src.ChatId, src.Id)
+	}
+
+	if len(overflowTmpMessageIds) <= len(overflow) {
+		return
+	}
+	var messageIds []int64
+	for _, overflowTmpMessageId := range overflowTmpMessageIds[len(overflow):] {
+		overflowNewMessageId := h.storageService.GetNewMessageId(dstChatId, overflowTmpMessageId)
+		h.storageService.DeleteNewMessageId(dstChatId, overflowTmpMessageId)
+		if overflowNewMessageId == 0 {
+			h.storageService.DeleteOriginMessageId(dstChatId, overflowTmpMessageId)
+			continue
+		}
+		h.storageService.DeleteTmpMessageId(dstChatId, overflowNewMessageId)
+		h.storageService.DeleteOriginMessageId(dstChatId, overflowNewMessageId)
+		messageIds = append(messageIds, overflowNewMessageId)
+	}
+	if len(messageIds) == 0 {
+		return
+	}
+	_, err = h.telegramRepo.DeleteMessages(&client.DeleteMessagesRequest{
+		ChatId:     dstChatId,
+		MessageIds: messageIds,
+		Revoke:     true,
+	})
+}
+
+// sendEditDiff отправляет ответ на копию с изменениями текста оригинала
+func (h *Handler) sendEditDiff(ctx context.Context, oldText, newText string,
+	dstChatId, messageThreadId, tmpMessageId, newMessageId int64, engineConfig *domain.EngineConfig,
+) {
+	var (
+		err                   error
+		editDiffTmpMessageIds []int64
+	)
+	defer func() {
+		// связь сохраняется и для частично отправленных изменений, чтобы удалить их вместе с копией
+		if len(editDiffTmpMessageIds) > 0 {
+			h.storageService.AddEditDiffMessageIds(dstChatId, tmpMessageId, editDiffTmpMessageIds)
+		}
+		h.log.ErrorOrDebugContext(ctx, err, "",
+			"dstChatId", dstChatId,
+			"tmpMessageId", tmpMessageId,
+			"newMessageId", newMessageId,
+			"editDiffTmpMessageIds", editDiffTmpMessageIds,
+		)
+	}()
+
+	formattedText := h.transformService.FormatEditDiff(oldText, newText, dstChatId, engineConfig)
+	if formattedText == nil {
+		return // текст не изменился
+	}
+
+	// изменения длинного текста не умещаются в одно сообщение, остаток уходит следующими ответами
+	content := &client.InputMessageText{
+		Text: formattedText,
+	}
+	overflow := h.messageService.SplitOverflow(content)
+	parts := append([]*client.FormattedText{content.Text}, overflow...)
+	for _, part := range parts {
+		var message *client.Message
+		message, err = h.telegramRepo.SendMessage(&client.SendMessageRequest{
+			ChatId:          dstChatId,
+			MessageThreadId: messageThreadId,
+			InputMessageContent: &client.InputMessageText{
+				Text: part,
+			},
+			ReplyTo: &client.InputMessageReplyToMessage{
+				MessageId: newMessageId,
+			},
+		})
+		if err != nil {
+			return
+		}
+		editDiffTmpMessageIds = append(editDiffTmpMessageIds, message.Id)
+	}
+}
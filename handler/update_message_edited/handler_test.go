@@ -0,0 +1,232 @@
+package update_message_edited
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/handler/update_message_edited/mocks"
+)
+
+func TestAddRevisionWithReplace(t *testing.T) {
+	t.Parallel()
+
+	const (
+		srcChatId    = int64(-1001)
+		dstChatId    = int64(-1002)
+		tmpMessageId = int64(20)
+		newMessageId = int64(21)
+	)
+
+	forwardRule := &domain.ForwardRule{
+		Id:   "Rule1",
+		From: srcChatId,
+		To:   []domain.ChatId{dstChatId},
+		Revisions: &domain.Revisions{
+			Run:  true,
+			Mode: domain.RevisionsReplace,
+		},
+	}
+	src := &client.Message{
+		Id:         10,
+		ChatId:     srcChatId,
+		CanBeSaved: true,
+	}
+	toChatMessage := &domain.ChatMessage{
+		ForwardRuleId: forwardRule.Id,
+		ChatId:        dstChatId,
+		MessageId:     tmpMessageId,
+	}
+
+	tests := []struct {
+		name          string
+		toChatMessage *domain.ChatMessage // связь после пересылки
+		isDeleted     bool
+	}{
+		{
+			name: "forward_succeeded",
+			toChatMessage: &domain.ChatMessage{
+				ForwardRuleId: forwardRule.Id,
+				ChatId:        dstChatId,
+				MessageId:     30,
+			},
+			isDeleted: true,
+		},
+		{
+			name:          "forward_failed",
+			toChatMessage: toChatMessage,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			telegramRepo := mocks.NewTelegramRepo(t)
+			storageService := mocks.NewStorageService(t)
+			forwarderService := mocks.NewForwarderService(t)
+
+			forwarderService.EXPECT().ForwardMessages(mock.Anything, []*client.Message{src}, "", srcChatId, dstChatId, int64(0), false, forwardRule.Id, mock.Anything).Once()
+			storageService.EXPECT().GetCopiedMessageIds(srcChatId, src.Id).Return([]*domain.ChatMessage{test.toChatMessage})
+			if test.isDeleted {
+				storageService.EXPECT().GetReplyContextMessageId(dstChatId, tmpMessageId).Return(0)
+				telegramRepo.EXPECT().DeleteMessages(&client.DeleteMessagesRequest{
+					ChatId:     dstChatId,
+					MessageIds: []int64{newMessageId},
+					Revoke:     true,
+				}).Return(&client.Ok{}, nil).Once()
+				storageService.EXPECT().DeleteTmpMessageId(dstChatId, newMessageId).Once()
+				storageService.EXPECT().DeleteNewMessageId(dstChatId, tmpMessageId).Once()
+				storageService.EXPECT().DeleteOriginMessageId(dstChatId, newMessageId).Once()
+				storageService.EXPECT().DeleteRevisionMessageId(srcChatId, src.Id, toChatMessage).Once()
+			}
+			// иначе прежняя редакция и связь с ней остаются: DeleteMessages не вызывается
+
+			h := New(telegramRepo, nil, storageService, nil, nil, nil, forwarderService)
+			h.addRevision(context.Background(), src, toChatMessage, dstChatId, tmpMessageId, newMessageId, forwardRule, &domain.EngineConfig{})
+		})
+	}
+}
+
+func TestResendForward(t *testing.T) {
+	t.Parallel()
+
+	const (
+		srcChatId    = int64(-1001)
+		dstChatId    = int64(-1002)
+		tmpMessageId = int64(20)
+		newMessageId = int64(21)
+	)
+
+	forwardRule := &domain.ForwardRule{
+		Id:           "Rule1",
+		From:         srcChatId,
+		To:           []domain.ChatId{dstChatId},
+		SyncForwards: true,
+	}
+	src := &client.Message{
+		Id:         10,
+		ChatId:     srcChatId,
+		CanBeSaved: true,
+	}
+
+	tests := []struct {
+		name           string
+		toChatMessages []*domain.ChatMessage // связи после пересылки
+		isDeleted      bool
+	}{
+		{
+			name: "forward_succeeded",
+			toChatMessages: []*domain.ChatMessage{
+				{ForwardRuleId: forwardRule.Id, ChatId: dstChatId, MessageId: 30},
+			},
+			isDeleted: true,
+		},
+		{
+			name: "forward_failed",
+			toChatMessages: []*domain.ChatMessage{
+				{ForwardRuleId: forwardRule.Id, ChatId: dstChatId, MessageId: tmpMessageId},
+			},
+		},
+		{
+			name: "mapping_not_found",
+			toChatMessages: []*domain.ChatMessage{
+				{ForwardRuleId: "Rule2", ChatId: dstChatId, MessageId: 30},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			telegramRepo := mocks.NewTelegramRepo(t)
+			storageService := mocks.NewStorageService(t)
+			forwarderService := mocks.NewForwarderService(t)
+
+			forwarderService.EXPECT().ForwardMessages(mock.Anything, []*client.Message{src}, "", srcChatId, dstChatId, int64(0), false, forwardRule.Id, mock.Anything).Once()
+			storageService.EXPECT().GetCopiedMessageIds(srcChatId, src.Id).Return(test.toChatMessages)
+			if test.isDeleted {
+				storageService.EXPECT().GetReplyContextMessageId(dstChatId, tmpMessageId).Return(0)
+				telegramRepo.EXPECT().DeleteMessages(&client.DeleteMessagesRequest{
+					ChatId:     dstChatId,
+					MessageIds: []int64{newMessageId},
+					Revoke:     true,
+				}).Return(&client.Ok{}, nil).Once()
+				storageService.EXPECT().DeleteTmpMessageId(dstChatId, newMessageId).Once()
+				storageService.EXPECT().DeleteNewMessageId(dstChatId, tmpMessageId).Once()
+				storageService.EXPECT().DeleteOriginMessageId(dstChatId, newMessageId).Once()
+			}
+			// иначе прежнее пересланное сообщение и связь с ним остаются
+
+			h := New(telegramRepo, nil, storageService, nil, nil, nil, forwarderService)
+			h.resendForward(context.Background(), src, dstChatId, tmpMessageId, newMessageId, forwardRule, &domain.EngineConfig{})
+		})
+	}
+}
+
+func TestSendEditDiff(t *testing.T) {
+	t.Parallel()
+
+	const (
+		dstChatId       = int64(-1002)
+		messageThreadId = int64(7)
+		tmpMessageId    = int64(20)
+		newMessageId    = int64(21)
+	)
+
+	engineConfig := &domain.EngineConfig{}
+	formattedText := &client.FormattedText{Text: "diff"}
+	overflow := []*client.FormattedText{{Text: "diff overflow"}}
+
+	tests := []struct {
+		name                  string
+		sendErr               error // ошибка отправки продолжения
+		editDiffTmpMessageIds []int64
+	}{
+		{
+			name:                  "all_parts_sent",
+			editDiffTmpMessageIds: []int64{30, 31},
+		},
+		{
+			name:                  "overflow_failed",
+			sendErr:               errors.New("send failed"),
+			editDiffTmpMessageIds: []int64{30},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			telegramRepo := mocks.NewTelegramRepo(t)
+			storageService := mocks.NewStorageService(t)
+			messageService := mocks.NewMessageService(t)
+			transformService := mocks.NewTransformService(t)
+
+			transformService.EXPECT().FormatEditDiff("old", "new", dstChatId, engineConfig).Return(formattedText)
+			messageService.EXPECT().SplitOverflow(mock.Anything).Return(overflow)
+			telegramRepo.EXPECT().SendMessage(mock.MatchedBy(func(req *client.SendMessageRequest) bool {
+				return req.MessageThreadId == messageThreadId &&
+					req.InputMessageContent.(*client.InputMessageText).Text == formattedText
+			})).Return(&client.Message{Id: 30}, nil).Once()
+			var message *client.Message
+			if test.sendErr == nil {
+				message = &client.Message{Id: 31}
+			}
+			telegramRepo.EXPECT().SendMessage(mock.MatchedBy(func(req *client.SendMessageRequest) bool {
+				return req.MessageThreadId == messageThreadId &&
+					req.InputMessageContent.(*client.InputMessageText).Text == overflow[0]
+			})).Return(message, test.sendErr).Once()
+			// отправленные части связываются с копией, чтобы удалить их вместе с ней
+			storageService.EXPECT().AddEditDiffMessageIds(dstChatId, tmpMessageId, test.editDiffTmpMessageIds).Once()
+
+			h := New(telegramRepo, nil, storageService, messageService, transformService, nil, nil)
+			h.sendEditDiff(context.Background(), "old", "new", dstChatId, messageThreadId, tmpMessageId, newMessageId, engineConfig)
+		})
+	}
+}
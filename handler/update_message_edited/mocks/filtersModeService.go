@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	domain "github.com/comerc/budva43/app/domain"
-	client "github.com/zelenin/go-tdlib/client"
+	context "context"
 
+	domain "github.com/comerc/budva43/app/domain"
 	mock "github.com/stretchr/testify/mock"
+	client "github.com/zelenin/go-tdlib/client"
 )
 
 // FiltersModeService is an autogenerated mock type for the filtersModeService type
@@ -22,17 +23,17 @@ func (_m *FiltersModeService) EXPECT() *FiltersModeService_Expecter {
 	return &FiltersModeService_Expecter{mock: &_m.Mock}
 }
 
-// Map provides a mock function with given fields: formattedText, forwardRule
-func (_m *FiltersModeService) Map(formattedText *client.FormattedText, forwardRule *domain.ForwardRule) string {
-	ret := _m.Called(formattedText, forwardRule)
+// Map provides a mock function with given fields: ctx, formattedText, forwardRule
+func (_m *FiltersModeService) Map(ctx context.Context, formattedText *client.FormattedText, forwardRule *domain.ForwardRule) string {
+	ret := _m.Called(ctx, formattedText, forwardRule)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Map")
 	}
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(*client.FormattedText, *domain.ForwardRule) string); ok {
-		r0 = rf(formattedText, forwardRule)
+	if rf, ok := ret.Get(0).(func(context.Context, *client.FormattedText, *domain.ForwardRule) string); ok {
+		r0 = rf(ctx, formattedText, forwardRule)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
@@ -46,15 +47,16 @@ type FiltersModeService_Map_Call struct {
 }
 
 // Map is a helper method to define mock.On call
+//   - ctx context.Context
 //   - formattedText *client.FormattedText
 //   - forwardRule *domain.ForwardRule
-func (_e *FiltersModeService_Expecter) Map(formattedText interface{}, forwardRule interface{}) *FiltersModeService_Map_Call {
-	return &FiltersModeService_Map_Call{Call: _e.mock.On("Map", formattedText, forwardRule)}
+func (_e *FiltersModeService_Expecter) Map(ctx interface{}, formattedText interface{}, forwardRule interface{}) *FiltersModeService_Map_Call {
+	return &FiltersModeService_Map_Call{Call: _e.mock.On("Map", ctx, formattedText, forwardRule)}
 }
 
-func (_c *FiltersModeService_Map_Call) Run(run func(formattedText *client.FormattedText, forwardRule *domain.ForwardRule)) *FiltersModeService_Map_Call {
+func (_c *FiltersModeService_Map_Call) Run(run func(ctx context.Context, formattedText *client.FormattedText, forwardRule *domain.ForwardRule)) *FiltersModeService_Map_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*client.FormattedText), args[1].(*domain.ForwardRule))
+		run(args[0].(context.Context), args[1].(*client.FormattedText), args[2].(*domain.ForwardRule))
 	})
 	return _c
 }
@@ -64,7 +66,7 @@ func (_c *FiltersModeService_Map_Call) Return(_a0 string) *FiltersModeService_Ma
 	return _c
 }
 
-func (_c *FiltersModeService_Map_Call) RunAndReturn(run func(*client.FormattedText, *domain.ForwardRule) string) *FiltersModeService_Map_Call {
+func (_c *FiltersModeService_Map_Call) RunAndReturn(run func(context.Context, *client.FormattedText, *domain.ForwardRule) string) *FiltersModeService_Map_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	domain "github.com/comerc/budva43/app/domain"
-	client "github.com/zelenin/go-tdlib/client"
+	context "context"
 
+	domain "github.com/comerc/budva43/app/domain"
 	mock "github.com/stretchr/testify/mock"
+	client "github.com/zelenin/go-tdlib/client"
 )
 
 // ForwarderService is an autogenerated mock type for the forwarderService type
@@ -22,9 +23,46 @@ func (_m *ForwarderService) EXPECT() *ForwarderService_Expecter {
 	return &ForwarderService_Expecter{mock: &_m.Mock}
 }
 
-// ForwardMessages provides a mock function with given fields: messages, filtersMode, srcChatId, dstChatId, prevMessageId, isSendCopy, forwardRuleId, engineConfig
-func (_m *ForwarderService) ForwardMessages(messages []*client.Message, filtersMode string, srcChatId int64, dstChatId int64, prevMessageId int64, isSendCopy bool, forwardRuleId string, engineConfig *domain.EngineConfig) {
-	_m.Called(messages, filtersMode, srcChatId, dstChatId, prevMessageId, isSendCopy, forwardRuleId, engineConfig)
+// AddReplyContext provides a mock function with given fields: ctx, formattedText, src, dstChatId, forwardRule
+func (_m *ForwarderService) AddReplyContext(ctx context.Context, formattedText *client.FormattedText, src *client.Message, dstChatId int64, forwardRule *domain.ForwardRule) {
+	_m.Called(ctx, formattedText, src, dstChatId, forwardRule)
+}
+
+// ForwarderService_AddReplyContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddReplyContext'
+type ForwarderService_AddReplyContext_Call struct {
+	*mock.Call
+}
+
+// AddReplyContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - formattedText *client.FormattedText
+//   - src *client.Message
+//   - dstChatId int64
+//   - forwardRule *domain.ForwardRule
+func (_e *ForwarderService_Expecter) AddReplyContext(ctx interface{}, formattedText interface{}, src interface{}, dstChatId interface{}, forwardRule interface{}) *ForwarderService_AddReplyContext_Call {
+	return &ForwarderService_AddReplyContext_Call{Call: _e.mock.On("AddReplyContext", ctx, formattedText, src, dstChatId, forwardRule)}
+}
+
+func (_c *ForwarderService_AddReplyContext_Call) Run(run func(ctx context.Context, formattedText *client.FormattedText, src *client.Message, dstChatId int64, forwardRule *domain.ForwardRule)) *ForwarderService_AddReplyContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*client.FormattedText), args[2].(*client.Message), args[3].(int64), args[4].(*domain.ForwardRule))
+	})
+	return _c
+}
+
+func (_c *ForwarderService_AddReplyContext_Call) Return() *ForwarderService_AddReplyContext_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ForwarderService_AddReplyContext_Call) RunAndReturn(run func(context.Context, *client.FormattedText, *client.Message, int64, *domain.ForwardRule)) *ForwarderService_AddReplyContext_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ForwardMessages provides a mock function with given fields: ctx, messages, filtersMode, srcChatId, dstChatId, prevMessageId, isSendCopy, forwardRuleId, engineConfig
+func (_m *ForwarderService) ForwardMessages(ctx context.Context, messages []*client.Message, filtersMode string, srcChatId int64, dstChatId int64, prevMessageId int64, isSendCopy bool, forwardRuleId string, engineConfig *domain.EngineConfig) {
+	_m.Called(ctx, messages, filtersMode, srcChatId, dstChatId, prevMessageId, isSendCopy, forwardRuleId, engineConfig)
 }
 
 // ForwarderService_ForwardMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForwardMessages'
@@ -33,6 +71,7 @@ type ForwarderService_ForwardMessages_Call struct {
 }
 
 // ForwardMessages is a helper method to define mock.On call
+//   - ctx context.Context
 //   - messages []*client.Message
 //   - filtersMode string
 //   - srcChatId int64
@@ -41,13 +80,13 @@ type ForwarderService_ForwardMessages_Call struct {
 //   - isSendCopy bool
 //   - forwardRuleId string
 //   - engineConfig *domain.EngineConfig
-func (_e *ForwarderService_Expecter) ForwardMessages(messages interface{}, filtersMode interface{}, srcChatId interface{}, dstChatId interface{}, prevMessageId interface{}, isSendCopy interface{}, forwardRuleId interface{}, engineConfig interface{}) *ForwarderService_ForwardMessages_Call {
-	return &ForwarderService_ForwardMessages_Call{Call: _e.mock.On("ForwardMessages", messages, filtersMode, srcChatId, dstChatId, prevMessageId, isSendCopy, forwardRuleId, engineConfig)}
+func (_e *ForwarderService_Expecter) ForwardMessages(ctx interface{}, messages interface{}, filtersMode interface{}, srcChatId interface{}, dstChatId interface{}, prevMessageId interface{}, isSendCopy interface{}, forwardRuleId interface{}, engineConfig interface{}) *ForwarderService_ForwardMessages_Call {
+	return &ForwarderService_ForwardMessages_Call{Call: _e.mock.On("ForwardMessages", ctx, messages, filtersMode, srcChatId, dstChatId, prevMessageId, isSendCopy, forwardRuleId, engineConfig)}
 }
 
-func (_c *ForwarderService_ForwardMessages_Call) Run(run func(messages []*client.Message, filtersMode string, srcChatId int64, dstChatId int64, prevMessageId int64, isSendCopy bool, forwardRuleId string, engineConfig *domain.EngineConfig)) *ForwarderService_ForwardMessages_Call {
+func (_c *ForwarderService_ForwardMessages_Call) Run(run func(ctx context.Context, messages []*client.Message, filtersMode string, srcChatId int64, dstChatId int64, prevMessageId int64, isSendCopy bool, forwardRuleId string, engineConfig *domain.EngineConfig)) *ForwarderService_ForwardMessages_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]*client.Message), args[1].(string), args[2].(int64), args[3].(int64), args[4].(int64), args[5].(bool), args[6].(string), args[7].(*domain.EngineConfig))
+		run(args[0].(context.Context), args[1].([]*client.Message), args[2].(string), args[3].(int64), args[4].(int64), args[5].(int64), args[6].(bool), args[7].(string), args[8].(*domain.EngineConfig))
 	})
 	return _c
 }
@@ -57,11 +96,61 @@ func (_c *ForwarderService_ForwardMessages_Call) Return() *ForwarderService_Forw
 	return _c
 }
 
-func (_c *ForwarderService_ForwardMessages_Call) RunAndReturn(run func([]*client.Message, string, int64, int64, int64, bool, string, *domain.EngineConfig)) *ForwarderService_ForwardMessages_Call {
+func (_c *ForwarderService_ForwardMessages_Call) RunAndReturn(run func(context.Context, []*client.Message, string, int64, int64, int64, bool, string, *domain.EngineConfig)) *ForwarderService_ForwardMessages_Call {
 	_c.Run(run)
 	return _c
 }
 
+// GetMessageThreadId provides a mock function with given fields: ctx, srcChatId, dstChatId, forwardRule, engineConfig
+func (_m *ForwarderService) GetMessageThreadId(ctx context.Context, srcChatId int64, dstChatId int64, forwardRule *domain.ForwardRule, engineConfig *domain.EngineConfig) int64 {
+	ret := _m.Called(ctx, srcChatId, dstChatId, forwardRule, engineConfig)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessageThreadId")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *domain.ForwardRule, *domain.EngineConfig) int64); ok {
+		r0 = rf(ctx, srcChatId, dstChatId, forwardRule, engineConfig)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// ForwarderService_GetMessageThreadId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMessageThreadId'
+type ForwarderService_GetMessageThreadId_Call struct {
+	*mock.Call
+}
+
+// GetMessageThreadId is a helper method to define mock.On call
+//   - ctx context.Context
+//   - srcChatId int64
+//   - dstChatId int64
+//   - forwardRule *domain.ForwardRule
+//   - engineConfig *domain.EngineConfig
+func (_e *ForwarderService_Expecter) GetMessageThreadId(ctx interface{}, srcChatId interface{}, dstChatId interface{}, forwardRule interface{}, engineConfig interface{}) *ForwarderService_GetMessageThreadId_Call {
+	return &ForwarderService_GetMessageThreadId_Call{Call: _e.mock.On("GetMessageThreadId", ctx, srcChatId, dstChatId, forwardRule, engineConfig)}
+}
+
+func (_c *ForwarderService_GetMessageThreadId_Call) Run(run func(ctx context.Context, srcChatId int64, dstChatId int64, forwardRule *domain.ForwardRule, engineConfig *domain.EngineConfig)) *ForwarderService_GetMessageThreadId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(*domain.ForwardRule), args[4].(*domain.EngineConfig))
+	})
+	return _c
+}
+
+func (_c *ForwarderService_GetMessageThreadId_Call) Return(_a0 int64) *ForwarderService_GetMessageThreadId_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ForwarderService_GetMessageThreadId_Call) RunAndReturn(run func(context.Context, int64, int64, *domain.ForwardRule, *domain.EngineConfig) int64) *ForwarderService_GetMessageThreadId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewForwarderService creates a new instance of ForwarderService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewForwarderService(t interface {
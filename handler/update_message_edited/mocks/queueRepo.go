@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // QueueRepo is an autogenerated mock type for the queueRepo type
 type QueueRepo struct {
@@ -17,9 +21,9 @@ func (_m *QueueRepo) EXPECT() *QueueRepo_Expecter {
 	return &QueueRepo_Expecter{mock: &_m.Mock}
 }
 
-// Add provides a mock function with given fields: fn
-func (_m *QueueRepo) Add(fn func()) {
-	_m.Called(fn)
+// Add provides a mock function with given fields: ctx, fn
+func (_m *QueueRepo) Add(ctx context.Context, fn func(context.Context)) {
+	_m.Called(ctx, fn)
 }
 
 // QueueRepo_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
@@ -28,14 +32,15 @@ type QueueRepo_Add_Call struct {
 }
 
 // Add is a helper method to define mock.On call
-//   - fn func()
-func (_e *QueueRepo_Expecter) Add(fn interface{}) *QueueRepo_Add_Call {
-	return &QueueRepo_Add_Call{Call: _e.mock.On("Add", fn)}
+//   - ctx context.Context
+//   - fn func(context.Context)
+func (_e *QueueRepo_Expecter) Add(ctx interface{}, fn interface{}) *QueueRepo_Add_Call {
+	return &QueueRepo_Add_Call{Call: _e.mock.On("Add", ctx, fn)}
 }
 
-func (_c *QueueRepo_Add_Call) Run(run func(fn func())) *QueueRepo_Add_Call {
+func (_c *QueueRepo_Add_Call) Run(run func(ctx context.Context, fn func(context.Context))) *QueueRepo_Add_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(func()))
+		run(args[0].(context.Context), args[1].(func(context.Context)))
 	})
 	return _c
 }
@@ -45,7 +50,7 @@ func (_c *QueueRepo_Add_Call) Return() *QueueRepo_Add_Call {
 	return _c
 }
 
-func (_c *QueueRepo_Add_Call) RunAndReturn(run func(func())) *QueueRepo_Add_Call {
+func (_c *QueueRepo_Add_Call) RunAndReturn(run func(context.Context, func(context.Context))) *QueueRepo_Add_Call {
 	_c.Run(run)
 	return _c
 }
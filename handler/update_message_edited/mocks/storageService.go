@@ -2,7 +2,10 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	domain "github.com/comerc/budva43/app/domain"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // StorageService is an autogenerated mock type for the storageService type
 type StorageService struct {
@@ -17,6 +20,41 @@ func (_m *StorageService) EXPECT() *StorageService_Expecter {
 	return &StorageService_Expecter{mock: &_m.Mock}
 }
 
+// AddEditDiffMessageIds provides a mock function with given fields: dstChatId, tmpMessageId, editDiffTmpMessageIds
+func (_m *StorageService) AddEditDiffMessageIds(dstChatId int64, tmpMessageId int64, editDiffTmpMessageIds []int64) {
+	_m.Called(dstChatId, tmpMessageId, editDiffTmpMessageIds)
+}
+
+// StorageService_AddEditDiffMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddEditDiffMessageIds'
+type StorageService_AddEditDiffMessageIds_Call struct {
+	*mock.Call
+}
+
+// AddEditDiffMessageIds is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+//   - editDiffTmpMessageIds []int64
+func (_e *StorageService_Expecter) AddEditDiffMessageIds(dstChatId interface{}, tmpMessageId interface{}, editDiffTmpMessageIds interface{}) *StorageService_AddEditDiffMessageIds_Call {
+	return &StorageService_AddEditDiffMessageIds_Call{Call: _e.mock.On("AddEditDiffMessageIds", dstChatId, tmpMessageId, editDiffTmpMessageIds)}
+}
+
+func (_c *StorageService_AddEditDiffMessageIds_Call) Run(run func(dstChatId int64, tmpMessageId int64, editDiffTmpMessageIds []int64)) *StorageService_AddEditDiffMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].([]int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_AddEditDiffMessageIds_Call) Return() *StorageService_AddEditDiffMessageIds_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_AddEditDiffMessageIds_Call) RunAndReturn(run func(int64, int64, []int64)) *StorageService_AddEditDiffMessageIds_Call {
+	_c.Run(run)
+	return _c
+}
+
 // DeleteAnswerMessageId provides a mock function with given fields: dstChatId, tmpMessageId
 func (_m *StorageService) DeleteAnswerMessageId(dstChatId int64, tmpMessageId int64) {
 	_m.Called(dstChatId, tmpMessageId)
@@ -51,20 +89,225 @@ func (_c *StorageService_DeleteAnswerMessageId_Call) RunAndReturn(run func(int64
 	return _c
 }
 
+// DeleteNewMessageId provides a mock function with given fields: chatId, tmpMessageId
+func (_m *StorageService) DeleteNewMessageId(chatId int64, tmpMessageId int64) {
+	_m.Called(chatId, tmpMessageId)
+}
+
+// StorageService_DeleteNewMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNewMessageId'
+type StorageService_DeleteNewMessageId_Call struct {
+	*mock.Call
+}
+
+// DeleteNewMessageId is a helper method to define mock.On call
+//   - chatId int64
+//   - tmpMessageId int64
+func (_e *StorageService_Expecter) DeleteNewMessageId(chatId interface{}, tmpMessageId interface{}) *StorageService_DeleteNewMessageId_Call {
+	return &StorageService_DeleteNewMessageId_Call{Call: _e.mock.On("DeleteNewMessageId", chatId, tmpMessageId)}
+}
+
+func (_c *StorageService_DeleteNewMessageId_Call) Run(run func(chatId int64, tmpMessageId int64)) *StorageService_DeleteNewMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteNewMessageId_Call) Return() *StorageService_DeleteNewMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteNewMessageId_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteNewMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteOriginMessageId provides a mock function with given fields: dstChatId, dstMessageId
+func (_m *StorageService) DeleteOriginMessageId(dstChatId int64, dstMessageId int64) {
+	_m.Called(dstChatId, dstMessageId)
+}
+
+// StorageService_DeleteOriginMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOriginMessageId'
+type StorageService_DeleteOriginMessageId_Call struct {
+	*mock.Call
+}
+
+// DeleteOriginMessageId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - dstMessageId int64
+func (_e *StorageService_Expecter) DeleteOriginMessageId(dstChatId interface{}, dstMessageId interface{}) *StorageService_DeleteOriginMessageId_Call {
+	return &StorageService_DeleteOriginMessageId_Call{Call: _e.mock.On("DeleteOriginMessageId", dstChatId, dstMessageId)}
+}
+
+func (_c *StorageService_DeleteOriginMessageId_Call) Run(run func(dstChatId int64, dstMessageId int64)) *StorageService_DeleteOriginMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteOriginMessageId_Call) Return() *StorageService_DeleteOriginMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteOriginMessageId_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteOriginMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteOverflowMessageIds provides a mock function with given fields: dstChatId, tmpMessageId
+func (_m *StorageService) DeleteOverflowMessageIds(dstChatId int64, tmpMessageId int64) {
+	_m.Called(dstChatId, tmpMessageId)
+}
+
+// StorageService_DeleteOverflowMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOverflowMessageIds'
+type StorageService_DeleteOverflowMessageIds_Call struct {
+	*mock.Call
+}
+
+// DeleteOverflowMessageIds is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+func (_e *StorageService_Expecter) DeleteOverflowMessageIds(dstChatId interface{}, tmpMessageId interface{}) *StorageService_DeleteOverflowMessageIds_Call {
+	return &StorageService_DeleteOverflowMessageIds_Call{Call: _e.mock.On("DeleteOverflowMessageIds", dstChatId, tmpMessageId)}
+}
+
+func (_c *StorageService_DeleteOverflowMessageIds_Call) Run(run func(dstChatId int64, tmpMessageId int64)) *StorageService_DeleteOverflowMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteOverflowMessageIds_Call) Return() *StorageService_DeleteOverflowMessageIds_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteOverflowMessageIds_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteOverflowMessageIds_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteReplyContextMessageId provides a mock function with given fields: dstChatId, tmpMessageId
+func (_m *StorageService) DeleteReplyContextMessageId(dstChatId int64, tmpMessageId int64) {
+	_m.Called(dstChatId, tmpMessageId)
+}
+
+// StorageService_DeleteReplyContextMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReplyContextMessageId'
+type StorageService_DeleteReplyContextMessageId_Call struct {
+	*mock.Call
+}
+
+// DeleteReplyContextMessageId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+func (_e *StorageService_Expecter) DeleteReplyContextMessageId(dstChatId interface{}, tmpMessageId interface{}) *StorageService_DeleteReplyContextMessageId_Call {
+	return &StorageService_DeleteReplyContextMessageId_Call{Call: _e.mock.On("DeleteReplyContextMessageId", dstChatId, tmpMessageId)}
+}
+
+func (_c *StorageService_DeleteReplyContextMessageId_Call) Run(run func(dstChatId int64, tmpMessageId int64)) *StorageService_DeleteReplyContextMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteReplyContextMessageId_Call) Return() *StorageService_DeleteReplyContextMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteReplyContextMessageId_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteReplyContextMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteRevisionMessageId provides a mock function with given fields: chatId, messageId, toChatMessage
+func (_m *StorageService) DeleteRevisionMessageId(chatId int64, messageId int64, toChatMessage *domain.ChatMessage) {
+	_m.Called(chatId, messageId, toChatMessage)
+}
+
+// StorageService_DeleteRevisionMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRevisionMessageId'
+type StorageService_DeleteRevisionMessageId_Call struct {
+	*mock.Call
+}
+
+// DeleteRevisionMessageId is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+//   - toChatMessage *domain.ChatMessage
+func (_e *StorageService_Expecter) DeleteRevisionMessageId(chatId interface{}, messageId interface{}, toChatMessage interface{}) *StorageService_DeleteRevisionMessageId_Call {
+	return &StorageService_DeleteRevisionMessageId_Call{Call: _e.mock.On("DeleteRevisionMessageId", chatId, messageId, toChatMessage)}
+}
+
+func (_c *StorageService_DeleteRevisionMessageId_Call) Run(run func(chatId int64, messageId int64, toChatMessage *domain.ChatMessage)) *StorageService_DeleteRevisionMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(*domain.ChatMessage))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteRevisionMessageId_Call) Return() *StorageService_DeleteRevisionMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteRevisionMessageId_Call) RunAndReturn(run func(int64, int64, *domain.ChatMessage)) *StorageService_DeleteRevisionMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteTmpMessageId provides a mock function with given fields: chatId, newMessageId
+func (_m *StorageService) DeleteTmpMessageId(chatId int64, newMessageId int64) {
+	_m.Called(chatId, newMessageId)
+}
+
+// StorageService_DeleteTmpMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTmpMessageId'
+type StorageService_DeleteTmpMessageId_Call struct {
+	*mock.Call
+}
+
+// DeleteTmpMessageId is a helper method to define mock.On call
+//   - chatId int64
+//   - newMessageId int64
+func (_e *StorageService_Expecter) DeleteTmpMessageId(chatId interface{}, newMessageId interface{}) *StorageService_DeleteTmpMessageId_Call {
+	return &StorageService_DeleteTmpMessageId_Call{Call: _e.mock.On("DeleteTmpMessageId", chatId, newMessageId)}
+}
+
+func (_c *StorageService_DeleteTmpMessageId_Call) Run(run func(chatId int64, newMessageId int64)) *StorageService_DeleteTmpMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteTmpMessageId_Call) Return() *StorageService_DeleteTmpMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteTmpMessageId_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteTmpMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
 // GetCopiedMessageIds provides a mock function with given fields: chatId, messageId
-func (_m *StorageService) GetCopiedMessageIds(chatId int64, messageId int64) []string {
+func (_m *StorageService) GetCopiedMessageIds(chatId int64, messageId int64) []*domain.ChatMessage {
 	ret := _m.Called(chatId, messageId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCopiedMessageIds")
 	}
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func(int64, int64) []string); ok {
+	var r0 []*domain.ChatMessage
+	if rf, ok := ret.Get(0).(func(int64, int64) []*domain.ChatMessage); ok {
 		r0 = rf(chatId, messageId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]*domain.ChatMessage)
 		}
 	}
 
@@ -90,12 +333,61 @@ func (_c *StorageService_GetCopiedMessageIds_Call) Run(run func(chatId int64, me
 	return _c
 }
 
-func (_c *StorageService_GetCopiedMessageIds_Call) Return(_a0 []string) *StorageService_GetCopiedMessageIds_Call {
+func (_c *StorageService_GetCopiedMessageIds_Call) Return(_a0 []*domain.ChatMessage) *StorageService_GetCopiedMessageIds_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *StorageService_GetCopiedMessageIds_Call) RunAndReturn(run func(int64, int64) []string) *StorageService_GetCopiedMessageIds_Call {
+func (_c *StorageService_GetCopiedMessageIds_Call) RunAndReturn(run func(int64, int64) []*domain.ChatMessage) *StorageService_GetCopiedMessageIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMediaAlbumMessageIds provides a mock function with given fields: chatId, messageId
+func (_m *StorageService) GetMediaAlbumMessageIds(chatId int64, messageId int64) []int64 {
+	ret := _m.Called(chatId, messageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMediaAlbumMessageIds")
+	}
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(int64, int64) []int64); ok {
+		r0 = rf(chatId, messageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	return r0
+}
+
+// StorageService_GetMediaAlbumMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMediaAlbumMessageIds'
+type StorageService_GetMediaAlbumMessageIds_Call struct {
+	*mock.Call
+}
+
+// GetMediaAlbumMessageIds is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+func (_e *StorageService_Expecter) GetMediaAlbumMessageIds(chatId interface{}, messageId interface{}) *StorageService_GetMediaAlbumMessageIds_Call {
+	return &StorageService_GetMediaAlbumMessageIds_Call{Call: _e.mock.On("GetMediaAlbumMessageIds", chatId, messageId)}
+}
+
+func (_c *StorageService_GetMediaAlbumMessageIds_Call) Run(run func(chatId int64, messageId int64)) *StorageService_GetMediaAlbumMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetMediaAlbumMessageIds_Call) Return(_a0 []int64) *StorageService_GetMediaAlbumMessageIds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetMediaAlbumMessageIds_Call) RunAndReturn(run func(int64, int64) []int64) *StorageService_GetMediaAlbumMessageIds_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -147,6 +439,159 @@ func (_c *StorageService_GetNewMessageId_Call) RunAndReturn(run func(int64, int6
 	return _c
 }
 
+// GetOverflowMessageIds provides a mock function with given fields: dstChatId, tmpMessageId
+func (_m *StorageService) GetOverflowMessageIds(dstChatId int64, tmpMessageId int64) []int64 {
+	ret := _m.Called(dstChatId, tmpMessageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverflowMessageIds")
+	}
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(int64, int64) []int64); ok {
+		r0 = rf(dstChatId, tmpMessageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	return r0
+}
+
+// StorageService_GetOverflowMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOverflowMessageIds'
+type StorageService_GetOverflowMessageIds_Call struct {
+	*mock.Call
+}
+
+// GetOverflowMessageIds is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+func (_e *StorageService_Expecter) GetOverflowMessageIds(dstChatId interface{}, tmpMessageId interface{}) *StorageService_GetOverflowMessageIds_Call {
+	return &StorageService_GetOverflowMessageIds_Call{Call: _e.mock.On("GetOverflowMessageIds", dstChatId, tmpMessageId)}
+}
+
+func (_c *StorageService_GetOverflowMessageIds_Call) Run(run func(dstChatId int64, tmpMessageId int64)) *StorageService_GetOverflowMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetOverflowMessageIds_Call) Return(_a0 []int64) *StorageService_GetOverflowMessageIds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetOverflowMessageIds_Call) RunAndReturn(run func(int64, int64) []int64) *StorageService_GetOverflowMessageIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReplyContextMessageId provides a mock function with given fields: dstChatId, tmpMessageId
+func (_m *StorageService) GetReplyContextMessageId(dstChatId int64, tmpMessageId int64) int64 {
+	ret := _m.Called(dstChatId, tmpMessageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReplyContextMessageId")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(dstChatId, tmpMessageId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// StorageService_GetReplyContextMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReplyContextMessageId'
+type StorageService_GetReplyContextMessageId_Call struct {
+	*mock.Call
+}
+
+// GetReplyContextMessageId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+func (_e *StorageService_Expecter) GetReplyContextMessageId(dstChatId interface{}, tmpMessageId interface{}) *StorageService_GetReplyContextMessageId_Call {
+	return &StorageService_GetReplyContextMessageId_Call{Call: _e.mock.On("GetReplyContextMessageId", dstChatId, tmpMessageId)}
+}
+
+func (_c *StorageService_GetReplyContextMessageId_Call) Run(run func(dstChatId int64, tmpMessageId int64)) *StorageService_GetReplyContextMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetReplyContextMessageId_Call) Return(_a0 int64) *StorageService_GetReplyContextMessageId_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetReplyContextMessageId_Call) RunAndReturn(run func(int64, int64) int64) *StorageService_GetReplyContextMessageId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTextSnapshot provides a mock function with given fields: chatId, messageId
+func (_m *StorageService) GetTextSnapshot(chatId int64, messageId int64) (string, bool) {
+	ret := _m.Called(chatId, messageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTextSnapshot")
+	}
+
+	var r0 string
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(int64, int64) (string, bool)); ok {
+		return rf(chatId, messageId)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) string); ok {
+		r0 = rf(chatId, messageId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) bool); ok {
+		r1 = rf(chatId, messageId)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// StorageService_GetTextSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTextSnapshot'
+type StorageService_GetTextSnapshot_Call struct {
+	*mock.Call
+}
+
+// GetTextSnapshot is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+func (_e *StorageService_Expecter) GetTextSnapshot(chatId interface{}, messageId interface{}) *StorageService_GetTextSnapshot_Call {
+	return &StorageService_GetTextSnapshot_Call{Call: _e.mock.On("GetTextSnapshot", chatId, messageId)}
+}
+
+func (_c *StorageService_GetTextSnapshot_Call) Run(run func(chatId int64, messageId int64)) *StorageService_GetTextSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetTextSnapshot_Call) Return(_a0 string, _a1 bool) *StorageService_GetTextSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageService_GetTextSnapshot_Call) RunAndReturn(run func(int64, int64) (string, bool)) *StorageService_GetTextSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetAnswerMessageId provides a mock function with given fields: dstChatId, tmpMessageId, chatId, messageId
 func (_m *StorageService) SetAnswerMessageId(dstChatId int64, tmpMessageId int64, chatId int64, messageId int64) {
 	_m.Called(dstChatId, tmpMessageId, chatId, messageId)
@@ -183,6 +628,113 @@ func (_c *StorageService_SetAnswerMessageId_Call) RunAndReturn(run func(int64, i
 	return _c
 }
 
+// SetOriginMessageId provides a mock function with given fields: dstChatId, dstMessageId, forwardRuleId, chatId, messageId
+func (_m *StorageService) SetOriginMessageId(dstChatId int64, dstMessageId int64, forwardRuleId string, chatId int64, messageId int64) {
+	_m.Called(dstChatId, dstMessageId, forwardRuleId, chatId, messageId)
+}
+
+// StorageService_SetOriginMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOriginMessageId'
+type StorageService_SetOriginMessageId_Call struct {
+	*mock.Call
+}
+
+// SetOriginMessageId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - dstMessageId int64
+//   - forwardRuleId string
+//   - chatId int64
+//   - messageId int64
+func (_e *StorageService_Expecter) SetOriginMessageId(dstChatId interface{}, dstMessageId interface{}, forwardRuleId interface{}, chatId interface{}, messageId interface{}) *StorageService_SetOriginMessageId_Call {
+	return &StorageService_SetOriginMessageId_Call{Call: _e.mock.On("SetOriginMessageId", dstChatId, dstMessageId, forwardRuleId, chatId, messageId)}
+}
+
+func (_c *StorageService_SetOriginMessageId_Call) Run(run func(dstChatId int64, dstMessageId int64, forwardRuleId string, chatId int64, messageId int64)) *StorageService_SetOriginMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(string), args[3].(int64), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_SetOriginMessageId_Call) Return() *StorageService_SetOriginMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_SetOriginMessageId_Call) RunAndReturn(run func(int64, int64, string, int64, int64)) *StorageService_SetOriginMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetOverflowMessageIds provides a mock function with given fields: dstChatId, tmpMessageId, overflowTmpMessageIds
+func (_m *StorageService) SetOverflowMessageIds(dstChatId int64, tmpMessageId int64, overflowTmpMessageIds []int64) {
+	_m.Called(dstChatId, tmpMessageId, overflowTmpMessageIds)
+}
+
+// StorageService_SetOverflowMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOverflowMessageIds'
+type StorageService_SetOverflowMessageIds_Call struct {
+	*mock.Call
+}
+
+// SetOverflowMessageIds is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+//   - overflowTmpMessageIds []int64
+func (_e *StorageService_Expecter) SetOverflowMessageIds(dstChatId interface{}, tmpMessageId interface{}, overflowTmpMessageIds interface{}) *StorageService_SetOverflowMessageIds_Call {
+	return &StorageService_SetOverflowMessageIds_Call{Call: _e.mock.On("SetOverflowMessageIds", dstChatId, tmpMessageId, overflowTmpMessageIds)}
+}
+
+func (_c *StorageService_SetOverflowMessageIds_Call) Run(run func(dstChatId int64, tmpMessageId int64, overflowTmpMessageIds []int64)) *StorageService_SetOverflowMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].([]int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_SetOverflowMessageIds_Call) Return() *StorageService_SetOverflowMessageIds_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_SetOverflowMessageIds_Call) RunAndReturn(run func(int64, int64, []int64)) *StorageService_SetOverflowMessageIds_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetTextSnapshot provides a mock function with given fields: chatId, messageId, text
+func (_m *StorageService) SetTextSnapshot(chatId int64, messageId int64, text string) {
+	_m.Called(chatId, messageId, text)
+}
+
+// StorageService_SetTextSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTextSnapshot'
+type StorageService_SetTextSnapshot_Call struct {
+	*mock.Call
+}
+
+// SetTextSnapshot is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+//   - text string
+func (_e *StorageService_Expecter) SetTextSnapshot(chatId interface{}, messageId interface{}, text interface{}) *StorageService_SetTextSnapshot_Call {
+	return &StorageService_SetTextSnapshot_Call{Call: _e.mock.On("SetTextSnapshot", chatId, messageId, text)}
+}
+
+func (_c *StorageService_SetTextSnapshot_Call) Run(run func(chatId int64, messageId int64, text string)) *StorageService_SetTextSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *StorageService_SetTextSnapshot_Call) Return() *StorageService_SetTextSnapshot_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_SetTextSnapshot_Call) RunAndReturn(run func(int64, int64, string)) *StorageService_SetTextSnapshot_Call {
+	_c.Run(run)
+	return _c
+}
+
 // NewStorageService creates a new instance of StorageService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStorageService(t interface {
@@ -20,6 +20,64 @@ func (_m *TelegramRepo) EXPECT() *TelegramRepo_Expecter {
 	return &TelegramRepo_Expecter{mock: &_m.Mock}
 }
 
+// DeleteMessages provides a mock function with given fields: _a0
+func (_m *TelegramRepo) DeleteMessages(_a0 *client.DeleteMessagesRequest) (*client.Ok, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMessages")
+	}
+
+	var r0 *client.Ok
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*client.DeleteMessagesRequest) (*client.Ok, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*client.DeleteMessagesRequest) *client.Ok); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Ok)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*client.DeleteMessagesRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TelegramRepo_DeleteMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMessages'
+type TelegramRepo_DeleteMessages_Call struct {
+	*mock.Call
+}
+
+// DeleteMessages is a helper method to define mock.On call
+//   - _a0 *client.DeleteMessagesRequest
+func (_e *TelegramRepo_Expecter) DeleteMessages(_a0 interface{}) *TelegramRepo_DeleteMessages_Call {
+	return &TelegramRepo_DeleteMessages_Call{Call: _e.mock.On("DeleteMessages", _a0)}
+}
+
+func (_c *TelegramRepo_DeleteMessages_Call) Run(run func(_a0 *client.DeleteMessagesRequest)) *TelegramRepo_DeleteMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.DeleteMessagesRequest))
+	})
+	return _c
+}
+
+func (_c *TelegramRepo_DeleteMessages_Call) Return(_a0 *client.Ok, _a1 error) *TelegramRepo_DeleteMessages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TelegramRepo_DeleteMessages_Call) RunAndReturn(run func(*client.DeleteMessagesRequest) (*client.Ok, error)) *TelegramRepo_DeleteMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EditMessageCaption provides a mock function with given fields: _a0
 func (_m *TelegramRepo) EditMessageCaption(_a0 *client.EditMessageCaptionRequest) (*client.Message, error) {
 	ret := _m.Called(_a0)
@@ -78,6 +136,64 @@ func (_c *TelegramRepo_EditMessageCaption_Call) RunAndReturn(run func(*client.Ed
 	return _c
 }
 
+// EditMessageLiveLocation provides a mock function with given fields: _a0
+func (_m *TelegramRepo) EditMessageLiveLocation(_a0 *client.EditMessageLiveLocationRequest) (*client.Message, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditMessageLiveLocation")
+	}
+
+	var r0 *client.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*client.EditMessageLiveLocationRequest) (*client.Message, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*client.EditMessageLiveLocationRequest) *client.Message); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*client.EditMessageLiveLocationRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TelegramRepo_EditMessageLiveLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditMessageLiveLocation'
+type TelegramRepo_EditMessageLiveLocation_Call struct {
+	*mock.Call
+}
+
+// EditMessageLiveLocation is a helper method to define mock.On call
+//   - _a0 *client.EditMessageLiveLocationRequest
+func (_e *TelegramRepo_Expecter) EditMessageLiveLocation(_a0 interface{}) *TelegramRepo_EditMessageLiveLocation_Call {
+	return &TelegramRepo_EditMessageLiveLocation_Call{Call: _e.mock.On("EditMessageLiveLocation", _a0)}
+}
+
+func (_c *TelegramRepo_EditMessageLiveLocation_Call) Run(run func(_a0 *client.EditMessageLiveLocationRequest)) *TelegramRepo_EditMessageLiveLocation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.EditMessageLiveLocationRequest))
+	})
+	return _c
+}
+
+func (_c *TelegramRepo_EditMessageLiveLocation_Call) Return(_a0 *client.Message, _a1 error) *TelegramRepo_EditMessageLiveLocation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TelegramRepo_EditMessageLiveLocation_Call) RunAndReturn(run func(*client.EditMessageLiveLocationRequest) (*client.Message, error)) *TelegramRepo_EditMessageLiveLocation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EditMessageText provides a mock function with given fields: _a0
 func (_m *TelegramRepo) EditMessageText(_a0 *client.EditMessageTextRequest) (*client.Message, error) {
 	ret := _m.Called(_a0)
@@ -194,6 +310,122 @@ func (_c *TelegramRepo_GetMessage_Call) RunAndReturn(run func(*client.GetMessage
 	return _c
 }
 
+// SendMessage provides a mock function with given fields: _a0
+func (_m *TelegramRepo) SendMessage(_a0 *client.SendMessageRequest) (*client.Message, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMessage")
+	}
+
+	var r0 *client.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*client.SendMessageRequest) (*client.Message, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*client.SendMessageRequest) *client.Message); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*client.SendMessageRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TelegramRepo_SendMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMessage'
+type TelegramRepo_SendMessage_Call struct {
+	*mock.Call
+}
+
+// SendMessage is a helper method to define mock.On call
+//   - _a0 *client.SendMessageRequest
+func (_e *TelegramRepo_Expecter) SendMessage(_a0 interface{}) *TelegramRepo_SendMessage_Call {
+	return &TelegramRepo_SendMessage_Call{Call: _e.mock.On("SendMessage", _a0)}
+}
+
+func (_c *TelegramRepo_SendMessage_Call) Run(run func(_a0 *client.SendMessageRequest)) *TelegramRepo_SendMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.SendMessageRequest))
+	})
+	return _c
+}
+
+func (_c *TelegramRepo_SendMessage_Call) Return(_a0 *client.Message, _a1 error) *TelegramRepo_SendMessage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TelegramRepo_SendMessage_Call) RunAndReturn(run func(*client.SendMessageRequest) (*client.Message, error)) *TelegramRepo_SendMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StopPoll provides a mock function with given fields: _a0
+func (_m *TelegramRepo) StopPoll(_a0 *client.StopPollRequest) (*client.Ok, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopPoll")
+	}
+
+	var r0 *client.Ok
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*client.StopPollRequest) (*client.Ok, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*client.StopPollRequest) *client.Ok); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Ok)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*client.StopPollRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TelegramRepo_StopPoll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopPoll'
+type TelegramRepo_StopPoll_Call struct {
+	*mock.Call
+}
+
+// StopPoll is a helper method to define mock.On call
+//   - _a0 *client.StopPollRequest
+func (_e *TelegramRepo_Expecter) StopPoll(_a0 interface{}) *TelegramRepo_StopPoll_Call {
+	return &TelegramRepo_StopPoll_Call{Call: _e.mock.On("StopPoll", _a0)}
+}
+
+func (_c *TelegramRepo_StopPoll_Call) Run(run func(_a0 *client.StopPollRequest)) *TelegramRepo_StopPoll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.StopPollRequest))
+	})
+	return _c
+}
+
+func (_c *TelegramRepo_StopPoll_Call) Return(_a0 *client.Ok, _a1 error) *TelegramRepo_StopPoll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TelegramRepo_StopPoll_Call) RunAndReturn(run func(*client.StopPollRequest) (*client.Ok, error)) *TelegramRepo_StopPoll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewTelegramRepo creates a new instance of TelegramRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTelegramRepo(t interface {
@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	domain "github.com/comerc/budva43/app/domain"
-	client "github.com/zelenin/go-tdlib/client"
+	context "context"
 
+	domain "github.com/comerc/budva43/app/domain"
 	mock "github.com/stretchr/testify/mock"
+	client "github.com/zelenin/go-tdlib/client"
 )
 
 // TransformService is an autogenerated mock type for the transformService type
@@ -22,9 +23,94 @@ func (_m *TransformService) EXPECT() *TransformService_Expecter {
 	return &TransformService_Expecter{mock: &_m.Mock}
 }
 
-// Transform provides a mock function with given fields: formattedText, withSources, src, dstChatId, prevMessageId, engineConfig
-func (_m *TransformService) Transform(formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId int64, prevMessageId int64, engineConfig *domain.EngineConfig) {
-	_m.Called(formattedText, withSources, src, dstChatId, prevMessageId, engineConfig)
+// AddProtectedContentNote provides a mock function with given fields: formattedText, forwardRule
+func (_m *TransformService) AddProtectedContentNote(formattedText *client.FormattedText, forwardRule *domain.ForwardRule) {
+	_m.Called(formattedText, forwardRule)
+}
+
+// TransformService_AddProtectedContentNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddProtectedContentNote'
+type TransformService_AddProtectedContentNote_Call struct {
+	*mock.Call
+}
+
+// AddProtectedContentNote is a helper method to define mock.On call
+//   - formattedText *client.FormattedText
+//   - forwardRule *domain.ForwardRule
+func (_e *TransformService_Expecter) AddProtectedContentNote(formattedText interface{}, forwardRule interface{}) *TransformService_AddProtectedContentNote_Call {
+	return &TransformService_AddProtectedContentNote_Call{Call: _e.mock.On("AddProtectedContentNote", formattedText, forwardRule)}
+}
+
+func (_c *TransformService_AddProtectedContentNote_Call) Run(run func(formattedText *client.FormattedText, forwardRule *domain.ForwardRule)) *TransformService_AddProtectedContentNote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.FormattedText), args[1].(*domain.ForwardRule))
+	})
+	return _c
+}
+
+func (_c *TransformService_AddProtectedContentNote_Call) Return() *TransformService_AddProtectedContentNote_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *TransformService_AddProtectedContentNote_Call) RunAndReturn(run func(*client.FormattedText, *domain.ForwardRule)) *TransformService_AddProtectedContentNote_Call {
+	_c.Run(run)
+	return _c
+}
+
+// FormatEditDiff provides a mock function with given fields: oldText, newText, dstChatId, engineConfig
+func (_m *TransformService) FormatEditDiff(oldText string, newText string, dstChatId int64, engineConfig *domain.EngineConfig) *client.FormattedText {
+	ret := _m.Called(oldText, newText, dstChatId, engineConfig)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FormatEditDiff")
+	}
+
+	var r0 *client.FormattedText
+	if rf, ok := ret.Get(0).(func(string, string, int64, *domain.EngineConfig) *client.FormattedText); ok {
+		r0 = rf(oldText, newText, dstChatId, engineConfig)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.FormattedText)
+		}
+	}
+
+	return r0
+}
+
+// TransformService_FormatEditDiff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FormatEditDiff'
+type TransformService_FormatEditDiff_Call struct {
+	*mock.Call
+}
+
+// FormatEditDiff is a helper method to define mock.On call
+//   - oldText string
+//   - newText string
+//   - dstChatId int64
+//   - engineConfig *domain.EngineConfig
+func (_e *TransformService_Expecter) FormatEditDiff(oldText interface{}, newText interface{}, dstChatId interface{}, engineConfig interface{}) *TransformService_FormatEditDiff_Call {
+	return &TransformService_FormatEditDiff_Call{Call: _e.mock.On("FormatEditDiff", oldText, newText, dstChatId, engineConfig)}
+}
+
+func (_c *TransformService_FormatEditDiff_Call) Run(run func(oldText string, newText string, dstChatId int64, engineConfig *domain.EngineConfig)) *TransformService_FormatEditDiff_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(int64), args[3].(*domain.EngineConfig))
+	})
+	return _c
+}
+
+func (_c *TransformService_FormatEditDiff_Call) Return(_a0 *client.FormattedText) *TransformService_FormatEditDiff_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TransformService_FormatEditDiff_Call) RunAndReturn(run func(string, string, int64, *domain.EngineConfig) *client.FormattedText) *TransformService_FormatEditDiff_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Transform provides a mock function with given fields: ctx, formattedText, withSources, src, dstChatId, prevMessageId, engineConfig
+func (_m *TransformService) Transform(ctx context.Context, formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId int64, prevMessageId int64, engineConfig *domain.EngineConfig) {
+	_m.Called(ctx, formattedText, withSources, src, dstChatId, prevMessageId, engineConfig)
 }
 
 // TransformService_Transform_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Transform'
@@ -33,19 +119,20 @@ type TransformService_Transform_Call struct {
 }
 
 // Transform is a helper method to define mock.On call
+//   - ctx context.Context
 //   - formattedText *client.FormattedText
 //   - withSources bool
 //   - src *client.Message
 //   - dstChatId int64
 //   - prevMessageId int64
 //   - engineConfig *domain.EngineConfig
-func (_e *TransformService_Expecter) Transform(formattedText interface{}, withSources interface{}, src interface{}, dstChatId interface{}, prevMessageId interface{}, engineConfig interface{}) *TransformService_Transform_Call {
-	return &TransformService_Transform_Call{Call: _e.mock.On("Transform", formattedText, withSources, src, dstChatId, prevMessageId, engineConfig)}
+func (_e *TransformService_Expecter) Transform(ctx interface{}, formattedText interface{}, withSources interface{}, src interface{}, dstChatId interface{}, prevMessageId interface{}, engineConfig interface{}) *TransformService_Transform_Call {
+	return &TransformService_Transform_Call{Call: _e.mock.On("Transform", ctx, formattedText, withSources, src, dstChatId, prevMessageId, engineConfig)}
 }
 
-func (_c *TransformService_Transform_Call) Run(run func(formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId int64, prevMessageId int64, engineConfig *domain.EngineConfig)) *TransformService_Transform_Call {
+func (_c *TransformService_Transform_Call) Run(run func(ctx context.Context, formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId int64, prevMessageId int64, engineConfig *domain.EngineConfig)) *TransformService_Transform_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*client.FormattedText), args[1].(bool), args[2].(*client.Message), args[3].(int64), args[4].(int64), args[5].(*domain.EngineConfig))
+		run(args[0].(context.Context), args[1].(*client.FormattedText), args[2].(bool), args[3].(*client.Message), args[4].(int64), args[5].(int64), args[6].(*domain.EngineConfig))
 	})
 	return _c
 }
@@ -55,7 +142,7 @@ func (_c *TransformService_Transform_Call) Return() *TransformService_Transform_
 	return _c
 }
 
-func (_c *TransformService_Transform_Call) RunAndReturn(run func(*client.FormattedText, bool, *client.Message, int64, int64, *domain.EngineConfig)) *TransformService_Transform_Call {
+func (_c *TransformService_Transform_Call) RunAndReturn(run func(context.Context, *client.FormattedText, bool, *client.Message, int64, int64, *domain.EngineConfig)) *TransformService_Transform_Call {
 	_c.Run(run)
 	return _c
 }
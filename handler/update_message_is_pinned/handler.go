@@ -0,0 +1,140 @@
+package update_message_is_pinned
+
+import (
+	"context"
+
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/log"
+)
+
+//go:generate mockery --name=telegramRepo --exported
+type telegramRepo interface {
+	// tdlibClient methods
+	GetMessageProperties(*client.GetMessagePropertiesRequest) (*client.MessageProperties, error)
+	PinChatMessage(*client.PinChatMessageRequest) (*client.Ok, error)
+	UnpinChatMessage(*client.UnpinChatMessageRequest) (*client.Ok, error)
+}
+
+//go:generate mockery --name=queueRepo --exported
+type queueRepo interface {
+	Add(ctx context.Context, fn func(ctx context.Context))
+}
+
+//go:generate mockery --name=storageService --exported
+type storageService interface {
+	GetCopiedMessageIds(chatId, messageId int64) []*domain.ChatMessage
+	GetNewMessageId(chatId, tmpMessageId int64) int64
+}
+
+type Handler struct {
+	log *log.Logger
+	//
+	telegramRepo   telegramRepo
+	queueRepo      queueRepo
+	storageService storageService
+}
+
+func New(
+	telegramRepo telegramRepo,
+	queueRepo queueRepo,
+	storageService storageService,
+) *Handler {
+	return &Handler{
+		log: log.NewLogger(),
+		//
+		telegramRepo:   telegramRepo,
+		queueRepo:      queueRepo,
+		storageService: storageService,
+	}
+}
+
+// Run выполняет обрабатку обновления о закреплении или откреплении сообщения
+func (h *Handler) Run(ctx context.Context, update *client.UpdateMessageIsPinned) {
+	engineConfig := config.Engine // копируем, см. WATCH-CONFIG.md
+
+	source, ok := engineConfig.Sources[update.ChatId]
+	if !ok || !source.SyncPins {
+		return
+	}
+
+	fn := func(ctx context.Context) {
+		h.syncPins(update.ChatId, update.MessageId, update.IsPinned, source, engineConfig)
+	}
+	h.queueRepo.Add(ctx, fn)
+}
+
+// syncPins закрепляет или открепляет копии сообщения во всех получателях
+func (h *Handler) syncPins(chatId, messageId int64, isPinned bool, source *domain.Source, engineConfig *domain.EngineConfig) {
+	toChatMessages := h.storageService.GetCopiedMessageIds(chatId, messageId)
+	defer func() {
+		h.log.ErrorOrDebug(nil, "",
+			"chatId", chatId,
+			"messageId", messageId,
+			"isPinned", isPinned,
+			"toChatMessages", toChatMessages,
+		)
+	}()
+
+	for _, toChatMessage := range toChatMessages {
+		func() {
+			var (
+				err          error
+				newMessageId int64
+			)
+			defer func() {
+				h.log.ErrorOrInfo(err, "sync pin",
+					"chatId", chatId,
+					"messageId", messageId,
+					"toChatMessage", toChatMessage,
+					"newMessageId", newMessageId,
+					"isPinned", isPinned,
+				)
+			}()
+
+			forwardRuleId := toChatMessage.ForwardRuleId
+			dstChatId := toChatMessage.ChatId
+			tmpMessageId := toChatMessage.MessageId
+
+			if _, ok := engineConfig.ForwardRules[forwardRuleId]; !ok {
+				err = log.NewError("forwardRule not found")
+				return
+			}
+
+			newMessageId = h.storageService.GetNewMessageId(dstChatId, tmpMessageId)
+			if newMessageId == 0 {
+				err = log.NewError("newMessageId not found")
+				return
+			}
+
+			// закрепить и открепить можно только при наличии прав в получателе
+			var messageProperties *client.MessageProperties
+			messageProperties, err = h.telegramRepo.GetMessageProperties(&client.GetMessagePropertiesRequest{
+				ChatId:    dstChatId,
+				MessageId: newMessageId,
+			})
+			if err != nil {
+				return
+			}
+			if !messageProperties.CanBePinned {
+				err = log.NewError("message can't be pinned in dstChatId")
+				return
+			}
+
+			if isPinned {
+				_, err = h.telegramRepo.PinChatMessage(&client.PinChatMessageRequest{
+					ChatId:              dstChatId,
+					MessageId:           newMessageId,
+					DisableNotification: !source.PinNotification,
+				})
+			} else {
+				_, err = h.telegramRepo.UnpinChatMessage(&client.UnpinChatMessageRequest{
+					ChatId:    dstChatId,
+					MessageId: newMessageId,
+				})
+			}
+		}()
+	}
+}
@@ -0,0 +1,70 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// QueueRepo is an autogenerated mock type for the queueRepo type
+type QueueRepo struct {
+	mock.Mock
+}
+
+type QueueRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *QueueRepo) EXPECT() *QueueRepo_Expecter {
+	return &QueueRepo_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function with given fields: ctx, fn
+func (_m *QueueRepo) Add(ctx context.Context, fn func(context.Context)) {
+	_m.Called(ctx, fn)
+}
+
+// QueueRepo_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type QueueRepo_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(context.Context)
+func (_e *QueueRepo_Expecter) Add(ctx interface{}, fn interface{}) *QueueRepo_Add_Call {
+	return &QueueRepo_Add_Call{Call: _e.mock.On("Add", ctx, fn)}
+}
+
+func (_c *QueueRepo_Add_Call) Run(run func(ctx context.Context, fn func(context.Context))) *QueueRepo_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context)))
+	})
+	return _c
+}
+
+func (_c *QueueRepo_Add_Call) Return() *QueueRepo_Add_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *QueueRepo_Add_Call) RunAndReturn(run func(context.Context, func(context.Context))) *QueueRepo_Add_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewQueueRepo creates a new instance of QueueRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewQueueRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *QueueRepo {
+	mock := &QueueRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
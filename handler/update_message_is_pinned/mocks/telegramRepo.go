@@ -0,0 +1,209 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	client "github.com/zelenin/go-tdlib/client"
+)
+
+// TelegramRepo is an autogenerated mock type for the telegramRepo type
+type TelegramRepo struct {
+	mock.Mock
+}
+
+type TelegramRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TelegramRepo) EXPECT() *TelegramRepo_Expecter {
+	return &TelegramRepo_Expecter{mock: &_m.Mock}
+}
+
+// GetMessageProperties provides a mock function with given fields: _a0
+func (_m *TelegramRepo) GetMessageProperties(_a0 *client.GetMessagePropertiesRequest) (*client.MessageProperties, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessageProperties")
+	}
+
+	var r0 *client.MessageProperties
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*client.GetMessagePropertiesRequest) (*client.MessageProperties, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*client.GetMessagePropertiesRequest) *client.MessageProperties); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.MessageProperties)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*client.GetMessagePropertiesRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TelegramRepo_GetMessageProperties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMessageProperties'
+type TelegramRepo_GetMessageProperties_Call struct {
+	*mock.Call
+}
+
+// GetMessageProperties is a helper method to define mock.On call
+//   - _a0 *client.GetMessagePropertiesRequest
+func (_e *TelegramRepo_Expecter) GetMessageProperties(_a0 interface{}) *TelegramRepo_GetMessageProperties_Call {
+	return &TelegramRepo_GetMessageProperties_Call{Call: _e.mock.On("GetMessageProperties", _a0)}
+}
+
+func (_c *TelegramRepo_GetMessageProperties_Call) Run(run func(_a0 *client.GetMessagePropertiesRequest)) *TelegramRepo_GetMessageProperties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.GetMessagePropertiesRequest))
+	})
+	return _c
+}
+
+func (_c *TelegramRepo_GetMessageProperties_Call) Return(_a0 *client.MessageProperties, _a1 error) *TelegramRepo_GetMessageProperties_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TelegramRepo_GetMessageProperties_Call) RunAndReturn(run func(*client.GetMessagePropertiesRequest) (*client.MessageProperties, error)) *TelegramRepo_GetMessageProperties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PinChatMessage provides a mock function with given fields: _a0
+func (_m *TelegramRepo) PinChatMessage(_a0 *client.PinChatMessageRequest) (*client.Ok, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PinChatMessage")
+	}
+
+	var r0 *client.Ok
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*client.PinChatMessageRequest) (*client.Ok, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*client.PinChatMessageRequest) *client.Ok); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Ok)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*client.PinChatMessageRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TelegramRepo_PinChatMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PinChatMessage'
+type TelegramRepo_PinChatMessage_Call struct {
+	*mock.Call
+}
+
+// PinChatMessage is a helper method to define mock.On call
+//   - _a0 *client.PinChatMessageRequest
+func (_e *TelegramRepo_Expecter) PinChatMessage(_a0 interface{}) *TelegramRepo_PinChatMessage_Call {
+	return &TelegramRepo_PinChatMessage_Call{Call: _e.mock.On("PinChatMessage", _a0)}
+}
+
+func (_c *TelegramRepo_PinChatMessage_Call) Run(run func(_a0 *client.PinChatMessageRequest)) *TelegramRepo_PinChatMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.PinChatMessageRequest))
+	})
+	return _c
+}
+
+func (_c *TelegramRepo_PinChatMessage_Call) Return(_a0 *client.Ok, _a1 error) *TelegramRepo_PinChatMessage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TelegramRepo_PinChatMessage_Call) RunAndReturn(run func(*client.PinChatMessageRequest) (*client.Ok, error)) *TelegramRepo_PinChatMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnpinChatMessage provides a mock function with given fields: _a0
+func (_m *TelegramRepo) UnpinChatMessage(_a0 *client.UnpinChatMessageRequest) (*client.Ok, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnpinChatMessage")
+	}
+
+	var r0 *client.Ok
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*client.UnpinChatMessageRequest) (*client.Ok, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*client.UnpinChatMessageRequest) *client.Ok); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Ok)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*client.UnpinChatMessageRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TelegramRepo_UnpinChatMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnpinChatMessage'
+type TelegramRepo_UnpinChatMessage_Call struct {
+	*mock.Call
+}
+
+// UnpinChatMessage is a helper method to define mock.On call
+//   - _a0 *client.UnpinChatMessageRequest
+func (_e *TelegramRepo_Expecter) UnpinChatMessage(_a0 interface{}) *TelegramRepo_UnpinChatMessage_Call {
+	return &TelegramRepo_UnpinChatMessage_Call{Call: _e.mock.On("UnpinChatMessage", _a0)}
+}
+
+func (_c *TelegramRepo_UnpinChatMessage_Call) Run(run func(_a0 *client.UnpinChatMessageRequest)) *TelegramRepo_UnpinChatMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.UnpinChatMessageRequest))
+	})
+	return _c
+}
+
+func (_c *TelegramRepo_UnpinChatMessage_Call) Return(_a0 *client.Ok, _a1 error) *TelegramRepo_UnpinChatMessage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TelegramRepo_UnpinChatMessage_Call) RunAndReturn(run func(*client.UnpinChatMessageRequest) (*client.Ok, error)) *TelegramRepo_UnpinChatMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTelegramRepo creates a new instance of TelegramRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTelegramRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TelegramRepo {
+	mock := &TelegramRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,20 +1,24 @@
 package update_message_send
 
 import (
+	"context"
+
 	"github.com/zelenin/go-tdlib/client"
 
 	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/metrics"
 )
 
 //go:generate mockery --name=queueRepo --exported
 type queueRepo interface {
-	Add(fn func())
+	Add(ctx context.Context, fn func(ctx context.Context))
 }
 
 //go:generate mockery --name=storageService --exported
 type storageService interface {
 	SetNewMessageId(chatId, tmpMessageId, newMessageId int64)
 	SetTmpMessageId(chatId, newMessageId, tmpMessageId int64)
+	MoveOriginMessageId(dstChatId, tmpMessageId, newMessageId int64)
 }
 
 type Handler struct {
@@ -39,13 +43,15 @@ func New(
 // TODO: можно сделать адаптеры для UpdateMessageSendFailed и UpdateMessageSendAcknowledged
 
 // Run выполняет обрабатку обновления об успешной отправке сообщения
-func (h *Handler) Run(update *client.UpdateMessageSendSucceeded) {
+func (h *Handler) Run(ctx context.Context, update *client.UpdateMessageSendSucceeded) {
 	message := update.Message
 	tmpMessageId := update.OldMessageId
 
-	fn := func() {
+	metrics.FinishSend(message.ChatId, tmpMessageId)
+
+	fn := func(ctx context.Context) {
 		defer func() {
-			h.log.ErrorOrDebug(nil, "",
+			h.log.ErrorOrDebugContext(ctx, nil, "",
 				"chatId", message.ChatId,
 				"messageId", message.Id,
 				"tmpMessageId", tmpMessageId,
@@ -54,7 +60,8 @@ func (h *Handler) Run(update *client.UpdateMessageSendSucceeded) {
 
 		h.storageService.SetNewMessageId(message.ChatId, tmpMessageId, message.Id)
 		h.storageService.SetTmpMessageId(message.ChatId, message.Id, tmpMessageId)
+		h.storageService.MoveOriginMessageId(message.ChatId, tmpMessageId, message.Id)
 	}
 
-	h.queueRepo.Add(fn)
+	h.queueRepo.Add(ctx, fn)
 }
@@ -17,6 +17,41 @@ func (_m *StorageService) EXPECT() *StorageService_Expecter {
 	return &StorageService_Expecter{mock: &_m.Mock}
 }
 
+// MoveOriginMessageId provides a mock function with given fields: dstChatId, tmpMessageId, newMessageId
+func (_m *StorageService) MoveOriginMessageId(dstChatId int64, tmpMessageId int64, newMessageId int64) {
+	_m.Called(dstChatId, tmpMessageId, newMessageId)
+}
+
+// StorageService_MoveOriginMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveOriginMessageId'
+type StorageService_MoveOriginMessageId_Call struct {
+	*mock.Call
+}
+
+// MoveOriginMessageId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+//   - newMessageId int64
+func (_e *StorageService_Expecter) MoveOriginMessageId(dstChatId interface{}, tmpMessageId interface{}, newMessageId interface{}) *StorageService_MoveOriginMessageId_Call {
+	return &StorageService_MoveOriginMessageId_Call{Call: _e.mock.On("MoveOriginMessageId", dstChatId, tmpMessageId, newMessageId)}
+}
+
+func (_c *StorageService_MoveOriginMessageId_Call) Run(run func(dstChatId int64, tmpMessageId int64, newMessageId int64)) *StorageService_MoveOriginMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_MoveOriginMessageId_Call) Return() *StorageService_MoveOriginMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_MoveOriginMessageId_Call) RunAndReturn(run func(int64, int64, int64)) *StorageService_MoveOriginMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
 // SetNewMessageId provides a mock function with given fields: chatId, tmpMessageId, newMessageId
 func (_m *StorageService) SetNewMessageId(chatId int64, tmpMessageId int64, newMessageId int64) {
 	_m.Called(chatId, tmpMessageId, newMessageId)
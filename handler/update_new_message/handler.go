@@ -2,6 +2,7 @@ package update_new_message
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/zelenin/go-tdlib/client"
@@ -9,6 +10,7 @@ import (
 	"github.com/comerc/budva43/app/config"
 	"github.com/comerc/budva43/app/domain"
 	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/metrics"
 	"github.com/comerc/budva43/app/util"
 )
 
@@ -20,13 +22,18 @@ type telegramRepo interface {
 
 //go:generate mockery --name=queueRepo --exported
 type queueRepo interface {
-	Add(fn func())
+	Add(ctx context.Context, fn func(ctx context.Context))
 }
 
 //go:generate mockery --name=storageService --exported
 type storageService interface {
 	IncrementViewedMessages(toChatId int64, date string)
 	IncrementForwardedMessages(toChatId int64, date string)
+	IncrementProtectedMessages(toChatId int64, fallback string, date string)
+	IncrementStats(forwardRuleId string, srcChatId, dstChatId int64, outcome domain.StatsOutcome)
+	AddJournalRule(chatId, messageId int64, journalRule *domain.JournalRule)
+	AddJournalDestination(chatId, messageId int64, forwardRuleId string, destination *domain.JournalDestination)
+	GetOriginMessageId(dstChatId, dstMessageId int64) *domain.ChatMessage
 }
 
 //go:generate mockery --name=messageService --exported
@@ -45,7 +52,7 @@ type mediaAlbumService interface {
 
 //go:generate mockery --name=filtersModeService --exported
 type filtersModeService interface {
-	Map(formattedText *client.FormattedText, rule *domain.ForwardRule) domain.FiltersMode
+	Match(ctx context.Context, formattedText *client.FormattedText, rule *domain.ForwardRule) (domain.FiltersMode, string)
 }
 
 //go:generate mockery --name=forwardedToService --exported
@@ -56,7 +63,13 @@ type forwardedToService interface {
 
 //go:generate mockery --name=forwarderService --exported
 type forwarderService interface {
-	ForwardMessages(messages []*client.Message, filtersMode domain.FiltersMode, srcChatId, dstChatId, prevMessageId int64, isSendCopy bool, forwardRuleId string, engineConfig *domain.EngineConfig)
+	ForwardMessages(ctx context.Context, messages []*client.Message, filtersMode domain.FiltersMode, srcChatId, dstChatId, prevMessageId int64, isSendCopy bool, forwardRuleId string, engineConfig *domain.EngineConfig)
+}
+
+//go:generate mockery --name=bridgeService --exported
+type bridgeService interface {
+	Relay(message *client.Message, bridge *domain.Bridge)
+	IsRelayed(message *client.Message) bool
 }
 
 type Handler struct {
@@ -70,6 +83,7 @@ type Handler struct {
 	filtersModeService filtersModeService
 	forwardedToService forwardedToService
 	forwarderService   forwarderService
+	bridgeService      bridgeService
 }
 
 func New(
@@ -81,6 +95,7 @@ func New(
 	filtersModeService filtersModeService,
 	forwardedToService forwardedToService,
 	forwarderService forwarderService,
+	bridgeService bridgeService,
 ) *Handler {
 	return &Handler{
 		log: log.NewLogger(),
@@ -93,6 +108,7 @@ func New(
 		filtersModeService: filtersModeService,
 		forwardedToService: forwardedToService,
 		forwarderService:   forwarderService,
+		bridgeService:      bridgeService,
 	}
 }
 
@@ -100,7 +116,7 @@ func New(
 func (h *Handler) Run(ctx context.Context, update *client.UpdateNewMessage) {
 	src := update.Message
 	defer func() {
-		h.log.ErrorOrDebug(nil, "",
+		h.log.ErrorOrDebugContext(ctx, nil, "",
 			"chatId", src.ChatId,
 			"messageId", src.Id,
 		)
@@ -108,14 +124,27 @@ func (h *Handler) Run(ctx context.Context, update *client.UpdateNewMessage) {
 
 	engineConfig := config.Engine // копируем, см. WATCH-CONFIG.md
 
+	// защита от петли: ответ, отправленный мостом в источник, не пересылается обратно
+	if h.bridgeService.IsRelayed(src) {
+		return
+	}
+	h.relayBridges(ctx, src, engineConfig)
+
 	if _, ok := engineConfig.UniqueSources[src.ChatId]; !ok {
+		// закрепление копии (см. Source.SyncPins) порождает системное сообщение в получателе
+		if _, ok := src.Content.(*client.MessagePinMessage); ok {
+			fn := func(ctx context.Context) {
+				h.deletePinMessage(src, engineConfig)
+			}
+			h.queueRepo.Add(ctx, fn)
+		}
 		return
 	}
 	if h.messageService.IsSystemMessage(src) {
-		fn := func() {
+		fn := func(ctx context.Context) {
 			h.deleteSystemMessage(src, engineConfig)
 		}
-		h.queueRepo.Add(fn)
+		h.queueRepo.Add(ctx, fn)
 		return
 	}
 	formattedText := h.messageService.GetFormattedText(src)
@@ -124,42 +153,52 @@ func (h *Handler) Run(ctx context.Context, update *client.UpdateNewMessage) {
 	}
 	isExist := false
 	forwardedTo := make(map[int64]bool)
-	checkFns := make(map[int64]func())
-	otherFns := make(map[int64]func())
+	checkFns := make(map[int64]func(ctx context.Context))
+	otherFns := make(map[int64]func(ctx context.Context))
 	for _, forwardRuleId := range engineConfig.OrderedForwardRules {
 		forwardRule := engineConfig.ForwardRules[forwardRuleId]
 		if src.ChatId != forwardRule.From {
 			continue
 		}
+		if len(forwardRule.FromThreads) > 0 && !slices.Contains(forwardRule.FromThreads, src.MessageThreadId) {
+			h.addJournalVerdict(src, forwardRule, domain.JournalOtherThread)
+			continue // сообщение не из отслеживаемой темы форума
+		}
 		if !forwardRule.SendCopy && !src.CanBeSaved {
-			continue
+			fallback := h.decideFallback(src, forwardRule)
+			if fallback == domain.FallbackSkip {
+				h.addJournalVerdict(src, forwardRule, domain.JournalProtected)
+				h.addProtectedStatistics(src, forwardRule)
+				continue
+			}
 		}
 		isExist = true // как минимум, собираем статистику просмотренных сообщений
 		h.forwardedToService.Init(forwardedTo, forwardRule.To)
 		if src.MediaAlbumId == 0 {
-			fn := func() {
-				h.processMessage([]*client.Message{src}, forwardRule, forwardedTo, checkFns, otherFns, engineConfig)
+			fn := func(ctx context.Context) {
+				h.processMessage(ctx, []*client.Message{src}, forwardRule, forwardedTo, checkFns, otherFns, engineConfig)
 			}
-			h.queueRepo.Add(fn)
+			h.queueRepo.Add(ctx, fn)
 		} else {
 			key := h.mediaAlbumsService.GetKey(forwardRule.Id, src.MediaAlbumId)
 			isFirstMessage := h.mediaAlbumsService.AddMessage(key, src)
 			if !isFirstMessage {
 				continue
 			}
-			cb := func(messages []*client.Message) {
-				h.processMessage(messages, forwardRule, forwardedTo, checkFns, otherFns, engineConfig)
+			cb := func(ctx context.Context, messages []*client.Message) {
+				h.processMessage(ctx, messages, forwardRule, forwardedTo, checkFns, otherFns, engineConfig)
 			}
-			fn := func() {
-				h.processMediaAlbum(ctx, key, cb)
+			wait := getMediaAlbumWait(src.ChatId, engineConfig)
+			fn := func(ctx context.Context) {
+				h.processMediaAlbum(ctx, key, wait, cb)
 			}
-			h.queueRepo.Add(fn)
+			h.queueRepo.Add(ctx, fn)
 		}
 	}
 	if !isExist {
 		return
 	}
-	fn := func() {
+	fn := func(ctx context.Context) {
 		h.addStatistics(forwardedTo)
 		for check, fn := range checkFns {
 			h.log.ErrorOrDebug(nil, "",
@@ -169,7 +208,7 @@ func (h *Handler) Run(ctx context.Context, update *client.UpdateNewMessage) {
 			if fn == nil {
 				continue
 			}
-			fn()
+			fn(ctx)
 		}
 		for other, fn := range otherFns {
 			h.log.ErrorOrDebug(nil, "",
@@ -179,10 +218,31 @@ func (h *Handler) Run(ctx context.Context, update *client.UpdateNewMessage) {
 			if fn == nil {
 				continue
 			}
-			fn()
+			fn(ctx)
+		}
+	}
+	h.queueRepo.Add(ctx, fn)
+}
+
+// relayBridges ставит в очередь отправку ответа из получателя-зеркала в источник;
+// сообщения, отправленные самим движком (в том числе копии), через мост не возвращаются
+func (h *Handler) relayBridges(ctx context.Context, src *client.Message, engineConfig *domain.EngineConfig) {
+	if src.SendingState != nil {
+		return
+	}
+	replyTo, ok := src.ReplyTo.(*client.MessageReplyToMessage)
+	if !ok || replyTo.ChatId != src.ChatId {
+		return // мост возвращает только ответы в том же чате
+	}
+	for _, bridge := range engineConfig.Bridges {
+		if bridge.From != src.ChatId {
+			continue
+		}
+		fn := func(ctx context.Context) {
+			h.bridgeService.Relay(src, bridge)
 		}
+		h.queueRepo.Add(ctx, fn)
 	}
-	h.queueRepo.Add(fn)
 }
 
 // deleteSystemMessage удаляет системное сообщение
@@ -209,23 +269,97 @@ func (h *Handler) deleteSystemMessage(src *client.Message, engineConfig *domain.
 	})
 }
 
+// deletePinMessage удаляет системное сообщение о закреплении копии в получателе,
+// если источник копии удаляет свои системные сообщения
+func (h *Handler) deletePinMessage(src *client.Message, engineConfig *domain.EngineConfig) {
+	var (
+		err    error
+		origin *domain.ChatMessage
+	)
+	defer func() {
+		h.log.ErrorOrDebug(err, "",
+			"chatId", src.ChatId,
+			"messageId", src.Id,
+			"origin", origin,
+		)
+	}()
+
+	content, ok := src.Content.(*client.MessagePinMessage)
+	if !ok {
+		return
+	}
+	origin = h.storageService.GetOriginMessageId(src.ChatId, content.MessageId)
+	if origin == nil {
+		return // закреплена не копия
+	}
+	source, ok := engineConfig.Sources[origin.ChatId]
+	if !ok || !source.SyncPins || !source.DeleteSystemMessages {
+		return
+	}
+	_, err = h.telegramRepo.DeleteMessages(&client.DeleteMessagesRequest{
+		ChatId:     src.ChatId,
+		MessageIds: []int64{src.Id},
+		Revoke:     true,
+	})
+}
+
+// decideFallback выбирает политику для защищённого содержимого
+func (h *Handler) decideFallback(src *client.Message, forwardRule *domain.ForwardRule) domain.FallbackPolicy {
+	fallback := forwardRule.Fallback
+	if fallback == "" {
+		fallback = domain.FallbackSkip
+	}
+	h.log.ErrorOrInfo(nil, "protected content",
+		"chatId", src.ChatId,
+		"messageId", src.Id,
+		"forwardRuleId", forwardRule.Id,
+		"fallback", fallback,
+	)
+	return fallback
+}
+
+// addProtectedStatistics учитывает получателей правила, до которых защищённое содержимое
+// не дошло по политике Fallback (skip)
+func (h *Handler) addProtectedStatistics(src *client.Message, forwardRule *domain.ForwardRule) {
+	date := util.GetCurrentDate()
+	for _, dstChatId := range forwardRule.To {
+		h.storageService.IncrementProtectedMessages(dstChatId, domain.FallbackSkip, date)
+		h.storageService.IncrementStats(forwardRule.Id, src.ChatId, dstChatId, domain.StatsProtected)
+		metrics.AddForward(forwardRule.Id, dstChatId, domain.StatsProtected)
+	}
+}
+
+// addFallbackStatistics учитывает политику для защищённого содержимого, прошедшего фильтры
+func (h *Handler) addFallbackStatistics(forwardRule *domain.ForwardRule) {
+	date := util.GetCurrentDate()
+	if forwardRule.Fallback == domain.FallbackCheck {
+		h.storageService.IncrementProtectedMessages(forwardRule.Check, forwardRule.Fallback, date)
+		return
+	}
+	for _, dstChatId := range forwardRule.To {
+		h.storageService.IncrementProtectedMessages(dstChatId, forwardRule.Fallback, date)
+	}
+}
+
 // processMessage обрабатывает сообщения и выполняет пересылку согласно правилам
-func (h *Handler) processMessage(messages []*client.Message,
+func (h *Handler) processMessage(ctx context.Context, messages []*client.Message,
 	forwardRule *domain.ForwardRule, forwardedTo map[int64]bool,
-	checkFns map[int64]func(), otherFns map[int64]func(),
+	checkFns map[int64]func(ctx context.Context), otherFns map[int64]func(ctx context.Context),
 	engineConfig *domain.EngineConfig) {
 	var (
 		err         error
 		filtersMode string
+		pattern     string
 		result      []int64
 	)
 	src := messages[0]
 	defer func() {
-		h.log.ErrorOrDebug(err, "",
+		h.log.ErrorOrDebugContext(ctx, err, "",
 			"chatId", src.ChatId,
 			"messageId", src.Id,
 			"mediaAlbumId", src.MediaAlbumId,
 			"filtersMode", filtersMode,
+			"pattern", pattern,
 			"result", result,
 		)
 	}()
@@ -236,39 +370,68 @@ func (h *Handler) processMessage(messages []*client.Message,
 		return
 	}
 
-	filtersMode = h.filtersModeService.Map(formattedText, forwardRule)
+	isSendCopy := forwardRule.SendCopy
+	filtersMode, pattern = h.filtersModeService.Match(ctx, formattedText, forwardRule)
+	if !forwardRule.SendCopy && !src.CanBeSaved {
+		// защищённое содержимое невозможно форвардить, см. decideFallback
+		isSendCopy = true
+		// решение фильтров главнее: политика применяется только к прошедшему фильтры сообщению
+		if filtersMode == domain.FiltersOK {
+			if forwardRule.Fallback == domain.FallbackCheck {
+				filtersMode = domain.FiltersCheck
+				pattern = "fallback: " + domain.FallbackCheck
+			}
+			h.addFallbackStatistics(forwardRule)
+		}
+	}
+	h.storageService.AddJournalRule(src.ChatId, src.Id, &domain.JournalRule{
+		ForwardRuleId: forwardRule.Id,
+		Verdict:       domain.JournalEvaluated,
+		FiltersMode:   filtersMode,
+		Pattern:       pattern,
+	})
 	switch filtersMode {
 	case domain.FiltersOK:
 		// checkFns[rule.Check] = nil // !! не надо сбрасывать - хочу проверить сообщение, даже если где-то прошли фильтры
 		otherFns[forwardRule.Other] = nil
 		for _, dstChatId := range forwardRule.To {
 			if h.forwardedToService.Add(forwardedTo, dstChatId) {
-				h.forwarderService.ForwardMessages(
+				h.forwarderService.ForwardMessages(ctx,
 					messages,
 					filtersMode,
 					src.ChatId,
 					dstChatId,
 					0, // prevMessageId
-					forwardRule.SendCopy,
+					isSendCopy,
 					forwardRule.Id,
 					engineConfig,
 				)
 				result = append(result, dstChatId)
+			} else {
+				h.storageService.IncrementStats(forwardRule.Id, src.ChatId, dstChatId, domain.StatsDeduped)
+				metrics.AddForward(forwardRule.Id, dstChatId, domain.StatsDeduped)
+				h.storageService.AddJournalDestination(src.ChatId, src.Id, forwardRule.Id, &domain.JournalDestination{
+					ChatId:  dstChatId,
+					Outcome: domain.StatsDeduped,
+				})
 			}
 		}
 	case domain.FiltersCheck:
+		h.addFilteredStatistics(src, forwardRule)
 		if forwardRule.Check != 0 {
 			_, ok := checkFns[forwardRule.Check]
 			if !ok {
-				checkFns[forwardRule.Check] = func() {
-					const isSendCopy = false // обязательно надо форвардить, иначе не видно текущего сообщения
-					h.forwarderService.ForwardMessages(
+				checkFns[forwardRule.Check] = func(ctx context.Context) {
+					// обязательно надо форвардить, иначе не видно текущего сообщения;
+					// кроме защищённого содержимого, которое невозможно форвардить
+					isCheckCopy := !src.CanBeSaved
+					h.forwarderService.ForwardMessages(ctx,
 						messages,
 						filtersMode,
 						src.ChatId,
 						forwardRule.Check,
 						0, // prevMessageId
-						isSendCopy,
+						isCheckCopy,
 						forwardRule.Id,
 						engineConfig,
 					)
@@ -276,12 +439,13 @@ func (h *Handler) processMessage(messages []*client.Message,
 			}
 		}
 	case domain.FiltersOther:
+		h.addFilteredStatistics(src, forwardRule)
 		if forwardRule.Other != 0 {
 			_, ok := otherFns[forwardRule.Other]
 			if !ok {
-				otherFns[forwardRule.Other] = func() {
+				otherFns[forwardRule.Other] = func(ctx context.Context) {
 					const isSendCopy = true // обязательно надо копировать, иначе не видно редактирование исходного сообщения
-					h.forwarderService.ForwardMessages(
+					h.forwarderService.ForwardMessages(ctx,
 						messages,
 						filtersMode,
 						src.ChatId,
@@ -299,23 +463,32 @@ func (h *Handler) processMessage(messages []*client.Message,
 
 const waitForMediaAlbum = 3 * time.Second
 
+// getMediaAlbumWait возвращает время ожидания следующей части медиа-альбома для источника
+func getMediaAlbumWait(srcChatId int64, engineConfig *domain.EngineConfig) time.Duration {
+	source := engineConfig.Sources[srcChatId]
+	if source == nil || source.MediaAlbumWait == 0 {
+		return waitForMediaAlbum
+	}
+	return source.MediaAlbumWait
+}
+
 // processMediaAlbum обрабатывает медиа-альбом
-func (h *Handler) processMediaAlbum(ctx context.Context, key domain.MediaAlbumKey, cb func([]*client.Message)) {
+func (h *Handler) processMediaAlbum(ctx context.Context, key domain.MediaAlbumKey, wait time.Duration, cb func(context.Context, []*client.Message)) {
 	diff := h.mediaAlbumsService.GetLastReceivedDiff(key)
-	if diff < waitForMediaAlbum {
-		timer := time.NewTimer(waitForMediaAlbum - diff)
+	if diff < wait {
+		timer := time.NewTimer(wait - diff)
 		defer timer.Stop()
 
 		select {
 		case <-ctx.Done():
 			return
 		case <-timer.C:
-			h.processMediaAlbum(ctx, key, cb)
+			h.processMediaAlbum(ctx, key, wait, cb)
 		}
 		return
 	}
 	messages := h.mediaAlbumsService.PopMessages(key)
-	cb(messages)
+	cb(ctx, messages)
 }
 
 // addStatistics добавляет статистику пересылаемых и просмотренных сообщений
@@ -328,3 +501,24 @@ func (h *Handler) addStatistics(forwardedTo map[int64]bool) {
 		h.storageService.IncrementViewedMessages(dstChatId, date)
 	}
 }
+
+// addFilteredStatistics учитывает получателей правила, до которых сообщение не дошло из-за фильтров;
+// доставку в Check и Other учитывает forwarderService
+func (h *Handler) addFilteredStatistics(src *client.Message, forwardRule *domain.ForwardRule) {
+	for _, dstChatId := range forwardRule.To {
+		h.storageService.IncrementStats(forwardRule.Id, src.ChatId, dstChatId, domain.StatsFiltered)
+		metrics.AddForward(forwardRule.Id, dstChatId, domain.StatsFiltered)
+		h.storageService.AddJournalDestination(src.ChatId, src.Id, forwardRule.Id, &domain.JournalDestination{
+			ChatId:  dstChatId,
+			Outcome: domain.StatsFiltered,
+		})
+	}
+}
+
+// addJournalVerdict записывает в журнал решение правила, принятое до проверки фильтров
+func (h *Handler) addJournalVerdict(src *client.Message, forwardRule *domain.ForwardRule, verdict domain.JournalVerdict) {
+	h.storageService.AddJournalRule(src.ChatId, src.Id, &domain.JournalRule{
+		ForwardRuleId: forwardRule.Id,
+		Verdict:       verdict,
+	})
+}
@@ -0,0 +1,65 @@
+package update_new_message
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/handler/update_new_message/mocks"
+)
+
+func TestRunWithProtectedContentSkip(t *testing.T) {
+	// t.Parallel() // !! нельзя параллелить, тестирую с подменой глобальных переменных
+
+	const (
+		srcChatId = int64(-1001)
+		dstChatId = int64(-1002)
+	)
+
+	engineConfig := config.Engine
+	t.Cleanup(func() {
+		config.Engine = engineConfig
+	})
+	forwardRule := &domain.ForwardRule{
+		Id:   "Rule1",
+		From: srcChatId,
+		To:   []domain.ChatId{dstChatId},
+	}
+	config.Engine = &domain.EngineConfig{
+		ForwardRules:        map[domain.ForwardRuleId]*domain.ForwardRule{forwardRule.Id: forwardRule},
+		UniqueSources:       map[domain.ChatId]struct{}{srcChatId: {}},
+		OrderedForwardRules: []domain.ForwardRuleId{forwardRule.Id},
+	}
+
+	src := &client.Message{
+		Id:         10,
+		ChatId:     srcChatId,
+		CanBeSaved: false,
+		Content: &client.MessageText{
+			Text: &client.FormattedText{Text: "protected"},
+		},
+	}
+
+	storageService := mocks.NewStorageService(t)
+	messageService := mocks.NewMessageService(t)
+	bridgeService := mocks.NewBridgeService(t)
+
+	bridgeService.EXPECT().IsRelayed(src).Return(false)
+	messageService.EXPECT().IsSystemMessage(src).Return(false)
+	messageService.EXPECT().GetFormattedText(src).Return(&client.FormattedText{Text: "protected"})
+	storageService.EXPECT().AddJournalRule(srcChatId, src.Id, &domain.JournalRule{
+		ForwardRuleId: forwardRule.Id,
+		Verdict:       domain.JournalProtected,
+	}).Once()
+	// пропуск по политике skip (по умолчанию) учитывается в статистике
+	storageService.EXPECT().IncrementProtectedMessages(dstChatId, domain.FallbackSkip, mock.Anything).Once()
+	storageService.EXPECT().IncrementStats(forwardRule.Id, srcChatId, dstChatId, domain.StatsProtected).Once()
+
+	// пересылка не ставится в очередь: queueRepo и forwarderService не вызываются
+	h := New(nil, mocks.NewQueueRepo(t), storageService, messageService, nil, nil, nil, mocks.NewForwarderService(t), bridgeService)
+	h.Run(context.Background(), &client.UpdateNewMessage{Message: src})
+}
@@ -0,0 +1,116 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/comerc/budva43/app/domain"
+	mock "github.com/stretchr/testify/mock"
+	client "github.com/zelenin/go-tdlib/client"
+)
+
+// BridgeService is an autogenerated mock type for the bridgeService type
+type BridgeService struct {
+	mock.Mock
+}
+
+type BridgeService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BridgeService) EXPECT() *BridgeService_Expecter {
+	return &BridgeService_Expecter{mock: &_m.Mock}
+}
+
+// IsRelayed provides a mock function with given fields: message
+func (_m *BridgeService) IsRelayed(message *client.Message) bool {
+	ret := _m.Called(message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsRelayed")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*client.Message) bool); ok {
+		r0 = rf(message)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// BridgeService_IsRelayed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRelayed'
+type BridgeService_IsRelayed_Call struct {
+	*mock.Call
+}
+
+// IsRelayed is a helper method to define mock.On call
+//   - message *client.Message
+func (_e *BridgeService_Expecter) IsRelayed(message interface{}) *BridgeService_IsRelayed_Call {
+	return &BridgeService_IsRelayed_Call{Call: _e.mock.On("IsRelayed", message)}
+}
+
+func (_c *BridgeService_IsRelayed_Call) Run(run func(message *client.Message)) *BridgeService_IsRelayed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.Message))
+	})
+	return _c
+}
+
+func (_c *BridgeService_IsRelayed_Call) Return(_a0 bool) *BridgeService_IsRelayed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BridgeService_IsRelayed_Call) RunAndReturn(run func(*client.Message) bool) *BridgeService_IsRelayed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Relay provides a mock function with given fields: message, bridge
+func (_m *BridgeService) Relay(message *client.Message, bridge *domain.Bridge) {
+	_m.Called(message, bridge)
+}
+
+// BridgeService_Relay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Relay'
+type BridgeService_Relay_Call struct {
+	*mock.Call
+}
+
+// Relay is a helper method to define mock.On call
+//   - message *client.Message
+//   - bridge *domain.Bridge
+func (_e *BridgeService_Expecter) Relay(message interface{}, bridge interface{}) *BridgeService_Relay_Call {
+	return &BridgeService_Relay_Call{Call: _e.mock.On("Relay", message, bridge)}
+}
+
+func (_c *BridgeService_Relay_Call) Run(run func(message *client.Message, bridge *domain.Bridge)) *BridgeService_Relay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.Message), args[1].(*domain.Bridge))
+	})
+	return _c
+}
+
+func (_c *BridgeService_Relay_Call) Return() *BridgeService_Relay_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *BridgeService_Relay_Call) RunAndReturn(run func(*client.Message, *domain.Bridge)) *BridgeService_Relay_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewBridgeService creates a new instance of BridgeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBridgeService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BridgeService {
+	mock := &BridgeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
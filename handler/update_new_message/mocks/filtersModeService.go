@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	domain "github.com/comerc/budva43/app/domain"
-	client "github.com/zelenin/go-tdlib/client"
+	context "context"
 
+	domain "github.com/comerc/budva43/app/domain"
 	mock "github.com/stretchr/testify/mock"
+	client "github.com/zelenin/go-tdlib/client"
 )
 
 // FiltersModeService is an autogenerated mock type for the filtersModeService type
@@ -22,49 +23,60 @@ func (_m *FiltersModeService) EXPECT() *FiltersModeService_Expecter {
 	return &FiltersModeService_Expecter{mock: &_m.Mock}
 }
 
-// Map provides a mock function with given fields: formattedText, rule
-func (_m *FiltersModeService) Map(formattedText *client.FormattedText, rule *domain.ForwardRule) string {
-	ret := _m.Called(formattedText, rule)
+// Match provides a mock function with given fields: ctx, formattedText, rule
+func (_m *FiltersModeService) Match(ctx context.Context, formattedText *client.FormattedText, rule *domain.ForwardRule) (string, string) {
+	ret := _m.Called(ctx, formattedText, rule)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Map")
+		panic("no return value specified for Match")
 	}
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(*client.FormattedText, *domain.ForwardRule) string); ok {
-		r0 = rf(formattedText, rule)
+	var r1 string
+	if rf, ok := ret.Get(0).(func(context.Context, *client.FormattedText, *domain.ForwardRule) (string, string)); ok {
+		return rf(ctx, formattedText, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *client.FormattedText, *domain.ForwardRule) string); ok {
+		r0 = rf(ctx, formattedText, rule)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *client.FormattedText, *domain.ForwardRule) string); ok {
+		r1 = rf(ctx, formattedText, rule)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
 }
 
-// FiltersModeService_Map_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Map'
-type FiltersModeService_Map_Call struct {
+// FiltersModeService_Match_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Match'
+type FiltersModeService_Match_Call struct {
 	*mock.Call
 }
 
-// Map is a helper method to define mock.On call
+// Match is a helper method to define mock.On call
+//   - ctx context.Context
 //   - formattedText *client.FormattedText
 //   - rule *domain.ForwardRule
-func (_e *FiltersModeService_Expecter) Map(formattedText interface{}, rule interface{}) *FiltersModeService_Map_Call {
-	return &FiltersModeService_Map_Call{Call: _e.mock.On("Map", formattedText, rule)}
+func (_e *FiltersModeService_Expecter) Match(ctx interface{}, formattedText interface{}, rule interface{}) *FiltersModeService_Match_Call {
+	return &FiltersModeService_Match_Call{Call: _e.mock.On("Match", ctx, formattedText, rule)}
 }
 
-func (_c *FiltersModeService_Map_Call) Run(run func(formattedText *client.FormattedText, rule *domain.ForwardRule)) *FiltersModeService_Map_Call {
+func (_c *FiltersModeService_Match_Call) Run(run func(ctx context.Context, formattedText *client.FormattedText, rule *domain.ForwardRule)) *FiltersModeService_Match_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*client.FormattedText), args[1].(*domain.ForwardRule))
+		run(args[0].(context.Context), args[1].(*client.FormattedText), args[2].(*domain.ForwardRule))
 	})
 	return _c
 }
 
-func (_c *FiltersModeService_Map_Call) Return(_a0 string) *FiltersModeService_Map_Call {
-	_c.Call.Return(_a0)
+func (_c *FiltersModeService_Match_Call) Return(_a0 string, _a1 string) *FiltersModeService_Match_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *FiltersModeService_Map_Call) RunAndReturn(run func(*client.FormattedText, *domain.ForwardRule) string) *FiltersModeService_Map_Call {
+func (_c *FiltersModeService_Match_Call) RunAndReturn(run func(context.Context, *client.FormattedText, *domain.ForwardRule) (string, string)) *FiltersModeService_Match_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,10 +3,11 @@
 package mocks
 
 import (
-	domain "github.com/comerc/budva43/app/domain"
-	client "github.com/zelenin/go-tdlib/client"
+	context "context"
 
+	domain "github.com/comerc/budva43/app/domain"
 	mock "github.com/stretchr/testify/mock"
+	client "github.com/zelenin/go-tdlib/client"
 )
 
 // ForwarderService is an autogenerated mock type for the forwarderService type
@@ -22,9 +23,9 @@ func (_m *ForwarderService) EXPECT() *ForwarderService_Expecter {
 	return &ForwarderService_Expecter{mock: &_m.Mock}
 }
 
-// ForwardMessages provides a mock function with given fields: messages, filtersMode, srcChatId, dstChatId, prevMessageId, isSendCopy, forwardRuleId, engineConfig
-func (_m *ForwarderService) ForwardMessages(messages []*client.Message, filtersMode string, srcChatId int64, dstChatId int64, prevMessageId int64, isSendCopy bool, forwardRuleId string, engineConfig *domain.EngineConfig) {
-	_m.Called(messages, filtersMode, srcChatId, dstChatId, prevMessageId, isSendCopy, forwardRuleId, engineConfig)
+// ForwardMessages provides a mock function with given fields: ctx, messages, filtersMode, srcChatId, dstChatId, prevMessageId, isSendCopy, forwardRuleId, engineConfig
+func (_m *ForwarderService) ForwardMessages(ctx context.Context, messages []*client.Message, filtersMode string, srcChatId int64, dstChatId int64, prevMessageId int64, isSendCopy bool, forwardRuleId string, engineConfig *domain.EngineConfig) {
+	_m.Called(ctx, messages, filtersMode, srcChatId, dstChatId, prevMessageId, isSendCopy, forwardRuleId, engineConfig)
 }
 
 // ForwarderService_ForwardMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForwardMessages'
@@ -33,6 +34,7 @@ type ForwarderService_ForwardMessages_Call struct {
 }
 
 // ForwardMessages is a helper method to define mock.On call
+//   - ctx context.Context
 //   - messages []*client.Message
 //   - filtersMode string
 //   - srcChatId int64
@@ -41,13 +43,13 @@ type ForwarderService_ForwardMessages_Call struct {
 //   - isSendCopy bool
 //   - forwardRuleId string
 //   - engineConfig *domain.EngineConfig
-func (_e *ForwarderService_Expecter) ForwardMessages(messages interface{}, filtersMode interface{}, srcChatId interface{}, dstChatId interface{}, prevMessageId interface{}, isSendCopy interface{}, forwardRuleId interface{}, engineConfig interface{}) *ForwarderService_ForwardMessages_Call {
-	return &ForwarderService_ForwardMessages_Call{Call: _e.mock.On("ForwardMessages", messages, filtersMode, srcChatId, dstChatId, prevMessageId, isSendCopy, forwardRuleId, engineConfig)}
+func (_e *ForwarderService_Expecter) ForwardMessages(ctx interface{}, messages interface{}, filtersMode interface{}, srcChatId interface{}, dstChatId interface{}, prevMessageId interface{}, isSendCopy interface{}, forwardRuleId interface{}, engineConfig interface{}) *ForwarderService_ForwardMessages_Call {
+	return &ForwarderService_ForwardMessages_Call{Call: _e.mock.On("ForwardMessages", ctx, messages, filtersMode, srcChatId, dstChatId, prevMessageId, isSendCopy, forwardRuleId, engineConfig)}
 }
 
-func (_c *ForwarderService_ForwardMessages_Call) Run(run func(messages []*client.Message, filtersMode string, srcChatId int64, dstChatId int64, prevMessageId int64, isSendCopy bool, forwardRuleId string, engineConfig *domain.EngineConfig)) *ForwarderService_ForwardMessages_Call {
+func (_c *ForwarderService_ForwardMessages_Call) Run(run func(ctx context.Context, messages []*client.Message, filtersMode string, srcChatId int64, dstChatId int64, prevMessageId int64, isSendCopy bool, forwardRuleId string, engineConfig *domain.EngineConfig)) *ForwarderService_ForwardMessages_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]*client.Message), args[1].(string), args[2].(int64), args[3].(int64), args[4].(int64), args[5].(bool), args[6].(string), args[7].(*domain.EngineConfig))
+		run(args[0].(context.Context), args[1].([]*client.Message), args[2].(string), args[3].(int64), args[4].(int64), args[5].(int64), args[6].(bool), args[7].(string), args[8].(*domain.EngineConfig))
 	})
 	return _c
 }
@@ -57,7 +59,7 @@ func (_c *ForwarderService_ForwardMessages_Call) Return() *ForwarderService_Forw
 	return _c
 }
 
-func (_c *ForwarderService_ForwardMessages_Call) RunAndReturn(run func([]*client.Message, string, int64, int64, int64, bool, string, *domain.EngineConfig)) *ForwarderService_ForwardMessages_Call {
+func (_c *ForwarderService_ForwardMessages_Call) RunAndReturn(run func(context.Context, []*client.Message, string, int64, int64, int64, bool, string, *domain.EngineConfig)) *ForwarderService_ForwardMessages_Call {
 	_c.Run(run)
 	return _c
 }
@@ -2,7 +2,10 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	domain "github.com/comerc/budva43/app/domain"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // StorageService is an autogenerated mock type for the storageService type
 type StorageService struct {
@@ -17,6 +20,126 @@ func (_m *StorageService) EXPECT() *StorageService_Expecter {
 	return &StorageService_Expecter{mock: &_m.Mock}
 }
 
+// AddJournalDestination provides a mock function with given fields: chatId, messageId, forwardRuleId, destination
+func (_m *StorageService) AddJournalDestination(chatId int64, messageId int64, forwardRuleId string, destination *domain.JournalDestination) {
+	_m.Called(chatId, messageId, forwardRuleId, destination)
+}
+
+// StorageService_AddJournalDestination_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddJournalDestination'
+type StorageService_AddJournalDestination_Call struct {
+	*mock.Call
+}
+
+// AddJournalDestination is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+//   - forwardRuleId string
+//   - destination *domain.JournalDestination
+func (_e *StorageService_Expecter) AddJournalDestination(chatId interface{}, messageId interface{}, forwardRuleId interface{}, destination interface{}) *StorageService_AddJournalDestination_Call {
+	return &StorageService_AddJournalDestination_Call{Call: _e.mock.On("AddJournalDestination", chatId, messageId, forwardRuleId, destination)}
+}
+
+func (_c *StorageService_AddJournalDestination_Call) Run(run func(chatId int64, messageId int64, forwardRuleId string, destination *domain.JournalDestination)) *StorageService_AddJournalDestination_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(string), args[3].(*domain.JournalDestination))
+	})
+	return _c
+}
+
+func (_c *StorageService_AddJournalDestination_Call) Return() *StorageService_AddJournalDestination_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_AddJournalDestination_Call) RunAndReturn(run func(int64, int64, string, *domain.JournalDestination)) *StorageService_AddJournalDestination_Call {
+	_c.Run(run)
+	return _c
+}
+
+// AddJournalRule provides a mock function with given fields: chatId, messageId, journalRule
+func (_m *StorageService) AddJournalRule(chatId int64, messageId int64, journalRule *domain.JournalRule) {
+	_m.Called(chatId, messageId, journalRule)
+}
+
+// StorageService_AddJournalRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddJournalRule'
+type StorageService_AddJournalRule_Call struct {
+	*mock.Call
+}
+
+// AddJournalRule is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+//   - journalRule *domain.JournalRule
+func (_e *StorageService_Expecter) AddJournalRule(chatId interface{}, messageId interface{}, journalRule interface{}) *StorageService_AddJournalRule_Call {
+	return &StorageService_AddJournalRule_Call{Call: _e.mock.On("AddJournalRule", chatId, messageId, journalRule)}
+}
+
+func (_c *StorageService_AddJournalRule_Call) Run(run func(chatId int64, messageId int64, journalRule *domain.JournalRule)) *StorageService_AddJournalRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(*domain.JournalRule))
+	})
+	return _c
+}
+
+func (_c *StorageService_AddJournalRule_Call) Return() *StorageService_AddJournalRule_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_AddJournalRule_Call) RunAndReturn(run func(int64, int64, *domain.JournalRule)) *StorageService_AddJournalRule_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetOriginMessageId provides a mock function with given fields: dstChatId, dstMessageId
+func (_m *StorageService) GetOriginMessageId(dstChatId int64, dstMessageId int64) *domain.ChatMessage {
+	ret := _m.Called(dstChatId, dstMessageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOriginMessageId")
+	}
+
+	var r0 *domain.ChatMessage
+	if rf, ok := ret.Get(0).(func(int64, int64) *domain.ChatMessage); ok {
+		r0 = rf(dstChatId, dstMessageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ChatMessage)
+		}
+	}
+
+	return r0
+}
+
+// StorageService_GetOriginMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOriginMessageId'
+type StorageService_GetOriginMessageId_Call struct {
+	*mock.Call
+}
+
+// GetOriginMessageId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - dstMessageId int64
+func (_e *StorageService_Expecter) GetOriginMessageId(dstChatId interface{}, dstMessageId interface{}) *StorageService_GetOriginMessageId_Call {
+	return &StorageService_GetOriginMessageId_Call{Call: _e.mock.On("GetOriginMessageId", dstChatId, dstMessageId)}
+}
+
+func (_c *StorageService_GetOriginMessageId_Call) Run(run func(dstChatId int64, dstMessageId int64)) *StorageService_GetOriginMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetOriginMessageId_Call) Return(_a0 *domain.ChatMessage) *StorageService_GetOriginMessageId_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetOriginMessageId_Call) RunAndReturn(run func(int64, int64) *domain.ChatMessage) *StorageService_GetOriginMessageId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IncrementForwardedMessages provides a mock function with given fields: toChatId, date
 func (_m *StorageService) IncrementForwardedMessages(toChatId int64, date string) {
 	_m.Called(toChatId, date)
@@ -51,6 +174,77 @@ func (_c *StorageService_IncrementForwardedMessages_Call) RunAndReturn(run func(
 	return _c
 }
 
+// IncrementProtectedMessages provides a mock function with given fields: toChatId, fallback, date
+func (_m *StorageService) IncrementProtectedMessages(toChatId int64, fallback string, date string) {
+	_m.Called(toChatId, fallback, date)
+}
+
+// StorageService_IncrementProtectedMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementProtectedMessages'
+type StorageService_IncrementProtectedMessages_Call struct {
+	*mock.Call
+}
+
+// IncrementProtectedMessages is a helper method to define mock.On call
+//   - toChatId int64
+//   - fallback string
+//   - date string
+func (_e *StorageService_Expecter) IncrementProtectedMessages(toChatId interface{}, fallback interface{}, date interface{}) *StorageService_IncrementProtectedMessages_Call {
+	return &StorageService_IncrementProtectedMessages_Call{Call: _e.mock.On("IncrementProtectedMessages", toChatId, fallback, date)}
+}
+
+func (_c *StorageService_IncrementProtectedMessages_Call) Run(run func(toChatId int64, fallback string, date string)) *StorageService_IncrementProtectedMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *StorageService_IncrementProtectedMessages_Call) Return() *StorageService_IncrementProtectedMessages_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_IncrementProtectedMessages_Call) RunAndReturn(run func(int64, string, string)) *StorageService_IncrementProtectedMessages_Call {
+	_c.Run(run)
+	return _c
+}
+
+// IncrementStats provides a mock function with given fields: forwardRuleId, srcChatId, dstChatId, outcome
+func (_m *StorageService) IncrementStats(forwardRuleId string, srcChatId int64, dstChatId int64, outcome string) {
+	_m.Called(forwardRuleId, srcChatId, dstChatId, outcome)
+}
+
+// StorageService_IncrementStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementStats'
+type StorageService_IncrementStats_Call struct {
+	*mock.Call
+}
+
+// IncrementStats is a helper method to define mock.On call
+//   - forwardRuleId string
+//   - srcChatId int64
+//   - dstChatId int64
+//   - outcome string
+func (_e *StorageService_Expecter) IncrementStats(forwardRuleId interface{}, srcChatId interface{}, dstChatId interface{}, outcome interface{}) *StorageService_IncrementStats_Call {
+	return &StorageService_IncrementStats_Call{Call: _e.mock.On("IncrementStats", forwardRuleId, srcChatId, dstChatId, outcome)}
+}
+
+func (_c *StorageService_IncrementStats_Call) Run(run func(forwardRuleId string, srcChatId int64, dstChatId int64, outcome string)) *StorageService_IncrementStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int64), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *StorageService_IncrementStats_Call) Return() *StorageService_IncrementStats_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_IncrementStats_Call) RunAndReturn(run func(string, int64, int64, string)) *StorageService_IncrementStats_Call {
+	_c.Run(run)
+	return _c
+}
+
 // IncrementViewedMessages provides a mock function with given fields: toChatId, date
 func (_m *StorageService) IncrementViewedMessages(toChatId int64, date string) {
 	_m.Called(toChatId, date)
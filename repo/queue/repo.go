@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/metrics"
+	"github.com/comerc/budva43/app/trace"
 )
 
 // Repo предоставляет функциональность асинхронной очереди задач
@@ -40,11 +42,16 @@ func (s *Repo) Close() error {
 	return nil
 }
 
-// Add добавляет задачу в очередь
-func (s *Repo) Add(fn func()) {
+// Add добавляет задачу в очередь; задача продолжает трассу из ctx в спане "queue"
+func (s *Repo) Add(ctx context.Context, fn func(ctx context.Context)) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.queue.PushBack(fn)
+	s.queue.PushBack(func() {
+		ctx, end := trace.Start(ctx, "queue")
+		defer end(nil)
+		fn(ctx)
+	})
+	metrics.SetQueueLength(s.queue.Len())
 }
 
 // Len возвращает количество задач в очереди
@@ -78,6 +85,7 @@ func (s *Repo) processQueue() {
 		fn := front.Value.(func())
 		// Это позволит удалить выделенную память и избежать утечек памяти
 		s.queue.Remove(front)
+		metrics.SetQueueLength(s.queue.Len())
 		s.executeTask(fn)
 	}
 }
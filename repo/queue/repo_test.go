@@ -38,30 +38,30 @@ func Test(t *testing.T) {
 		executed := 0
 
 		// Добавляем задачи, одна из которых вызывает панику
-		var fn func()
-		fn = func() {
+		var fn func(ctx context.Context)
+		fn = func(ctx context.Context) {
 			executed++
 			panic("Alarm!")
 		}
-		queueRepo.Add(fn)
+		queueRepo.Add(ctx, fn)
 
 		engineConfig = newEngineConfig(-123)
 		engineConfig1 := engineConfig // копируем, см. WATCH-CONFIG.md
-		fn = func() {
+		fn = func(ctx context.Context) {
 			assert.Equal(t, int64(-123), engineConfig1.ForwardRules["rule1"].From,
 				"Замыкается engineConfig1")
 			executed++
 		}
-		queueRepo.Add(fn)
+		queueRepo.Add(ctx, fn)
 
 		engineConfig = newEngineConfig(-321)
 		engineConfig2 := engineConfig // копируем, см. WATCH-CONFIG.md
-		fn = func() {
+		fn = func(ctx context.Context) {
 			assert.Equal(t, int64(-321), engineConfig2.ForwardRules["rule1"].From,
 				"Замыкается engineConfig2")
 			executed++
 		}
-		queueRepo.Add(fn)
+		queueRepo.Add(ctx, fn)
 
 		require.Equal(t, 3, queueRepo.Len(), "В очереди должно быть 3 задачи")
 
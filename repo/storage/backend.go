@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/comerc/budva43/app/dto/storage/dto"
+	"github.com/comerc/budva43/app/log"
+)
+
+// ErrKeyNotFound возвращается всеми реализациями хранилища при отсутствии ключа
+var ErrKeyNotFound = badger.ErrKeyNotFound
+
+// Backend хранилище записей; реализация выбирается по config.Storage.Backend
+type Backend interface {
+	StartContext(ctx context.Context) error
+	Close() error
+	Increment(key string) (uint64, error)
+	GetCounter(key string) (uint64, error)
+	GetSet(key string, fn func(record *dto.Record) (*dto.Record, error), ttl time.Duration) (*dto.Record, error)
+	Get(key string) (*dto.Record, error)
+	Set(key string, record *dto.Record, ttl time.Duration) error
+	Delete(key string) error
+	DeleteBatch(keys []string) error
+	Scan(fn func(key string, record *dto.Record), prefixes ...string) error
+	ScanCounters(fn func(key string, value uint64), from, to string) error
+	GetUsage() ([]*dto.Usage, error)
+	Backup() (*dto.Backup, error)
+	GetBackups() ([]*dto.Backup, error)
+	Restore(path string) error
+	Export(fn func(entry *dto.Entry) error) error
+	Import(entries []*dto.Entry) error
+}
+
+// backends реестр реализаций хранилища по имени
+var backends = map[string]func() Backend{
+	"badger": func() Backend { return New() },
+	"sqlite": func() Backend { return NewSQLite() },
+}
+
+// NewBackend создает хранилище по имени реализации
+func NewBackend(name string) (Backend, error) {
+	newBackend, ok := backends[name]
+	if !ok {
+		return nil, log.NewError("unknown storage backend",
+			"name", name,
+			"backends", GetBackendNames(),
+		)
+	}
+	return newBackend(), nil
+}
+
+// GetBackendNames возвращает имена реализаций хранилища
+func GetBackendNames() []string {
+	result := make([]string, 0, len(backends))
+	for name := range backends {
+		result = append(result, name)
+	}
+	slices.Sort(result)
+	return result
+}
@@ -0,0 +1,288 @@
+package storage
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/dto/storage/dto"
+	"github.com/comerc/budva43/app/log"
+)
+
+const (
+	backupPrefix     = "backup-"
+	backupExt        = ".bak"
+	backupGzipExt    = ".gz"
+	backupEncryptExt = ".enc"
+	backupTimeLayout = "20060102-150405.000"
+	// restoreMaxPendingWrites ограничивает число записей в полёте при восстановлении
+	restoreMaxPendingWrites = 256
+)
+
+// runBackup периодически создаёт резервные копии базы данных
+func runBackup(ctx context.Context, backup func() (*dto.Backup, error)) {
+	ticker := time.NewTicker(config.Storage.BackupFrequency)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = backup() // ошибка логируется внутри
+		}
+	}
+}
+
+// Backup создаёт полную резервную копию базы данных, не останавливая работу,
+// и удаляет старые копии сверх config.Storage.BackupCount
+func (r *Repo) Backup() (*dto.Backup, error) {
+	var (
+		err    error
+		result *dto.Backup
+	)
+	defer func() {
+		r.log.ErrorOrInfo(err, "backup",
+			"result", result,
+		)
+	}()
+
+	r.backupMu.Lock()
+	defer r.backupMu.Unlock()
+
+	// копия зашифрованной базы тоже шифруется, иначе поток Badger выдаст данные открытыми
+	var key []byte
+	key, _, err = getEncryptionKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	name := backupPrefix + time.Now().UTC().Format(backupTimeLayout) + backupExt
+	if config.Storage.BackupCompress {
+		name += backupGzipExt
+	}
+	if key != nil {
+		name += backupEncryptExt
+	}
+	path := filepath.Join(config.Storage.BackupDirectory, name)
+
+	err = r.writeBackup(path, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var info os.FileInfo
+	info, err = os.Stat(path)
+	if err != nil {
+		err = log.WrapError(err) // внешняя ошибка
+		return nil, err
+	}
+	result = newBackup(info)
+
+	err = pruneBackups(backupExt)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// writeBackup пишет копию во временный файл и переименовывает его,
+// чтобы незавершённая копия не попала в список; сжатие выполняется до шифрования
+func (r *Repo) writeBackup(path string, key []byte) error {
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	defer os.Remove(tmpPath) //nolint:errcheck
+	defer file.Close()       //nolint:errcheck
+
+	var (
+		w  io.Writer = file
+		ew io.WriteCloser
+	)
+	if key != nil {
+		ew, err = newEncryptWriter(w, key)
+		if err != nil {
+			return err
+		}
+		w = ew
+	}
+	var zw *gzip.Writer
+	if strings.HasSuffix(strings.TrimSuffix(path, backupEncryptExt), backupGzipExt) {
+		zw = gzip.NewWriter(w)
+		w = zw
+	}
+
+	_, err = r.db.Backup(w, 0)
+	if err == nil && zw != nil {
+		err = zw.Close()
+	}
+	if err == nil && ew != nil {
+		err = ew.Close()
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	err = errors.Join(err, file.Close())
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	return nil
+}
+
+// pruneBackups удаляет самые старые копии с расширением ext сверх config.Storage.BackupCount
+func pruneBackups(ext string) error {
+	if config.Storage.BackupCount <= 0 {
+		return nil
+	}
+	backups, err := getBackups(ext)
+	if err != nil {
+		return err
+	}
+	for i := config.Storage.BackupCount; i < len(backups); i++ {
+		err = os.Remove(filepath.Join(config.Storage.BackupDirectory, backups[i].Name))
+		if err != nil {
+			return log.WrapError(err) // внешняя ошибка
+		}
+	}
+	return nil
+}
+
+// GetBackups возвращает резервные копии, начиная с последней
+func (r *Repo) GetBackups() ([]*dto.Backup, error) {
+	return getBackups(backupExt)
+}
+
+// getBackups возвращает резервные копии с расширением ext, начиная с последней
+func getBackups(ext string) ([]*dto.Backup, error) {
+	entries, err := os.ReadDir(config.Storage.BackupDirectory)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+
+	result := []*dto.Backup{}
+	for _, entry := range entries {
+		if entry.IsDir() || !isBackupName(entry.Name(), ext) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, log.WrapError(err) // внешняя ошибка
+		}
+		result = append(result, newBackup(info))
+	}
+	// имя содержит время создания, поэтому сортировка по имени - по времени
+	slices.SortFunc(result, func(a, b *dto.Backup) int {
+		return strings.Compare(b.Name, a.Name)
+	})
+	return result, nil
+}
+
+// Restore восстанавливает базу данных из резервной копии; вызывается до StartContext:
+// текущая база не удаляется, а переносится рядом с суффиксом времени восстановления
+func (r *Repo) Restore(path string) error {
+	var err error
+	defer func() {
+		r.log.ErrorOrInfo(err, "restore",
+			"path", path,
+		)
+	}()
+
+	if r.db != nil {
+		err = log.NewError("restore requires closed database")
+		return err
+	}
+
+	var key, prevKey []byte
+	key, prevKey, err = getEncryptionKeys()
+	if err != nil {
+		return err
+	}
+
+	var file *os.File
+	file, err = os.Open(path)
+	if err != nil {
+		err = log.WrapError(err) // внешняя ошибка
+		return err
+	}
+	defer file.Close() //nolint:errcheck
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, backupEncryptExt) {
+		reader, err = newBackupDecryptReader(file, key, prevKey)
+		if err != nil {
+			return err
+		}
+		path = strings.TrimSuffix(path, backupEncryptExt)
+	}
+	if strings.HasSuffix(path, backupGzipExt) {
+		var zr *gzip.Reader
+		zr, err = gzip.NewReader(reader)
+		if err != nil {
+			err = log.WrapError(err) // внешняя ошибка
+			return err
+		}
+		defer zr.Close() //nolint:errcheck
+		reader = zr
+	}
+
+	dir := config.Storage.DatabaseDirectory
+	var entries []os.DirEntry
+	entries, err = os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		err = log.WrapError(err) // внешняя ошибка
+		return err
+	}
+	if len(entries) > 0 {
+		err = os.Rename(dir, dir+"-"+time.Now().UTC().Format(backupTimeLayout))
+		if err != nil {
+			err = log.WrapError(err) // внешняя ошибка
+			return err
+		}
+	}
+
+	var db *badger.DB
+	db, err = badger.Open(newOptions(dir, key))
+	if err != nil {
+		err = log.WrapError(err) // внешняя ошибка
+		return err
+	}
+	err = db.Load(reader, restoreMaxPendingWrites)
+	err = errors.Join(err, db.Close())
+	if err != nil {
+		err = log.WrapError(err) // внешняя ошибка
+		return err
+	}
+	return nil
+}
+
+// isBackupName проверяет, что файл является резервной копией с расширением ext
+func isBackupName(name, ext string) bool {
+	name = strings.TrimSuffix(name, backupEncryptExt)
+	name = strings.TrimSuffix(name, backupGzipExt)
+	return strings.HasPrefix(name, backupPrefix) && strings.HasSuffix(name, ext)
+}
+
+// newBackup создает описание резервной копии по файлу
+func newBackup(info os.FileInfo) *dto.Backup {
+	return &dto.Backup{
+		Name:      info.Name(),
+		Size:      info.Size(),
+		CreatedAt: info.ModTime(),
+	}
+}
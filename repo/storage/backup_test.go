@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/dto/storage/dto"
+)
+
+func TestBackup(t *testing.T) {
+	// t.Parallel() // !! нельзя параллелить, тестирую с подменой глобальных переменных
+
+	storage := *config.Storage
+	t.Cleanup(func() {
+		*config.Storage = storage
+	})
+	dir := t.TempDir()
+	config.Storage.BackupDirectory = filepath.Join(dir, "backup")
+	config.Storage.DatabaseDirectory = filepath.Join(dir, "db")
+	config.Storage.BackupCount = 2
+	err := os.MkdirAll(config.Storage.BackupDirectory, 0o755)
+	require.NoError(t, err)
+
+	for _, isCompress := range []bool{false, true} {
+		config.Storage.BackupCompress = isCompress
+
+		r := newTestRepo(t)
+		err = r.Set("newMsgId:-1002:20", &dto.Record{MessageId: 21}, 0)
+		require.NoError(t, err)
+
+		var backup *dto.Backup
+		backup, err = r.Backup()
+		require.NoError(t, err)
+		assert.Equal(t, isCompress, filepath.Ext(backup.Name) == backupGzipExt)
+
+		// восстановление в новую базу
+		restored := New()
+		err = restored.Restore(filepath.Join(config.Storage.BackupDirectory, backup.Name))
+		require.NoError(t, err)
+		err = restored.StartContext(t.Context())
+		require.NoError(t, err)
+		var record *dto.Record
+		record, err = restored.Get("newMsgId:-1002:20")
+		require.NoError(t, err)
+		assert.Equal(t, int64(21), record.MessageId)
+		err = restored.Close()
+		require.NoError(t, err)
+	}
+
+	// при восстановлении поверх текущая база сохраняется рядом
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3) // backup, db, db-<время>
+
+	// сверх BackupCount старые копии удаляются
+	r := newTestRepo(t)
+	for range 3 {
+		_, err = r.Backup()
+		require.NoError(t, err)
+	}
+	backups, err := r.GetBackups()
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+}
+
+func TestSQLiteBackup(t *testing.T) {
+	// t.Parallel() // !! нельзя параллелить, тестирую с подменой глобальных переменных
+
+	storage := *config.Storage
+	t.Cleanup(func() {
+		*config.Storage = storage
+	})
+	dir := t.TempDir()
+	config.Storage.BackupDirectory = filepath.Join(dir, "backup")
+	config.Storage.SQLiteDirectory = filepath.Join(dir, "sqlite")
+	config.Storage.BackupCount = 2
+	err := os.MkdirAll(config.Storage.BackupDirectory, 0o755)
+	require.NoError(t, err)
+	err = os.MkdirAll(config.Storage.SQLiteDirectory, 0o755)
+	require.NoError(t, err)
+
+	for _, isCompress := range []bool{false, true} {
+		config.Storage.BackupCompress = isCompress
+
+		r := NewSQLite()
+		err = r.StartContext(t.Context())
+		require.NoError(t, err)
+		err = r.Set("newMsgId:-1002:20", &dto.Record{MessageId: 21}, 0)
+		require.NoError(t, err)
+
+		var backup *dto.Backup
+		backup, err = r.Backup()
+		require.NoError(t, err)
+		assert.Equal(t, isCompress, filepath.Ext(backup.Name) == backupGzipExt)
+
+		// после копии запись удалена: восстановление возвращает её
+		err = r.Delete("newMsgId:-1002:20")
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+
+		restored := NewSQLite()
+		err = restored.Restore(filepath.Join(config.Storage.BackupDirectory, backup.Name))
+		require.NoError(t, err)
+		err = restored.StartContext(t.Context())
+		require.NoError(t, err)
+		var record *dto.Record
+		record, err = restored.Get("newMsgId:-1002:20")
+		require.NoError(t, err)
+		assert.Equal(t, int64(21), record.MessageId)
+		require.NoError(t, restored.Close())
+	}
+
+	// копия BadgerDB не восстанавливается в SQLite и не попадает в список копий
+	badgerBackup := filepath.Join(config.Storage.BackupDirectory, backupPrefix+"20250101-000000.000"+backupExt)
+	err = os.WriteFile(badgerBackup, []byte("badger"), 0o600)
+	require.NoError(t, err)
+	assert.ErrorContains(t, NewSQLite().Restore(badgerBackup), "not a sqlite backup")
+
+	// повреждённая копия отвергается до подмены текущей базы
+	brokenBackup := filepath.Join(config.Storage.BackupDirectory, backupPrefix+"20250101-000000.000"+sqliteBackupExt)
+	err = os.WriteFile(brokenBackup, []byte("broken"), 0o600)
+	require.NoError(t, err)
+	assert.Error(t, NewSQLite().Restore(brokenBackup))
+	require.NoError(t, os.Remove(brokenBackup))
+
+	// сверх BackupCount старые копии удаляются
+	r := NewSQLite()
+	err = r.StartContext(t.Context())
+	require.NoError(t, err)
+	for range 3 {
+		_, err = r.Backup()
+		require.NoError(t, err)
+	}
+	backups, err := r.GetBackups()
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+	require.NoError(t, r.Close())
+	assert.FileExists(t, badgerBackup)
+}
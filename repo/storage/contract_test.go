@@ -0,0 +1,244 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/comerc/budva43/app/dto/storage/dto"
+)
+
+func newTestSQLiteRepo(t *testing.T) *SQLiteRepo {
+	r := NewSQLite()
+	err := r.open(filepath.Join(t.TempDir(), sqliteFileName))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		r.Close() //nolint:errcheck,gosec
+	})
+	return r
+}
+
+// testBackends реализации хранилища, которые обязаны проходить общий набор тестов
+var testBackends = map[string]func(t *testing.T) Backend{
+	"badger": func(t *testing.T) Backend { return newTestRepo(t) },
+	"sqlite": func(t *testing.T) Backend { return newTestSQLiteRepo(t) },
+}
+
+func TestContract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, r Backend)
+	}{
+		{
+			name: "get_missing",
+			fn: func(t *testing.T, r Backend) {
+				_, err := r.Get("newMsgId:-1002:20")
+				assert.ErrorIs(t, err, ErrKeyNotFound)
+				_, err = r.GetCounter("viewedMsgs:-1002:2025-01-01")
+				assert.ErrorIs(t, err, ErrKeyNotFound)
+			},
+		},
+		{
+			name: "set_get_delete",
+			fn: func(t *testing.T, r Backend) {
+				record := &dto.Record{
+					ChatMessages: []*dto.ChatMessage{{ForwardRuleId: "rule:1", ChatId: -1002, MessageId: 20}},
+				}
+				err := r.Set("copiedMsgIds:-1001:10", record, 0)
+				require.NoError(t, err)
+				result, err := r.Get("copiedMsgIds:-1001:10")
+				require.NoError(t, err)
+				assert.True(t, proto.Equal(record, result))
+
+				err = r.Delete("copiedMsgIds:-1001:10")
+				require.NoError(t, err)
+				_, err = r.Get("copiedMsgIds:-1001:10")
+				assert.ErrorIs(t, err, ErrKeyNotFound)
+			},
+		},
+		{
+			name: "get_set",
+			fn: func(t *testing.T, r Backend) {
+				add := func(record *dto.Record) (*dto.Record, error) {
+					record.MessageIds = append(record.MessageIds, int64(len(record.MessageIds)+10))
+					return record, nil
+				}
+				_, err := r.GetSet("albumMsgIds:-1001:10", add, 0)
+				require.NoError(t, err)
+				result, err := r.GetSet("albumMsgIds:-1001:10", add, time.Hour)
+				require.NoError(t, err)
+				assert.Equal(t, []int64{10, 11}, result.MessageIds)
+				result, err = r.Get("albumMsgIds:-1001:10")
+				require.NoError(t, err)
+				assert.Equal(t, []int64{10, 11}, result.MessageIds)
+			},
+		},
+		{
+			name: "increment",
+			fn: func(t *testing.T, r Backend) {
+				for i := uint64(1); i <= 3; i++ {
+					result, err := r.Increment("viewedMsgs:-1002:2025-01-01")
+					require.NoError(t, err)
+					assert.Equal(t, i, result)
+				}
+				result, err := r.GetCounter("viewedMsgs:-1002:2025-01-01")
+				require.NoError(t, err)
+				assert.Equal(t, uint64(3), result)
+			},
+		},
+		{
+			name: "expired",
+			fn: func(t *testing.T, r Backend) {
+				data, err := encodeRecord(&dto.Record{MessageId: 21})
+				require.NoError(t, err)
+				err = r.Import([]*dto.Entry{
+					{Key: "newMsgId:-1002:20", Value: data, ExpiresAt: uint64(time.Now().Add(-time.Minute).Unix())},
+					{Key: "newMsgId:-1002:30", Value: data, ExpiresAt: uint64(time.Now().Add(time.Hour).Unix())},
+				})
+				require.NoError(t, err)
+				_, err = r.Get("newMsgId:-1002:20")
+				assert.ErrorIs(t, err, ErrKeyNotFound)
+				_, err = r.Get("newMsgId:-1002:30")
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "scan_delete_batch",
+			fn: func(t *testing.T, r Backend) {
+				for _, key := range []string{"newMsgId:-1002:30", "newMsgId:-1002:20", "tmpMsgId:-1002:21", "copiedMsgIds:-1001:10"} {
+					err := r.Set(key, &dto.Record{}, 0)
+					require.NoError(t, err)
+				}
+				var keys []string
+				err := r.Scan(func(key string, record *dto.Record) {
+					keys = append(keys, key)
+				}, "tmpMsgId", "newMsgId")
+				require.NoError(t, err)
+				assert.Equal(t, []string{"tmpMsgId:-1002:21", "newMsgId:-1002:20", "newMsgId:-1002:30"}, keys)
+
+				err = r.DeleteBatch(keys)
+				require.NoError(t, err)
+				_, err = r.Get("newMsgId:-1002:20")
+				assert.ErrorIs(t, err, ErrKeyNotFound)
+				_, err = r.Get("copiedMsgIds:-1001:10")
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "usage",
+			fn: func(t *testing.T, r Backend) {
+				for _, key := range []string{"newMsgId:-1002:20", "newMsgId:-1002:30", "copiedMsgIds:-1001:10"} {
+					err := r.Set(key, &dto.Record{MessageId: 1}, 0)
+					require.NoError(t, err)
+				}
+				result, err := r.GetUsage()
+				require.NoError(t, err)
+				require.Len(t, result, 2)
+				assert.Equal(t, "copiedMsgIds", result[0].Prefix)
+				assert.Equal(t, int64(1), result[0].Keys)
+				assert.Equal(t, "newMsgId", result[1].Prefix)
+				assert.Equal(t, int64(2), result[1].Keys)
+				assert.Positive(t, result[1].Size)
+			},
+		},
+		{
+			name: "scan_counters",
+			fn: func(t *testing.T, r Backend) {
+				for _, key := range []string{
+					"stats:2025010100:-1001:-1002:ok:rule1",
+					"stats:2025010101:-1001:-1002:ok:rule1",
+					"stats:2025010101:-1001:-1002:ok:rule1",
+					"stats:2025010102:-1001:-1002:ok:rule1",
+				} {
+					_, err := r.Increment(key)
+					require.NoError(t, err)
+				}
+				err := r.Set("newMsgId:-1002:20", &dto.Record{MessageId: 21}, 0)
+				require.NoError(t, err)
+
+				result := make(map[string]uint64)
+				err = r.ScanCounters(func(key string, value uint64) {
+					result[key] = value
+				}, "stats:2025010101", "stats:2025010102")
+				require.NoError(t, err)
+				assert.Equal(t, map[string]uint64{
+					"stats:2025010101:-1001:-1002:ok:rule1": 2,
+				}, result)
+			},
+		},
+		{
+			name: "export_import",
+			fn: func(t *testing.T, r Backend) {
+				err := r.Set("newMsgId:-1002:20", &dto.Record{MessageId: 21}, time.Hour)
+				require.NoError(t, err)
+				_, err = r.Increment("viewedMsgs:-1002:2025-01-01")
+				require.NoError(t, err)
+
+				var entries []*dto.Entry
+				err = r.Export(func(entry *dto.Entry) error {
+					entries = append(entries, entry)
+					return nil
+				})
+				require.NoError(t, err)
+				require.Len(t, entries, 2)
+				assert.Equal(t, "newMsgId:-1002:20", entries[0].Key)
+				assert.NotZero(t, entries[0].ExpiresAt)
+				assert.Equal(t, "viewedMsgs:-1002:2025-01-01", entries[1].Key)
+				assert.Zero(t, entries[1].ExpiresAt)
+			},
+		},
+	}
+
+	for name, newBackend := range testBackends {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, test := range tests {
+				t.Run(test.name, func(t *testing.T) {
+					t.Parallel()
+
+					test.fn(t, newBackend(t))
+				})
+			}
+		})
+	}
+}
+
+func TestCopy(t *testing.T) {
+	t.Parallel()
+
+	from := newTestRepo(t)
+	to := newTestSQLiteRepo(t)
+
+	// значение до версионирования приводится к записи
+	err := from.Import([]*dto.Entry{{Key: "copiedMsgIds:-1001:10", Value: []byte("Rule1:-1002:20")}})
+	require.NoError(t, err)
+	err = from.Set("newMsgId:-1002:20", &dto.Record{MessageId: 21}, time.Hour)
+	require.NoError(t, err)
+	_, err = from.Increment("viewedMsgs:-1002:2025-01-01")
+	require.NoError(t, err)
+
+	result, err := Copy(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result)
+
+	record, err := to.Get("copiedMsgIds:-1001:10")
+	require.NoError(t, err)
+	assert.Equal(t, "Rule1", record.ChatMessages[0].ForwardRuleId)
+	counter, err := to.GetCounter("viewedMsgs:-1002:2025-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), counter)
+
+	// записи доступны для запросов в JSON
+	var chatId int64
+	err = to.db.QueryRow(`SELECT json_extract(data, '$.chatMessages[0].chatId') FROM records WHERE key = ?`,
+		"copiedMsgIds:-1001:10").Scan(&chatId)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1002), chatId)
+}
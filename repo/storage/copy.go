@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"github.com/comerc/budva43/app/dto/storage/dto"
+)
+
+// copyBatchSize количество значений в одной записи при копировании
+const copyBatchSize = 1000
+
+// Copy копирует все значения из одного хранилища в другое, сохраняя сроки хранения
+func Copy(from, to Backend) (int, error) {
+	var (
+		result int
+		batch  []*dto.Entry
+	)
+	err := from.Export(func(entry *dto.Entry) error {
+		batch = append(batch, entry)
+		if len(batch) < copyBatchSize {
+			return nil
+		}
+		err := to.Import(batch)
+		if err != nil {
+			return err
+		}
+		result += len(batch)
+		batch = nil
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	if len(batch) > 0 {
+		err = to.Import(batch)
+		if err != nil {
+			return result, err
+		}
+		result += len(batch)
+	}
+	return result, nil
+}
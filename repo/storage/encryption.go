@@ -0,0 +1,423 @@
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/util"
+)
+
+// reencryptMaxPendingWrites ограничивает число записей в полёте при перешифровании
+const reencryptMaxPendingWrites = 256
+
+// errBackupKeyMismatch резервная копия зашифрована другим ключом (см. backupKeyCheck)
+var errBackupKeyMismatch = errors.New("backup encryption key mismatch")
+
+// newOptions возвращает настройки BadgerDB; при шифровании нужен кэш индексов
+func newOptions(dir string, encryptionKey []byte) badger.Options {
+	opts := badger.DefaultOptions(dir)
+	opts.Logger = NewLogger()
+	if len(encryptionKey) > 0 {
+		opts = opts.
+			WithEncryptionKey(encryptionKey).
+			WithIndexCacheSize(int64(config.Storage.IndexCacheSize) << 20)
+	}
+	return opts
+}
+
+// getEncryptionKeys возвращает текущий и прежний ключи шифрования (nil - без шифрования)
+func getEncryptionKeys() ([]byte, []byte, error) {
+	key, err := readEncryptionKey(config.Storage.EncryptionKey, config.Storage.EncryptionKeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	prevKey, err := readEncryptionKey(config.Storage.PrevEncryptionKey, config.Storage.PrevEncryptionKeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if prevKey != nil && key == nil {
+		return nil, nil, log.NewError("prev encryption key requires encryption key")
+	}
+	return key, prevKey, nil
+}
+
+// readEncryptionKey разбирает ключ в hex из значения или файла
+func readEncryptionKey(value, file string) ([]byte, error) {
+	if value == "" && file != "" {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(util.ProjectRoot, file)
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, log.WrapError(err) // внешняя ошибка
+		}
+		value = string(data)
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	key, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, log.NewError("encryption key must be hex",
+			"file", file,
+		)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, log.NewError("encryption key must be 16, 24 or 32 bytes",
+			"file", file,
+			"len", len(key),
+		)
+	}
+	return key, nil
+}
+
+// openDB открывает базу текущим ключом; если база зашифрована прежним ключом
+// или ещё не зашифрована, перешифровывает её текущим ключом
+func (r *Repo) openDB() (*badger.DB, error) {
+	dir := config.Storage.DatabaseDirectory
+	key, prevKey, err := getEncryptionKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.recoverReencrypt(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := badger.Open(newOptions(dir, key))
+	if err == nil {
+		return db, nil
+	}
+	if !errors.Is(err, badger.ErrEncryptionKeyMismatch) || key == nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+
+	// ключ не подошёл: база зашифрована прежним ключом или не зашифрована вовсе
+	oldKeys := [][]byte{nil}
+	if prevKey != nil {
+		oldKeys = [][]byte{prevKey, nil}
+	}
+	for _, oldKey := range oldKeys {
+		err = r.reencrypt(dir, oldKey, key)
+		if errors.Is(err, badger.ErrEncryptionKeyMismatch) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		db, err = badger.Open(newOptions(dir, key))
+		if err != nil {
+			return nil, log.WrapError(err) // внешняя ошибка
+		}
+		return db, nil
+	}
+
+	return nil, log.NewError("wrong storage encryption key",
+		"dir", dir,
+		"hint", "check BUDVA43__STORAGE__ENCRYPTION_KEY or storage.encryption-key-file",
+	)
+}
+
+// reencrypt переписывает базу в новую директорию новым ключом и подменяет ею старую;
+// старая база удаляется, чтобы не оставлять данные под старым ключом
+func (r *Repo) reencrypt(dir string, oldKey, newKey []byte) error {
+	var err error
+	defer func() {
+		if errors.Is(err, badger.ErrEncryptionKeyMismatch) {
+			return // пробуем следующий ключ
+		}
+		r.log.ErrorOrInfo(err, "reencrypt",
+			"dir", dir,
+			"isPlaintext", oldKey == nil,
+		)
+	}()
+
+	var oldDB *badger.DB
+	oldDB, err = badger.Open(newOptions(dir, oldKey))
+	if err != nil {
+		if !errors.Is(err, badger.ErrEncryptionKeyMismatch) {
+			err = log.WrapError(err) // внешняя ошибка
+		}
+		return err
+	}
+
+	newDir := dir + "-" + time.Now().UTC().Format(backupTimeLayout)
+	var newDB *badger.DB
+	newDB, err = badger.Open(newOptions(newDir, newKey))
+	if err != nil {
+		err = errors.Join(log.WrapError(err), oldDB.Close()) // внешняя ошибка
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	backupErr := make(chan error, 1)
+	go func() {
+		_, err := oldDB.Backup(pw, 0)
+		pw.CloseWithError(err) //nolint:errcheck,gosec
+		backupErr <- err
+	}()
+	err = newDB.Load(pr, reencryptMaxPendingWrites)
+	pr.CloseWithError(err) //nolint:errcheck,gosec
+	err = errors.Join(err, <-backupErr, oldDB.Close(), newDB.Close())
+	if err != nil {
+		err = errors.Join(log.WrapError(err), os.RemoveAll(newDir)) // внешняя ошибка
+		return err
+	}
+
+	// маркер фиксирует готовую новую базу до подмены: если процесс упадёт
+	// между переименованиями, recoverReencrypt доведёт подмену при запуске
+	err = writeReencryptMarker(dir, newDir)
+	if err != nil {
+		err = errors.Join(err, os.RemoveAll(newDir))
+		return err
+	}
+	oldDir := newDir + ".old"
+	err = os.Rename(dir, oldDir)
+	if err == nil {
+		err = os.Rename(newDir, dir)
+	}
+	if err == nil {
+		err = os.RemoveAll(oldDir)
+	}
+	if err == nil {
+		err = os.Remove(getReencryptMarker(dir))
+	}
+	if err != nil {
+		err = log.WrapError(err) // внешняя ошибка
+		return err
+	}
+	return nil
+}
+
+// getReencryptMarker возвращает путь к маркеру незавершённой подмены базы
+func getReencryptMarker(dir string) string {
+	return dir + ".reencrypt"
+}
+
+// writeReencryptMarker сохраняет на диск директорию новой базы перед подменой
+func writeReencryptMarker(dir, newDir string) error {
+	file, err := os.Create(getReencryptMarker(dir))
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	_, err = file.WriteString(newDir)
+	if err == nil {
+		err = file.Sync()
+	}
+	err = errors.Join(err, file.Close())
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	return nil
+}
+
+// recoverReencrypt доводит подмену базы, прерванную падением процесса (см. reencrypt):
+// если базы нет, на её место ставится новая, а без новой - возвращается старая;
+// оставшиеся директории и маркер удаляются
+func (r *Repo) recoverReencrypt(dir string) error {
+	marker := getReencryptMarker(dir)
+	data, err := os.ReadFile(marker)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	newDir := string(data)
+	oldDir := newDir + ".old"
+	defer func() {
+		r.log.ErrorOrInfo(err, "recoverReencrypt",
+			"dir", dir,
+			"newDir", newDir,
+		)
+	}()
+
+	if !isPathExists(dir) {
+		if isPathExists(newDir) {
+			err = os.Rename(newDir, dir)
+		} else {
+			err = os.Rename(oldDir, dir)
+		}
+	}
+	if err == nil {
+		err = errors.Join(os.RemoveAll(newDir), os.RemoveAll(oldDir))
+	}
+	if err == nil {
+		err = os.Remove(marker)
+	}
+	if err != nil {
+		err = log.WrapError(err) // внешняя ошибка
+		return err
+	}
+	return nil
+}
+
+// isPathExists проверяет, существует ли файл или директория
+func isPathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// encryptWriter шифрует поток резервной копии и по Close дописывает имитовставку
+// (HMAC-SHA256 вектора инициализации и шифротекста), чтобы подмена копии распознавалась
+type encryptWriter struct {
+	io.Writer
+	w   io.Writer
+	mac hash.Hash
+}
+
+// Close дописывает имитовставку; сам поток не закрывается
+func (e *encryptWriter) Close() error {
+	_, err := e.w.Write(e.mac.Sum(nil))
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	return nil
+}
+
+// newEncryptWriter шифрует поток резервной копии AES-CTR; первыми пишутся
+// вектор инициализации и проверочное значение ключа, последней - имитовставка
+func newEncryptWriter(w io.Writer, key []byte) (io.WriteCloser, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	iv := make([]byte, aes.BlockSize)
+	_, err = rand.Read(iv)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	_, err = w.Write(iv)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	_, err = w.Write(backupKeyCheck(key, iv))
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	mac := hmac.New(sha256.New, backupMacKey(key))
+	mac.Write(iv) //nolint:errcheck,gosec
+	return &encryptWriter{
+		Writer: &cipher.StreamWriter{S: cipher.NewCTR(block, iv), W: io.MultiWriter(w, mac)},
+		w:      w,
+		mac:    mac,
+	}, nil
+}
+
+// newDecryptReader расшифровывает резервную копию, записанную newEncryptWriter;
+// имитовставка проверяется по всему файлу до расшифровки, т.к. восстановление
+// переносит текущую базу; возвращает errBackupKeyMismatch, если копия зашифрована другим ключом
+func newDecryptReader(file io.ReadSeeker, key []byte) (io.Reader, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	const headerSize = aes.BlockSize + sha256.Size
+	dataSize := size - headerSize - sha256.Size
+	if dataSize < 0 {
+		return nil, log.NewError("backup is truncated",
+			"size", size,
+		)
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	_, err = io.ReadFull(file, iv)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	keyCheck := make([]byte, sha256.Size)
+	_, err = io.ReadFull(file, keyCheck)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	if !hmac.Equal(keyCheck, backupKeyCheck(key, iv)) {
+		return nil, errBackupKeyMismatch
+	}
+
+	mac := hmac.New(sha256.New, backupMacKey(key))
+	mac.Write(iv) //nolint:errcheck,gosec
+	_, err = io.CopyN(mac, file, dataSize)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	tag := make([]byte, sha256.Size)
+	_, err = io.ReadFull(file, tag)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	if !hmac.Equal(tag, mac.Sum(nil)) {
+		return nil, log.NewError("backup is corrupted or tampered")
+	}
+
+	_, err = file.Seek(headerSize, io.SeekStart)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return &cipher.StreamReader{S: cipher.NewCTR(block, iv), R: io.LimitReader(file, dataSize)}, nil
+}
+
+// newBackupDecryptReader расшифровывает резервную копию текущим ключом,
+// а копию, снятую до ротации ключа, - прежним
+func newBackupDecryptReader(file io.ReadSeeker, key, prevKey []byte) (io.Reader, error) {
+	if key == nil {
+		return nil, log.NewError("encrypted backup requires encryption key")
+	}
+	for _, k := range [][]byte{key, prevKey} {
+		if k == nil {
+			continue
+		}
+		reader, err := newDecryptReader(file, k)
+		if errors.Is(err, errBackupKeyMismatch) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return reader, nil
+	}
+	return nil, log.NewError("wrong backup encryption key",
+		"hint", "check BUDVA43__STORAGE__PREV_ENCRYPTION_KEY or storage.prev-encryption-key-file",
+	)
+}
+
+// backupKeyCheck возвращает проверочное значение ключа для заголовка резервной копии:
+// неверный ключ распознаётся до расшифровки, а не по ошибке разбора данных
+func backupKeyCheck(key, iv []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(iv) //nolint:errcheck,gosec
+	return mac.Sum(nil)
+}
+
+// backupMacKey возвращает ключ имитовставки резервной копии, отдельный от ключа шифрования
+func backupMacKey(key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("budva43 backup mac")) //nolint:errcheck,gosec
+	return mac.Sum(nil)
+}
@@ -0,0 +1,244 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/dto/storage/dto"
+)
+
+func TestEncryption(t *testing.T) {
+	// t.Parallel() // !! нельзя параллелить, тестирую с подменой глобальных переменных
+
+	storage := *config.Storage
+	t.Cleanup(func() {
+		*config.Storage = storage
+	})
+	dir := t.TempDir()
+	config.Storage.DatabaseDirectory = filepath.Join(dir, "db")
+	config.Storage.BackupDirectory = filepath.Join(dir, "backup")
+	err := os.MkdirAll(config.Storage.BackupDirectory, 0o755)
+	require.NoError(t, err)
+
+	const (
+		key1 = "000102030405060708090a0b0c0d0e0f"
+		key2 = "101112131415161718191a1b1c1d1e1f101112131415161718191a1b1c1d1e1f"
+		key3 = "202122232425262728292a2b2c2d2e2f"
+	)
+
+	open := func() (*Repo, error) {
+		r := New()
+		err := r.StartContext(t.Context())
+		return r, err
+	}
+	assertRecord := func(r *Repo) {
+		record, err := r.Get("newMsgId:-1002:20")
+		require.NoError(t, err)
+		assert.Equal(t, int64(21), record.MessageId)
+	}
+
+	// база без шифрования
+	r, err := open()
+	require.NoError(t, err)
+	err = r.Set("newMsgId:-1002:20", &dto.Record{MessageId: 21}, 0)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	// первый запуск с ключом шифрует существующую базу
+	config.Storage.EncryptionKey = key1
+	r, err = open()
+	require.NoError(t, err)
+	assertRecord(r)
+	require.NoError(t, r.Close())
+
+	_, err = badger.Open(newOptions(config.Storage.DatabaseDirectory, nil))
+	assert.ErrorIs(t, err, badger.ErrEncryptionKeyMismatch)
+
+	// неверный ключ
+	config.Storage.EncryptionKey = key2
+	_, err = open()
+	assert.ErrorContains(t, err, "wrong storage encryption key")
+
+	// ротация ключа из файла
+	keyFile := filepath.Join(dir, "storage.key")
+	err = os.WriteFile(keyFile, []byte(key2+"\n"), 0o600)
+	require.NoError(t, err)
+	config.Storage.EncryptionKey = ""
+	config.Storage.EncryptionKeyFile = keyFile
+	config.Storage.PrevEncryptionKey = key1
+	r, err = open()
+	require.NoError(t, err)
+	assertRecord(r)
+
+	// резервная копия зашифрованной базы тоже зашифрована
+	backup, err := r.Backup()
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(backup.Name, backupEncryptExt))
+	require.NoError(t, r.Close())
+
+	config.Storage.PrevEncryptionKey = ""
+	r, err = open()
+	require.NoError(t, err)
+	assertRecord(r)
+	require.NoError(t, r.Close())
+
+	restore := func() error {
+		restored := New()
+		err := restored.Restore(filepath.Join(config.Storage.BackupDirectory, backup.Name))
+		if err != nil {
+			return err
+		}
+		err = restored.StartContext(t.Context())
+		require.NoError(t, err)
+		assertRecord(restored)
+		require.NoError(t, restored.Close())
+		return nil
+	}
+	require.NoError(t, restore())
+
+	// копия, снятая до ротации ключа, восстанавливается прежним ключом
+	config.Storage.EncryptionKeyFile = ""
+	config.Storage.EncryptionKey = key3
+	config.Storage.PrevEncryptionKey = key2
+	require.NoError(t, restore())
+
+	// неверный ключ распознаётся до расшифровки
+	config.Storage.PrevEncryptionKey = key1
+	assert.ErrorContains(t, restore(), "wrong backup encryption key")
+
+	// подменённая копия отвергается до переноса текущей базы
+	config.Storage.PrevEncryptionKey = key2
+	backupPath := filepath.Join(config.Storage.BackupDirectory, backup.Name)
+	data, err := os.ReadFile(backupPath)
+	require.NoError(t, err)
+	data[len(data)/2] ^= 0xff
+	err = os.WriteFile(backupPath, data, 0o600)
+	require.NoError(t, err)
+	assert.ErrorContains(t, restore(), "backup is corrupted or tampered")
+	r, err = open()
+	require.NoError(t, err)
+	assertRecord(r)
+	require.NoError(t, r.Close())
+}
+
+func TestRecoverReencrypt(t *testing.T) {
+	// t.Parallel() // !! нельзя параллелить, тестирую с подменой глобальных переменных
+
+	storage := *config.Storage
+	t.Cleanup(func() {
+		*config.Storage = storage
+	})
+
+	const key = "000102030405060708090a0b0c0d0e0f"
+
+	tests := []struct {
+		name  string
+		state func(dir, newDir, oldDir string) // состояние после падения процесса
+	}{
+		{
+			name: "swap_not_started",
+			state: func(dir, newDir, oldDir string) {
+				copyDir(t, dir, newDir)
+			},
+		},
+		{
+			name: "between_renames",
+			state: func(dir, newDir, oldDir string) {
+				copyDir(t, dir, newDir)
+				require.NoError(t, os.Rename(dir, oldDir))
+			},
+		},
+		{
+			name: "old_dir_not_removed",
+			state: func(dir, newDir, oldDir string) {
+				copyDir(t, dir, oldDir)
+			},
+		},
+		{
+			name: "new_dir_lost",
+			state: func(dir, newDir, oldDir string) {
+				require.NoError(t, os.Rename(dir, oldDir))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "db")
+			config.Storage.DatabaseDirectory = dir
+			config.Storage.EncryptionKey = key
+
+			r := New()
+			require.NoError(t, r.StartContext(t.Context()))
+			err := r.Set("newMsgId:-1002:20", &dto.Record{MessageId: 21}, 0)
+			require.NoError(t, err)
+			require.NoError(t, r.Close())
+
+			newDir := dir + "-20250101-000000"
+			oldDir := newDir + ".old"
+			require.NoError(t, writeReencryptMarker(dir, newDir))
+			test.state(dir, newDir, oldDir)
+
+			r = New()
+			require.NoError(t, r.StartContext(t.Context()))
+			record, err := r.Get("newMsgId:-1002:20")
+			require.NoError(t, err)
+			assert.Equal(t, int64(21), record.MessageId)
+			require.NoError(t, r.Close())
+
+			for _, path := range []string{newDir, oldDir, getReencryptMarker(dir)} {
+				assert.NoFileExists(t, path)
+				assert.NoDirExists(t, path)
+			}
+		})
+	}
+}
+
+// copyDir копирует файлы директории базы
+func copyDir(t *testing.T, src, dst string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dst, 0o755))
+	entries, err := os.ReadDir(src)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(src, entry.Name()))
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(dst, entry.Name()), data, 0o600)
+		require.NoError(t, err)
+	}
+}
+
+func TestReadEncryptionKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		isError bool
+	}{
+		{name: "empty", value: ""},
+		{name: "aes128", value: "000102030405060708090a0b0c0d0e0f"},
+		{name: "not_hex", value: "not a hex key", isError: true},
+		{name: "wrong_length", value: "0001", isError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := readEncryptionKey(test.value, "")
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/comerc/budva43/app/dto/storage/dto"
+	"github.com/comerc/budva43/app/log"
+)
+
+// Export обходит все значения базы как есть, вместе со сроком хранения
+func (r *Repo) Export(fn func(entry *dto.Entry) error) error {
+	err := r.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			err = fn(&dto.Entry{
+				Key:       string(item.KeyCopy(nil)),
+				Value:     val,
+				ExpiresAt: item.ExpiresAt(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// Import записывает значения как есть, сохраняя срок хранения
+func (r *Repo) Import(entries []*dto.Entry) error {
+	wb := r.db.NewWriteBatch()
+	defer wb.Cancel()
+	for _, entry := range entries {
+		// прежние версии (в том числе несведённые слагаемые счетчика) заменяются
+		e := badger.NewEntry([]byte(entry.Key), entry.Value).WithDiscard()
+		e.ExpiresAt = entry.ExpiresAt
+		err := wb.SetEntry(e)
+		if err != nil {
+			return log.WrapError(err) // внешняя ошибка
+		}
+	}
+	err := wb.Flush()
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	return nil
+}
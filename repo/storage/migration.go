@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/comerc/budva43/app/log"
+)
+
+// migrationBatchSize количество ключей в одной транзакции миграции
+const migrationBatchSize = 1000
+
+// runMigration переводит записи на текущую версию схемы, не останавливая работу:
+// пока миграция идёт, чтение разбирает старые значения, а запись сразу пишет новые
+func (r *Repo) runMigration(ctx context.Context) {
+	var (
+		err     error
+		version uint64
+		result  int
+	)
+	defer func() {
+		r.log.ErrorOrInfo(err, "migration",
+			"version", version,
+			"schemaVersion", schemaVersion,
+			"result", result,
+		)
+	}()
+
+	version, err = r.getSchemaVersion()
+	if err != nil || version >= schemaVersion {
+		return
+	}
+
+	result, err = r.migrate(ctx)
+	if err != nil {
+		return
+	}
+
+	err = r.db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(schemaVersionKey), convertUint64ToBytes(schemaVersion))
+	})
+}
+
+// getSchemaVersion возвращает версию схемы базы (0 - до версионирования)
+func (r *Repo) getSchemaVersion() (uint64, error) {
+	var result uint64
+	err := r.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(schemaVersionKey))
+		if err == badger.ErrKeyNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			result = ConvertBytesToUint64(val)
+			return nil
+		})
+	})
+	return result, err
+}
+
+// migrate переписывает строковые значения в версионированные записи по всем префиксам
+func (r *Repo) migrate(ctx context.Context) (int, error) {
+	var result int
+
+	prefixes := make([]string, 0, len(legacyParsers))
+	for prefix := range legacyParsers {
+		prefixes = append(prefixes, prefix)
+	}
+	slices.Sort(prefixes)
+
+	for _, prefix := range prefixes {
+		count, err := r.migratePrefix(ctx, prefix)
+		result += count
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// migratePrefix переписывает значения одного префикса пакетами по migrationBatchSize
+func (r *Repo) migratePrefix(ctx context.Context, prefix string) (int, error) {
+	var result int
+
+	keyPrefix := []byte(prefix + ":")
+	seek := keyPrefix
+	for seek != nil {
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		default:
+		}
+
+		var (
+			next  []byte
+			count int
+		)
+		err := r.db.Update(func(txn *badger.Txn) error {
+			next = nil
+			count = 0
+			opts := badger.DefaultIteratorOptions
+			opts.Prefix = keyPrefix
+			it := txn.NewIterator(opts)
+			defer it.Close()
+			visited := 0
+			for it.Seek(seek); it.Valid(); it.Next() {
+				item := it.Item()
+				if visited == migrationBatchSize {
+					next = item.KeyCopy(nil)
+					break
+				}
+				visited++
+				val, err := item.ValueCopy(nil)
+				if err != nil {
+					return err
+				}
+				if isRecord(val) {
+					continue
+				}
+				key := item.KeyCopy(nil)
+				record, err := decodeLegacyRecord(string(key), val)
+				if err != nil {
+					// повреждённое значение не должно останавливать миграцию
+					r.log.ErrorOrDebug(err, "skip record")
+					continue
+				}
+				data, err := encodeRecord(record)
+				if err != nil {
+					return err
+				}
+				err = txn.Set(key, data)
+				if err != nil {
+					return err
+				}
+				count++
+			}
+			return nil
+		})
+		if errors.Is(err, badger.ErrConflict) {
+			// значения пакета изменились параллельно - повторяем пакет
+			continue
+		}
+		if err != nil {
+			return result, log.WrapError(err) // внешняя ошибка
+		}
+		result += count
+		seek = next
+	}
+
+	return result, nil
+}
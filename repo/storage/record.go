@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/comerc/budva43/app/dto/storage/dto"
+	"github.com/comerc/budva43/app/log"
+)
+
+const (
+	// recordMagic открывает заголовок записи; байт не встречается в UTF-8,
+	// поэтому записи не спутать со строковыми значениями до версионирования
+	recordMagic = 0xFF
+	// schemaVersion текущая версия схемы записей
+	schemaVersion = 1
+	// schemaVersionKey ключ, под которым хранится версия схемы всей базы
+	schemaVersionKey = "schemaVersion"
+)
+
+// legacyParsers разбирают строковые значения до версионирования (версия 0) по префиксу ключа;
+// счетчики (viewedMsgs, forwardedMsgs, protectedMsgs) всегда хранились в бинарном виде
+var legacyParsers = map[string]func(val string) (*dto.Record, error){
+	"copiedMsgIds":   parseLegacyChatMessages,
+	"revisionMsgIds": parseLegacyChatMessages,
+	"answerMsgId":    parseLegacyChatMessages,
+	"originMsgId":    parseLegacyChatMessages,
+	"newMsgId":       parseLegacyMessageId,
+	"tmpMsgId":       parseLegacyMessageId,
+	"forumTopicId":   parseLegacyMessageId,
+	"replyContextId": parseLegacyMessageId,
+	"albumMsgIds":    parseLegacyMessageIds,
+	"overflowMsgIds": parseLegacyMessageIds,
+	"textSnapshot":   parseLegacyText,
+}
+
+// isRecord проверяет, что значение записано в версионированном формате
+func isRecord(val []byte) bool {
+	return len(val) >= 2 && val[0] == recordMagic
+}
+
+// encodeRecord кодирует запись: заголовок (magic, версия схемы) и protobuf
+func encodeRecord(record *dto.Record) ([]byte, error) {
+	data, err := proto.Marshal(record)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return append([]byte{recordMagic, schemaVersion}, data...), nil
+}
+
+// decodeRecord декодирует запись; значения до версионирования разбираются по префиксу ключа
+func decodeRecord(key string, val []byte) (*dto.Record, error) {
+	if !isRecord(val) {
+		return decodeLegacyRecord(key, val)
+	}
+	version := val[1]
+	if version > schemaVersion {
+		return nil, log.NewError("unsupported record version",
+			"key", key,
+			"version", version,
+		)
+	}
+	record := &dto.Record{}
+	err := proto.Unmarshal(val[2:], record)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return record, nil
+}
+
+// decodeLegacyRecord разбирает строковое значение до версионирования
+func decodeLegacyRecord(key string, val []byte) (*dto.Record, error) {
+	prefix, _, _ := strings.Cut(key, ":")
+	parse, ok := legacyParsers[prefix]
+	if !ok {
+		return nil, log.NewError("unknown record prefix",
+			"key", key,
+		)
+	}
+	record, err := parse(string(val))
+	if err != nil {
+		return nil, log.NewError("invalid legacy record",
+			"key", key,
+			"val", string(val),
+		)
+	}
+	return record, nil
+}
+
+// parseLegacyChatMessages разбирает список "[forwardRuleId:]chatId:messageId" через запятую
+func parseLegacyChatMessages(val string) (*dto.Record, error) {
+	record := &dto.Record{}
+	if val == "" {
+		return record, nil
+	}
+	for _, s := range strings.Split(val, ",") {
+		a := strings.Split(s, ":")
+		chatMessage := &dto.ChatMessage{}
+		if len(a) == 3 {
+			chatMessage.ForwardRuleId = a[0]
+			a = a[1:]
+		}
+		if len(a) != 2 {
+			return nil, strconv.ErrSyntax
+		}
+		var err error
+		chatMessage.ChatId, err = strconv.ParseInt(a[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		chatMessage.MessageId, err = strconv.ParseInt(a[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		record.ChatMessages = append(record.ChatMessages, chatMessage)
+	}
+	return record, nil
+}
+
+// parseLegacyMessageId разбирает одиночный идентификатор
+func parseLegacyMessageId(val string) (*dto.Record, error) {
+	messageId, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Record{MessageId: messageId}, nil
+}
+
+// parseLegacyMessageIds разбирает список идентификаторов через запятую
+func parseLegacyMessageIds(val string) (*dto.Record, error) {
+	record := &dto.Record{}
+	if val == "" {
+		return record, nil
+	}
+	for _, s := range strings.Split(val, ",") {
+		messageId, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		record.MessageIds = append(record.MessageIds, messageId)
+	}
+	return record, nil
+}
+
+// parseLegacyText возвращает текст как есть
+func parseLegacyText(val string) (*dto.Record, error) {
+	return &dto.Record{Text: val}, nil
+}
@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/comerc/budva43/app/dto/storage/dto"
+	"github.com/comerc/budva43/app/log"
+)
+
+func newTestRepo(t *testing.T) *Repo {
+	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close() //nolint:errcheck,gosec
+	})
+	return &Repo{
+		log: log.NewLogger(),
+		db:  db,
+	}
+}
+
+func TestDecodeLegacyRecord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		key      string
+		val      string
+		expected *dto.Record
+		isError  bool
+	}{
+		{
+			name: "copied_message_ids",
+			key:  "copiedMsgIds:-1001:10",
+			val:  "Rule1:-1002:20,Rule2:-1003:30",
+			expected: &dto.Record{
+				ChatMessages: []*dto.ChatMessage{
+					{ForwardRuleId: "Rule1", ChatId: -1002, MessageId: 20},
+					{ForwardRuleId: "Rule2", ChatId: -1003, MessageId: 30},
+				},
+			},
+		},
+		{
+			name: "answer_message_id",
+			key:  "answerMsgId:-1002:20",
+			val:  "-1001:10",
+			expected: &dto.Record{
+				ChatMessages: []*dto.ChatMessage{{ChatId: -1001, MessageId: 10}},
+			},
+		},
+		{
+			name:     "new_message_id",
+			key:      "newMsgId:-1002:20",
+			val:      "21",
+			expected: &dto.Record{MessageId: 21},
+		},
+		{
+			name:     "album_message_ids",
+			key:      "albumMsgIds:-1001:10",
+			val:      "10,11,12",
+			expected: &dto.Record{MessageIds: []int64{10, 11, 12}},
+		},
+		{
+			name:     "text_snapshot",
+			key:      "textSnapshot:-1001:10",
+			val:      "text: with, separators",
+			expected: &dto.Record{Text: "text: with, separators"},
+		},
+		{
+			name:    "invalid",
+			key:     "newMsgId:-1002:20",
+			val:     "abc",
+			isError: true,
+		},
+		{
+			name:    "unknown_prefix",
+			key:     "viewedMsgs:-1002:2025-01-01",
+			val:     "1",
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			record, err := decodeRecord(test.key, []byte(test.val))
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(test.expected, record))
+		})
+	}
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRepo(t)
+
+	// в идентификаторе правила допустимы любые символы
+	record := &dto.Record{
+		ChatMessages: []*dto.ChatMessage{{ForwardRuleId: "rule:1,2", ChatId: -1002, MessageId: 20}},
+	}
+	err := r.Set("copiedMsgIds:-1001:10", record, 0)
+	require.NoError(t, err)
+
+	result, err := r.Get("copiedMsgIds:-1001:10")
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(record, result))
+
+	result, err = r.GetSet("copiedMsgIds:-1001:10", func(record *dto.Record) (*dto.Record, error) {
+		record.ChatMessages = append(record.ChatMessages, &dto.ChatMessage{ChatId: -1003, MessageId: 30})
+		return record, nil
+	}, 0)
+	require.NoError(t, err)
+	assert.Len(t, result.ChatMessages, 2)
+
+	_, err = r.Increment("viewedMsgs:-1002:2025-01-01")
+	require.NoError(t, err)
+	counter, err := r.GetCounter("viewedMsgs:-1002:2025-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), counter)
+}
+
+func TestMigration(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRepo(t)
+
+	legacy := map[string]string{
+		"copiedMsgIds:-1001:10": "Rule1:-1002:20",
+		"newMsgId:-1002:20":     "21",
+		"textSnapshot:-1001:10": "text",
+	}
+	err := r.db.Update(func(txn *badger.Txn) error {
+		for key, val := range legacy {
+			err := txn.Set([]byte(key), []byte(val))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	_, err = r.Increment("viewedMsgs:-1002:2025-01-01")
+	require.NoError(t, err)
+
+	r.runMigration(context.Background())
+
+	version, err := r.getSchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(schemaVersion), version)
+
+	err = r.db.View(func(txn *badger.Txn) error {
+		for key := range legacy {
+			item, err := txn.Get([]byte(key))
+			if err != nil {
+				return err
+			}
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			assert.True(t, isRecord(val), key)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	result, err := r.Get("newMsgId:-1002:20")
+	require.NoError(t, err)
+	assert.Equal(t, int64(21), result.MessageId)
+
+	// счетчики не затрагиваются миграцией
+	counter, err := r.GetCounter("viewedMsgs:-1002:2025-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), counter)
+}
@@ -3,11 +3,15 @@ package storage
 import (
 	"context"
 	"encoding/binary"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/dgraph-io/badger/v4"
 
 	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/dto/storage/dto"
 	"github.com/comerc/budva43/app/log"
 )
 
@@ -15,7 +19,8 @@ import (
 type Repo struct {
 	log *log.Logger
 	//
-	db *badger.DB
+	db       *badger.DB
+	backupMu sync.Mutex // копии по расписанию и по запросу не пересекаются
 }
 
 // New создает новый экземпляр репозитория для BadgerDB
@@ -31,15 +36,17 @@ func New() *Repo {
 func (r *Repo) StartContext(ctx context.Context) error {
 	var err error
 
-	opts := badger.DefaultOptions(config.Storage.DatabaseDirectory)
-	opts.Logger = NewLogger()
-	db, err := badger.Open(opts)
+	db, err := r.openDB()
 	if err != nil {
 		return err
 	}
 	r.db = db
 
 	go r.runGarbageCollection(ctx)
+	go r.runMigration(ctx)
+	if config.Storage.BackupEnabled && config.Storage.BackupFrequency > 0 {
+		go runBackup(ctx, r.Backup)
+	}
 
 	return nil
 }
@@ -108,11 +115,47 @@ func (r *Repo) Increment(key string) (uint64, error) {
 	return result, nil
 }
 
-// GetSet получает значение по ключу и устанавливает новое значение
-func (r *Repo) GetSet(key string, fn func(val string) (string, error)) (string, error) {
+// GetCounter получает значение счетчика по ключу; версии, которые Increment
+// ещё не успел слить в одну, суммируются при чтении так же, как при слиянии
+func (r *Repo) GetCounter(key string) (uint64, error) {
+	var result uint64
+	err := r.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.AllVersions = true
+		it := txn.NewKeyIterator([]byte(key), opts)
+		defer it.Close()
+		isFound := false
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			if item.IsDeletedOrExpired() {
+				break
+			}
+			isFound = true
+			err := item.Value(func(val []byte) error {
+				result += ConvertBytesToUint64(val)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if item.DiscardEarlierVersions() {
+				break
+			}
+		}
+		if !isFound {
+			return badger.ErrKeyNotFound
+		}
+		return nil
+	})
+	return result, err
+}
+
+// GetSet получает запись по ключу и устанавливает новую запись;
+// при отсутствии ключа fn получает пустую запись; ttl 0 - хранить бессрочно
+func (r *Repo) GetSet(key string, fn func(record *dto.Record) (*dto.Record, error), ttl time.Duration) (*dto.Record, error) {
 	var (
-		val string
-		err error
+		record *dto.Record
+		err    error
 	)
 	err = r.db.Update(func(txn *badger.Txn) error {
 		var item *badger.Item
@@ -120,30 +163,40 @@ func (r *Repo) GetSet(key string, fn func(val string) (string, error)) (string,
 		if err != nil && err != badger.ErrKeyNotFound {
 			return err
 		}
-		var valBytes []byte
+		record = &dto.Record{}
 		if err != badger.ErrKeyNotFound {
+			var valBytes []byte
 			valBytes, err = item.ValueCopy(nil)
 			if err != nil {
 				return err
 			}
+			record, err = decodeRecord(key, valBytes)
+			if err != nil {
+				return err
+			}
+		}
+		record, err = fn(record)
+		if err != nil {
+			return err
 		}
-		val, err = fn(string(valBytes))
+		var data []byte
+		data, err = encodeRecord(record)
 		if err != nil {
 			return err
 		}
-		return txn.Set([]byte(key), []byte(val))
+		return txn.SetEntry(newEntry(key, data, ttl))
 	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return val, nil
+	return record, nil
 }
 
-// Get получает значение по ключу
-func (r *Repo) Get(key string) (string, error) {
+// Get получает запись по ключу
+func (r *Repo) Get(key string) (*dto.Record, error) {
 	var (
-		val string
-		err error
+		record *dto.Record
+		err    error
 	)
 	err = r.db.View(func(txn *badger.Txn) error {
 		var item *badger.Item
@@ -156,20 +209,164 @@ func (r *Repo) Get(key string) (string, error) {
 		if err != nil {
 			return err
 		}
-		val = string(valBytes)
+		record, err = decodeRecord(key, valBytes)
+		return err
+	})
+	return record, err
+}
+
+// Set устанавливает запись по ключу; ttl 0 - хранить бессрочно
+func (r *Repo) Set(key string, record *dto.Record, ttl time.Duration) error {
+	data, err := encodeRecord(record)
+	if err != nil {
+		return err
+	}
+	err = r.db.Update(func(txn *badger.Txn) error {
+		return txn.SetEntry(newEntry(key, data, ttl))
+	})
+	return err
+}
+
+// newEntry создает запись для BadgerDB со сроком хранения
+func newEntry(key string, data []byte, ttl time.Duration) *badger.Entry {
+	entry := badger.NewEntry([]byte(key), data)
+	if ttl > 0 {
+		entry = entry.WithTTL(ttl)
+	}
+	return entry
+}
+
+// Scan обходит записи с указанными префиксами в одном снимке базы, в порядке префиксов
+func (r *Repo) Scan(fn func(key string, record *dto.Record), prefixes ...string) error {
+	err := r.db.View(func(txn *badger.Txn) error {
+		for _, prefix := range prefixes {
+			opts := badger.DefaultIteratorOptions
+			opts.Prefix = []byte(prefix + ":")
+			it := txn.NewIterator(opts)
+			for it.Rewind(); it.Valid(); it.Next() {
+				item := it.Item()
+				key := string(item.Key())
+				val, err := item.ValueCopy(nil)
+				if err != nil {
+					it.Close()
+					return err
+				}
+				record, err := decodeRecord(key, val)
+				if err != nil {
+					r.log.ErrorOrDebug(err, "skip record")
+					continue
+				}
+				fn(key, record)
+			}
+			it.Close()
+		}
 		return nil
 	})
-	return val, err
+	return err
 }
 
-// Set устанавливает значение по ключу
-func (r *Repo) Set(key, val string) error {
-	err := r.db.Update(func(txn *badger.Txn) error {
-		return txn.Set([]byte(key), []byte(val))
+// ScanCounters обходит счетчики с ключами в диапазоне [from, to) в порядке ключей;
+// версии, которые Increment ещё не успел слить, суммируются как в GetCounter
+func (r *Repo) ScanCounters(fn func(key string, value uint64), from, to string) error {
+	err := r.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.AllVersions = true
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		var (
+			key    string
+			value  uint64
+			isDone bool // старые версии текущего ключа уже учтены слиянием
+		)
+		flush := func() {
+			if key != "" && value > 0 {
+				fn(key, value)
+			}
+		}
+		for it.Seek([]byte(from)); it.Valid(); it.Next() {
+			item := it.Item()
+			itemKey := string(item.Key())
+			if itemKey >= to {
+				break
+			}
+			if itemKey != key {
+				flush()
+				key, value, isDone = itemKey, 0, false
+			}
+			if isDone {
+				continue
+			}
+			if item.IsDeletedOrExpired() {
+				isDone = true
+				continue
+			}
+			err := item.Value(func(val []byte) error {
+				value += ConvertBytesToUint64(val)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			isDone = item.DiscardEarlierVersions()
+		}
+		flush()
+		return nil
 	})
 	return err
 }
 
+// DeleteBatch удаляет значения по ключам пакетно
+func (r *Repo) DeleteBatch(keys []string) error {
+	wb := r.db.NewWriteBatch()
+	defer wb.Cancel()
+	for _, key := range keys {
+		err := wb.Delete([]byte(key))
+		if err != nil {
+			return log.WrapError(err) // внешняя ошибка
+		}
+	}
+	err := wb.Flush()
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	return nil
+}
+
+// GetUsage возвращает количество ключей и занимаемое место по префиксам ключей
+func (r *Repo) GetUsage() ([]*dto.Usage, error) {
+	usageByPrefix := make(map[string]*dto.Usage)
+	err := r.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			prefix, _, _ := strings.Cut(string(item.Key()), ":")
+			usage, ok := usageByPrefix[prefix]
+			if !ok {
+				usage = &dto.Usage{Prefix: prefix}
+				usageByPrefix[prefix] = usage
+			}
+			usage.Keys++
+			usage.Size += item.EstimatedSize()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+
+	result := make([]*dto.Usage, 0, len(usageByPrefix))
+	for _, usage := range usageByPrefix {
+		result = append(result, usage)
+	}
+	slices.SortFunc(result, func(a, b *dto.Usage) int {
+		return strings.Compare(a.Prefix, b.Prefix)
+	})
+	return result, nil
+}
+
 // Delete удаляет значение по ключу
 func (r *Repo) Delete(key string) error {
 	err := r.db.Update(func(txn *badger.Txn) error {
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/comerc/budva43/app/dto/storage/dto"
+)
+
+func TestSetTTL(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRepo(t)
+
+	err := r.Set("newMsgId:-1002:20", &dto.Record{MessageId: 21}, time.Hour)
+	require.NoError(t, err)
+	err = r.Set("newMsgId:-1002:30", &dto.Record{MessageId: 31}, 0)
+	require.NoError(t, err)
+
+	err = r.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte("newMsgId:-1002:20"))
+		require.NoError(t, err)
+		assert.NotZero(t, item.ExpiresAt())
+		item, err = txn.Get([]byte("newMsgId:-1002:30"))
+		require.NoError(t, err)
+		assert.Zero(t, item.ExpiresAt())
+		return nil
+	})
+	require.NoError(t, err)
+}
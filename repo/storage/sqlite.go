@@ -0,0 +1,639 @@
+package storage
+
+import (
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	_ "modernc.org/sqlite" // драйвер database/sql
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/dto/storage/dto"
+	"github.com/comerc/budva43/app/log"
+)
+
+// sqliteFileName имя файла базы в config.Storage.SQLiteDirectory
+const sqliteFileName = "storage.db"
+
+// sqliteBackupExt расширение резервной копии SQLite, отличное от копии BadgerDB (backupExt)
+const sqliteBackupExt = ".db"
+
+// sqliteSchema ключи и значения те же, что в BadgerDB; data - запись в JSON
+// для запросов по истории пересылок (NULL для счетчиков)
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS records (
+	key        TEXT PRIMARY KEY,
+	prefix     TEXT NOT NULL,
+	value      BLOB NOT NULL,
+	data       TEXT,
+	expires_at INTEGER NOT NULL DEFAULT 0
+) WITHOUT ROWID;
+CREATE INDEX IF NOT EXISTS records_prefix ON records (prefix, key);
+CREATE INDEX IF NOT EXISTS records_expires_at ON records (expires_at) WHERE expires_at > 0;
+`
+
+// sqliteIsLive условие для неистёкших значений (expires_at 0 - бессрочно)
+const sqliteIsLive = "(expires_at = 0 OR expires_at > ?)"
+
+// sqliteExecer общее для *sql.DB и *sql.Tx
+type sqliteExecer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// SQLiteRepo реализация хранилища на SQLite; база хранится без шифрования
+type SQLiteRepo struct {
+	log *log.Logger
+	//
+	db       *sql.DB
+	backupMu sync.Mutex // копии по расписанию и по запросу не пересекаются
+}
+
+// NewSQLite создает новый экземпляр репозитория для SQLite
+func NewSQLite() *SQLiteRepo {
+	return &SQLiteRepo{
+		log: log.NewLogger(),
+		//
+		db: nil,
+	}
+}
+
+// StartContext открывает базу данных
+func (r *SQLiteRepo) StartContext(ctx context.Context) error {
+	key, _, err := getEncryptionKeys()
+	if err != nil {
+		return err
+	}
+	if key != nil {
+		return log.NewError("sqlite backend does not support encryption",
+			"hint", "use storage.backend: badger or remove the storage encryption key",
+		)
+	}
+
+	err = r.open(filepath.Join(config.Storage.SQLiteDirectory, sqliteFileName))
+	if err != nil {
+		return err
+	}
+
+	go r.runExpiration(ctx)
+	if config.Storage.BackupEnabled && config.Storage.BackupFrequency > 0 {
+		go runBackup(ctx, r.Backup)
+	}
+
+	return nil
+}
+
+// open открывает базу данных по пути и создает схему
+func (r *SQLiteRepo) open(path string) error {
+	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	// SQLite допускает одного писателя: одно соединение исключает SQLITE_BUSY
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec(sqliteSchema)
+	if err != nil {
+		return errors.Join(log.WrapError(err), db.Close()) // внешняя ошибка
+	}
+	r.db = db
+	return nil
+}
+
+// Close закрывает соединение с базой данных
+func (r *SQLiteRepo) Close() error {
+	if r.db != nil {
+		err := r.db.Close()
+		if err != nil {
+			return log.WrapError(err) // внешняя ошибка
+		}
+	}
+	return nil
+}
+
+// runExpiration периодически удаляет истёкшие значения
+func (r *SQLiteRepo) runExpiration(ctx context.Context) {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := r.db.Exec("DELETE FROM records WHERE expires_at > 0 AND expires_at <= ?", time.Now().Unix())
+			r.log.ErrorOrDebug(err, "")
+		}
+	}
+}
+
+// Increment увеличивает значение по ключу на 1
+func (r *SQLiteRepo) Increment(key string) (uint64, error) {
+	var result uint64
+	err := r.update(func(tx *sql.Tx) error {
+		val, err := r.getValue(tx, key)
+		if err != nil && !errors.Is(err, ErrKeyNotFound) {
+			return err
+		}
+		result = 1
+		if err == nil {
+			result += ConvertBytesToUint64(val)
+		}
+		return r.put(tx, key, convertUint64ToBytes(result), sql.NullString{}, 0)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// GetCounter получает значение счетчика по ключу
+func (r *SQLiteRepo) GetCounter(key string) (uint64, error) {
+	val, err := r.getValue(r.db, key)
+	if err != nil {
+		return 0, err
+	}
+	return ConvertBytesToUint64(val), nil
+}
+
+// GetSet получает запись по ключу и устанавливает новую запись;
+// при отсутствии ключа fn получает пустую запись; ttl 0 - хранить бессрочно
+func (r *SQLiteRepo) GetSet(key string, fn func(record *dto.Record) (*dto.Record, error), ttl time.Duration) (*dto.Record, error) {
+	var record *dto.Record
+	err := r.update(func(tx *sql.Tx) error {
+		val, err := r.getValue(tx, key)
+		if err != nil && !errors.Is(err, ErrKeyNotFound) {
+			return err
+		}
+		record = &dto.Record{}
+		if err == nil {
+			record, err = decodeRecord(key, val)
+			if err != nil {
+				return err
+			}
+		}
+		record, err = fn(record)
+		if err != nil {
+			return err
+		}
+		return r.putRecord(tx, key, record, getExpiresAt(ttl))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Get получает запись по ключу
+func (r *SQLiteRepo) Get(key string) (*dto.Record, error) {
+	val, err := r.getValue(r.db, key)
+	if err != nil {
+		return nil, err
+	}
+	return decodeRecord(key, val)
+}
+
+// Set устанавливает запись по ключу; ttl 0 - хранить бессрочно
+func (r *SQLiteRepo) Set(key string, record *dto.Record, ttl time.Duration) error {
+	return r.putRecord(r.db, key, record, getExpiresAt(ttl))
+}
+
+// Delete удаляет значение по ключу
+func (r *SQLiteRepo) Delete(key string) error {
+	_, err := r.db.Exec("DELETE FROM records WHERE key = ?", key)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	return nil
+}
+
+// DeleteBatch удаляет значения по ключам в одной транзакции
+func (r *SQLiteRepo) DeleteBatch(keys []string) error {
+	return r.update(func(tx *sql.Tx) error {
+		for _, key := range keys {
+			_, err := tx.Exec("DELETE FROM records WHERE key = ?", key)
+			if err != nil {
+				return log.WrapError(err) // внешняя ошибка
+			}
+		}
+		return nil
+	})
+}
+
+// Scan обходит записи с указанными префиксами в одной транзакции, в порядке префиксов
+func (r *SQLiteRepo) Scan(fn func(key string, record *dto.Record), prefixes ...string) error {
+	return r.update(func(tx *sql.Tx) error {
+		now := time.Now().Unix()
+		for _, prefix := range prefixes {
+			rows, err := tx.Query("SELECT key, value FROM records WHERE prefix = ? AND "+sqliteIsLive+" ORDER BY key", prefix, now)
+			if err != nil {
+				return log.WrapError(err) // внешняя ошибка
+			}
+			for rows.Next() {
+				var (
+					key string
+					val []byte
+				)
+				err = rows.Scan(&key, &val)
+				if err != nil {
+					return errors.Join(log.WrapError(err), rows.Close()) // внешняя ошибка
+				}
+				record, err := decodeRecord(key, val)
+				if err != nil {
+					r.log.ErrorOrDebug(err, "skip record")
+					continue
+				}
+				fn(key, record)
+			}
+			err = errors.Join(rows.Err(), rows.Close())
+			if err != nil {
+				return log.WrapError(err) // внешняя ошибка
+			}
+		}
+		return nil
+	})
+}
+
+// ScanCounters обходит счетчики с ключами в диапазоне [from, to) в порядке ключей
+func (r *SQLiteRepo) ScanCounters(fn func(key string, value uint64), from, to string) error {
+	rows, err := r.db.Query("SELECT key, value FROM records WHERE key >= ? AND key < ? AND data IS NULL AND "+sqliteIsLive+" ORDER BY key", from, to, time.Now().Unix())
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	defer rows.Close() //nolint:errcheck
+
+	for rows.Next() {
+		var (
+			key string
+			val []byte
+		)
+		err = rows.Scan(&key, &val)
+		if err != nil {
+			return log.WrapError(err) // внешняя ошибка
+		}
+		fn(key, ConvertBytesToUint64(val))
+	}
+	err = rows.Err()
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	return nil
+}
+
+// GetUsage возвращает количество ключей и занимаемое место по префиксам ключей
+func (r *SQLiteRepo) GetUsage() ([]*dto.Usage, error) {
+	rows, err := r.db.Query("SELECT prefix, COUNT(*), SUM(length(key) + length(value) + IFNULL(length(data), 0)) FROM records WHERE "+sqliteIsLive+" GROUP BY prefix ORDER BY prefix", time.Now().Unix())
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	defer rows.Close() //nolint:errcheck
+
+	result := []*dto.Usage{}
+	for rows.Next() {
+		usage := &dto.Usage{}
+		err = rows.Scan(&usage.Prefix, &usage.Keys, &usage.Size)
+		if err != nil {
+			return nil, log.WrapError(err) // внешняя ошибка
+		}
+		result = append(result, usage)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return result, nil
+}
+
+// Backup создаёт резервную копию базы через VACUUM INTO, не останавливая работу,
+// и удаляет старые копии сверх config.Storage.BackupCount; копия не шифруется, как и база
+func (r *SQLiteRepo) Backup() (*dto.Backup, error) {
+	var (
+		err    error
+		result *dto.Backup
+	)
+	defer func() {
+		r.log.ErrorOrInfo(err, "backup",
+			"result", result,
+		)
+	}()
+
+	r.backupMu.Lock()
+	defer r.backupMu.Unlock()
+
+	name := backupPrefix + time.Now().UTC().Format(backupTimeLayout) + sqliteBackupExt
+	if config.Storage.BackupCompress {
+		name += backupGzipExt
+	}
+	path := filepath.Join(config.Storage.BackupDirectory, name)
+
+	err = r.writeBackup(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var info os.FileInfo
+	info, err = os.Stat(path)
+	if err != nil {
+		err = log.WrapError(err) // внешняя ошибка
+		return nil, err
+	}
+	result = newBackup(info)
+
+	err = pruneBackups(sqliteBackupExt)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// writeBackup записывает копию базы во временный файл и переименовывает его,
+// чтобы незавершённая копия не попала в список
+func (r *SQLiteRepo) writeBackup(path string) error {
+	dbPath := strings.TrimSuffix(path, backupGzipExt) + ".tmp"
+	defer os.Remove(dbPath) //nolint:errcheck
+	// VACUUM INTO не перезаписывает существующий файл
+	err := os.Remove(dbPath)
+	if err != nil && !os.IsNotExist(err) {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	_, err = r.db.Exec("VACUUM INTO ?", dbPath)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	if !strings.HasSuffix(path, backupGzipExt) {
+		err = os.Rename(dbPath, path)
+		if err != nil {
+			return log.WrapError(err) // внешняя ошибка
+		}
+		return nil
+	}
+
+	src, err := os.Open(dbPath)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	defer src.Close() //nolint:errcheck
+
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	defer os.Remove(tmpPath) //nolint:errcheck
+	defer file.Close()       //nolint:errcheck
+
+	zw := gzip.NewWriter(file)
+	_, err = io.Copy(zw, src)
+	if err == nil {
+		err = zw.Close()
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	err = errors.Join(err, file.Close())
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	return nil
+}
+
+// GetBackups возвращает резервные копии, начиная с последней
+func (r *SQLiteRepo) GetBackups() ([]*dto.Backup, error) {
+	return getBackups(sqliteBackupExt)
+}
+
+// Restore восстанавливает базу данных из резервной копии; вызывается до StartContext:
+// копия проверяется до подмены, а текущая база (вместе с журналом WAL)
+// переносится рядом с суффиксом времени восстановления
+func (r *SQLiteRepo) Restore(path string) error {
+	var err error
+	defer func() {
+		r.log.ErrorOrInfo(err, "restore",
+			"path", path,
+		)
+	}()
+
+	if r.db != nil {
+		err = log.NewError("restore requires closed database")
+		return err
+	}
+	if !isBackupName(filepath.Base(path), sqliteBackupExt) {
+		err = log.NewError("not a sqlite backup",
+			"ext", sqliteBackupExt,
+		)
+		return err
+	}
+
+	dir := config.Storage.SQLiteDirectory
+	err = os.MkdirAll(dir, 0o755)
+	if err != nil {
+		err = log.WrapError(err) // внешняя ошибка
+		return err
+	}
+	tmpPath := filepath.Join(dir, sqliteFileName+".restore")
+	defer os.Remove(tmpPath) //nolint:errcheck
+	err = copyBackupFile(path, tmpPath)
+	if err != nil {
+		return err
+	}
+	err = checkSQLiteFile(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	dbPath := filepath.Join(dir, sqliteFileName)
+	movedPath := dbPath + "-" + time.Now().UTC().Format(backupTimeLayout)
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		err = os.Rename(dbPath+suffix, movedPath+suffix)
+		if err != nil && !os.IsNotExist(err) {
+			err = log.WrapError(err) // внешняя ошибка
+			return err
+		}
+	}
+	err = os.Rename(tmpPath, dbPath)
+	if err != nil {
+		err = log.WrapError(err) // внешняя ошибка
+		return err
+	}
+	return nil
+}
+
+// copyBackupFile распаковывает (при необходимости) копию в файл базы
+func copyBackupFile(path, dstPath string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	defer file.Close() //nolint:errcheck
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, backupGzipExt) {
+		zr, err := gzip.NewReader(file)
+		if err != nil {
+			return log.WrapError(err) // внешняя ошибка
+		}
+		defer zr.Close() //nolint:errcheck
+		reader = zr
+	}
+
+	dst, err := os.Create(dstPath)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	defer dst.Close() //nolint:errcheck
+	_, err = io.Copy(dst, reader)
+	if err == nil {
+		err = dst.Sync()
+	}
+	err = errors.Join(err, dst.Close())
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	return nil
+}
+
+// checkSQLiteFile проверяет целостность базы и наличие таблицы записей
+func checkSQLiteFile(path string) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	defer db.Close() //nolint:errcheck
+
+	var result string
+	err = db.QueryRow("PRAGMA integrity_check").Scan(&result)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	if result != "ok" {
+		return log.NewError("sqlite backup is corrupted",
+			"result", result,
+		)
+	}
+	var count int64 // копия другой базы SQLite без таблицы записей отвергается
+	err = db.QueryRow("SELECT count(*) FROM records").Scan(&count)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	return nil
+}
+
+// Export обходит все неистёкшие значения как есть, вместе со сроком хранения
+func (r *SQLiteRepo) Export(fn func(entry *dto.Entry) error) error {
+	rows, err := r.db.Query("SELECT key, value, expires_at FROM records WHERE "+sqliteIsLive+" ORDER BY key", time.Now().Unix())
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	defer rows.Close() //nolint:errcheck
+
+	for rows.Next() {
+		entry := &dto.Entry{}
+		err = rows.Scan(&entry.Key, &entry.Value, &entry.ExpiresAt)
+		if err != nil {
+			return log.WrapError(err) // внешняя ошибка
+		}
+		err = fn(entry)
+		if err != nil {
+			return err
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	return nil
+}
+
+// Import записывает значения, сохраняя срок хранения; записи приводятся
+// к текущей версии схемы, остальные значения (счетчики) пишутся как есть
+func (r *SQLiteRepo) Import(entries []*dto.Entry) error {
+	return r.update(func(tx *sql.Tx) error {
+		for _, entry := range entries {
+			record, err := decodeRecord(entry.Key, entry.Value)
+			if err != nil {
+				err = r.put(tx, entry.Key, entry.Value, sql.NullString{}, entry.ExpiresAt)
+			} else {
+				err = r.putRecord(tx, entry.Key, record, entry.ExpiresAt)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// update выполняет fn в транзакции
+func (r *SQLiteRepo) update(fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	err = fn(tx)
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	err = tx.Commit()
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	return nil
+}
+
+// getValue получает неистёкшее значение по ключу
+func (r *SQLiteRepo) getValue(db sqliteExecer, key string) ([]byte, error) {
+	var val []byte
+	err := db.QueryRow("SELECT value FROM records WHERE key = ? AND "+sqliteIsLive, key, time.Now().Unix()).Scan(&val)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return val, nil
+}
+
+// putRecord кодирует запись и сохраняет её вместе с JSON для запросов
+func (r *SQLiteRepo) putRecord(db sqliteExecer, key string, record *dto.Record, expiresAt uint64) error {
+	val, err := encodeRecord(record)
+	if err != nil {
+		return err
+	}
+	data, err := protojson.Marshal(record)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	return r.put(db, key, val, sql.NullString{String: string(data), Valid: true}, expiresAt)
+}
+
+// put сохраняет значение по ключу
+func (r *SQLiteRepo) put(db sqliteExecer, key string, val []byte, data sql.NullString, expiresAt uint64) error {
+	prefix, _, _ := strings.Cut(key, ":")
+	_, err := db.Exec(`INSERT INTO records (key, prefix, value, data, expires_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value, data = excluded.data, expires_at = excluded.expires_at`,
+		key, prefix, val, data, expiresAt)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	return nil
+}
+
+// getExpiresAt переводит срок хранения в unix-время истечения (0 - бессрочно), как в BadgerDB
+func getExpiresAt(ttl time.Duration) uint64 {
+	if ttl <= 0 {
+		return 0
+	}
+	return uint64(time.Now().Add(ttl).Unix()) //nolint:gosec
+}
@@ -1,8 +1,12 @@
 package telegram
 
 import (
-	"github.com/comerc/budva43/app/log"
+	"time"
+
 	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/metrics"
 )
 
 // clientAdapter - tdlibClient methods (для моков в юнит-тестах)
@@ -13,12 +17,22 @@ type clientAdapter interface {
 	SendMessageAlbum(*client.SendMessageAlbumRequest) (*client.Messages, error)
 	EditMessageText(*client.EditMessageTextRequest) (*client.Message, error)
 	EditMessageCaption(*client.EditMessageCaptionRequest) (*client.Message, error)
+	EditMessageLiveLocation(*client.EditMessageLiveLocationRequest) (*client.Message, error)
+	StopPoll(*client.StopPollRequest) (*client.Ok, error)
 	DeleteMessages(*client.DeleteMessagesRequest) (*client.Ok, error)
 	GetMessages(*client.GetMessagesRequest) (*client.Messages, error)
+	GetMessageProperties(*client.GetMessagePropertiesRequest) (*client.MessageProperties, error)
+	PinChatMessage(*client.PinChatMessageRequest) (*client.Ok, error)
+	UnpinChatMessage(*client.UnpinChatMessageRequest) (*client.Ok, error)
 
 	// Forward operations
 	ForwardMessages(*client.ForwardMessagesRequest) (*client.Messages, error)
 
+	// File operations
+	GetRemoteFile(*client.GetRemoteFileRequest) (*client.File, error)
+	DownloadFile(*client.DownloadFileRequest) (*client.File, error)
+	DeleteFile(*client.DeleteFileRequest) (*client.Ok, error)
+
 	// Link operations
 	GetMessageLink(*client.GetMessageLinkRequest) (*client.MessageLink, error)
 	GetMessageLinkInfo(*client.GetMessageLinkInfoRequest) (*client.MessageLinkInfo, error)
@@ -27,6 +41,7 @@ type clientAdapter interface {
 	LoadChats(*client.LoadChatsRequest) (*client.Ok, error)
 	GetChatHistory(*client.GetChatHistoryRequest) (*client.Messages, error)
 	GetChat(*client.GetChatRequest) (*client.Chat, error)
+	CreateForumTopic(*client.CreateForumTopicRequest) (*client.ForumTopicInfo, error)
 	// GetChats(*client.GetChatsRequest) (*client.Chats, error)
 	// GetChatMessageCount(*client.GetChatMessageCountRequest) (*client.Count, error)
 
@@ -43,7 +58,9 @@ type clientAdapter interface {
 
 // GetMessage выводит информацию о сообщении
 func (r *Repo) GetMessage(req *client.GetMessageRequest) (*client.Message, error) {
+	start := time.Now()
 	msg, err := r.getClient().GetMessage(req)
+	metrics.ObserveTdlibCall("GetMessage", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
@@ -52,7 +69,9 @@ func (r *Repo) GetMessage(req *client.GetMessageRequest) (*client.Message, error
 
 // SendMessage отправляет сообщение
 func (r *Repo) SendMessage(req *client.SendMessageRequest) (*client.Message, error) {
+	start := time.Now()
 	msg, err := r.getClient().SendMessage(req)
+	metrics.ObserveTdlibCall("SendMessage", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
@@ -61,7 +80,9 @@ func (r *Repo) SendMessage(req *client.SendMessageRequest) (*client.Message, err
 
 // SendMessageAlbum отправляет альбом сообщений
 func (r *Repo) SendMessageAlbum(req *client.SendMessageAlbumRequest) (*client.Messages, error) {
+	start := time.Now()
 	messages, err := r.getClient().SendMessageAlbum(req)
+	metrics.ObserveTdlibCall("SendMessageAlbum", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
@@ -70,7 +91,9 @@ func (r *Repo) SendMessageAlbum(req *client.SendMessageAlbumRequest) (*client.Me
 
 // EditMessageText редактирует текст сообщения
 func (r *Repo) EditMessageText(req *client.EditMessageTextRequest) (*client.Message, error) {
+	start := time.Now()
 	msg, err := r.getClient().EditMessageText(req)
+	metrics.ObserveTdlibCall("EditMessageText", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
@@ -79,16 +102,42 @@ func (r *Repo) EditMessageText(req *client.EditMessageTextRequest) (*client.Mess
 
 // EditMessageCaption редактирует подпись сообщения
 func (r *Repo) EditMessageCaption(req *client.EditMessageCaptionRequest) (*client.Message, error) {
+	start := time.Now()
 	msg, err := r.getClient().EditMessageCaption(req)
+	metrics.ObserveTdlibCall("EditMessageCaption", start, err)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return msg, nil
+}
+
+// EditMessageLiveLocation редактирует транслируемую геопозицию
+func (r *Repo) EditMessageLiveLocation(req *client.EditMessageLiveLocationRequest) (*client.Message, error) {
+	start := time.Now()
+	msg, err := r.getClient().EditMessageLiveLocation(req)
+	metrics.ObserveTdlibCall("EditMessageLiveLocation", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
 	return msg, nil
 }
 
+// StopPoll останавливает опрос
+func (r *Repo) StopPoll(req *client.StopPollRequest) (*client.Ok, error) {
+	start := time.Now()
+	ok, err := r.getClient().StopPoll(req)
+	metrics.ObserveTdlibCall("StopPoll", start, err)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return ok, nil
+}
+
 // DeleteMessages удаляет сообщения
 func (r *Repo) DeleteMessages(req *client.DeleteMessagesRequest) (*client.Ok, error) {
+	start := time.Now()
 	ok, err := r.getClient().DeleteMessages(req)
+	metrics.ObserveTdlibCall("DeleteMessages", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
@@ -96,16 +145,53 @@ func (r *Repo) DeleteMessages(req *client.DeleteMessagesRequest) (*client.Ok, er
 }
 
 func (r *Repo) GetMessages(req *client.GetMessagesRequest) (*client.Messages, error) {
+	start := time.Now()
 	messages, err := r.getClient().GetMessages(req)
+	metrics.ObserveTdlibCall("GetMessages", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
 	return messages, nil
 }
 
+// GetRemoteFile получает информацию о файле по его удалённому идентификатору
+func (r *Repo) GetRemoteFile(req *client.GetRemoteFileRequest) (*client.File, error) {
+	start := time.Now()
+	file, err := r.getClient().GetRemoteFile(req)
+	metrics.ObserveTdlibCall("GetRemoteFile", start, err)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return file, nil
+}
+
+// DownloadFile скачивает файл в кеш TDLib
+func (r *Repo) DownloadFile(req *client.DownloadFileRequest) (*client.File, error) {
+	start := time.Now()
+	file, err := r.getClient().DownloadFile(req)
+	metrics.ObserveTdlibCall("DownloadFile", start, err)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return file, nil
+}
+
+// DeleteFile удаляет файл из кеша TDLib
+func (r *Repo) DeleteFile(req *client.DeleteFileRequest) (*client.Ok, error) {
+	start := time.Now()
+	ok, err := r.getClient().DeleteFile(req)
+	metrics.ObserveTdlibCall("DeleteFile", start, err)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return ok, nil
+}
+
 // ForwardMessages пересылает сообщения
 func (r *Repo) ForwardMessages(req *client.ForwardMessagesRequest) (*client.Messages, error) {
+	start := time.Now()
 	messages, err := r.getClient().ForwardMessages(req)
+	metrics.ObserveTdlibCall("ForwardMessages", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
@@ -114,7 +200,9 @@ func (r *Repo) ForwardMessages(req *client.ForwardMessagesRequest) (*client.Mess
 
 // GetMessageLink выводит ссылку на сообщение
 func (r *Repo) GetMessageLink(req *client.GetMessageLinkRequest) (*client.MessageLink, error) {
+	start := time.Now()
 	link, err := r.getClient().GetMessageLink(req)
+	metrics.ObserveTdlibCall("GetMessageLink", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
@@ -123,7 +211,9 @@ func (r *Repo) GetMessageLink(req *client.GetMessageLinkRequest) (*client.Messag
 
 // GetMessageLinkInfo выводит информацию о ссылке на сообщение
 func (r *Repo) GetMessageLinkInfo(req *client.GetMessageLinkInfoRequest) (*client.MessageLinkInfo, error) {
+	start := time.Now()
 	info, err := r.getClient().GetMessageLinkInfo(req)
+	metrics.ObserveTdlibCall("GetMessageLinkInfo", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
@@ -136,7 +226,9 @@ func (r *Repo) LoadChats(req *client.LoadChatsRequest) (*client.Ok, error) {
 	if r.client == nil {
 		return nil, log.NewError("client is not initialized")
 	}
+	start := time.Now()
 	ok, err := r.client.LoadChats(req)
+	metrics.ObserveTdlibCall("LoadChats", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
@@ -149,7 +241,9 @@ func (r *Repo) GetChatHistory(req *client.GetChatHistoryRequest) (*client.Messag
 	if r.client == nil {
 		return nil, log.NewError("client is not initialized")
 	}
+	start := time.Now()
 	messages, err := r.client.GetChatHistory(req)
+	metrics.ObserveTdlibCall("GetChatHistory", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
@@ -158,13 +252,59 @@ func (r *Repo) GetChatHistory(req *client.GetChatHistoryRequest) (*client.Messag
 
 // GetChat выводит информацию о чате
 func (r *Repo) GetChat(req *client.GetChatRequest) (*client.Chat, error) {
+	start := time.Now()
 	chat, err := r.getClient().GetChat(req)
+	metrics.ObserveTdlibCall("GetChat", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
 	return chat, nil
 }
 
+// CreateForumTopic создаёт тему форума
+func (r *Repo) CreateForumTopic(req *client.CreateForumTopicRequest) (*client.ForumTopicInfo, error) {
+	start := time.Now()
+	forumTopicInfo, err := r.getClient().CreateForumTopic(req)
+	metrics.ObserveTdlibCall("CreateForumTopic", start, err)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return forumTopicInfo, nil
+}
+
+// GetMessageProperties возвращает доступные действия с сообщением
+func (r *Repo) GetMessageProperties(req *client.GetMessagePropertiesRequest) (*client.MessageProperties, error) {
+	start := time.Now()
+	messageProperties, err := r.getClient().GetMessageProperties(req)
+	metrics.ObserveTdlibCall("GetMessageProperties", start, err)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return messageProperties, nil
+}
+
+// PinChatMessage закрепляет сообщение в чате
+func (r *Repo) PinChatMessage(req *client.PinChatMessageRequest) (*client.Ok, error) {
+	start := time.Now()
+	ok, err := r.getClient().PinChatMessage(req)
+	metrics.ObserveTdlibCall("PinChatMessage", start, err)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return ok, nil
+}
+
+// UnpinChatMessage открепляет сообщение в чате
+func (r *Repo) UnpinChatMessage(req *client.UnpinChatMessageRequest) (*client.Ok, error) {
+	start := time.Now()
+	ok, err := r.getClient().UnpinChatMessage(req)
+	metrics.ObserveTdlibCall("UnpinChatMessage", start, err)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return ok, nil
+}
+
 // GetListener возвращает слушателя TDLib
 func (r *Repo) GetListener() *client.Listener {
 	return r.getClient().GetListener()
@@ -199,7 +339,9 @@ func (r *Repo) GetMarkdownText(req *client.GetMarkdownTextRequest) (*client.Form
 
 // GetCallbackQueryAnswer выводит информацию о ответе на callback-запрос
 func (r *Repo) GetCallbackQueryAnswer(req *client.GetCallbackQueryAnswerRequest) (*client.CallbackQueryAnswer, error) {
+	start := time.Now()
 	answer, err := r.getClient().GetCallbackQueryAnswer(req)
+	metrics.ObserveTdlibCall("GetCallbackQueryAnswer", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
@@ -217,7 +359,9 @@ func (r *Repo) GetOption(req *client.GetOptionRequest) (client.OptionValue, erro
 
 // GetMe выводит информацию о пользователе
 func (r *Repo) GetMe() (*client.User, error) {
+	start := time.Now()
 	user, err := r.getClient().GetMe()
+	metrics.ObserveTdlibCall("GetMe", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
@@ -226,7 +370,9 @@ func (r *Repo) GetMe() (*client.User, error) {
 
 // TranslateText переводит текст
 func (r *Repo) TranslateText(req *client.TranslateTextRequest) (*client.FormattedText, error) {
+	start := time.Now()
 	formattedText, err := r.getClient().TranslateText(req)
+	metrics.ObserveTdlibCall("TranslateText", start, err)
 	if err != nil {
 		return nil, log.WrapError(err) // внешняя ошибка
 	}
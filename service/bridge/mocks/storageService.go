@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/comerc/budva43/app/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StorageService is an autogenerated mock type for the storageService type
+type StorageService struct {
+	mock.Mock
+}
+
+type StorageService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *StorageService) EXPECT() *StorageService_Expecter {
+	return &StorageService_Expecter{mock: &_m.Mock}
+}
+
+// GetOriginMessageId provides a mock function with given fields: dstChatId, dstMessageId
+func (_m *StorageService) GetOriginMessageId(dstChatId int64, dstMessageId int64) *domain.ChatMessage {
+	ret := _m.Called(dstChatId, dstMessageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOriginMessageId")
+	}
+
+	var r0 *domain.ChatMessage
+	if rf, ok := ret.Get(0).(func(int64, int64) *domain.ChatMessage); ok {
+		r0 = rf(dstChatId, dstMessageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ChatMessage)
+		}
+	}
+
+	return r0
+}
+
+// StorageService_GetOriginMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOriginMessageId'
+type StorageService_GetOriginMessageId_Call struct {
+	*mock.Call
+}
+
+// GetOriginMessageId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - dstMessageId int64
+func (_e *StorageService_Expecter) GetOriginMessageId(dstChatId interface{}, dstMessageId interface{}) *StorageService_GetOriginMessageId_Call {
+	return &StorageService_GetOriginMessageId_Call{Call: _e.mock.On("GetOriginMessageId", dstChatId, dstMessageId)}
+}
+
+func (_c *StorageService_GetOriginMessageId_Call) Run(run func(dstChatId int64, dstMessageId int64)) *StorageService_GetOriginMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetOriginMessageId_Call) Return(_a0 *domain.ChatMessage) *StorageService_GetOriginMessageId_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetOriginMessageId_Call) RunAndReturn(run func(int64, int64) *domain.ChatMessage) *StorageService_GetOriginMessageId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewStorageService creates a new instance of StorageService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStorageService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StorageService {
+	mock := &StorageService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,149 @@
+package bridge
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/log"
+)
+
+//go:generate mockery --name=telegramRepo --exported
+type telegramRepo interface {
+	// tdlibClient methods
+	SendMessage(*client.SendMessageRequest) (*client.Message, error)
+}
+
+//go:generate mockery --name=storageService --exported
+type storageService interface {
+	GetOriginMessageId(dstChatId, dstMessageId int64) *domain.ChatMessage
+}
+
+//go:generate mockery --name=messageService --exported
+type messageService interface {
+	GetFormattedText(message *client.Message) *client.FormattedText
+	GetInputMessageContent(message *client.Message, formattedText *client.FormattedText) client.InputMessageContent
+}
+
+// Service отправляет ответы из получателей-зеркал в источники
+type Service struct {
+	log *log.Logger
+	//
+	telegramRepo   telegramRepo
+	storageService storageService
+	messageService messageService
+	mu             sync.Mutex
+	relays         map[string]int // chatId:replyToMessageId -> количество ожидаемых отправок
+}
+
+// New создает новый экземпляр сервиса мостов
+func New(
+	telegramRepo telegramRepo,
+	storageService storageService,
+	messageService messageService,
+) *Service {
+	return &Service{
+		log: log.NewLogger(),
+		//
+		telegramRepo:   telegramRepo,
+		storageService: storageService,
+		messageService: messageService,
+		relays:         make(map[string]int),
+	}
+}
+
+// Relay отправляет ответ на копию в получателе-зеркале в источник ответом на оригинал
+func (s *Service) Relay(message *client.Message, bridge *domain.Bridge) {
+	var (
+		err         error
+		fromMessage *domain.ChatMessage
+		result      int64
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"bridgeId", bridge.Id,
+			"chatId", message.ChatId,
+			"messageId", message.Id,
+			"fromMessage", fromMessage,
+			"result", result,
+		)
+	}()
+
+	// обычные ответы в получателе-зеркале (не на копии из bridge.To) пропускаются без ошибки
+	replyTo, ok := message.ReplyTo.(*client.MessageReplyToMessage)
+	if !ok || replyTo.ChatId != message.ChatId {
+		return
+	}
+
+	fromMessage = s.storageService.GetOriginMessageId(message.ChatId, replyTo.MessageId)
+	if fromMessage == nil || fromMessage.ChatId != bridge.To {
+		return
+	}
+	chatId := fromMessage.ChatId
+	messageId := fromMessage.MessageId
+
+	formattedText := s.messageService.GetFormattedText(message)
+	if formattedText == nil {
+		err = log.NewError("unsupported message content")
+		return
+	}
+	content := s.messageService.GetInputMessageContent(message, formattedText)
+	if content == nil {
+		err = log.NewError("unsupported message content")
+		return
+	}
+
+	// ответ регистрируется до отправки, т.к. обновление о новом сообщении может опередить результат
+	key := fmt.Sprintf("%d:%d", chatId, messageId)
+	s.addRelay(key)
+
+	var dst *client.Message
+	dst, err = s.telegramRepo.SendMessage(&client.SendMessageRequest{
+		ChatId:              chatId,
+		InputMessageContent: content,
+		ReplyTo: &client.InputMessageReplyToMessage{
+			MessageId: messageId,
+		},
+	})
+	if err != nil {
+		s.popRelay(key)
+		return
+	}
+	result = dst.Id
+}
+
+// IsRelayed проверяет, что сообщение отправлено мостом и не должно пересылаться снова
+func (s *Service) IsRelayed(message *client.Message) bool {
+	if message.SendingState == nil {
+		return false
+	}
+	replyTo, ok := message.ReplyTo.(*client.MessageReplyToMessage)
+	if !ok {
+		return false
+	}
+	key := fmt.Sprintf("%d:%d", message.ChatId, replyTo.MessageId)
+	return s.popRelay(key)
+}
+
+// addRelay регистрирует ожидаемую отправку ответа
+func (s *Service) addRelay(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.relays[key]++
+}
+
+// popRelay снимает регистрацию ожидаемой отправки ответа; возвращает false, если её не было
+func (s *Service) popRelay(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.relays[key] == 0 {
+		return false
+	}
+	s.relays[key]--
+	if s.relays[key] == 0 {
+		delete(s.relays, key)
+	}
+	return true
+}
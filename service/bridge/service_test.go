@@ -0,0 +1,119 @@
+package bridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/service/bridge/mocks"
+)
+
+func TestRelay(t *testing.T) {
+	t.Parallel()
+
+	const (
+		srcChatId    = int64(-1001)
+		dstChatId    = int64(-1002)
+		srcMessageId = int64(10)
+		newMessageId = int64(20)
+	)
+
+	bridge := &domain.Bridge{
+		Id:   "Bridge1",
+		From: dstChatId,
+		To:   srcChatId,
+	}
+	message := &client.Message{
+		Id:     30,
+		ChatId: dstChatId,
+		ReplyTo: &client.MessageReplyToMessage{
+			ChatId:    dstChatId,
+			MessageId: newMessageId,
+		},
+		Content: &client.MessageText{
+			Text: &client.FormattedText{Text: "answer"},
+		},
+	}
+	formattedText := &client.FormattedText{Text: "answer"}
+	content := &client.InputMessageText{Text: formattedText}
+
+	telegramRepo := mocks.NewTelegramRepo(t)
+	storageService := mocks.NewStorageService(t)
+	messageService := mocks.NewMessageService(t)
+
+	storageService.EXPECT().GetOriginMessageId(dstChatId, newMessageId).Return(&domain.ChatMessage{ForwardRuleId: "Rule1", ChatId: -1001, MessageId: 10})
+	messageService.EXPECT().GetFormattedText(message).Return(formattedText)
+	messageService.EXPECT().GetInputMessageContent(message, formattedText).Return(content)
+	telegramRepo.EXPECT().SendMessage(&client.SendMessageRequest{
+		ChatId:              srcChatId,
+		InputMessageContent: content,
+		ReplyTo: &client.InputMessageReplyToMessage{
+			MessageId: srcMessageId,
+		},
+	}).Return(&client.Message{Id: 40, ChatId: srcChatId}, nil)
+
+	s := New(telegramRepo, storageService, messageService)
+	s.Relay(message, bridge)
+
+	relayed := &client.Message{
+		Id:           40,
+		ChatId:       srcChatId,
+		SendingState: &client.MessageSendingStatePending{},
+		ReplyTo: &client.MessageReplyToMessage{
+			ChatId:    srcChatId,
+			MessageId: srcMessageId,
+		},
+	}
+	// ответ через мост пропускается только один раз
+	assert.True(t, s.IsRelayed(relayed))
+	assert.False(t, s.IsRelayed(relayed))
+}
+
+func TestRelayWithOriginFromOtherSource(t *testing.T) {
+	t.Parallel()
+
+	storageService := mocks.NewStorageService(t)
+	storageService.EXPECT().GetOriginMessageId(int64(-1002), int64(20)).Return(&domain.ChatMessage{ForwardRuleId: "Rule1", ChatId: -1003, MessageId: 10})
+
+	s := New(nil, storageService, nil)
+	s.Relay(&client.Message{
+		Id:     30,
+		ChatId: -1002,
+		ReplyTo: &client.MessageReplyToMessage{
+			ChatId:    -1002,
+			MessageId: 20,
+		},
+	}, &domain.Bridge{
+		Id:   "Bridge1",
+		From: -1002,
+		To:   -1001,
+	})
+
+	assert.Empty(t, s.relays)
+}
+
+func TestRelayWithoutOrigin(t *testing.T) {
+	t.Parallel()
+
+	// обычный ответ в получателе-зеркале не на копию пропускается
+	storageService := mocks.NewStorageService(t)
+	storageService.EXPECT().GetOriginMessageId(int64(-1002), int64(20)).Return(nil)
+
+	s := New(nil, storageService, nil)
+	s.Relay(&client.Message{
+		Id:     30,
+		ChatId: -1002,
+		ReplyTo: &client.MessageReplyToMessage{
+			ChatId:    -1002,
+			MessageId: 20,
+		},
+	}, &domain.Bridge{
+		Id:   "Bridge1",
+		From: -1002,
+		To:   -1001,
+	})
+
+	assert.Empty(t, s.relays)
+}
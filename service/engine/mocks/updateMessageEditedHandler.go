@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	client "github.com/zelenin/go-tdlib/client"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,9 +23,9 @@ func (_m *UpdateMessageEditedHandler) EXPECT() *UpdateMessageEditedHandler_Expec
 	return &UpdateMessageEditedHandler_Expecter{mock: &_m.Mock}
 }
 
-// Run provides a mock function with given fields: update
-func (_m *UpdateMessageEditedHandler) Run(update *client.UpdateMessageEdited) {
-	_m.Called(update)
+// Run provides a mock function with given fields: ctx, update
+func (_m *UpdateMessageEditedHandler) Run(ctx context.Context, update *client.UpdateMessageEdited) {
+	_m.Called(ctx, update)
 }
 
 // UpdateMessageEditedHandler_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
@@ -32,14 +34,15 @@ type UpdateMessageEditedHandler_Run_Call struct {
 }
 
 // Run is a helper method to define mock.On call
+//   - ctx context.Context
 //   - update *client.UpdateMessageEdited
-func (_e *UpdateMessageEditedHandler_Expecter) Run(update interface{}) *UpdateMessageEditedHandler_Run_Call {
-	return &UpdateMessageEditedHandler_Run_Call{Call: _e.mock.On("Run", update)}
+func (_e *UpdateMessageEditedHandler_Expecter) Run(ctx interface{}, update interface{}) *UpdateMessageEditedHandler_Run_Call {
+	return &UpdateMessageEditedHandler_Run_Call{Call: _e.mock.On("Run", ctx, update)}
 }
 
-func (_c *UpdateMessageEditedHandler_Run_Call) Run(run func(update *client.UpdateMessageEdited)) *UpdateMessageEditedHandler_Run_Call {
+func (_c *UpdateMessageEditedHandler_Run_Call) Run(run func(ctx context.Context, update *client.UpdateMessageEdited)) *UpdateMessageEditedHandler_Run_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*client.UpdateMessageEdited))
+		run(args[0].(context.Context), args[1].(*client.UpdateMessageEdited))
 	})
 	return _c
 }
@@ -49,7 +52,7 @@ func (_c *UpdateMessageEditedHandler_Run_Call) Return() *UpdateMessageEditedHand
 	return _c
 }
 
-func (_c *UpdateMessageEditedHandler_Run_Call) RunAndReturn(run func(*client.UpdateMessageEdited)) *UpdateMessageEditedHandler_Run_Call {
+func (_c *UpdateMessageEditedHandler_Run_Call) RunAndReturn(run func(context.Context, *client.UpdateMessageEdited)) *UpdateMessageEditedHandler_Run_Call {
 	_c.Run(run)
 	return _c
 }
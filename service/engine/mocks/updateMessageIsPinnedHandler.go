@@ -0,0 +1,72 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	client "github.com/zelenin/go-tdlib/client"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UpdateMessageIsPinnedHandler is an autogenerated mock type for the updateMessageIsPinnedHandler type
+type UpdateMessageIsPinnedHandler struct {
+	mock.Mock
+}
+
+type UpdateMessageIsPinnedHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UpdateMessageIsPinnedHandler) EXPECT() *UpdateMessageIsPinnedHandler_Expecter {
+	return &UpdateMessageIsPinnedHandler_Expecter{mock: &_m.Mock}
+}
+
+// Run provides a mock function with given fields: ctx, update
+func (_m *UpdateMessageIsPinnedHandler) Run(ctx context.Context, update *client.UpdateMessageIsPinned) {
+	_m.Called(ctx, update)
+}
+
+// UpdateMessageIsPinnedHandler_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type UpdateMessageIsPinnedHandler_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+//   - update *client.UpdateMessageIsPinned
+func (_e *UpdateMessageIsPinnedHandler_Expecter) Run(ctx interface{}, update interface{}) *UpdateMessageIsPinnedHandler_Run_Call {
+	return &UpdateMessageIsPinnedHandler_Run_Call{Call: _e.mock.On("Run", ctx, update)}
+}
+
+func (_c *UpdateMessageIsPinnedHandler_Run_Call) Run(run func(ctx context.Context, update *client.UpdateMessageIsPinned)) *UpdateMessageIsPinnedHandler_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*client.UpdateMessageIsPinned))
+	})
+	return _c
+}
+
+func (_c *UpdateMessageIsPinnedHandler_Run_Call) Return() *UpdateMessageIsPinnedHandler_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *UpdateMessageIsPinnedHandler_Run_Call) RunAndReturn(run func(context.Context, *client.UpdateMessageIsPinned)) *UpdateMessageIsPinnedHandler_Run_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewUpdateMessageIsPinnedHandler creates a new instance of UpdateMessageIsPinnedHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUpdateMessageIsPinnedHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UpdateMessageIsPinnedHandler {
+	mock := &UpdateMessageIsPinnedHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	client "github.com/zelenin/go-tdlib/client"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,9 +23,9 @@ func (_m *UpdateMessageSendHandler) EXPECT() *UpdateMessageSendHandler_Expecter
 	return &UpdateMessageSendHandler_Expecter{mock: &_m.Mock}
 }
 
-// Run provides a mock function with given fields: update
-func (_m *UpdateMessageSendHandler) Run(update *client.UpdateMessageSendSucceeded) {
-	_m.Called(update)
+// Run provides a mock function with given fields: ctx, update
+func (_m *UpdateMessageSendHandler) Run(ctx context.Context, update *client.UpdateMessageSendSucceeded) {
+	_m.Called(ctx, update)
 }
 
 // UpdateMessageSendHandler_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
@@ -32,14 +34,15 @@ type UpdateMessageSendHandler_Run_Call struct {
 }
 
 // Run is a helper method to define mock.On call
+//   - ctx context.Context
 //   - update *client.UpdateMessageSendSucceeded
-func (_e *UpdateMessageSendHandler_Expecter) Run(update interface{}) *UpdateMessageSendHandler_Run_Call {
-	return &UpdateMessageSendHandler_Run_Call{Call: _e.mock.On("Run", update)}
+func (_e *UpdateMessageSendHandler_Expecter) Run(ctx interface{}, update interface{}) *UpdateMessageSendHandler_Run_Call {
+	return &UpdateMessageSendHandler_Run_Call{Call: _e.mock.On("Run", ctx, update)}
 }
 
-func (_c *UpdateMessageSendHandler_Run_Call) Run(run func(update *client.UpdateMessageSendSucceeded)) *UpdateMessageSendHandler_Run_Call {
+func (_c *UpdateMessageSendHandler_Run_Call) Run(run func(ctx context.Context, update *client.UpdateMessageSendSucceeded)) *UpdateMessageSendHandler_Run_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*client.UpdateMessageSendSucceeded))
+		run(args[0].(context.Context), args[1].(*client.UpdateMessageSendSucceeded))
 	})
 	return _c
 }
@@ -49,7 +52,7 @@ func (_c *UpdateMessageSendHandler_Run_Call) Return() *UpdateMessageSendHandler_
 	return _c
 }
 
-func (_c *UpdateMessageSendHandler_Run_Call) RunAndReturn(run func(*client.UpdateMessageSendSucceeded)) *UpdateMessageSendHandler_Run_Call {
+func (_c *UpdateMessageSendHandler_Run_Call) RunAndReturn(run func(context.Context, *client.UpdateMessageSendSucceeded)) *UpdateMessageSendHandler_Run_Call {
 	_c.Run(run)
 	return _c
 }
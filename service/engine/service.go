@@ -6,6 +6,8 @@ import (
 	"github.com/zelenin/go-tdlib/client"
 
 	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/metrics"
+	"github.com/comerc/budva43/app/trace"
 )
 
 //go:generate mockery --name=telegramRepo --exported
@@ -22,28 +24,34 @@ type updateNewMessageHandler interface {
 
 //go:generate mockery --name=updateMessageEditedHandler --exported
 type updateMessageEditedHandler interface {
-	Run(update *client.UpdateMessageEdited)
+	Run(ctx context.Context, update *client.UpdateMessageEdited)
 }
 
 //go:generate mockery --name=updateDeleteMessagesHandler --exported
 type updateDeleteMessagesHandler interface {
-	Run(update *client.UpdateDeleteMessages)
+	Run(ctx context.Context, update *client.UpdateDeleteMessages)
 }
 
 //go:generate mockery --name=updateMessageSendHandler --exported
 type updateMessageSendHandler interface {
-	Run(update *client.UpdateMessageSendSucceeded)
+	Run(ctx context.Context, update *client.UpdateMessageSendSucceeded)
+}
+
+//go:generate mockery --name=updateMessageIsPinnedHandler --exported
+type updateMessageIsPinnedHandler interface {
+	Run(ctx context.Context, update *client.UpdateMessageIsPinned)
 }
 
 // Service предоставляет функциональность движка пересылки сообщений
 type Service struct {
 	log *log.Logger
 	//
-	telegramRepo                telegramRepo
-	updateNewMessageHandler     updateNewMessageHandler
-	updateMessageEditedHandler  updateMessageEditedHandler
-	updateDeleteMessagesHandler updateDeleteMessagesHandler
-	updateMessageSendHandler    updateMessageSendHandler
+	telegramRepo                 telegramRepo
+	updateNewMessageHandler      updateNewMessageHandler
+	updateMessageEditedHandler   updateMessageEditedHandler
+	updateDeleteMessagesHandler  updateDeleteMessagesHandler
+	updateMessageSendHandler     updateMessageSendHandler
+	updateMessageIsPinnedHandler updateMessageIsPinnedHandler
 }
 
 // New создает новый экземпляр сервиса engine
@@ -53,15 +61,17 @@ func New(
 	updateMessageEditedHandler updateMessageEditedHandler,
 	updateDeleteMessagesHandler updateDeleteMessagesHandler,
 	updateMessageSendHandler updateMessageSendHandler,
+	updateMessageIsPinnedHandler updateMessageIsPinnedHandler,
 ) *Service {
 	return &Service{
 		log: log.NewLogger(),
 		//
-		telegramRepo:                telegramRepo,
-		updateNewMessageHandler:     updateNewMessageHandler,
-		updateMessageEditedHandler:  updateMessageEditedHandler,
-		updateDeleteMessagesHandler: updateDeleteMessagesHandler,
-		updateMessageSendHandler:    updateMessageSendHandler,
+		telegramRepo:                 telegramRepo,
+		updateNewMessageHandler:      updateNewMessageHandler,
+		updateMessageEditedHandler:   updateMessageEditedHandler,
+		updateDeleteMessagesHandler:  updateDeleteMessagesHandler,
+		updateMessageSendHandler:     updateMessageSendHandler,
+		updateMessageIsPinnedHandler: updateMessageIsPinnedHandler,
 	}
 }
 
@@ -106,16 +116,29 @@ func (s *Service) handleUpdates(ctx context.Context, listener *client.Listener)
 				continue
 			}
 
-			switch updateByType := update.(type) {
-			case *client.UpdateNewMessage:
-				s.updateNewMessageHandler.Run(ctx, updateByType)
-			case *client.UpdateMessageEdited:
-				s.updateMessageEditedHandler.Run(updateByType)
-			case *client.UpdateDeleteMessages:
-				s.updateDeleteMessagesHandler.Run(updateByType)
-			case *client.UpdateMessageSendSucceeded:
-				s.updateMessageSendHandler.Run(updateByType)
-			}
+			metrics.AddUpdate(update)
+
+			s.handleUpdate(ctx, update)
 		}
 	}
 }
+
+// handleUpdate передаёт обновление обработчику в корневом спане новой трассы:
+// ctx со спаном передаётся в задачи очереди и далее по этапам обработки
+func (s *Service) handleUpdate(ctx context.Context, update client.Type) {
+	ctx, end := trace.Start(ctx, update.GetType())
+	defer end(nil)
+
+	switch updateByType := update.(type) {
+	case *client.UpdateNewMessage:
+		s.updateNewMessageHandler.Run(ctx, updateByType)
+	case *client.UpdateMessageEdited:
+		s.updateMessageEditedHandler.Run(ctx, updateByType)
+	case *client.UpdateDeleteMessages:
+		s.updateDeleteMessagesHandler.Run(ctx, updateByType)
+	case *client.UpdateMessageSendSucceeded:
+		s.updateMessageSendHandler.Run(ctx, updateByType)
+	case *client.UpdateMessageIsPinned:
+		s.updateMessageIsPinnedHandler.Run(ctx, updateByType)
+	}
+}
@@ -0,0 +1,132 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/comerc/budva43/app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StorageService is an autogenerated mock type for the storageService type
+type StorageService struct {
+	mock.Mock
+}
+
+type StorageService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *StorageService) EXPECT() *StorageService_Expecter {
+	return &StorageService_Expecter{mock: &_m.Mock}
+}
+
+// GetJournal provides a mock function with given fields: chatId, messageId
+func (_m *StorageService) GetJournal(chatId int64, messageId int64) *domain.Journal {
+	ret := _m.Called(chatId, messageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJournal")
+	}
+
+	var r0 *domain.Journal
+	if rf, ok := ret.Get(0).(func(int64, int64) *domain.Journal); ok {
+		r0 = rf(chatId, messageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Journal)
+		}
+	}
+
+	return r0
+}
+
+// StorageService_GetJournal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJournal'
+type StorageService_GetJournal_Call struct {
+	*mock.Call
+}
+
+// GetJournal is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+func (_e *StorageService_Expecter) GetJournal(chatId interface{}, messageId interface{}) *StorageService_GetJournal_Call {
+	return &StorageService_GetJournal_Call{Call: _e.mock.On("GetJournal", chatId, messageId)}
+}
+
+func (_c *StorageService_GetJournal_Call) Run(run func(chatId int64, messageId int64)) *StorageService_GetJournal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetJournal_Call) Return(_a0 *domain.Journal) *StorageService_GetJournal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetJournal_Call) RunAndReturn(run func(int64, int64) *domain.Journal) *StorageService_GetJournal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNewMessageId provides a mock function with given fields: chatId, tmpMessageId
+func (_m *StorageService) GetNewMessageId(chatId int64, tmpMessageId int64) int64 {
+	ret := _m.Called(chatId, tmpMessageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNewMessageId")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(chatId, tmpMessageId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// StorageService_GetNewMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNewMessageId'
+type StorageService_GetNewMessageId_Call struct {
+	*mock.Call
+}
+
+// GetNewMessageId is a helper method to define mock.On call
+//   - chatId int64
+//   - tmpMessageId int64
+func (_e *StorageService_Expecter) GetNewMessageId(chatId interface{}, tmpMessageId interface{}) *StorageService_GetNewMessageId_Call {
+	return &StorageService_GetNewMessageId_Call{Call: _e.mock.On("GetNewMessageId", chatId, tmpMessageId)}
+}
+
+func (_c *StorageService_GetNewMessageId_Call) Run(run func(chatId int64, tmpMessageId int64)) *StorageService_GetNewMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetNewMessageId_Call) Return(_a0 int64) *StorageService_GetNewMessageId_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetNewMessageId_Call) RunAndReturn(run func(int64, int64) int64) *StorageService_GetNewMessageId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewStorageService creates a new instance of StorageService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStorageService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StorageService {
+	mock := &StorageService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
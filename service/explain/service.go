@@ -0,0 +1,78 @@
+package explain
+
+import (
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/log"
+)
+
+//go:generate mockery --name=telegramRepo --exported
+type telegramRepo interface {
+	// tdlibClient methods
+	GetMessageLinkInfo(*client.GetMessageLinkInfoRequest) (*client.MessageLinkInfo, error)
+}
+
+//go:generate mockery --name=storageService --exported
+type storageService interface {
+	GetJournal(chatId, messageId int64) *domain.Journal
+	GetNewMessageId(chatId, tmpMessageId int64) int64
+}
+
+// Service объясняет решения по исходному сообщению: почему оно переслано или нет
+type Service struct {
+	log *log.Logger
+	//
+	telegramRepo   telegramRepo
+	storageService storageService
+}
+
+// New создает новый экземпляр сервиса объяснения решений
+func New(
+	telegramRepo telegramRepo,
+	storageService storageService,
+) *Service {
+	return &Service{
+		log: log.NewLogger(),
+		//
+		telegramRepo:   telegramRepo,
+		storageService: storageService,
+	}
+}
+
+// Explain возвращает журнал решений для исходного сообщения по ссылке на него
+func (s *Service) Explain(link string) (*domain.Journal, error) {
+	messageLinkInfo, err := s.telegramRepo.GetMessageLinkInfo(&client.GetMessageLinkInfoRequest{
+		Url: link,
+	})
+	if err != nil {
+		return nil, err
+	}
+	src := messageLinkInfo.Message
+	if src == nil {
+		return nil, log.NewError("message not found by link", "link", link)
+	}
+
+	journal := s.storageService.GetJournal(src.ChatId, src.Id)
+	if journal == nil {
+		// журнал медиа-альбома ведётся по первому сообщению
+		return nil, log.NewError("journal not found",
+			"chatId", src.ChatId,
+			"messageId", src.Id,
+			"mediaAlbumId", src.MediaAlbumId,
+		)
+	}
+
+	// временные идентификаторы заменяются постоянными, если отправка подтверждена
+	for _, rule := range journal.Rules {
+		for _, destination := range rule.Destinations {
+			for i, tmpMessageId := range destination.MessageIds {
+				if newMessageId := s.storageService.GetNewMessageId(destination.ChatId, tmpMessageId); newMessageId != 0 {
+					destination.MessageIds[i] = newMessageId
+				}
+			}
+		}
+	}
+
+	return journal, nil
+}
@@ -0,0 +1,78 @@
+package explain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/service/explain/mocks"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	const link = "https://t.me/c/1/10"
+
+	tests := []struct {
+		name     string
+		journal  *domain.Journal
+		expected []int64
+		isError  bool
+	}{
+		{
+			name: "found",
+			journal: &domain.Journal{
+				ChatId:    -1001,
+				MessageId: 10,
+				Rules: []*domain.JournalRule{
+					{
+						ForwardRuleId: "rule1",
+						Verdict:       domain.JournalEvaluated,
+						FiltersMode:   domain.FiltersOK,
+						Destinations: []*domain.JournalDestination{
+							{ChatId: -1002, Outcome: domain.StatsOk, MessageIds: []int64{1, 2}},
+						},
+					},
+				},
+			},
+			expected: []int64{20, 2},
+		},
+		{
+			name:    "not_found",
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			telegramRepo := mocks.NewTelegramRepo(t)
+			storageService := mocks.NewStorageService(t)
+
+			telegramRepo.EXPECT().GetMessageLinkInfo(&client.GetMessageLinkInfoRequest{
+				Url: link,
+			}).Return(&client.MessageLinkInfo{
+				Message: &client.Message{Id: 10, ChatId: -1001},
+			}, nil)
+			storageService.EXPECT().GetJournal(int64(-1001), int64(10)).Return(test.journal)
+			if test.journal != nil {
+				// подтверждена только отправка первого сообщения
+				storageService.EXPECT().GetNewMessageId(int64(-1002), int64(1)).Return(20)
+				storageService.EXPECT().GetNewMessageId(int64(-1002), int64(2)).Return(0)
+			}
+
+			s := New(telegramRepo, storageService)
+			result, err := s.Explain(link)
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result.Rules[0].Destinations[0].MessageIds)
+		})
+	}
+}
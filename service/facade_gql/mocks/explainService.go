@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/comerc/budva43/app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExplainService is an autogenerated mock type for the explainService type
+type ExplainService struct {
+	mock.Mock
+}
+
+type ExplainService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ExplainService) EXPECT() *ExplainService_Expecter {
+	return &ExplainService_Expecter{mock: &_m.Mock}
+}
+
+// Explain provides a mock function with given fields: link
+func (_m *ExplainService) Explain(link string) (*domain.Journal, error) {
+	ret := _m.Called(link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Explain")
+	}
+
+	var r0 *domain.Journal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Journal, error)); ok {
+		return rf(link)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Journal); ok {
+		r0 = rf(link)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Journal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplainService_Explain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Explain'
+type ExplainService_Explain_Call struct {
+	*mock.Call
+}
+
+// Explain is a helper method to define mock.On call
+//   - link string
+func (_e *ExplainService_Expecter) Explain(link interface{}) *ExplainService_Explain_Call {
+	return &ExplainService_Explain_Call{Call: _e.mock.On("Explain", link)}
+}
+
+func (_c *ExplainService_Explain_Call) Run(run func(link string)) *ExplainService_Explain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *ExplainService_Explain_Call) Return(_a0 *domain.Journal, _a1 error) *ExplainService_Explain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExplainService_Explain_Call) RunAndReturn(run func(string) (*domain.Journal, error)) *ExplainService_Explain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewExplainService creates a new instance of ExplainService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExplainService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExplainService {
+	mock := &ExplainService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,8 +1,11 @@
 package facade_gql
 
 import (
+	"time"
+
 	"github.com/zelenin/go-tdlib/client"
 
+	"github.com/comerc/budva43/app/domain"
 	"github.com/comerc/budva43/app/dto/gql/dto"
 	"github.com/comerc/budva43/app/log"
 	"github.com/comerc/budva43/app/util"
@@ -17,17 +20,36 @@ type telegramRepo interface {
 	GetMe() (*client.User, error)
 }
 
+//go:generate mockery --name=explainService --exported
+type explainService interface {
+	Explain(link string) (*domain.Journal, error)
+}
+
+//go:generate mockery --name=storageService --exported
+type storageService interface {
+	GetStats(filter *domain.StatsFilter) ([]*domain.Stats, error)
+	GetTopStats(filter *domain.StatsFilter, groupBy domain.StatsGroupBy, limit int) ([]*domain.Stats, error)
+}
+
 type Service struct {
 	log *log.Logger
 	//
-	telegramRepo telegramRepo
+	telegramRepo   telegramRepo
+	explainService explainService
+	storageService storageService
 }
 
-func New(telegramRepo telegramRepo) *Service {
+func New(
+	telegramRepo telegramRepo,
+	explainService explainService,
+	storageService storageService,
+) *Service {
 	return &Service{
 		log: log.NewLogger(),
 		//
-		telegramRepo: telegramRepo,
+		telegramRepo:   telegramRepo,
+		explainService: explainService,
+		storageService: storageService,
 	}
 }
 
@@ -58,3 +80,116 @@ func (s *Service) GetStatus() (*dto.Status, error) {
 		UserId:         me.Id,
 	}, nil
 }
+
+// GetStats возвращает почасовую статистику пересылок за период
+func (s *Service) GetStats(filter *dto.StatsFilter) ([]*dto.Stats, error) {
+	if s.storageService == nil {
+		return nil, log.NewError("stats is not available without storage")
+	}
+
+	stats, err := s.storageService.GetStats(mapStatsFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapStats(stats), nil
+}
+
+// GetTopStats возвращает наибольшие суммы статистики пересылок за период по измерению groupBy
+func (s *Service) GetTopStats(filter *dto.StatsFilter, groupBy string, limit int) ([]*dto.Stats, error) {
+	if s.storageService == nil {
+		return nil, log.NewError("stats is not available without storage")
+	}
+
+	stats, err := s.storageService.GetTopStats(mapStatsFilter(filter), groupBy, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapStats(stats), nil
+}
+
+// GetExplain возвращает журнал решений для исходного сообщения по ссылке
+func (s *Service) GetExplain(link string) (*dto.Journal, error) {
+	if s.explainService == nil {
+		return nil, log.NewError("explain is not available without storage")
+	}
+
+	journal, err := s.explainService.Explain(link)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.Journal{
+		ChatId:    journal.ChatId,
+		MessageId: journal.MessageId,
+		Date:      journal.Date,
+		Rules:     make([]*dto.JournalRule, 0, len(journal.Rules)),
+	}
+	for _, rule := range journal.Rules {
+		journalRule := &dto.JournalRule{
+			ForwardRuleId: rule.ForwardRuleId,
+			Verdict:       rule.Verdict,
+			FiltersMode:   rule.FiltersMode,
+			Pattern:       rule.Pattern,
+			Destinations:  make([]*dto.JournalDestination, 0, len(rule.Destinations)),
+		}
+		for _, destination := range rule.Destinations {
+			journalRule.Destinations = append(journalRule.Destinations, &dto.JournalDestination{
+				ChatId:     destination.ChatId,
+				Outcome:    destination.Outcome,
+				MessageIds: destination.MessageIds,
+				Error:      destination.Error,
+			})
+		}
+		result.Rules = append(result.Rules, journalRule)
+	}
+	return result, nil
+}
+
+// mapStatsFilter преобразует dto.StatsFilter в отбор статистики; nil - без отбора
+func mapStatsFilter(filter *dto.StatsFilter) *domain.StatsFilter {
+	result := &domain.StatsFilter{}
+	if filter == nil {
+		return result
+	}
+	if filter.From != nil {
+		result.From = *filter.From
+	}
+	if filter.To != nil {
+		result.To = *filter.To
+	}
+	if filter.ForwardRuleId != nil {
+		result.ForwardRuleId = *filter.ForwardRuleId
+	}
+	if filter.SrcChatId != nil {
+		result.SrcChatId = *filter.SrcChatId
+	}
+	if filter.DstChatId != nil {
+		result.DstChatId = *filter.DstChatId
+	}
+	if filter.Outcome != nil {
+		result.Outcome = *filter.Outcome
+	}
+	return result
+}
+
+// mapStats преобразует статистику в dto.Stats; час пуст в суммах top-N
+func mapStats(stats []*domain.Stats) []*dto.Stats {
+	result := make([]*dto.Stats, 0, len(stats))
+	for _, item := range stats {
+		var hour *time.Time
+		if !item.Hour.IsZero() {
+			hour = &item.Hour
+		}
+		result = append(result, &dto.Stats{
+			Hour:          hour,
+			ForwardRuleId: item.ForwardRuleId,
+			SrcChatId:     item.SrcChatId,
+			DstChatId:     item.DstChatId,
+			Outcome:       item.Outcome,
+			Count:         item.Count,
+		})
+	}
+	return result
+}
@@ -3,10 +3,12 @@ package facade_gql
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/zelenin/go-tdlib/client"
 
+	"github.com/comerc/budva43/app/domain"
 	"github.com/comerc/budva43/app/dto/gql/dto"
 	"github.com/comerc/budva43/app/util"
 	"github.com/comerc/budva43/service/facade_gql/mocks"
@@ -64,7 +66,7 @@ func TestGetStatus(t *testing.T) {
 			if tt.setup != nil {
 				tt.setup(t, tg)
 			}
-			s := New(tg)
+			s := New(tg, nil, nil)
 			status, err := s.GetStatus()
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -76,3 +78,36 @@ func TestGetStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestGetStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+
+		ss := mocks.NewStorageService(t)
+		s := New(nil, nil, ss)
+
+		hour := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		srcChatId := int64(-1001)
+		ss.EXPECT().GetStats(&domain.StatsFilter{From: hour, SrcChatId: srcChatId}).Return([]*domain.Stats{
+			{Hour: hour, ForwardRuleId: "rule1", SrcChatId: srcChatId, DstChatId: -1002, Outcome: domain.StatsOk, Count: 3},
+		}, nil)
+
+		result, err := s.GetStats(&dto.StatsFilter{From: &hour, SrcChatId: &srcChatId})
+		assert.NoError(t, err)
+		assert.Equal(t, []*dto.Stats{
+			{Hour: &hour, ForwardRuleId: "rule1", SrcChatId: srcChatId, DstChatId: -1002, Outcome: "ok", Count: 3},
+		}, result)
+	})
+
+	t.Run("without_storage", func(t *testing.T) {
+		t.Parallel()
+
+		s := New(nil, nil, nil)
+
+		result, err := s.GetStats(nil)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,269 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/comerc/budva43/app/domain"
+	dto "github.com/comerc/budva43/app/dto/storage/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StorageService is an autogenerated mock type for the storageService type
+type StorageService struct {
+	mock.Mock
+}
+
+type StorageService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *StorageService) EXPECT() *StorageService_Expecter {
+	return &StorageService_Expecter{mock: &_m.Mock}
+}
+
+// CreateBackup provides a mock function with no fields
+func (_m *StorageService) CreateBackup() (*dto.Backup, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBackup")
+	}
+
+	var r0 *dto.Backup
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*dto.Backup, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *dto.Backup); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Backup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageService_CreateBackup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBackup'
+type StorageService_CreateBackup_Call struct {
+	*mock.Call
+}
+
+// CreateBackup is a helper method to define mock.On call
+func (_e *StorageService_Expecter) CreateBackup() *StorageService_CreateBackup_Call {
+	return &StorageService_CreateBackup_Call{Call: _e.mock.On("CreateBackup")}
+}
+
+func (_c *StorageService_CreateBackup_Call) Run(run func()) *StorageService_CreateBackup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *StorageService_CreateBackup_Call) Return(_a0 *dto.Backup, _a1 error) *StorageService_CreateBackup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageService_CreateBackup_Call) RunAndReturn(run func() (*dto.Backup, error)) *StorageService_CreateBackup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBackups provides a mock function with no fields
+func (_m *StorageService) GetBackups() ([]*dto.Backup, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBackups")
+	}
+
+	var r0 []*dto.Backup
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*dto.Backup, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*dto.Backup); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.Backup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageService_GetBackups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBackups'
+type StorageService_GetBackups_Call struct {
+	*mock.Call
+}
+
+// GetBackups is a helper method to define mock.On call
+func (_e *StorageService_Expecter) GetBackups() *StorageService_GetBackups_Call {
+	return &StorageService_GetBackups_Call{Call: _e.mock.On("GetBackups")}
+}
+
+func (_c *StorageService_GetBackups_Call) Run(run func()) *StorageService_GetBackups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *StorageService_GetBackups_Call) Return(_a0 []*dto.Backup, _a1 error) *StorageService_GetBackups_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageService_GetBackups_Call) RunAndReturn(run func() ([]*dto.Backup, error)) *StorageService_GetBackups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStats provides a mock function with given fields: filter
+func (_m *StorageService) GetStats(filter *domain.StatsFilter) ([]*domain.Stats, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
+	}
+
+	var r0 []*domain.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.StatsFilter) ([]*domain.Stats, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.StatsFilter) []*domain.Stats); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.StatsFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageService_GetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStats'
+type StorageService_GetStats_Call struct {
+	*mock.Call
+}
+
+// GetStats is a helper method to define mock.On call
+//   - filter *domain.StatsFilter
+func (_e *StorageService_Expecter) GetStats(filter interface{}) *StorageService_GetStats_Call {
+	return &StorageService_GetStats_Call{Call: _e.mock.On("GetStats", filter)}
+}
+
+func (_c *StorageService_GetStats_Call) Run(run func(filter *domain.StatsFilter)) *StorageService_GetStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*domain.StatsFilter))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetStats_Call) Return(_a0 []*domain.Stats, _a1 error) *StorageService_GetStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageService_GetStats_Call) RunAndReturn(run func(*domain.StatsFilter) ([]*domain.Stats, error)) *StorageService_GetStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTopStats provides a mock function with given fields: filter, groupBy, limit
+func (_m *StorageService) GetTopStats(filter *domain.StatsFilter, groupBy string, limit int) ([]*domain.Stats, error) {
+	ret := _m.Called(filter, groupBy, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTopStats")
+	}
+
+	var r0 []*domain.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.StatsFilter, string, int) ([]*domain.Stats, error)); ok {
+		return rf(filter, groupBy, limit)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.StatsFilter, string, int) []*domain.Stats); ok {
+		r0 = rf(filter, groupBy, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.StatsFilter, string, int) error); ok {
+		r1 = rf(filter, groupBy, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageService_GetTopStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTopStats'
+type StorageService_GetTopStats_Call struct {
+	*mock.Call
+}
+
+// GetTopStats is a helper method to define mock.On call
+//   - filter *domain.StatsFilter
+//   - groupBy string
+//   - limit int
+func (_e *StorageService_Expecter) GetTopStats(filter interface{}, groupBy interface{}, limit interface{}) *StorageService_GetTopStats_Call {
+	return &StorageService_GetTopStats_Call{Call: _e.mock.On("GetTopStats", filter, groupBy, limit)}
+}
+
+func (_c *StorageService_GetTopStats_Call) Run(run func(filter *domain.StatsFilter, groupBy string, limit int)) *StorageService_GetTopStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*domain.StatsFilter), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetTopStats_Call) Return(_a0 []*domain.Stats, _a1 error) *StorageService_GetTopStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageService_GetTopStats_Call) RunAndReturn(run func(*domain.StatsFilter, string, int) ([]*domain.Stats, error)) *StorageService_GetTopStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewStorageService creates a new instance of StorageService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStorageService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StorageService {
+	mock := &StorageService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/comerc/budva43/app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WhenceService is an autogenerated mock type for the whenceService type
+type WhenceService struct {
+	mock.Mock
+}
+
+type WhenceService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WhenceService) EXPECT() *WhenceService_Expecter {
+	return &WhenceService_Expecter{mock: &_m.Mock}
+}
+
+// Lookup provides a mock function with given fields: link
+func (_m *WhenceService) Lookup(link string) (*domain.Whence, error) {
+	ret := _m.Called(link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lookup")
+	}
+
+	var r0 *domain.Whence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Whence, error)); ok {
+		return rf(link)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Whence); ok {
+		r0 = rf(link)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Whence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WhenceService_Lookup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lookup'
+type WhenceService_Lookup_Call struct {
+	*mock.Call
+}
+
+// Lookup is a helper method to define mock.On call
+//   - link string
+func (_e *WhenceService_Expecter) Lookup(link interface{}) *WhenceService_Lookup_Call {
+	return &WhenceService_Lookup_Call{Call: _e.mock.On("Lookup", link)}
+}
+
+func (_c *WhenceService_Lookup_Call) Run(run func(link string)) *WhenceService_Lookup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *WhenceService_Lookup_Call) Return(_a0 *domain.Whence, _a1 error) *WhenceService_Lookup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WhenceService_Lookup_Call) RunAndReturn(run func(string) (*domain.Whence, error)) *WhenceService_Lookup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWhenceService creates a new instance of WhenceService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWhenceService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WhenceService {
+	mock := &WhenceService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/zelenin/go-tdlib/client"
 
+	"github.com/comerc/budva43/app/domain"
 	"github.com/comerc/budva43/app/dto/grpc/dto"
+	storageDto "github.com/comerc/budva43/app/dto/storage/dto"
 	"github.com/comerc/budva43/app/log"
 )
 
@@ -39,18 +41,42 @@ type mediaAlbumService interface {
 	// TODO: пригодится для реализации API?
 }
 
+//go:generate mockery --name=whenceService --exported
+type whenceService interface {
+	Lookup(link string) (*domain.Whence, error)
+}
+
+//go:generate mockery --name=explainService --exported
+type explainService interface {
+	Explain(link string) (*domain.Journal, error)
+}
+
+//go:generate mockery --name=storageService --exported
+type storageService interface {
+	CreateBackup() (*storageDto.Backup, error)
+	GetBackups() ([]*storageDto.Backup, error)
+	GetStats(filter *domain.StatsFilter) ([]*domain.Stats, error)
+	GetTopStats(filter *domain.StatsFilter, groupBy domain.StatsGroupBy, limit int) ([]*domain.Stats, error)
+}
+
 type Service struct {
 	log *log.Logger
 	//
 	telegramRepo      telegramRepo
 	messageService    messageService
 	mediaAlbumService mediaAlbumService
+	whenceService     whenceService
+	explainService    explainService
+	storageService    storageService
 }
 
 func New(
 	telegramRepo telegramRepo,
 	messageService messageService,
 	mediaAlbumService mediaAlbumService,
+	whenceService whenceService,
+	explainService explainService,
+	storageService storageService,
 ) *Service {
 	return &Service{
 		log: log.NewLogger(),
@@ -58,6 +84,9 @@ func New(
 		telegramRepo:      telegramRepo,
 		messageService:    messageService,
 		mediaAlbumService: mediaAlbumService,
+		whenceService:     whenceService,
+		explainService:    explainService,
+		storageService:    storageService,
 	}
 }
 
@@ -343,6 +372,161 @@ func (s *Service) GetMessageLinkInfo(link string) (*dto.Message, error) {
 	return result, nil
 }
 
+// GetWhence возвращает источник сообщения получателя по ссылке
+func (s *Service) GetWhence(link string) (*dto.Whence, error) {
+	if s.whenceService == nil {
+		return nil, log.NewError("whence is not available without storage")
+	}
+
+	whence, err := s.whenceService.Lookup(link)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.Whence{
+		ForwardRuleId: whence.ForwardRuleId,
+		ChatId:        whence.ChatId,
+		MessageId:     whence.MessageId,
+		Link:          whence.Link,
+	}
+	return result, nil
+}
+
+// GetExplain возвращает журнал решений для исходного сообщения по ссылке
+func (s *Service) GetExplain(link string) (*dto.Journal, error) {
+	if s.explainService == nil {
+		return nil, log.NewError("explain is not available without storage")
+	}
+
+	journal, err := s.explainService.Explain(link)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.Journal{
+		ChatId:    journal.ChatId,
+		MessageId: journal.MessageId,
+		Date:      journal.Date,
+		Rules:     make([]*dto.JournalRule, 0, len(journal.Rules)),
+	}
+	for _, rule := range journal.Rules {
+		journalRule := &dto.JournalRule{
+			ForwardRuleId: rule.ForwardRuleId,
+			Verdict:       rule.Verdict,
+			FiltersMode:   rule.FiltersMode,
+			Pattern:       rule.Pattern,
+			Destinations:  make([]*dto.JournalDestination, 0, len(rule.Destinations)),
+		}
+		for _, destination := range rule.Destinations {
+			journalRule.Destinations = append(journalRule.Destinations, &dto.JournalDestination{
+				ChatId:     destination.ChatId,
+				Outcome:    destination.Outcome,
+				MessageIds: destination.MessageIds,
+				Error:      destination.Error,
+			})
+		}
+		result.Rules = append(result.Rules, journalRule)
+	}
+	return result, nil
+}
+
+// CreateBackup создает резервную копию хранилища
+func (s *Service) CreateBackup() (*dto.Backup, error) {
+	if s.storageService == nil {
+		return nil, log.NewError("backup is not available without storage")
+	}
+
+	backup, err := s.storageService.CreateBackup()
+	if err != nil {
+		return nil, err
+	}
+
+	return mapBackup(backup), nil
+}
+
+// GetBackups возвращает резервные копии хранилища, начиная с последней
+func (s *Service) GetBackups() ([]*dto.Backup, error) {
+	if s.storageService == nil {
+		return nil, log.NewError("backup is not available without storage")
+	}
+
+	backups, err := s.storageService.GetBackups()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.Backup, 0, len(backups))
+	for _, backup := range backups {
+		result = append(result, mapBackup(backup))
+	}
+	return result, nil
+}
+
+// mapBackup преобразует резервную копию хранилища в dto.Backup
+func mapBackup(backup *storageDto.Backup) *dto.Backup {
+	return &dto.Backup{
+		Name:      backup.Name,
+		Size:      backup.Size,
+		CreatedAt: backup.CreatedAt,
+	}
+}
+
+// GetStats возвращает почасовую статистику пересылок за период
+func (s *Service) GetStats(filter *dto.StatsFilter) ([]*dto.Stats, error) {
+	if s.storageService == nil {
+		return nil, log.NewError("stats is not available without storage")
+	}
+
+	stats, err := s.storageService.GetStats(mapStatsFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapStats(stats), nil
+}
+
+// GetTopStats возвращает наибольшие суммы статистики пересылок за период по измерению groupBy
+func (s *Service) GetTopStats(filter *dto.StatsFilter, groupBy string, limit int) ([]*dto.Stats, error) {
+	if s.storageService == nil {
+		return nil, log.NewError("stats is not available without storage")
+	}
+
+	stats, err := s.storageService.GetTopStats(mapStatsFilter(filter), groupBy, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapStats(stats), nil
+}
+
+// mapStatsFilter преобразует dto.StatsFilter в отбор статистики
+func mapStatsFilter(filter *dto.StatsFilter) *domain.StatsFilter {
+	return &domain.StatsFilter{
+		From:          filter.From,
+		To:            filter.To,
+		ForwardRuleId: filter.ForwardRuleId,
+		SrcChatId:     filter.SrcChatId,
+		DstChatId:     filter.DstChatId,
+		Outcome:       filter.Outcome,
+	}
+}
+
+// mapStats преобразует статистику в dto.Stats
+func mapStats(stats []*domain.Stats) []*dto.Stats {
+	result := make([]*dto.Stats, 0, len(stats))
+	for _, item := range stats {
+		result = append(result, &dto.Stats{
+			Hour:          item.Hour,
+			ForwardRuleId: item.ForwardRuleId,
+			SrcChatId:     item.SrcChatId,
+			DstChatId:     item.DstChatId,
+			Outcome:       item.Outcome,
+			Count:         item.Count,
+		})
+	}
+	return result
+}
+
 // mapMessage преобразует сообщение из tdlib в dto.Message
 func (s *Service) mapMessage(message *client.Message) (*dto.Message, error) {
 	var err error
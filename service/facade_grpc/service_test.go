@@ -3,11 +3,14 @@ package facade_grpc
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/zelenin/go-tdlib/client"
 
+	"github.com/comerc/budva43/app/domain"
 	"github.com/comerc/budva43/app/dto/grpc/dto"
+	storageDto "github.com/comerc/budva43/app/dto/storage/dto"
 	"github.com/comerc/budva43/service/facade_grpc/mocks"
 )
 
@@ -16,7 +19,7 @@ func TestGetMessages(t *testing.T) {
 
 	tg := mocks.NewTelegramRepo(t)
 	ms := mocks.NewMessageService(t)
-	s := New(tg, ms, nil)
+	s := New(tg, ms, nil, nil, nil, nil)
 
 	chatId := int64(1)
 	msgIds := []int64{10, 20}
@@ -47,7 +50,7 @@ func TestSendMessage(t *testing.T) {
 
 	tg := mocks.NewTelegramRepo(t)
 	ms := mocks.NewMessageService(t)
-	s := New(tg, ms, nil)
+	s := New(tg, ms, nil, nil, nil, nil)
 
 	in := &dto.NewMessage{ChatId: 1, Text: "hi", ReplyToMessageId: 2}
 	msg := &client.Message{Id: 100}
@@ -76,7 +79,7 @@ func TestSendMessageAlbum(t *testing.T) {
 
 	tg := mocks.NewTelegramRepo(t)
 	ms := mocks.NewMessageService(t)
-	s := New(tg, ms, nil)
+	s := New(tg, ms, nil, nil, nil, nil)
 
 	newMessages := []*dto.NewMessage{
 		{ChatId: 1, Text: "first", ReplyToMessageId: 10, FilePath: "123"},
@@ -131,7 +134,7 @@ func TestForwardMessage(t *testing.T) {
 
 	tg := mocks.NewTelegramRepo(t)
 	ms := mocks.NewMessageService(t)
-	s := New(tg, ms, nil)
+	s := New(tg, ms, nil, nil, nil, nil)
 
 	chatId := int64(1)
 	msgId := int64(2)
@@ -153,7 +156,7 @@ func TestGetMessage(t *testing.T) {
 
 	tg := mocks.NewTelegramRepo(t)
 	ms := mocks.NewMessageService(t)
-	s := New(tg, ms, nil)
+	s := New(tg, ms, nil, nil, nil, nil)
 
 	chatId := int64(1)
 	msgId := int64(2)
@@ -175,7 +178,7 @@ func TestUpdateMessage(t *testing.T) {
 
 	tg := mocks.NewTelegramRepo(t)
 	ms := mocks.NewMessageService(t)
-	s := New(tg, ms, nil)
+	s := New(tg, ms, nil, nil, nil, nil)
 
 	upd := &dto.Message{Id: 2, ChatId: 1, Text: "upd"}
 	orig := &client.Message{Id: 2, ReplyMarkup: &client.ReplyMarkupInlineKeyboard{}} // пример
@@ -206,7 +209,7 @@ func TestDeleteMessages(t *testing.T) {
 
 	tg := mocks.NewTelegramRepo(t)
 	ms := mocks.NewMessageService(t)
-	s := New(tg, ms, nil)
+	s := New(tg, ms, nil, nil, nil, nil)
 
 	chatId := int64(1)
 	msgIds := []int64{2, 3}
@@ -221,7 +224,7 @@ func TestErrorFromRepo(t *testing.T) {
 
 	tg := mocks.NewTelegramRepo(t)
 	ms := mocks.NewMessageService(t)
-	s := New(tg, ms, nil)
+	s := New(tg, ms, nil, nil, nil, nil)
 
 	tg.EXPECT().GetMessages(&client.GetMessagesRequest{ChatId: 1, MessageIds: []int64{1}}).Return(nil, errors.New("fail"))
 	msgs, err := s.GetMessages(1, []int64{1})
@@ -234,7 +237,7 @@ func TestGetMessageLink(t *testing.T) {
 
 	tg := mocks.NewTelegramRepo(t)
 	ms := mocks.NewMessageService(t)
-	s := New(tg, ms, nil)
+	s := New(tg, ms, nil, nil, nil, nil)
 
 	chatId := int64(1)
 	msgId := int64(2)
@@ -254,7 +257,7 @@ func TestGetMessageLinkInfo(t *testing.T) {
 
 	tg := mocks.NewTelegramRepo(t)
 	ms := mocks.NewMessageService(t)
-	s := New(tg, ms, nil)
+	s := New(tg, ms, nil, nil, nil, nil)
 
 	link := "https://t.me/c/1/2"
 	msg := &client.Message{Id: 2, ChatId: 1, ForwardInfo: &client.MessageForwardInfo{}}
@@ -272,7 +275,7 @@ func TestGetChatHistory(t *testing.T) {
 
 	tg := mocks.NewTelegramRepo(t)
 	ms := mocks.NewMessageService(t)
-	s := New(tg, ms, nil)
+	s := New(tg, ms, nil, nil, nil, nil)
 
 	chatId := int64(1)
 	fromMessageId := int64(100)
@@ -313,3 +316,169 @@ func TestGetChatHistory(t *testing.T) {
 	assert.Equal(t, "message 2", result[1].Text)
 	assert.Equal(t, chatId, result[1].ChatId)
 }
+
+func TestGetWhence(t *testing.T) {
+	t.Parallel()
+
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+
+		ws := mocks.NewWhenceService(t)
+		s := New(nil, nil, nil, ws, nil, nil)
+
+		link := "https://t.me/c/1/2"
+		ws.EXPECT().Lookup(link).Return(&domain.Whence{
+			ForwardRuleId: "rule1",
+			ChatId:        -1003,
+			MessageId:     4,
+			Link:          "https://t.me/c/3/4",
+		}, nil)
+
+		result, err := s.GetWhence(link)
+		assert.NoError(t, err)
+		assert.Equal(t, &dto.Whence{
+			ForwardRuleId: "rule1",
+			ChatId:        -1003,
+			MessageId:     4,
+			Link:          "https://t.me/c/3/4",
+		}, result)
+	})
+
+	t.Run("without_storage", func(t *testing.T) {
+		t.Parallel()
+
+		s := New(nil, nil, nil, nil, nil, nil)
+
+		result, err := s.GetWhence("https://t.me/c/1/2")
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestGetExplain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+
+		es := mocks.NewExplainService(t)
+		s := New(nil, nil, nil, nil, es, nil)
+
+		link := "https://t.me/c/1/2"
+		date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		es.EXPECT().Explain(link).Return(&domain.Journal{
+			ChatId:    -1001,
+			MessageId: 2,
+			Date:      date,
+			Rules: []*domain.JournalRule{
+				{
+					ForwardRuleId: "rule1",
+					Verdict:       domain.JournalEvaluated,
+					FiltersMode:   domain.FiltersOK,
+					Pattern:       "include: новости",
+					Destinations: []*domain.JournalDestination{
+						{ChatId: -1002, Outcome: domain.StatsOk, MessageIds: []int64{20}},
+						{ChatId: -1003, Outcome: domain.StatsFailed, Error: "fail"},
+					},
+				},
+				{ForwardRuleId: "rule2", Verdict: domain.JournalOtherThread},
+			},
+		}, nil)
+
+		result, err := s.GetExplain(link)
+		assert.NoError(t, err)
+		assert.Equal(t, &dto.Journal{
+			ChatId:    -1001,
+			MessageId: 2,
+			Date:      date,
+			Rules: []*dto.JournalRule{
+				{
+					ForwardRuleId: "rule1",
+					Verdict:       "evaluated",
+					FiltersMode:   "ok",
+					Pattern:       "include: новости",
+					Destinations: []*dto.JournalDestination{
+						{ChatId: -1002, Outcome: "ok", MessageIds: []int64{20}},
+						{ChatId: -1003, Outcome: "failed", Error: "fail"},
+					},
+				},
+				{ForwardRuleId: "rule2", Verdict: "other_thread", Destinations: []*dto.JournalDestination{}},
+			},
+		}, result)
+	})
+
+	t.Run("without_storage", func(t *testing.T) {
+		t.Parallel()
+
+		s := New(nil, nil, nil, nil, nil, nil)
+
+		result, err := s.GetExplain("https://t.me/c/1/2")
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestGetBackups(t *testing.T) {
+	t.Parallel()
+
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+
+		ss := mocks.NewStorageService(t)
+		s := New(nil, nil, nil, nil, nil, ss)
+
+		createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		ss.EXPECT().GetBackups().Return([]*storageDto.Backup{
+			{Name: "backup-20250101-000000.000.bak", Size: 2048, CreatedAt: createdAt},
+		}, nil)
+
+		result, err := s.GetBackups()
+		assert.NoError(t, err)
+		assert.Equal(t, []*dto.Backup{
+			{Name: "backup-20250101-000000.000.bak", Size: 2048, CreatedAt: createdAt},
+		}, result)
+	})
+
+	t.Run("without_storage", func(t *testing.T) {
+		t.Parallel()
+
+		s := New(nil, nil, nil, nil, nil, nil)
+
+		result, err := s.GetBackups()
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestGetTopStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+
+		ss := mocks.NewStorageService(t)
+		s := New(nil, nil, nil, nil, nil, ss)
+
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		ss.EXPECT().GetTopStats(&domain.StatsFilter{From: from, Outcome: domain.StatsOk},
+			domain.StatsGroupBySource, 10).Return([]*domain.Stats{
+			{SrcChatId: -1001, Count: 12},
+		}, nil)
+
+		result, err := s.GetTopStats(&dto.StatsFilter{From: from, Outcome: "ok"}, "source", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []*dto.Stats{
+			{SrcChatId: -1001, Count: 12},
+		}, result)
+	})
+
+	t.Run("without_storage", func(t *testing.T) {
+		t.Parallel()
+
+		s := New(nil, nil, nil, nil, nil, nil)
+
+		result, err := s.GetTopStats(&dto.StatsFilter{}, "source", 10)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -1,6 +1,7 @@
 package filters_mode
 
 import (
+	"context"
 	"regexp"
 	"slices"
 
@@ -8,6 +9,7 @@ import (
 
 	"github.com/comerc/budva43/app/domain"
 	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/trace"
 )
 
 type Service struct {
@@ -21,7 +23,17 @@ func New() *Service {
 }
 
 // Map определяет, какой режим фильтрации применим
-func (s *Service) Map(formattedText *client.FormattedText, rule *domain.ForwardRule) domain.FiltersMode {
+func (s *Service) Map(ctx context.Context, formattedText *client.FormattedText, rule *domain.ForwardRule) domain.FiltersMode {
+	filtersMode, _ := s.Match(ctx, formattedText, rule)
+	return filtersMode
+}
+
+// Match определяет режим фильтрации и сработавший фильтр (для журнала решений);
+// фильтр пуст, если режим определён без совпадения с шаблоном
+func (s *Service) Match(ctx context.Context, formattedText *client.FormattedText, rule *domain.ForwardRule) (domain.FiltersMode, string) {
+	_, end := trace.Start(ctx, "filters", "forwardRuleId", rule.Id)
+	defer end(nil)
+
 	if formattedText.Text == "" {
 		hasInclude := false
 		if rule.Include != "" {
@@ -34,13 +46,13 @@ func (s *Service) Map(formattedText *client.FormattedText, rule *domain.ForwardR
 			}
 		}
 		if hasInclude {
-			return domain.FiltersOther
+			return domain.FiltersOther, ""
 		}
 	} else {
 		if rule.Exclude != "" {
 			re := regexp.MustCompile("(?i)" + rule.Exclude)
 			if re.FindString(formattedText.Text) != "" {
-				return domain.FiltersCheck
+				return domain.FiltersCheck, "exclude: " + rule.Exclude
 			}
 		}
 		hasInclude := false
@@ -48,7 +60,7 @@ func (s *Service) Map(formattedText *client.FormattedText, rule *domain.ForwardR
 			hasInclude = true
 			re := regexp.MustCompile("(?i)" + rule.Include)
 			if re.FindString(formattedText.Text) != "" {
-				return domain.FiltersOK
+				return domain.FiltersOK, "include: " + rule.Include
 			}
 		}
 		for _, includeSubmatch := range rule.IncludeSubmatch {
@@ -59,14 +71,14 @@ func (s *Service) Map(formattedText *client.FormattedText, rule *domain.ForwardR
 				for _, match := range matches {
 					s := match[includeSubmatch.Group]
 					if slices.Contains(includeSubmatch.Match, s) {
-						return domain.FiltersOK
+						return domain.FiltersOK, "include-submatch: " + includeSubmatch.Regexp + " = " + s
 					}
 				}
 			}
 		}
 		if hasInclude {
-			return domain.FiltersOther
+			return domain.FiltersOther, ""
 		}
 	}
-	return domain.FiltersOK
+	return domain.FiltersOK, ""
 }
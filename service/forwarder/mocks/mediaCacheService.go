@@ -0,0 +1,125 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	client "github.com/zelenin/go-tdlib/client"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MediaCacheService is an autogenerated mock type for the mediaCacheService type
+type MediaCacheService struct {
+	mock.Mock
+}
+
+type MediaCacheService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MediaCacheService) EXPECT() *MediaCacheService_Expecter {
+	return &MediaCacheService_Expecter{mock: &_m.Mock}
+}
+
+// Localize provides a mock function with given fields: content
+func (_m *MediaCacheService) Localize(content client.InputMessageContent) (bool, error) {
+	ret := _m.Called(content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Localize")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(client.InputMessageContent) (bool, error)); ok {
+		return rf(content)
+	}
+	if rf, ok := ret.Get(0).(func(client.InputMessageContent) bool); ok {
+		r0 = rf(content)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(client.InputMessageContent) error); ok {
+		r1 = rf(content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MediaCacheService_Localize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Localize'
+type MediaCacheService_Localize_Call struct {
+	*mock.Call
+}
+
+// Localize is a helper method to define mock.On call
+//   - content client.InputMessageContent
+func (_e *MediaCacheService_Expecter) Localize(content interface{}) *MediaCacheService_Localize_Call {
+	return &MediaCacheService_Localize_Call{Call: _e.mock.On("Localize", content)}
+}
+
+func (_c *MediaCacheService_Localize_Call) Run(run func(content client.InputMessageContent)) *MediaCacheService_Localize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(client.InputMessageContent))
+	})
+	return _c
+}
+
+func (_c *MediaCacheService_Localize_Call) Return(_a0 bool, _a1 error) *MediaCacheService_Localize_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MediaCacheService_Localize_Call) RunAndReturn(run func(client.InputMessageContent) (bool, error)) *MediaCacheService_Localize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: content
+func (_m *MediaCacheService) Release(content client.InputMessageContent) {
+	_m.Called(content)
+}
+
+// MediaCacheService_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type MediaCacheService_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - content client.InputMessageContent
+func (_e *MediaCacheService_Expecter) Release(content interface{}) *MediaCacheService_Release_Call {
+	return &MediaCacheService_Release_Call{Call: _e.mock.On("Release", content)}
+}
+
+func (_c *MediaCacheService_Release_Call) Run(run func(content client.InputMessageContent)) *MediaCacheService_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(client.InputMessageContent))
+	})
+	return _c
+}
+
+func (_c *MediaCacheService_Release_Call) Return() *MediaCacheService_Release_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MediaCacheService_Release_Call) RunAndReturn(run func(client.InputMessageContent)) *MediaCacheService_Release_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMediaCacheService creates a new instance of MediaCacheService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMediaCacheService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MediaCacheService {
+	mock := &MediaCacheService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
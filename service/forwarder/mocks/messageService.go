@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	domain "github.com/comerc/budva43/app/domain"
 	client "github.com/zelenin/go-tdlib/client"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,6 +22,41 @@ func (_m *MessageService) EXPECT() *MessageService_Expecter {
 	return &MessageService_Expecter{mock: &_m.Mock}
 }
 
+// ApplyMediaPolicy provides a mock function with given fields: content, message, mediaPolicy
+func (_m *MessageService) ApplyMediaPolicy(content client.InputMessageContent, message *client.Message, mediaPolicy *domain.MediaPolicy) {
+	_m.Called(content, message, mediaPolicy)
+}
+
+// MessageService_ApplyMediaPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyMediaPolicy'
+type MessageService_ApplyMediaPolicy_Call struct {
+	*mock.Call
+}
+
+// ApplyMediaPolicy is a helper method to define mock.On call
+//   - content client.InputMessageContent
+//   - message *client.Message
+//   - mediaPolicy *domain.MediaPolicy
+func (_e *MessageService_Expecter) ApplyMediaPolicy(content interface{}, message interface{}, mediaPolicy interface{}) *MessageService_ApplyMediaPolicy_Call {
+	return &MessageService_ApplyMediaPolicy_Call{Call: _e.mock.On("ApplyMediaPolicy", content, message, mediaPolicy)}
+}
+
+func (_c *MessageService_ApplyMediaPolicy_Call) Run(run func(content client.InputMessageContent, message *client.Message, mediaPolicy *domain.MediaPolicy)) *MessageService_ApplyMediaPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(client.InputMessageContent), args[1].(*client.Message), args[2].(*domain.MediaPolicy))
+	})
+	return _c
+}
+
+func (_c *MessageService_ApplyMediaPolicy_Call) Return() *MessageService_ApplyMediaPolicy_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MessageService_ApplyMediaPolicy_Call) RunAndReturn(run func(client.InputMessageContent, *client.Message, *domain.MediaPolicy)) *MessageService_ApplyMediaPolicy_Call {
+	_c.Run(run)
+	return _c
+}
+
 // GetFormattedText provides a mock function with given fields: message
 func (_m *MessageService) GetFormattedText(message *client.Message) *client.FormattedText {
 	ret := _m.Called(message)
@@ -166,6 +202,54 @@ func (_c *MessageService_GetReplyMarkupData_Call) RunAndReturn(run func(*client.
 	return _c
 }
 
+// SplitOverflow provides a mock function with given fields: content
+func (_m *MessageService) SplitOverflow(content client.InputMessageContent) []*client.FormattedText {
+	ret := _m.Called(content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SplitOverflow")
+	}
+
+	var r0 []*client.FormattedText
+	if rf, ok := ret.Get(0).(func(client.InputMessageContent) []*client.FormattedText); ok {
+		r0 = rf(content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*client.FormattedText)
+		}
+	}
+
+	return r0
+}
+
+// MessageService_SplitOverflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SplitOverflow'
+type MessageService_SplitOverflow_Call struct {
+	*mock.Call
+}
+
+// SplitOverflow is a helper method to define mock.On call
+//   - content client.InputMessageContent
+func (_e *MessageService_Expecter) SplitOverflow(content interface{}) *MessageService_SplitOverflow_Call {
+	return &MessageService_SplitOverflow_Call{Call: _e.mock.On("SplitOverflow", content)}
+}
+
+func (_c *MessageService_SplitOverflow_Call) Run(run func(content client.InputMessageContent)) *MessageService_SplitOverflow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(client.InputMessageContent))
+	})
+	return _c
+}
+
+func (_c *MessageService_SplitOverflow_Call) Return(_a0 []*client.FormattedText) *MessageService_SplitOverflow_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MessageService_SplitOverflow_Call) RunAndReturn(run func(client.InputMessageContent) []*client.FormattedText) *MessageService_SplitOverflow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMessageService creates a new instance of MessageService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMessageService(t interface {
@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	domain "github.com/comerc/budva43/app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // StorageService is an autogenerated mock type for the storageService type
 type StorageService struct {
@@ -17,20 +21,91 @@ func (_m *StorageService) EXPECT() *StorageService_Expecter {
 	return &StorageService_Expecter{mock: &_m.Mock}
 }
 
+// AddJournalDestination provides a mock function with given fields: chatId, messageId, forwardRuleId, destination
+func (_m *StorageService) AddJournalDestination(chatId int64, messageId int64, forwardRuleId string, destination *domain.JournalDestination) {
+	_m.Called(chatId, messageId, forwardRuleId, destination)
+}
+
+// StorageService_AddJournalDestination_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddJournalDestination'
+type StorageService_AddJournalDestination_Call struct {
+	*mock.Call
+}
+
+// AddJournalDestination is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+//   - forwardRuleId string
+//   - destination *domain.JournalDestination
+func (_e *StorageService_Expecter) AddJournalDestination(chatId interface{}, messageId interface{}, forwardRuleId interface{}, destination interface{}) *StorageService_AddJournalDestination_Call {
+	return &StorageService_AddJournalDestination_Call{Call: _e.mock.On("AddJournalDestination", chatId, messageId, forwardRuleId, destination)}
+}
+
+func (_c *StorageService_AddJournalDestination_Call) Run(run func(chatId int64, messageId int64, forwardRuleId string, destination *domain.JournalDestination)) *StorageService_AddJournalDestination_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(string), args[3].(*domain.JournalDestination))
+	})
+	return _c
+}
+
+func (_c *StorageService_AddJournalDestination_Call) Return() *StorageService_AddJournalDestination_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_AddJournalDestination_Call) RunAndReturn(run func(int64, int64, string, *domain.JournalDestination)) *StorageService_AddJournalDestination_Call {
+	_c.Run(run)
+	return _c
+}
+
+// AddRevisionMessageId provides a mock function with given fields: chatId, messageId, toChatMessage
+func (_m *StorageService) AddRevisionMessageId(chatId int64, messageId int64, toChatMessage *domain.ChatMessage) {
+	_m.Called(chatId, messageId, toChatMessage)
+}
+
+// StorageService_AddRevisionMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRevisionMessageId'
+type StorageService_AddRevisionMessageId_Call struct {
+	*mock.Call
+}
+
+// AddRevisionMessageId is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+//   - toChatMessage *domain.ChatMessage
+func (_e *StorageService_Expecter) AddRevisionMessageId(chatId interface{}, messageId interface{}, toChatMessage interface{}) *StorageService_AddRevisionMessageId_Call {
+	return &StorageService_AddRevisionMessageId_Call{Call: _e.mock.On("AddRevisionMessageId", chatId, messageId, toChatMessage)}
+}
+
+func (_c *StorageService_AddRevisionMessageId_Call) Run(run func(chatId int64, messageId int64, toChatMessage *domain.ChatMessage)) *StorageService_AddRevisionMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(*domain.ChatMessage))
+	})
+	return _c
+}
+
+func (_c *StorageService_AddRevisionMessageId_Call) Return() *StorageService_AddRevisionMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_AddRevisionMessageId_Call) RunAndReturn(run func(int64, int64, *domain.ChatMessage)) *StorageService_AddRevisionMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
 // GetCopiedMessageIds provides a mock function with given fields: chatId, messageId
-func (_m *StorageService) GetCopiedMessageIds(chatId int64, messageId int64) []string {
+func (_m *StorageService) GetCopiedMessageIds(chatId int64, messageId int64) []*domain.ChatMessage {
 	ret := _m.Called(chatId, messageId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCopiedMessageIds")
 	}
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func(int64, int64) []string); ok {
+	var r0 []*domain.ChatMessage
+	if rf, ok := ret.Get(0).(func(int64, int64) []*domain.ChatMessage); ok {
 		r0 = rf(chatId, messageId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]*domain.ChatMessage)
 		}
 	}
 
@@ -56,12 +131,59 @@ func (_c *StorageService_GetCopiedMessageIds_Call) Run(run func(chatId int64, me
 	return _c
 }
 
-func (_c *StorageService_GetCopiedMessageIds_Call) Return(_a0 []string) *StorageService_GetCopiedMessageIds_Call {
+func (_c *StorageService_GetCopiedMessageIds_Call) Return(_a0 []*domain.ChatMessage) *StorageService_GetCopiedMessageIds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetCopiedMessageIds_Call) RunAndReturn(run func(int64, int64) []*domain.ChatMessage) *StorageService_GetCopiedMessageIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetForumTopicId provides a mock function with given fields: dstChatId, srcChatId
+func (_m *StorageService) GetForumTopicId(dstChatId int64, srcChatId int64) int64 {
+	ret := _m.Called(dstChatId, srcChatId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForumTopicId")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(dstChatId, srcChatId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// StorageService_GetForumTopicId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetForumTopicId'
+type StorageService_GetForumTopicId_Call struct {
+	*mock.Call
+}
+
+// GetForumTopicId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - srcChatId int64
+func (_e *StorageService_Expecter) GetForumTopicId(dstChatId interface{}, srcChatId interface{}) *StorageService_GetForumTopicId_Call {
+	return &StorageService_GetForumTopicId_Call{Call: _e.mock.On("GetForumTopicId", dstChatId, srcChatId)}
+}
+
+func (_c *StorageService_GetForumTopicId_Call) Run(run func(dstChatId int64, srcChatId int64)) *StorageService_GetForumTopicId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetForumTopicId_Call) Return(_a0 int64) *StorageService_GetForumTopicId_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *StorageService_GetCopiedMessageIds_Call) RunAndReturn(run func(int64, int64) []string) *StorageService_GetCopiedMessageIds_Call {
+func (_c *StorageService_GetForumTopicId_Call) RunAndReturn(run func(int64, int64) int64) *StorageService_GetForumTopicId_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -113,6 +235,91 @@ func (_c *StorageService_GetNewMessageId_Call) RunAndReturn(run func(int64, int6
 	return _c
 }
 
+// GetRevisionMessageIds provides a mock function with given fields: chatId, messageId
+func (_m *StorageService) GetRevisionMessageIds(chatId int64, messageId int64) []*domain.ChatMessage {
+	ret := _m.Called(chatId, messageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisionMessageIds")
+	}
+
+	var r0 []*domain.ChatMessage
+	if rf, ok := ret.Get(0).(func(int64, int64) []*domain.ChatMessage); ok {
+		r0 = rf(chatId, messageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ChatMessage)
+		}
+	}
+
+	return r0
+}
+
+// StorageService_GetRevisionMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevisionMessageIds'
+type StorageService_GetRevisionMessageIds_Call struct {
+	*mock.Call
+}
+
+// GetRevisionMessageIds is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+func (_e *StorageService_Expecter) GetRevisionMessageIds(chatId interface{}, messageId interface{}) *StorageService_GetRevisionMessageIds_Call {
+	return &StorageService_GetRevisionMessageIds_Call{Call: _e.mock.On("GetRevisionMessageIds", chatId, messageId)}
+}
+
+func (_c *StorageService_GetRevisionMessageIds_Call) Run(run func(chatId int64, messageId int64)) *StorageService_GetRevisionMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetRevisionMessageIds_Call) Return(_a0 []*domain.ChatMessage) *StorageService_GetRevisionMessageIds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetRevisionMessageIds_Call) RunAndReturn(run func(int64, int64) []*domain.ChatMessage) *StorageService_GetRevisionMessageIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementStats provides a mock function with given fields: forwardRuleId, srcChatId, dstChatId, outcome
+func (_m *StorageService) IncrementStats(forwardRuleId string, srcChatId int64, dstChatId int64, outcome string) {
+	_m.Called(forwardRuleId, srcChatId, dstChatId, outcome)
+}
+
+// StorageService_IncrementStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementStats'
+type StorageService_IncrementStats_Call struct {
+	*mock.Call
+}
+
+// IncrementStats is a helper method to define mock.On call
+//   - forwardRuleId string
+//   - srcChatId int64
+//   - dstChatId int64
+//   - outcome string
+func (_e *StorageService_Expecter) IncrementStats(forwardRuleId interface{}, srcChatId interface{}, dstChatId interface{}, outcome interface{}) *StorageService_IncrementStats_Call {
+	return &StorageService_IncrementStats_Call{Call: _e.mock.On("IncrementStats", forwardRuleId, srcChatId, dstChatId, outcome)}
+}
+
+func (_c *StorageService_IncrementStats_Call) Run(run func(forwardRuleId string, srcChatId int64, dstChatId int64, outcome string)) *StorageService_IncrementStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int64), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *StorageService_IncrementStats_Call) Return() *StorageService_IncrementStats_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_IncrementStats_Call) RunAndReturn(run func(string, int64, int64, string)) *StorageService_IncrementStats_Call {
+	_c.Run(run)
+	return _c
+}
+
 // SetAnswerMessageId provides a mock function with given fields: dstChatId, tmpMessageId, chatId, messageId
 func (_m *StorageService) SetAnswerMessageId(dstChatId int64, tmpMessageId int64, chatId int64, messageId int64) {
 	_m.Called(dstChatId, tmpMessageId, chatId, messageId)
@@ -149,9 +356,9 @@ func (_c *StorageService_SetAnswerMessageId_Call) RunAndReturn(run func(int64, i
 	return _c
 }
 
-// SetCopiedMessageId provides a mock function with given fields: chatId, messageId, toChatMessageId
-func (_m *StorageService) SetCopiedMessageId(chatId int64, messageId int64, toChatMessageId string) {
-	_m.Called(chatId, messageId, toChatMessageId)
+// SetCopiedMessageId provides a mock function with given fields: chatId, messageId, toChatMessage
+func (_m *StorageService) SetCopiedMessageId(chatId int64, messageId int64, toChatMessage *domain.ChatMessage) {
+	_m.Called(chatId, messageId, toChatMessage)
 }
 
 // StorageService_SetCopiedMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCopiedMessageId'
@@ -162,14 +369,14 @@ type StorageService_SetCopiedMessageId_Call struct {
 // SetCopiedMessageId is a helper method to define mock.On call
 //   - chatId int64
 //   - messageId int64
-//   - toChatMessageId string
-func (_e *StorageService_Expecter) SetCopiedMessageId(chatId interface{}, messageId interface{}, toChatMessageId interface{}) *StorageService_SetCopiedMessageId_Call {
-	return &StorageService_SetCopiedMessageId_Call{Call: _e.mock.On("SetCopiedMessageId", chatId, messageId, toChatMessageId)}
+//   - toChatMessage *domain.ChatMessage
+func (_e *StorageService_Expecter) SetCopiedMessageId(chatId interface{}, messageId interface{}, toChatMessage interface{}) *StorageService_SetCopiedMessageId_Call {
+	return &StorageService_SetCopiedMessageId_Call{Call: _e.mock.On("SetCopiedMessageId", chatId, messageId, toChatMessage)}
 }
 
-func (_c *StorageService_SetCopiedMessageId_Call) Run(run func(chatId int64, messageId int64, toChatMessageId string)) *StorageService_SetCopiedMessageId_Call {
+func (_c *StorageService_SetCopiedMessageId_Call) Run(run func(chatId int64, messageId int64, toChatMessage *domain.ChatMessage)) *StorageService_SetCopiedMessageId_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64), args[1].(int64), args[2].(string))
+		run(args[0].(int64), args[1].(int64), args[2].(*domain.ChatMessage))
 	})
 	return _c
 }
@@ -179,7 +386,218 @@ func (_c *StorageService_SetCopiedMessageId_Call) Return() *StorageService_SetCo
 	return _c
 }
 
-func (_c *StorageService_SetCopiedMessageId_Call) RunAndReturn(run func(int64, int64, string)) *StorageService_SetCopiedMessageId_Call {
+func (_c *StorageService_SetCopiedMessageId_Call) RunAndReturn(run func(int64, int64, *domain.ChatMessage)) *StorageService_SetCopiedMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetForumTopicId provides a mock function with given fields: dstChatId, srcChatId, messageThreadId
+func (_m *StorageService) SetForumTopicId(dstChatId int64, srcChatId int64, messageThreadId int64) {
+	_m.Called(dstChatId, srcChatId, messageThreadId)
+}
+
+// StorageService_SetForumTopicId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetForumTopicId'
+type StorageService_SetForumTopicId_Call struct {
+	*mock.Call
+}
+
+// SetForumTopicId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - srcChatId int64
+//   - messageThreadId int64
+func (_e *StorageService_Expecter) SetForumTopicId(dstChatId interface{}, srcChatId interface{}, messageThreadId interface{}) *StorageService_SetForumTopicId_Call {
+	return &StorageService_SetForumTopicId_Call{Call: _e.mock.On("SetForumTopicId", dstChatId, srcChatId, messageThreadId)}
+}
+
+func (_c *StorageService_SetForumTopicId_Call) Run(run func(dstChatId int64, srcChatId int64, messageThreadId int64)) *StorageService_SetForumTopicId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_SetForumTopicId_Call) Return() *StorageService_SetForumTopicId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_SetForumTopicId_Call) RunAndReturn(run func(int64, int64, int64)) *StorageService_SetForumTopicId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetMediaAlbumMessageIds provides a mock function with given fields: chatId, messageIds
+func (_m *StorageService) SetMediaAlbumMessageIds(chatId int64, messageIds []int64) {
+	_m.Called(chatId, messageIds)
+}
+
+// StorageService_SetMediaAlbumMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMediaAlbumMessageIds'
+type StorageService_SetMediaAlbumMessageIds_Call struct {
+	*mock.Call
+}
+
+// SetMediaAlbumMessageIds is a helper method to define mock.On call
+//   - chatId int64
+//   - messageIds []int64
+func (_e *StorageService_Expecter) SetMediaAlbumMessageIds(chatId interface{}, messageIds interface{}) *StorageService_SetMediaAlbumMessageIds_Call {
+	return &StorageService_SetMediaAlbumMessageIds_Call{Call: _e.mock.On("SetMediaAlbumMessageIds", chatId, messageIds)}
+}
+
+func (_c *StorageService_SetMediaAlbumMessageIds_Call) Run(run func(chatId int64, messageIds []int64)) *StorageService_SetMediaAlbumMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_SetMediaAlbumMessageIds_Call) Return() *StorageService_SetMediaAlbumMessageIds_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_SetMediaAlbumMessageIds_Call) RunAndReturn(run func(int64, []int64)) *StorageService_SetMediaAlbumMessageIds_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetOriginMessageId provides a mock function with given fields: dstChatId, dstMessageId, forwardRuleId, chatId, messageId
+func (_m *StorageService) SetOriginMessageId(dstChatId int64, dstMessageId int64, forwardRuleId string, chatId int64, messageId int64) {
+	_m.Called(dstChatId, dstMessageId, forwardRuleId, chatId, messageId)
+}
+
+// StorageService_SetOriginMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOriginMessageId'
+type StorageService_SetOriginMessageId_Call struct {
+	*mock.Call
+}
+
+// SetOriginMessageId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - dstMessageId int64
+//   - forwardRuleId string
+//   - chatId int64
+//   - messageId int64
+func (_e *StorageService_Expecter) SetOriginMessageId(dstChatId interface{}, dstMessageId interface{}, forwardRuleId interface{}, chatId interface{}, messageId interface{}) *StorageService_SetOriginMessageId_Call {
+	return &StorageService_SetOriginMessageId_Call{Call: _e.mock.On("SetOriginMessageId", dstChatId, dstMessageId, forwardRuleId, chatId, messageId)}
+}
+
+func (_c *StorageService_SetOriginMessageId_Call) Run(run func(dstChatId int64, dstMessageId int64, forwardRuleId string, chatId int64, messageId int64)) *StorageService_SetOriginMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(string), args[3].(int64), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_SetOriginMessageId_Call) Return() *StorageService_SetOriginMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_SetOriginMessageId_Call) RunAndReturn(run func(int64, int64, string, int64, int64)) *StorageService_SetOriginMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetOverflowMessageIds provides a mock function with given fields: dstChatId, tmpMessageId, overflowTmpMessageIds
+func (_m *StorageService) SetOverflowMessageIds(dstChatId int64, tmpMessageId int64, overflowTmpMessageIds []int64) {
+	_m.Called(dstChatId, tmpMessageId, overflowTmpMessageIds)
+}
+
+// StorageService_SetOverflowMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOverflowMessageIds'
+type StorageService_SetOverflowMessageIds_Call struct {
+	*mock.Call
+}
+
+// SetOverflowMessageIds is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+//   - overflowTmpMessageIds []int64
+func (_e *StorageService_Expecter) SetOverflowMessageIds(dstChatId interface{}, tmpMessageId interface{}, overflowTmpMessageIds interface{}) *StorageService_SetOverflowMessageIds_Call {
+	return &StorageService_SetOverflowMessageIds_Call{Call: _e.mock.On("SetOverflowMessageIds", dstChatId, tmpMessageId, overflowTmpMessageIds)}
+}
+
+func (_c *StorageService_SetOverflowMessageIds_Call) Run(run func(dstChatId int64, tmpMessageId int64, overflowTmpMessageIds []int64)) *StorageService_SetOverflowMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].([]int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_SetOverflowMessageIds_Call) Return() *StorageService_SetOverflowMessageIds_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_SetOverflowMessageIds_Call) RunAndReturn(run func(int64, int64, []int64)) *StorageService_SetOverflowMessageIds_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetReplyContextMessageId provides a mock function with given fields: dstChatId, tmpMessageId, contextTmpMessageId
+func (_m *StorageService) SetReplyContextMessageId(dstChatId int64, tmpMessageId int64, contextTmpMessageId int64) {
+	_m.Called(dstChatId, tmpMessageId, contextTmpMessageId)
+}
+
+// StorageService_SetReplyContextMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReplyContextMessageId'
+type StorageService_SetReplyContextMessageId_Call struct {
+	*mock.Call
+}
+
+// SetReplyContextMessageId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - tmpMessageId int64
+//   - contextTmpMessageId int64
+func (_e *StorageService_Expecter) SetReplyContextMessageId(dstChatId interface{}, tmpMessageId interface{}, contextTmpMessageId interface{}) *StorageService_SetReplyContextMessageId_Call {
+	return &StorageService_SetReplyContextMessageId_Call{Call: _e.mock.On("SetReplyContextMessageId", dstChatId, tmpMessageId, contextTmpMessageId)}
+}
+
+func (_c *StorageService_SetReplyContextMessageId_Call) Run(run func(dstChatId int64, tmpMessageId int64, contextTmpMessageId int64)) *StorageService_SetReplyContextMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_SetReplyContextMessageId_Call) Return() *StorageService_SetReplyContextMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_SetReplyContextMessageId_Call) RunAndReturn(run func(int64, int64, int64)) *StorageService_SetReplyContextMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetTextSnapshot provides a mock function with given fields: chatId, messageId, text
+func (_m *StorageService) SetTextSnapshot(chatId int64, messageId int64, text string) {
+	_m.Called(chatId, messageId, text)
+}
+
+// StorageService_SetTextSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTextSnapshot'
+type StorageService_SetTextSnapshot_Call struct {
+	*mock.Call
+}
+
+// SetTextSnapshot is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+//   - text string
+func (_e *StorageService_Expecter) SetTextSnapshot(chatId interface{}, messageId interface{}, text interface{}) *StorageService_SetTextSnapshot_Call {
+	return &StorageService_SetTextSnapshot_Call{Call: _e.mock.On("SetTextSnapshot", chatId, messageId, text)}
+}
+
+func (_c *StorageService_SetTextSnapshot_Call) Run(run func(chatId int64, messageId int64, text string)) *StorageService_SetTextSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *StorageService_SetTextSnapshot_Call) Return() *StorageService_SetTextSnapshot_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_SetTextSnapshot_Call) RunAndReturn(run func(int64, int64, string)) *StorageService_SetTextSnapshot_Call {
 	_c.Run(run)
 	return _c
 }
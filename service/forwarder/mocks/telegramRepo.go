@@ -21,6 +21,64 @@ func (_m *TelegramRepo) EXPECT() *TelegramRepo_Expecter {
 	return &TelegramRepo_Expecter{mock: &_m.Mock}
 }
 
+// CreateForumTopic provides a mock function with given fields: _a0
+func (_m *TelegramRepo) CreateForumTopic(_a0 *client.CreateForumTopicRequest) (*client.ForumTopicInfo, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateForumTopic")
+	}
+
+	var r0 *client.ForumTopicInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*client.CreateForumTopicRequest) (*client.ForumTopicInfo, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*client.CreateForumTopicRequest) *client.ForumTopicInfo); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.ForumTopicInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*client.CreateForumTopicRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TelegramRepo_CreateForumTopic_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateForumTopic'
+type TelegramRepo_CreateForumTopic_Call struct {
+	*mock.Call
+}
+
+// CreateForumTopic is a helper method to define mock.On call
+//   - _a0 *client.CreateForumTopicRequest
+func (_e *TelegramRepo_Expecter) CreateForumTopic(_a0 interface{}) *TelegramRepo_CreateForumTopic_Call {
+	return &TelegramRepo_CreateForumTopic_Call{Call: _e.mock.On("CreateForumTopic", _a0)}
+}
+
+func (_c *TelegramRepo_CreateForumTopic_Call) Run(run func(_a0 *client.CreateForumTopicRequest)) *TelegramRepo_CreateForumTopic_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.CreateForumTopicRequest))
+	})
+	return _c
+}
+
+func (_c *TelegramRepo_CreateForumTopic_Call) Return(_a0 *client.ForumTopicInfo, _a1 error) *TelegramRepo_CreateForumTopic_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TelegramRepo_CreateForumTopic_Call) RunAndReturn(run func(*client.CreateForumTopicRequest) (*client.ForumTopicInfo, error)) *TelegramRepo_CreateForumTopic_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EditMessageText provides a mock function with given fields: _a0
 func (_m *TelegramRepo) EditMessageText(_a0 *client.EditMessageTextRequest) (*client.Message, error) {
 	ret := _m.Called(_a0)
@@ -137,6 +195,64 @@ func (_c *TelegramRepo_ForwardMessages_Call) RunAndReturn(run func(*client.Forwa
 	return _c
 }
 
+// GetChat provides a mock function with given fields: _a0
+func (_m *TelegramRepo) GetChat(_a0 *client.GetChatRequest) (*client.Chat, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChat")
+	}
+
+	var r0 *client.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*client.GetChatRequest) (*client.Chat, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*client.GetChatRequest) *client.Chat); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Chat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*client.GetChatRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TelegramRepo_GetChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChat'
+type TelegramRepo_GetChat_Call struct {
+	*mock.Call
+}
+
+// GetChat is a helper method to define mock.On call
+//   - _a0 *client.GetChatRequest
+func (_e *TelegramRepo_Expecter) GetChat(_a0 interface{}) *TelegramRepo_GetChat_Call {
+	return &TelegramRepo_GetChat_Call{Call: _e.mock.On("GetChat", _a0)}
+}
+
+func (_c *TelegramRepo_GetChat_Call) Run(run func(_a0 *client.GetChatRequest)) *TelegramRepo_GetChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.GetChatRequest))
+	})
+	return _c
+}
+
+func (_c *TelegramRepo_GetChat_Call) Return(_a0 *client.Chat, _a1 error) *TelegramRepo_GetChat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TelegramRepo_GetChat_Call) RunAndReturn(run func(*client.GetChatRequest) (*client.Chat, error)) *TelegramRepo_GetChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetMessage provides a mock function with given fields: _a0
 func (_m *TelegramRepo) GetMessage(_a0 *client.GetMessageRequest) (*client.Message, error) {
 	ret := _m.Called(_a0)
@@ -195,6 +311,64 @@ func (_c *TelegramRepo_GetMessage_Call) RunAndReturn(run func(*client.GetMessage
 	return _c
 }
 
+// GetMessageLink provides a mock function with given fields: _a0
+func (_m *TelegramRepo) GetMessageLink(_a0 *client.GetMessageLinkRequest) (*client.MessageLink, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessageLink")
+	}
+
+	var r0 *client.MessageLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*client.GetMessageLinkRequest) (*client.MessageLink, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*client.GetMessageLinkRequest) *client.MessageLink); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.MessageLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*client.GetMessageLinkRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TelegramRepo_GetMessageLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMessageLink'
+type TelegramRepo_GetMessageLink_Call struct {
+	*mock.Call
+}
+
+// GetMessageLink is a helper method to define mock.On call
+//   - _a0 *client.GetMessageLinkRequest
+func (_e *TelegramRepo_Expecter) GetMessageLink(_a0 interface{}) *TelegramRepo_GetMessageLink_Call {
+	return &TelegramRepo_GetMessageLink_Call{Call: _e.mock.On("GetMessageLink", _a0)}
+}
+
+func (_c *TelegramRepo_GetMessageLink_Call) Run(run func(_a0 *client.GetMessageLinkRequest)) *TelegramRepo_GetMessageLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.GetMessageLinkRequest))
+	})
+	return _c
+}
+
+func (_c *TelegramRepo_GetMessageLink_Call) Return(_a0 *client.MessageLink, _a1 error) *TelegramRepo_GetMessageLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TelegramRepo_GetMessageLink_Call) RunAndReturn(run func(*client.GetMessageLinkRequest) (*client.MessageLink, error)) *TelegramRepo_GetMessageLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SendMessage provides a mock function with given fields: _a0
 func (_m *TelegramRepo) SendMessage(_a0 *client.SendMessageRequest) (*client.Message, error) {
 	ret := _m.Called(_a0)
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	domain "github.com/comerc/budva43/app/domain"
 	client "github.com/zelenin/go-tdlib/client"
 
@@ -59,9 +61,114 @@ func (_c *TransformService_AddNextLink_Call) RunAndReturn(run func(*client.Forma
 	return _c
 }
 
-// Transform provides a mock function with given fields: formattedText, withSources, src, dstChatId, prevMessageId, engineConfig
-func (_m *TransformService) Transform(formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId int64, prevMessageId int64, engineConfig *domain.EngineConfig) {
-	_m.Called(formattedText, withSources, src, dstChatId, prevMessageId, engineConfig)
+// AddProtectedContentNote provides a mock function with given fields: formattedText, forwardRule
+func (_m *TransformService) AddProtectedContentNote(formattedText *client.FormattedText, forwardRule *domain.ForwardRule) {
+	_m.Called(formattedText, forwardRule)
+}
+
+// TransformService_AddProtectedContentNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddProtectedContentNote'
+type TransformService_AddProtectedContentNote_Call struct {
+	*mock.Call
+}
+
+// AddProtectedContentNote is a helper method to define mock.On call
+//   - formattedText *client.FormattedText
+//   - forwardRule *domain.ForwardRule
+func (_e *TransformService_Expecter) AddProtectedContentNote(formattedText interface{}, forwardRule interface{}) *TransformService_AddProtectedContentNote_Call {
+	return &TransformService_AddProtectedContentNote_Call{Call: _e.mock.On("AddProtectedContentNote", formattedText, forwardRule)}
+}
+
+func (_c *TransformService_AddProtectedContentNote_Call) Run(run func(formattedText *client.FormattedText, forwardRule *domain.ForwardRule)) *TransformService_AddProtectedContentNote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.FormattedText), args[1].(*domain.ForwardRule))
+	})
+	return _c
+}
+
+func (_c *TransformService_AddProtectedContentNote_Call) Return() *TransformService_AddProtectedContentNote_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *TransformService_AddProtectedContentNote_Call) RunAndReturn(run func(*client.FormattedText, *domain.ForwardRule)) *TransformService_AddProtectedContentNote_Call {
+	_c.Run(run)
+	return _c
+}
+
+// AddReplyContext provides a mock function with given fields: formattedText, quote, link, forwardRule
+func (_m *TransformService) AddReplyContext(formattedText *client.FormattedText, quote string, link string, forwardRule *domain.ForwardRule) {
+	_m.Called(formattedText, quote, link, forwardRule)
+}
+
+// TransformService_AddReplyContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddReplyContext'
+type TransformService_AddReplyContext_Call struct {
+	*mock.Call
+}
+
+// AddReplyContext is a helper method to define mock.On call
+//   - formattedText *client.FormattedText
+//   - quote string
+//   - link string
+//   - forwardRule *domain.ForwardRule
+func (_e *TransformService_Expecter) AddReplyContext(formattedText interface{}, quote interface{}, link interface{}, forwardRule interface{}) *TransformService_AddReplyContext_Call {
+	return &TransformService_AddReplyContext_Call{Call: _e.mock.On("AddReplyContext", formattedText, quote, link, forwardRule)}
+}
+
+func (_c *TransformService_AddReplyContext_Call) Run(run func(formattedText *client.FormattedText, quote string, link string, forwardRule *domain.ForwardRule)) *TransformService_AddReplyContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.FormattedText), args[1].(string), args[2].(string), args[3].(*domain.ForwardRule))
+	})
+	return _c
+}
+
+func (_c *TransformService_AddReplyContext_Call) Return() *TransformService_AddReplyContext_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *TransformService_AddReplyContext_Call) RunAndReturn(run func(*client.FormattedText, string, string, *domain.ForwardRule)) *TransformService_AddReplyContext_Call {
+	_c.Run(run)
+	return _c
+}
+
+// AddRevisionMark provides a mock function with given fields: formattedText, revision, forwardRule
+func (_m *TransformService) AddRevisionMark(formattedText *client.FormattedText, revision int, forwardRule *domain.ForwardRule) {
+	_m.Called(formattedText, revision, forwardRule)
+}
+
+// TransformService_AddRevisionMark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRevisionMark'
+type TransformService_AddRevisionMark_Call struct {
+	*mock.Call
+}
+
+// AddRevisionMark is a helper method to define mock.On call
+//   - formattedText *client.FormattedText
+//   - revision int
+//   - forwardRule *domain.ForwardRule
+func (_e *TransformService_Expecter) AddRevisionMark(formattedText interface{}, revision interface{}, forwardRule interface{}) *TransformService_AddRevisionMark_Call {
+	return &TransformService_AddRevisionMark_Call{Call: _e.mock.On("AddRevisionMark", formattedText, revision, forwardRule)}
+}
+
+func (_c *TransformService_AddRevisionMark_Call) Run(run func(formattedText *client.FormattedText, revision int, forwardRule *domain.ForwardRule)) *TransformService_AddRevisionMark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.FormattedText), args[1].(int), args[2].(*domain.ForwardRule))
+	})
+	return _c
+}
+
+func (_c *TransformService_AddRevisionMark_Call) Return() *TransformService_AddRevisionMark_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *TransformService_AddRevisionMark_Call) RunAndReturn(run func(*client.FormattedText, int, *domain.ForwardRule)) *TransformService_AddRevisionMark_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Transform provides a mock function with given fields: ctx, formattedText, withSources, src, dstChatId, prevMessageId, engineConfig
+func (_m *TransformService) Transform(ctx context.Context, formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId int64, prevMessageId int64, engineConfig *domain.EngineConfig) {
+	_m.Called(ctx, formattedText, withSources, src, dstChatId, prevMessageId, engineConfig)
 }
 
 // TransformService_Transform_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Transform'
@@ -70,19 +177,20 @@ type TransformService_Transform_Call struct {
 }
 
 // Transform is a helper method to define mock.On call
+//   - ctx context.Context
 //   - formattedText *client.FormattedText
 //   - withSources bool
 //   - src *client.Message
 //   - dstChatId int64
 //   - prevMessageId int64
 //   - engineConfig *domain.EngineConfig
-func (_e *TransformService_Expecter) Transform(formattedText interface{}, withSources interface{}, src interface{}, dstChatId interface{}, prevMessageId interface{}, engineConfig interface{}) *TransformService_Transform_Call {
-	return &TransformService_Transform_Call{Call: _e.mock.On("Transform", formattedText, withSources, src, dstChatId, prevMessageId, engineConfig)}
+func (_e *TransformService_Expecter) Transform(ctx interface{}, formattedText interface{}, withSources interface{}, src interface{}, dstChatId interface{}, prevMessageId interface{}, engineConfig interface{}) *TransformService_Transform_Call {
+	return &TransformService_Transform_Call{Call: _e.mock.On("Transform", ctx, formattedText, withSources, src, dstChatId, prevMessageId, engineConfig)}
 }
 
-func (_c *TransformService_Transform_Call) Run(run func(formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId int64, prevMessageId int64, engineConfig *domain.EngineConfig)) *TransformService_Transform_Call {
+func (_c *TransformService_Transform_Call) Run(run func(ctx context.Context, formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId int64, prevMessageId int64, engineConfig *domain.EngineConfig)) *TransformService_Transform_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*client.FormattedText), args[1].(bool), args[2].(*client.Message), args[3].(int64), args[4].(int64), args[5].(*domain.EngineConfig))
+		run(args[0].(context.Context), args[1].(*client.FormattedText), args[2].(bool), args[3].(*client.Message), args[4].(int64), args[5].(int64), args[6].(*domain.EngineConfig))
 	})
 	return _c
 }
@@ -92,7 +200,7 @@ func (_c *TransformService_Transform_Call) Return() *TransformService_Transform_
 	return _c
 }
 
-func (_c *TransformService_Transform_Call) RunAndReturn(run func(*client.FormattedText, bool, *client.Message, int64, int64, *domain.EngineConfig)) *TransformService_Transform_Call {
+func (_c *TransformService_Transform_Call) RunAndReturn(run func(context.Context, *client.FormattedText, bool, *client.Message, int64, int64, *domain.EngineConfig)) *TransformService_Transform_Call {
 	_c.Run(run)
 	return _c
 }
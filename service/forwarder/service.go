@@ -2,14 +2,17 @@ package forwarder
 
 import (
 	"context"
-	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/zelenin/go-tdlib/client"
 
 	"github.com/comerc/budva43/app/domain"
 	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/metrics"
+	"github.com/comerc/budva43/app/trace"
 	"github.com/comerc/budva43/app/util"
 )
 
@@ -18,17 +21,31 @@ type telegramRepo interface {
 	// tdlibClient methods
 	ForwardMessages(*client.ForwardMessagesRequest) (*client.Messages, error)
 	GetMessage(*client.GetMessageRequest) (*client.Message, error)
+	GetMessageLink(*client.GetMessageLinkRequest) (*client.MessageLink, error)
 	SendMessage(*client.SendMessageRequest) (*client.Message, error)
 	SendMessageAlbum(*client.SendMessageAlbumRequest) (*client.Messages, error)
 	EditMessageText(*client.EditMessageTextRequest) (*client.Message, error)
+	GetChat(*client.GetChatRequest) (*client.Chat, error)
+	CreateForumTopic(*client.CreateForumTopicRequest) (*client.ForumTopicInfo, error)
 }
 
 //go:generate mockery --name=storageService --exported
 type storageService interface {
-	SetCopiedMessageId(chatId, messageId int64, toChatMessageId string)
-	GetCopiedMessageIds(chatId, messageId int64) []string
+	SetCopiedMessageId(chatId, messageId int64, toChatMessage *domain.ChatMessage)
+	GetCopiedMessageIds(chatId, messageId int64) []*domain.ChatMessage
 	GetNewMessageId(chatId, tmpMessageId int64) int64
 	SetAnswerMessageId(dstChatId, tmpMessageId, chatId, messageId int64)
+	SetOriginMessageId(dstChatId, dstMessageId int64, forwardRuleId string, chatId, messageId int64)
+	AddRevisionMessageId(chatId, messageId int64, toChatMessage *domain.ChatMessage)
+	GetRevisionMessageIds(chatId, messageId int64) []*domain.ChatMessage
+	SetTextSnapshot(chatId, messageId int64, text string)
+	SetMediaAlbumMessageIds(chatId int64, messageIds []int64)
+	SetOverflowMessageIds(dstChatId, tmpMessageId int64, overflowTmpMessageIds []int64)
+	GetForumTopicId(dstChatId, srcChatId int64) int64
+	SetForumTopicId(dstChatId, srcChatId, messageThreadId int64)
+	SetReplyContextMessageId(dstChatId, tmpMessageId, contextTmpMessageId int64)
+	IncrementStats(forwardRuleId string, srcChatId, dstChatId int64, outcome domain.StatsOutcome)
+	AddJournalDestination(chatId, messageId int64, forwardRuleId string, destination *domain.JournalDestination)
 }
 
 //go:generate mockery --name=messageService --exported
@@ -36,12 +53,23 @@ type messageService interface {
 	GetFormattedText(message *client.Message) *client.FormattedText
 	GetInputMessageContent(message *client.Message, formattedText *client.FormattedText) client.InputMessageContent
 	GetReplyMarkupData(message *client.Message) []byte
+	ApplyMediaPolicy(content client.InputMessageContent, message *client.Message, mediaPolicy *domain.MediaPolicy)
+	SplitOverflow(content client.InputMessageContent) []*client.FormattedText
 }
 
 //go:generate mockery --name=transformService --exported
 type transformService interface {
-	Transform(formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId, prevMessageId int64, engineConfig *domain.EngineConfig)
+	Transform(ctx context.Context, formattedText *client.FormattedText, withSources bool, src *client.Message, dstChatId, prevMessageId int64, engineConfig *domain.EngineConfig)
 	AddNextLink(formattedText *client.FormattedText, srcChatId, dstChatId, newMessageId int64, engineConfig *domain.EngineConfig)
+	AddRevisionMark(formattedText *client.FormattedText, revision int, forwardRule *domain.ForwardRule)
+	AddProtectedContentNote(formattedText *client.FormattedText, forwardRule *domain.ForwardRule)
+	AddReplyContext(formattedText *client.FormattedText, quote, link string, forwardRule *domain.ForwardRule)
+}
+
+//go:generate mockery --name=mediaCacheService --exported
+type mediaCacheService interface {
+	Localize(content client.InputMessageContent) (bool, error)
+	Release(content client.InputMessageContent)
 }
 
 //go:generate mockery --name=rateLimiterService --exported
@@ -49,14 +77,22 @@ type rateLimiterService interface {
 	WaitForForward(ctx context.Context, dstChatId int64)
 }
 
+// replyContext контекст ответа на сообщение, которое не удалось связать в получателе
+type replyContext struct {
+	quote string
+	link  string
+}
+
 type Service struct {
 	log *log.Logger
 	ctx context.Context
 	//
+	mu                 sync.Mutex
 	telegramRepo       telegramRepo
 	storageService     storageService
 	messageService     messageService
 	transformService   transformService
+	mediaCacheService  mediaCacheService
 	rateLimiterService rateLimiterService
 }
 
@@ -65,6 +101,7 @@ func New(
 	storageService storageService,
 	messageService messageService,
 	transformService transformService,
+	mediaCacheService mediaCacheService,
 	rateLimiterService rateLimiterService,
 ) *Service {
 	return &Service{
@@ -74,40 +111,101 @@ func New(
 		storageService:     storageService,
 		messageService:     messageService,
 		transformService:   transformService,
+		mediaCacheService:  mediaCacheService,
 		rateLimiterService: rateLimiterService,
 	}
 }
 
 // ForwardMessages пересылает сообщения в целевой чат
-func (s *Service) ForwardMessages(
+func (s *Service) ForwardMessages(ctx context.Context,
 	messages []*client.Message, filtersMode domain.FiltersMode,
 	srcChatId, dstChatId, prevMessageId int64,
 	isSendCopy bool, forwardRuleId string, engineConfig *domain.EngineConfig,
 ) {
-	var err error
+	var (
+		err            error
+		isFallback     bool
+		sentMessageIds []int64
+	)
+	srcMessageId := messages[0].Id // до подмены на оригинал, см. replaceOriginMessages
+	ctx, end := trace.Start(ctx, "forward",
+		"forwardRuleId", forwardRuleId,
+		"srcChatId", srcChatId,
+		"dstChatId", dstChatId,
+	)
 	defer func() {
-		s.log.ErrorOrDebug(err, "",
+		end(err)
+		s.log.ErrorOrDebugContext(ctx, err, "",
 			"filtersMode", filtersMode,
 			"srcChatId", srcChatId,
 			"dstChatId", dstChatId,
 			"isSendCopy", isSendCopy,
 			"forwardRuleId", forwardRuleId,
 			"len(messages)", len(messages),
+			"isFallback", isFallback,
 		)
+		// пересылка правок (без filtersMode) не учитывается в статистике исходов
+		if filtersMode != "" {
+			outcome := filtersMode
+			if err != nil {
+				outcome = domain.StatsFailed
+			}
+			s.storageService.IncrementStats(forwardRuleId, srcChatId, dstChatId, outcome)
+			metrics.AddForward(forwardRuleId, dstChatId, outcome)
+			destination := &domain.JournalDestination{
+				ChatId:     dstChatId,
+				Outcome:    outcome,
+				MessageIds: sentMessageIds,
+			}
+			if err != nil {
+				destination.Error = err.Error()
+			}
+			s.storageService.AddJournalDestination(srcChatId, srcMessageId, forwardRuleId, destination)
+		}
 	}()
 
 	s.rateLimiterService.WaitForForward(s.ctx, dstChatId)
 
-	var result *client.Messages
+	forwardRule := engineConfig.ForwardRules[forwardRuleId]
+	hasRevisions := forwardRule != nil && forwardRule.Revisions != nil && forwardRule.Revisions.Run
+
+	// защищённое содержимое копируется только текстом первого сообщения, см. ForwardRule.Fallback
+	isFallback = isSendCopy && forwardRule != nil && !forwardRule.SendCopy && !messages[0].CanBeSaved
+	if isFallback {
+		messages = messages[:1]
+	}
+
+	var (
+		result              *client.Messages
+		overflows           [][]*client.FormattedText
+		contextTmpMessageId int64
+	)
+
+	messageThreadId := s.GetMessageThreadId(ctx, srcChatId, dstChatId, forwardRule, engineConfig)
+
+	// дата исходного сообщения до подмены на оригинал (для задержки доставки)
+	srcDate := messages[0].Date
 
 	if isSendCopy {
-		contents := s.prepareMessageContents(messages, dstChatId, prevMessageId, engineConfig)
-		replyToMessageId := s.getReplyToMessageId(messages[0], dstChatId)
-		result, err = s.sendMessages(dstChatId, contents, replyToMessageId)
+		s.replaceOriginMessages(ctx, messages)
+		replyToMessageId := s.getReplyToMessageId(ctx, messages[0], dstChatId, forwardRuleId)
+		var replyContext *replyContext
+		if replyToMessageId == 0 {
+			replyContext = s.getReplyContext(ctx, messages[0], forwardRule)
+		}
+		var contents []client.InputMessageContent
+		contents, overflows = s.prepareMessageContents(ctx, messages, dstChatId, prevMessageId, hasRevisions, isFallback, replyContext, forwardRule, engineConfig)
+		result, err = s.sendMessages(ctx, dstChatId, messageThreadId, contents, replyToMessageId)
 	} else {
+		// пересланное сообщение не может быть ответом, поэтому контекст отправляется перед ним
+		if replyContext := s.getReplyContext(ctx, messages[0], forwardRule); replyContext != nil {
+			replyToMessageId := s.getReplyToMessageId(ctx, messages[0], dstChatId, forwardRuleId)
+			contextTmpMessageId = s.sendReplyContext(ctx, dstChatId, messageThreadId, replyToMessageId, replyContext, forwardRule)
+		}
 		result, err = s.telegramRepo.ForwardMessages(&client.ForwardMessagesRequest{
-			ChatId:     dstChatId,
-			FromChatId: srcChatId,
+			ChatId:          dstChatId,
+			MessageThreadId: messageThreadId,
+			FromChatId:      srcChatId,
 			MessageIds: func() []int64 {
 				var messageIds []int64
 				for _, message := range messages {
@@ -127,8 +225,20 @@ func (s *Service) ForwardMessages(
 		})
 	}
 
+	// часть медиа-альбомов уже отправлена: связи сохраняются, чтобы до копий дошли правки и удаление
+	var sendErr error
 	if err != nil {
-		return
+		if result == nil || len(result.Messages) == 0 {
+			return
+		}
+		sendErr = err
+		err = nil
+	}
+
+	if contextTmpMessageId != 0 {
+		s.storageService.SetReplyContextMessageId(dstChatId, result.Messages[0].Id, contextTmpMessageId)
+		// ответ на сообщение с контекстом находит тот же оригинал, что и ответ на форвард
+		s.storageService.SetOriginMessageId(dstChatId, contextTmpMessageId, forwardRuleId, messages[0].ChatId, messages[0].Id)
 	}
 
 	if len(result.Messages) != int(result.TotalCount) || result.TotalCount == 0 {
@@ -136,27 +246,90 @@ func (s *Service) ForwardMessages(
 		return
 	}
 
-	if len(result.Messages) != len(messages) {
+	if len(result.Messages) != len(messages) && sendErr == nil {
 		err = log.NewError("invalid value", "len(result.Messages)", len(result.Messages))
 		return
 	}
 
-	if isSendCopy {
+	for _, dst := range result.Messages {
+		metrics.StartSend(dstChatId, dst.Id, srcDate)
+		sentMessageIds = append(sentMessageIds, dst.Id)
+	}
+
+	// для форвардинга связь сохраняется только ради цепочки редакций, синхронизации правок
+	// или сообщения с контекстом ответа, которое удаляется вместе с оригиналом
+	isForwardMapping := !isSendCopy && forwardRule != nil &&
+		(hasRevisions || forwardRule.SyncForwards || contextTmpMessageId != 0) && slices.Contains(forwardRule.To, dstChatId)
+
+	destination := engineConfig.Destinations[dstChatId]
+	hasEditDiff := destination != nil && destination.EditDiff != nil && destination.EditDiff.Run
+
+	if isSendCopy || isForwardMapping {
 		for i, dst := range result.Messages {
 			tmpMessageId := dst.Id
-			src := messages[i] // !! for origin message (in prepareMessageContents)
-			toChatMessageId := fmt.Sprintf("%s:%d:%d", forwardRuleId, dstChatId, tmpMessageId)
-			s.storageService.SetCopiedMessageId(src.ChatId, src.Id, toChatMessageId)
+			src := messages[i] // !! for origin message (in replaceOriginMessages)
+			toChatMessage := &domain.ChatMessage{
+				ForwardRuleId: forwardRuleId,
+				ChatId:        dstChatId,
+				MessageId:     tmpMessageId,
+			}
+			s.storageService.SetCopiedMessageId(src.ChatId, src.Id, toChatMessage)
+			s.storageService.SetOriginMessageId(dstChatId, tmpMessageId, forwardRuleId, src.ChatId, src.Id)
+			if hasRevisions {
+				s.storageService.AddRevisionMessageId(src.ChatId, src.Id, toChatMessage)
+			}
+			if !isSendCopy {
+				continue
+			}
+			if hasEditDiff {
+				formattedText := s.messageService.GetFormattedText(src)
+				s.storageService.SetTextSnapshot(src.ChatId, src.Id, formattedText.Text)
+			}
 			// TODO: isAnswer
 			if replyMarkupData := s.messageService.GetReplyMarkupData(src); len(replyMarkupData) > 0 {
 				s.storageService.SetAnswerMessageId(dstChatId, tmpMessageId, src.ChatId, src.Id)
 			}
 		}
-		if prevMessageId != 0 {
-			tmpMessageId := result.Messages[0].Id
-			go s.runNextLinkWorkflow(srcChatId, dstChatId, prevMessageId, tmpMessageId, engineConfig)
+	}
+
+	if isSendCopy && len(messages) > 1 {
+		// состав медиа-альбома нужен, чтобы подпись и ссылки оставались на первом сообщении
+		messageIds := make([]int64, 0, len(messages))
+		for _, src := range messages {
+			messageIds = append(messageIds, src.Id)
+		}
+		s.storageService.SetMediaAlbumMessageIds(messages[0].ChatId, messageIds)
+	}
+
+	if sendErr != nil {
+		err = sendErr
+		return
+	}
+
+	for i, overflow := range overflows {
+		if len(overflow) > 0 {
+			tmpMessageId := result.Messages[i].Id
+			origin := &domain.ChatMessage{
+				ForwardRuleId: forwardRuleId,
+				ChatId:        messages[i].ChatId,
+				MessageId:     messages[i].Id,
+			}
+			go func() {
+				ctx, end := trace.Start(ctx, "overflow")
+				defer end(nil)
+				s.runOverflowWorkflow(ctx, dstChatId, messageThreadId, tmpMessageId, overflow, origin)
+			}()
 		}
 	}
+
+	if isSendCopy && prevMessageId != 0 {
+		tmpMessageId := result.Messages[0].Id
+		go func() {
+			ctx, end := trace.Start(ctx, "next_link")
+			defer end(nil)
+			s.runNextLinkWorkflow(ctx, srcChatId, dstChatId, prevMessageId, tmpMessageId, engineConfig)
+		}()
+	}
 }
 
 // StartContext запускает процесс пересылки сообщений
@@ -173,10 +346,10 @@ func (s *Service) Close() error {
 }
 
 // getOriginMessage получает оригинальное сообщение для пересланного сообщения
-func (s *Service) getOriginMessage(message *client.Message) *client.Message {
+func (s *Service) getOriginMessage(ctx context.Context, message *client.Message) *client.Message {
 	var err error
 	defer func() {
-		s.log.ErrorOrDebug(err, "")
+		s.log.ErrorOrDebugContext(ctx, err, "")
 	}()
 
 	if message.ForwardInfo == nil {
@@ -211,27 +384,36 @@ func (s *Service) getOriginMessage(message *client.Message) *client.Message {
 	return originMessage
 }
 
-// prepareMessageContents подготавливает сообщения для отправки
-func (s *Service) prepareMessageContents(messages []*client.Message, dstChatId, prevMessageId int64, engineConfig *domain.EngineConfig) []client.InputMessageContent {
+// replaceOriginMessages заменяет пересланные сообщения их оригиналами
+func (s *Service) replaceOriginMessages(ctx context.Context, messages []*client.Message) {
+	for i, message := range messages {
+		originMessage := s.getOriginMessage(ctx, message)
+		if originMessage != nil {
+			messages[i] = originMessage
+		}
+	}
+}
+
+// prepareMessageContents подготавливает сообщения для отправки;
+// возвращает также продолжение текста, не поместившегося в каждое сообщение
+func (s *Service) prepareMessageContents(ctx context.Context, messages []*client.Message, dstChatId, prevMessageId int64,
+	hasRevisions, isFallback bool, replyContext *replyContext,
+	forwardRule *domain.ForwardRule, engineConfig *domain.EngineConfig,
+) ([]client.InputMessageContent, [][]*client.FormattedText) {
 	contents := make([]client.InputMessageContent, 0)
+	overflows := make([][]*client.FormattedText, 0)
 
-	for i, message := range messages {
+	for i, src := range messages {
 		func() {
 			var err error
 			defer func() {
-				s.log.ErrorOrDebug(err, "",
+				s.log.ErrorOrDebugContext(ctx, err, "",
 					"i", i,
-					"chatId", message.ChatId,
-					"messageId", message.Id,
+					"chatId", src.ChatId,
+					"messageId", src.Id,
 				)
 			}()
 
-			originMessage := s.getOriginMessage(message)
-			if originMessage != nil {
-				messages[i] = originMessage
-			}
-			src := messages[i] // !! for origin message
-
 			srcFormattedText := s.messageService.GetFormattedText(src)
 			var formattedText *client.FormattedText
 			formattedText, err = util.DeepCopy(srcFormattedText)
@@ -240,23 +422,166 @@ func (s *Service) prepareMessageContents(messages []*client.Message, dstChatId,
 			}
 
 			withSources := i == 0
-			s.transformService.Transform(formattedText, withSources, src, dstChatId, prevMessageId, engineConfig)
+			s.transformService.Transform(ctx, formattedText, withSources, src, dstChatId, prevMessageId, engineConfig)
+
+			if withSources && hasRevisions && prevMessageId != 0 {
+				revision := s.getRevision(src, forwardRule.Id, dstChatId)
+				s.transformService.AddRevisionMark(formattedText, revision, forwardRule)
+			}
+
+			if withSources && replyContext != nil {
+				s.transformService.AddReplyContext(formattedText, replyContext.quote, replyContext.link, forwardRule)
+			}
 
-			content := s.messageService.GetInputMessageContent(src, formattedText)
-			if content != nil {
-				contents = append(contents, content)
+			var content client.InputMessageContent
+			if isFallback {
+				s.transformService.AddProtectedContentNote(formattedText, forwardRule)
+				content = &client.InputMessageText{
+					Text: formattedText,
+				}
+			} else {
+				content = s.messageService.GetInputMessageContent(src, formattedText)
+				if content == nil {
+					return
+				}
+				if destination := engineConfig.Destinations[dstChatId]; destination != nil {
+					s.messageService.ApplyMediaPolicy(content, src, destination.Media)
+				}
 			}
+			overflow := s.messageService.SplitOverflow(content)
+			contents = append(contents, content)
+			overflows = append(overflows, overflow)
 		}()
 	}
 
-	return contents
+	return contents, overflows
+}
+
+// localizeContents заменяет удалённые файлы на локальные копии;
+// возвращает false, если повторная отправка не имеет смысла
+func (s *Service) localizeContents(ctx context.Context, contents []client.InputMessageContent) bool {
+	var err error
+	isLocalized := false
+	defer func() {
+		s.log.ErrorOrDebugContext(ctx, err, "",
+			"len(contents)", len(contents),
+			"isLocalized", isLocalized,
+		)
+	}()
+
+	for _, content := range contents {
+		var ok bool
+		ok, err = s.mediaCacheService.Localize(content)
+		if err != nil {
+			return false
+		}
+		isLocalized = isLocalized || ok
+	}
+
+	return isLocalized
+}
+
+// remoteFileErrors фрагменты ошибок TDLib о недоступном удалённом файле
+var remoteFileErrors = []string{
+	"remote file",
+	"file identifier",
+	"FILE_REFERENCE",
+	"MEDIA_EMPTY",
+}
+
+// isRemoteFileError проверяет, что ошибка вызвана недоступным удалённым файлом
+func isRemoteFileError(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, remoteFileError := range remoteFileErrors {
+		if strings.Contains(err.Error(), remoteFileError) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxForumTopicNameLength ограничение Telegram на длину названия темы форума
+const maxForumTopicNameLength = 128
+
+// GetMessageThreadId возвращает тему форума получателя: заданную в правиле
+// или созданную для источника (см. Destination.AutoTopics)
+func (s *Service) GetMessageThreadId(ctx context.Context, srcChatId, dstChatId int64,
+	forwardRule *domain.ForwardRule, engineConfig *domain.EngineConfig,
+) int64 {
+	var (
+		err    error
+		result int64
+	)
+	defer func() {
+		s.log.ErrorOrDebugContext(ctx, err, "",
+			"srcChatId", srcChatId,
+			"dstChatId", dstChatId,
+			"result", result,
+		)
+	}()
+
+	if forwardRule != nil && forwardRule.Threads[dstChatId] != 0 {
+		result = forwardRule.Threads[dstChatId]
+		return result
+	}
+	destination := engineConfig.Destinations[dstChatId]
+	if destination == nil || !destination.AutoTopics {
+		return 0
+	}
+
+	// тема создаётся однократно, даже если источник пересылается несколькими правилами
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result = s.storageService.GetForumTopicId(dstChatId, srcChatId)
+	if result != 0 {
+		return result
+	}
+
+	var chat *client.Chat
+	chat, err = s.telegramRepo.GetChat(&client.GetChatRequest{
+		ChatId: srcChatId,
+	})
+	if err != nil {
+		return 0
+	}
+	name := []rune(chat.Title)
+	if len(name) > maxForumTopicNameLength {
+		name = name[:maxForumTopicNameLength]
+	}
+
+	var forumTopicInfo *client.ForumTopicInfo
+	forumTopicInfo, err = s.telegramRepo.CreateForumTopic(&client.CreateForumTopicRequest{
+		ChatId: dstChatId,
+		Name:   string(name),
+	})
+	if err != nil {
+		return 0
+	}
+	result = forumTopicInfo.MessageThreadId
+	s.storageService.SetForumTopicId(dstChatId, srcChatId, result)
+	return result
+}
+
+// getRevision возвращает номер новой редакции сообщения для целевого чата
+func (s *Service) getRevision(src *client.Message, forwardRuleId string, dstChatId int64) int {
+	revision := 1
+	for _, toChatMessage := range s.storageService.GetRevisionMessageIds(src.ChatId, src.Id) {
+		if toChatMessage.ForwardRuleId == forwardRuleId && toChatMessage.ChatId == dstChatId {
+			revision++
+		}
+	}
+	return revision
 }
 
-// getReplyToMessageId получает ID сообщения для ответа
-func (s *Service) getReplyToMessageId(src *client.Message, dstChatId int64) int64 {
+// getReplyToMessageId получает ID сообщения для ответа;
+// предпочитает копию того же правила, чтобы ответ оказался в той же теме форума
+func (s *Service) getReplyToMessageId(ctx context.Context, src *client.Message, dstChatId int64, forwardRuleId string) int64 {
 	var err error
 	defer func() {
-		s.log.ErrorOrDebug(err, "")
+		s.log.ErrorOrDebugContext(ctx, err, "")
 	}()
 
 	var replyToMessageId int64
@@ -272,24 +597,29 @@ func (s *Service) getReplyToMessageId(src *client.Message, dstChatId int64) int6
 		return 0
 	}
 
+	// ответ на сообщение другого чата-источника тоже восстанавливается
 	replyInChatId := replyTo.ChatId
-	if replyInChatId != src.ChatId {
-		err = log.NewError("replyInChatId != src.ChatId")
+	if replyInChatId == 0 {
+		err = log.NewError("replied message is in unknown chat")
 		return 0
 	}
 
-	toChatMessageIds := s.storageService.GetCopiedMessageIds(replyInChatId, replyToMessageId)
+	toChatMessages := s.storageService.GetCopiedMessageIds(replyInChatId, replyToMessageId)
 
-	if len(toChatMessageIds) == 0 {
-		err = log.NewError("toChatMessageIds is empty")
+	if len(toChatMessages) == 0 {
+		err = log.NewError("toChatMessages is empty")
 		return 0
 	}
 
 	var tmpMessageId int64 = 0
-	for _, toChatMessageId := range toChatMessageIds {
-		a := strings.Split(toChatMessageId, ":")
-		if util.ConvertToInt[int64](a[1]) == dstChatId {
-			tmpMessageId = util.ConvertToInt[int64](a[2])
+	for _, toChatMessage := range toChatMessages {
+		if toChatMessage.ChatId != dstChatId {
+			continue
+		}
+		if tmpMessageId == 0 || toChatMessage.ForwardRuleId == forwardRuleId {
+			tmpMessageId = toChatMessage.MessageId
+		}
+		if toChatMessage.ForwardRuleId == forwardRuleId {
 			break
 		}
 	}
@@ -304,46 +634,227 @@ func (s *Service) getReplyToMessageId(src *client.Message, dstChatId int64) int6
 	return replyToMessageId
 }
 
-// runNextLinkWorkflow добавляет ссылку на следующую версию сообщения
-func (s *Service) runNextLinkWorkflow(
-	srcChatId, dstChatId, prevMessageId, tmpMessageId int64,
-	engineConfig *domain.EngineConfig,
+// AddReplyContext добавляет в текст копии контекст ответа, как при отправке:
+// только если копия в получателе не стала ответом на копию исходного сообщения
+func (s *Service) AddReplyContext(ctx context.Context, formattedText *client.FormattedText,
+	src *client.Message, dstChatId int64, forwardRule *domain.ForwardRule,
 ) {
+	if forwardRule == nil || forwardRule.ReplyContext == nil || !forwardRule.ReplyContext.Run {
+		return
+	}
+	if _, ok := src.ReplyTo.(*client.MessageReplyToMessage); !ok {
+		return
+	}
+	if s.getReplyToMessageId(ctx, src, dstChatId, forwardRule.Id) != 0 {
+		return
+	}
+	replyContext := s.getReplyContext(ctx, src, forwardRule)
+	if replyContext == nil {
+		return
+	}
+	s.transformService.AddReplyContext(formattedText, replyContext.quote, replyContext.link, forwardRule)
+}
+
+// getReplyContext получает цитату и ссылку на сообщение, на которое отвечает src;
+// возвращает nil, если контекст ответа не нужен или недоступен
+func (s *Service) getReplyContext(ctx context.Context, src *client.Message, forwardRule *domain.ForwardRule) *replyContext {
+	if forwardRule == nil || forwardRule.ReplyContext == nil || !forwardRule.ReplyContext.Run {
+		return nil
+	}
+	replyTo, ok := src.ReplyTo.(*client.MessageReplyToMessage)
+	if !ok {
+		return nil
+	}
+
+	var err error
+	result := &replyContext{}
+	defer func() {
+		s.log.ErrorOrDebugContext(ctx, err, "",
+			"chatId", src.ChatId,
+			"messageId", src.Id,
+			"replyInChatId", replyTo.ChatId,
+			"replyToMessageId", replyTo.MessageId,
+			"link", result.link,
+		)
+	}()
+
+	// выбранная при ответе цитата точнее полного текста сообщения
+	if replyTo.Quote != nil && replyTo.Quote.Text != nil {
+		result.quote = replyTo.Quote.Text.Text
+	}
+
+	if replyTo.ChatId != 0 && replyTo.MessageId != 0 {
+		if result.quote == "" {
+			var message *client.Message
+			message, err = s.telegramRepo.GetMessage(&client.GetMessageRequest{
+				ChatId:    replyTo.ChatId,
+				MessageId: replyTo.MessageId,
+			})
+			if err != nil {
+				return nil
+			}
+			// у стикеров, опросов и т.п. нет текста: остаётся только ссылка
+			if formattedText := s.messageService.GetFormattedText(message); formattedText != nil {
+				result.quote = formattedText.Text
+			}
+		}
+		// ссылки недоступны для сообщений из приватных чатов и обычных групп
+		messageLink, err := s.telegramRepo.GetMessageLink(&client.GetMessageLinkRequest{
+			ChatId:    replyTo.ChatId,
+			MessageId: replyTo.MessageId,
+		})
+		if err == nil {
+			result.link = messageLink.Link
+		}
+	}
+
+	if result.quote == "" && result.link == "" {
+		err = log.NewError("reply context is empty")
+		return nil
+	}
+
+	return result
+}
+
+// sendReplyContext отправляет контекст ответа отдельным сообщением (ответом, если возможно);
+// возвращает временный идентификатор отправленного сообщения
+func (s *Service) sendReplyContext(ctx context.Context, dstChatId, messageThreadId, replyToMessageId int64,
+	replyContext *replyContext, forwardRule *domain.ForwardRule,
+) int64 {
 	var (
-		err          error
-		newMessageId int64
-		repeatCount  int
+		err     error
+		message *client.Message
 	)
 	defer func() {
-		s.log.ErrorOrDebug(err, "",
-			"srcChatId", srcChatId,
+		s.log.ErrorOrDebugContext(ctx, err, "",
 			"dstChatId", dstChatId,
-			"prevMessageId", prevMessageId,
-			"tmpMessageId", tmpMessageId,
-			"newMessageId", newMessageId,
-			"repeatCount", repeatCount,
+			"replyToMessageId", replyToMessageId,
 		)
 	}()
 
-	// TODO: или вынести в отдельный сервис?
+	formattedText := &client.FormattedText{
+		Entities: []*client.TextEntity{},
+	}
+	s.transformService.AddReplyContext(formattedText, replyContext.quote, replyContext.link, forwardRule)
+	if formattedText.Text == "" {
+		err = log.NewError("formattedText.Text is empty")
+		return 0
+	}
 
+	message, err = s.telegramRepo.SendMessage(&client.SendMessageRequest{
+		ChatId:          dstChatId,
+		MessageThreadId: messageThreadId,
+		InputMessageContent: &client.InputMessageText{
+			Text: formattedText,
+		},
+		ReplyTo: &client.InputMessageReplyToMessage{
+			MessageId: replyToMessageId,
+		},
+	})
+	if err != nil {
+		return 0
+	}
+
+	return message.Id
+}
+
+// waitForNewMessageId ожидает, пока отправленное сообщение получит постоянный идентификатор
+func (s *Service) waitForNewMessageId(dstChatId, tmpMessageId int64) (int64, error) {
 	// TODO: перенести в temporal
+	repeatCount := 0
 	for {
 		select {
 		case <-s.ctx.Done():
-			err = s.ctx.Err()
-			return
+			return 0, s.ctx.Err()
 		case <-time.After(1 * time.Second):
 		}
-		newMessageId = s.storageService.GetNewMessageId(dstChatId, tmpMessageId)
+		newMessageId := s.storageService.GetNewMessageId(dstChatId, tmpMessageId)
 		if newMessageId != 0 {
-			break
+			return newMessageId, nil
 		}
 		repeatCount++
 		if repeatCount > 10 {
-			err = log.NewError("max retry count exceeded")
+			return 0, log.NewError("max retry count exceeded",
+				"repeatCount", repeatCount,
+			)
+		}
+	}
+}
+
+// runOverflowWorkflow отправляет продолжение текста ответами на копию;
+// ответы связываются с оригиналом копии для обратного поиска
+func (s *Service) runOverflowWorkflow(ctx context.Context, dstChatId, messageThreadId, tmpMessageId int64, overflow []*client.FormattedText, origin *domain.ChatMessage) {
+	var (
+		err                   error
+		newMessageId          int64
+		overflowTmpMessageIds []int64
+	)
+	defer func() {
+		s.log.ErrorOrDebugContext(ctx, err, "",
+			"dstChatId", dstChatId,
+			"tmpMessageId", tmpMessageId,
+			"newMessageId", newMessageId,
+			"overflowTmpMessageIds", overflowTmpMessageIds,
+		)
+	}()
+
+	// ответить можно только на сообщение с постоянным идентификатором
+	newMessageId, err = s.waitForNewMessageId(dstChatId, tmpMessageId)
+	if err != nil {
+		return
+	}
+
+	// связь сохраняется даже для частично отправленного продолжения
+	defer func() {
+		if len(overflowTmpMessageIds) > 0 {
+			s.storageService.SetOverflowMessageIds(dstChatId, tmpMessageId, overflowTmpMessageIds)
+		}
+	}()
+
+	for _, formattedText := range overflow {
+		var message *client.Message
+		message, err = s.telegramRepo.SendMessage(&client.SendMessageRequest{
+			ChatId:          dstChatId,
+			MessageThreadId: messageThreadId,
+			InputMessageContent: &client.InputMessageText{
+				Text: formattedText,
+			},
+			ReplyTo: &client.InputMessageReplyToMessage{
+				MessageId: newMessageId,
+			},
+		})
+		if err != nil {
 			return
 		}
+		overflowTmpMessageIds = append(overflowTmpMessageIds, message.Id)
+		s.storageService.SetOriginMessageId(dstChatId, message.Id, origin.ForwardRuleId, origin.ChatId, origin.MessageId)
+	}
+}
+
+// runNextLinkWorkflow добавляет ссылку на следующую версию сообщения
+func (s *Service) runNextLinkWorkflow(ctx context.Context,
+	srcChatId, dstChatId, prevMessageId, tmpMessageId int64,
+	engineConfig *domain.EngineConfig,
+) {
+	var (
+		err          error
+		newMessageId int64
+	)
+	defer func() {
+		s.log.ErrorOrDebugContext(ctx, err, "",
+			"srcChatId", srcChatId,
+			"dstChatId", dstChatId,
+			"prevMessageId", prevMessageId,
+			"tmpMessageId", tmpMessageId,
+			"newMessageId", newMessageId,
+		)
+	}()
+
+	// TODO: или вынести в отдельный сервис?
+
+	newMessageId, err = s.waitForNewMessageId(dstChatId, tmpMessageId)
+	if err != nil {
+		return
 	}
 
 	message, err := s.telegramRepo.GetMessage(&client.GetMessageRequest{
@@ -372,14 +883,74 @@ func (s *Service) runNextLinkWorkflow(
 	}
 }
 
-// sendMessages отправляет сообщения в чат
-func (s *Service) sendMessages(dstChatId int64, contents []client.InputMessageContent, replyToMessageId int64) (*client.Messages, error) {
+// sendMessages отправляет сообщения в чат, разбивая их на допустимые медиа-альбомы;
+// при ошибке возвращает также сообщения, отправленные до неё
+func (s *Service) sendMessages(ctx context.Context, dstChatId, messageThreadId int64, contents []client.InputMessageContent, replyToMessageId int64) (*client.Messages, error) {
+	result := &client.Messages{}
+	for _, part := range splitMediaAlbum(contents) {
+		messages, err := s.sendMediaAlbum(dstChatId, messageThreadId, part, replyToMessageId)
+		// удалённые идентификаторы файлов недоступны: загружаем медиа заново
+		if isRemoteFileError(err) {
+			if s.localizeContents(ctx, part) {
+				messages, err = s.sendMediaAlbum(dstChatId, messageThreadId, part, replyToMessageId)
+			}
+			// файлы всего медиа-альбома закреплены в кеше, пока он отправляется
+			for _, content := range part {
+				s.mediaCacheService.Release(content)
+			}
+		}
+		if err != nil {
+			return result, err
+		}
+		result.TotalCount += messages.TotalCount
+		result.Messages = append(result.Messages, messages.Messages...)
+	}
+	return result, nil
+}
+
+// maxMediaAlbumSize наибольшее количество сообщений в медиа-альбоме
+const maxMediaAlbumSize = 10
+
+// getMediaAlbumKind возвращает вид содержимого, совместимого внутри медиа-альбома;
+// пустая строка - содержимое невозможно объединить в медиа-альбом
+func getMediaAlbumKind(content client.InputMessageContent) string {
+	switch content.(type) {
+	case *client.InputMessagePhoto, *client.InputMessageVideo:
+		return "media"
+	case *client.InputMessageDocument:
+		return "document"
+	case *client.InputMessageAudio:
+		return "audio"
+	}
+	return ""
+}
+
+// splitMediaAlbum разбивает содержимое на допустимые медиа-альбомы, сохраняя порядок
+func splitMediaAlbum(contents []client.InputMessageContent) [][]client.InputMessageContent {
+	var result [][]client.InputMessageContent
+	prevKind := ""
+	for _, content := range contents {
+		kind := getMediaAlbumKind(content)
+		last := len(result) - 1
+		if last >= 0 && kind != "" && kind == prevKind && len(result[last]) < maxMediaAlbumSize {
+			result[last] = append(result[last], content)
+		} else {
+			result = append(result, []client.InputMessageContent{content})
+		}
+		prevKind = kind
+	}
+	return result
+}
+
+// sendMediaAlbum отправляет одно сообщение или медиа-альбом
+func (s *Service) sendMediaAlbum(dstChatId, messageThreadId int64, contents []client.InputMessageContent, replyToMessageId int64) (*client.Messages, error) {
 	var err error
 
 	if len(contents) == 1 {
 		var message *client.Message
 		message, err = s.telegramRepo.SendMessage(&client.SendMessageRequest{
 			ChatId:              dstChatId,
+			MessageThreadId:     messageThreadId,
 			InputMessageContent: contents[0],
 			ReplyTo: &client.InputMessageReplyToMessage{
 				MessageId: replyToMessageId,
@@ -396,6 +967,7 @@ func (s *Service) sendMessages(dstChatId int64, contents []client.InputMessageCo
 	var messages *client.Messages
 	messages, err = s.telegramRepo.SendMessageAlbum(&client.SendMessageAlbumRequest{
 		ChatId:               dstChatId,
+		MessageThreadId:      messageThreadId,
 		InputMessageContents: contents,
 		ReplyTo: &client.InputMessageReplyToMessage{
 			MessageId: replyToMessageId,
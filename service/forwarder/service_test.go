@@ -0,0 +1,216 @@
+package forwarder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/service/forwarder/mocks"
+)
+
+func Test_splitMediaAlbum(t *testing.T) {
+	t.Parallel()
+
+	photo := &client.InputMessagePhoto{}
+	video := &client.InputMessageVideo{}
+	document := &client.InputMessageDocument{}
+	audio := &client.InputMessageAudio{}
+	animation := &client.InputMessageAnimation{}
+
+	tests := []struct {
+		name     string
+		contents []client.InputMessageContent
+		expected []int
+	}{
+		{
+			name:     "single",
+			contents: []client.InputMessageContent{photo},
+			expected: []int{1},
+		},
+		{
+			name:     "photo_and_video",
+			contents: []client.InputMessageContent{photo, video, photo},
+			expected: []int{3},
+		},
+		{
+			name:     "media_and_documents",
+			contents: []client.InputMessageContent{photo, video, document, document},
+			expected: []int{2, 2},
+		},
+		{
+			name:     "documents_and_audio",
+			contents: []client.InputMessageContent{document, audio, audio, document},
+			expected: []int{1, 2, 1},
+		},
+		{
+			name:     "animations_are_not_grouped",
+			contents: []client.InputMessageContent{animation, animation, photo},
+			expected: []int{1, 1, 1},
+		},
+		{
+			name: "more_than_max_size",
+			contents: []client.InputMessageContent{
+				photo, photo, photo, photo, photo, photo, photo, photo, photo, photo, photo, photo,
+			},
+			expected: []int{10, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			parts := splitMediaAlbum(test.contents)
+
+			var sizes []int
+			var contents []client.InputMessageContent
+			for _, part := range parts {
+				sizes = append(sizes, len(part))
+				contents = append(contents, part...)
+			}
+			assert.Equal(t, test.expected, sizes)
+			assert.Equal(t, test.contents, contents) // порядок сохраняется
+		})
+	}
+}
+
+func Test_getReplyContext(t *testing.T) {
+	t.Parallel()
+
+	forwardRule := &domain.ForwardRule{
+		ReplyContext: &domain.ReplyContext{Run: true},
+	}
+	src := &client.Message{
+		Id:     20,
+		ChatId: -1001,
+		ReplyTo: &client.MessageReplyToMessage{
+			ChatId:    -1001,
+			MessageId: 10,
+		},
+	}
+	// ответ на стикер: текста для цитаты нет
+	sticker := &client.Message{
+		Id:      10,
+		ChatId:  -1001,
+		Content: &client.MessageSticker{},
+	}
+
+	telegramRepo := mocks.NewTelegramRepo(t)
+	messageService := mocks.NewMessageService(t)
+	telegramRepo.EXPECT().GetMessage(&client.GetMessageRequest{
+		ChatId:    -1001,
+		MessageId: 10,
+	}).Return(sticker, nil)
+	messageService.EXPECT().GetFormattedText(sticker).Return(nil)
+	telegramRepo.EXPECT().GetMessageLink(&client.GetMessageLinkRequest{
+		ChatId:    -1001,
+		MessageId: 10,
+	}).Return(&client.MessageLink{Link: "https://t.me/c/1/10"}, nil)
+
+	s := New(telegramRepo, nil, messageService, nil, nil, nil)
+	result := s.getReplyContext(context.Background(), src, forwardRule)
+	require.NotNil(t, result)
+	assert.Empty(t, result.quote)
+	assert.Equal(t, "https://t.me/c/1/10", result.link)
+}
+
+func Test_sendMessages(t *testing.T) {
+	t.Parallel()
+
+	photo := &client.InputMessagePhoto{}
+	document := &client.InputMessageDocument{}
+	sent := []*client.Message{{Id: 1}, {Id: 2}}
+
+	telegramRepo := mocks.NewTelegramRepo(t)
+	telegramRepo.EXPECT().SendMessageAlbum(&client.SendMessageAlbumRequest{
+		ChatId:               -1002,
+		InputMessageContents: []client.InputMessageContent{photo, photo},
+		ReplyTo:              &client.InputMessageReplyToMessage{},
+	}).Return(&client.Messages{TotalCount: 2, Messages: sent}, nil)
+	telegramRepo.EXPECT().SendMessage(&client.SendMessageRequest{
+		ChatId:              -1002,
+		InputMessageContent: document,
+		ReplyTo:             &client.InputMessageReplyToMessage{},
+	}).Return(nil, errors.New("too many requests"))
+
+	s := New(telegramRepo, nil, nil, nil, nil, nil)
+	// второй медиа-альбом не отправлен: первый возвращается вместе с ошибкой
+	result, err := s.sendMessages(context.Background(), -1002, 0, []client.InputMessageContent{photo, photo, document}, 0)
+	require.Error(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, int32(2), result.TotalCount)
+	assert.Equal(t, sent, result.Messages)
+}
+
+func Test_runOverflowWorkflow(t *testing.T) {
+	t.Parallel()
+
+	const (
+		dstChatId       = int64(-1002)
+		messageThreadId = int64(7)
+		tmpMessageId    = int64(20)
+		newMessageId    = int64(21)
+	)
+
+	overflow := []*client.FormattedText{{Text: "overflow"}}
+	origin := &domain.ChatMessage{ForwardRuleId: "Rule1", ChatId: -1001, MessageId: 10}
+
+	telegramRepo := mocks.NewTelegramRepo(t)
+	storageService := mocks.NewStorageService(t)
+	storageService.EXPECT().GetNewMessageId(dstChatId, tmpMessageId).Return(newMessageId)
+	// продолжение отправляется в ту же тему форума, что и копия
+	telegramRepo.EXPECT().SendMessage(&client.SendMessageRequest{
+		ChatId:          dstChatId,
+		MessageThreadId: messageThreadId,
+		InputMessageContent: &client.InputMessageText{
+			Text: overflow[0],
+		},
+		ReplyTo: &client.InputMessageReplyToMessage{
+			MessageId: newMessageId,
+		},
+	}).Return(&client.Message{Id: 30}, nil).Once()
+	storageService.EXPECT().SetOriginMessageId(dstChatId, int64(30), origin.ForwardRuleId, origin.ChatId, origin.MessageId).Once()
+	storageService.EXPECT().SetOverflowMessageIds(dstChatId, tmpMessageId, []int64{30}).Once()
+
+	s := New(telegramRepo, storageService, nil, nil, nil, nil)
+	require.NoError(t, s.StartContext(context.Background()))
+	s.runOverflowWorkflow(context.Background(), dstChatId, messageThreadId, tmpMessageId, overflow, origin)
+}
+
+func TestAddReplyContext(t *testing.T) {
+	t.Parallel()
+
+	forwardRule := &domain.ForwardRule{
+		Id:           "rule1",
+		ReplyContext: &domain.ReplyContext{Run: true},
+	}
+	src := &client.Message{
+		Id:     20,
+		ChatId: -1001,
+		ReplyTo: &client.MessageReplyToMessage{
+			ChatId:    -1001,
+			MessageId: 10,
+			Quote:     &client.TextQuote{Text: &client.FormattedText{Text: "вопрос"}},
+		},
+	}
+	formattedText := &client.FormattedText{Text: "ответ"}
+
+	telegramRepo := mocks.NewTelegramRepo(t)
+	storageService := mocks.NewStorageService(t)
+	transformService := mocks.NewTransformService(t)
+	// копии исходного сообщения нет: копия ответа отправлена не ответом
+	storageService.EXPECT().GetCopiedMessageIds(int64(-1001), int64(10)).Return(nil)
+	telegramRepo.EXPECT().GetMessageLink(&client.GetMessageLinkRequest{
+		ChatId:    -1001,
+		MessageId: 10,
+	}).Return(&client.MessageLink{Link: "https://t.me/c/1/10"}, nil)
+	transformService.EXPECT().AddReplyContext(formattedText, "вопрос", "https://t.me/c/1/10", forwardRule)
+
+	s := New(telegramRepo, storageService, nil, transformService, nil, nil)
+	s.AddReplyContext(context.Background(), formattedText, src, -1002, forwardRule)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/comerc/budva43/app/domain"
 	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/metrics"
 )
 
 // mediaAlbum представляет группу сообщений, составляющих медиа-альбом
@@ -47,6 +48,7 @@ func (s *Service) AddMessage(key domain.MediaAlbumKey, message *client.Message)
 	item.messages = append(item.messages, message)
 	item.lastReceived = time.Now()
 	s.mediaAlbums[key] = item
+	metrics.SetMediaAlbumsPending(len(s.mediaAlbums))
 	return !ok
 }
 
@@ -63,6 +65,7 @@ func (s *Service) PopMessages(key domain.MediaAlbumKey) []*client.Message {
 	defer s.mu.Unlock()
 	messages := s.mediaAlbums[key].messages
 	delete(s.mediaAlbums, key)
+	metrics.SetMediaAlbumsPending(len(s.mediaAlbums))
 	return messages
 }
 
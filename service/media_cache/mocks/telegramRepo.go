@@ -0,0 +1,210 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	client "github.com/zelenin/go-tdlib/client"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TelegramRepo is an autogenerated mock type for the telegramRepo type
+type TelegramRepo struct {
+	mock.Mock
+}
+
+type TelegramRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TelegramRepo) EXPECT() *TelegramRepo_Expecter {
+	return &TelegramRepo_Expecter{mock: &_m.Mock}
+}
+
+// DeleteFile provides a mock function with given fields: _a0
+func (_m *TelegramRepo) DeleteFile(_a0 *client.DeleteFileRequest) (*client.Ok, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFile")
+	}
+
+	var r0 *client.Ok
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*client.DeleteFileRequest) (*client.Ok, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*client.DeleteFileRequest) *client.Ok); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Ok)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*client.DeleteFileRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TelegramRepo_DeleteFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFile'
+type TelegramRepo_DeleteFile_Call struct {
+	*mock.Call
+}
+
+// DeleteFile is a helper method to define mock.On call
+//   - _a0 *client.DeleteFileRequest
+func (_e *TelegramRepo_Expecter) DeleteFile(_a0 interface{}) *TelegramRepo_DeleteFile_Call {
+	return &TelegramRepo_DeleteFile_Call{Call: _e.mock.On("DeleteFile", _a0)}
+}
+
+func (_c *TelegramRepo_DeleteFile_Call) Run(run func(_a0 *client.DeleteFileRequest)) *TelegramRepo_DeleteFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.DeleteFileRequest))
+	})
+	return _c
+}
+
+func (_c *TelegramRepo_DeleteFile_Call) Return(_a0 *client.Ok, _a1 error) *TelegramRepo_DeleteFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TelegramRepo_DeleteFile_Call) RunAndReturn(run func(*client.DeleteFileRequest) (*client.Ok, error)) *TelegramRepo_DeleteFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DownloadFile provides a mock function with given fields: _a0
+func (_m *TelegramRepo) DownloadFile(_a0 *client.DownloadFileRequest) (*client.File, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadFile")
+	}
+
+	var r0 *client.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*client.DownloadFileRequest) (*client.File, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*client.DownloadFileRequest) *client.File); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*client.DownloadFileRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TelegramRepo_DownloadFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DownloadFile'
+type TelegramRepo_DownloadFile_Call struct {
+	*mock.Call
+}
+
+// DownloadFile is a helper method to define mock.On call
+//   - _a0 *client.DownloadFileRequest
+func (_e *TelegramRepo_Expecter) DownloadFile(_a0 interface{}) *TelegramRepo_DownloadFile_Call {
+	return &TelegramRepo_DownloadFile_Call{Call: _e.mock.On("DownloadFile", _a0)}
+}
+
+func (_c *TelegramRepo_DownloadFile_Call) Run(run func(_a0 *client.DownloadFileRequest)) *TelegramRepo_DownloadFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.DownloadFileRequest))
+	})
+	return _c
+}
+
+func (_c *TelegramRepo_DownloadFile_Call) Return(_a0 *client.File, _a1 error) *TelegramRepo_DownloadFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TelegramRepo_DownloadFile_Call) RunAndReturn(run func(*client.DownloadFileRequest) (*client.File, error)) *TelegramRepo_DownloadFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRemoteFile provides a mock function with given fields: _a0
+func (_m *TelegramRepo) GetRemoteFile(_a0 *client.GetRemoteFileRequest) (*client.File, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRemoteFile")
+	}
+
+	var r0 *client.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*client.GetRemoteFileRequest) (*client.File, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*client.GetRemoteFileRequest) *client.File); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*client.GetRemoteFileRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TelegramRepo_GetRemoteFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRemoteFile'
+type TelegramRepo_GetRemoteFile_Call struct {
+	*mock.Call
+}
+
+// GetRemoteFile is a helper method to define mock.On call
+//   - _a0 *client.GetRemoteFileRequest
+func (_e *TelegramRepo_Expecter) GetRemoteFile(_a0 interface{}) *TelegramRepo_GetRemoteFile_Call {
+	return &TelegramRepo_GetRemoteFile_Call{Call: _e.mock.On("GetRemoteFile", _a0)}
+}
+
+func (_c *TelegramRepo_GetRemoteFile_Call) Run(run func(_a0 *client.GetRemoteFileRequest)) *TelegramRepo_GetRemoteFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*client.GetRemoteFileRequest))
+	})
+	return _c
+}
+
+func (_c *TelegramRepo_GetRemoteFile_Call) Return(_a0 *client.File, _a1 error) *TelegramRepo_GetRemoteFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TelegramRepo_GetRemoteFile_Call) RunAndReturn(run func(*client.GetRemoteFileRequest) (*client.File, error)) *TelegramRepo_GetRemoteFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTelegramRepo creates a new instance of TelegramRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTelegramRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TelegramRepo {
+	mock := &TelegramRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
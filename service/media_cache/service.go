@@ -0,0 +1,277 @@
+package media_cache
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/log"
+)
+
+//go:generate mockery --name=telegramRepo --exported
+type telegramRepo interface {
+	// tdlibClient methods
+	GetRemoteFile(*client.GetRemoteFileRequest) (*client.File, error)
+	DownloadFile(*client.DownloadFileRequest) (*client.File, error)
+	DeleteFile(*client.DeleteFileRequest) (*client.Ok, error)
+}
+
+// Service скачивает медиа через TDLib и хранит локальные копии
+// в ограниченном по размеру кеше для повторной загрузки
+type Service struct {
+	log *log.Logger
+	//
+	telegramRepo telegramRepo
+	mu           sync.Mutex
+	directory    string
+	maxSize      int64
+	pinned       map[string]int // путь -> число отправок, для которых файл закреплён в кеше
+}
+
+// New создает новый экземпляр сервиса кеша медиа
+func New(telegramRepo telegramRepo) *Service {
+	return &Service{
+		log: log.NewLogger(),
+		//
+		telegramRepo: telegramRepo,
+		directory:    config.Telegram.MediaCacheDirectory,
+		maxSize:      int64(config.Telegram.MediaCacheMaxSize) * 1024 * 1024,
+		pinned:       make(map[string]int),
+	}
+}
+
+// Localize заменяет удалённые файлы входного контента на локальные копии из кеша
+// и закрепляет их до вызова Release; возвращает false, если в контенте нет удалённых файлов
+func (s *Service) Localize(content client.InputMessageContent) (bool, error) {
+	var err error
+	result := []string{}
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"result", result,
+		)
+	}()
+
+	for _, inputFile := range getInputFiles(content) {
+		inputFileRemote, ok := (*inputFile).(*client.InputFileRemote)
+		if !ok {
+			continue
+		}
+		var path string
+		path, err = s.getLocalFile(inputFileRemote.Id)
+		if err != nil {
+			return false, err
+		}
+		*inputFile = &client.InputFileLocal{
+			Path: path,
+		}
+		result = append(result, path)
+	}
+
+	return len(result) > 0, nil
+}
+
+// Release снимает закрепление локальных копий файлов контента после отправки
+func (s *Service) Release(content client.InputMessageContent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, inputFile := range getInputFiles(content) {
+		inputFileLocal, ok := (*inputFile).(*client.InputFileLocal)
+		if !ok {
+			continue
+		}
+		if s.pinned[inputFileLocal.Path] > 1 {
+			s.pinned[inputFileLocal.Path]--
+		} else {
+			delete(s.pinned, inputFileLocal.Path)
+		}
+	}
+}
+
+// getInputFiles возвращает указатели на файлы входного контента;
+// миниатюры сбрасываются, т.к. TDLib сгенерирует их при загрузке
+func getInputFiles(content client.InputMessageContent) []*client.InputFile {
+	switch contentByType := content.(type) {
+	case *client.InputMessageAnimation:
+		contentByType.Thumbnail = nil
+		return []*client.InputFile{&contentByType.Animation}
+	case *client.InputMessageAudio:
+		contentByType.AlbumCoverThumbnail = nil
+		return []*client.InputFile{&contentByType.Audio}
+	case *client.InputMessageDocument:
+		contentByType.Thumbnail = nil
+		return []*client.InputFile{&contentByType.Document}
+	case *client.InputMessagePhoto:
+		contentByType.Thumbnail = nil
+		return []*client.InputFile{&contentByType.Photo}
+	case *client.InputMessageVideo:
+		contentByType.Thumbnail = nil
+		return []*client.InputFile{&contentByType.Video}
+	case *client.InputMessageVoiceNote:
+		return []*client.InputFile{&contentByType.VoiceNote}
+	case *client.InputMessageVideoNote:
+		contentByType.Thumbnail = nil
+		return []*client.InputFile{&contentByType.VideoNote}
+	case *client.InputMessageSticker:
+		contentByType.Thumbnail = nil
+		return []*client.InputFile{&contentByType.Sticker}
+	}
+	return nil
+}
+
+// getLocalFile возвращает путь к закреплённой локальной копии файла, при необходимости скачивает его
+func (s *Service) getLocalFile(remoteFileId string) (string, error) {
+	file, err := s.telegramRepo.GetRemoteFile(&client.GetRemoteFileRequest{
+		RemoteFileId: remoteFileId,
+	})
+	if err != nil {
+		return "", err
+	}
+	if file.Remote == nil || file.Remote.UniqueId == "" {
+		return "", log.NewError("remote file unique id is empty",
+			"remoteFileId", remoteFileId,
+		)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := filepath.Join(s.directory, file.Remote.UniqueId)
+	if _, err := os.Stat(path); err == nil {
+		now := time.Now()
+		err = os.Chtimes(path, now, now) // продлеваем жизнь файла в кеше
+		if err != nil {
+			return "", log.WrapError(err)
+		}
+		s.pinned[path]++
+		return path, nil
+	}
+
+	file, err = s.telegramRepo.DownloadFile(&client.DownloadFileRequest{
+		FileId:      file.Id,
+		Priority:    1,
+		Synchronous: true,
+	})
+	if err != nil {
+		return "", err
+	}
+	if file.Local == nil || !file.Local.IsDownloadingCompleted {
+		return "", log.NewError("file is not downloaded",
+			"remoteFileId", remoteFileId,
+		)
+	}
+
+	err = copyFile(file.Local.Path, path)
+	if err != nil {
+		return "", err
+	}
+
+	// файл в FilesDirectory больше не нужен, копия хранится в кеше
+	_, err = s.telegramRepo.DeleteFile(&client.DeleteFileRequest{
+		FileId: file.Id,
+	})
+	s.log.ErrorOrDebug(err, "delete downloaded file", "fileId", file.Id)
+
+	s.pinned[path]++
+	s.evict()
+
+	return path, nil
+}
+
+// copyFile копирует файл через временный, чтобы в кеше не оказалось недописанного файла
+func copyFile(srcPath, dstPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return log.WrapError(err)
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dstPath), ".tmp-*")
+	if err != nil {
+		return log.WrapError(err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	_, err = io.Copy(tmp, src)
+	if err != nil {
+		tmp.Close() //nolint:errcheck,gosec
+		return log.WrapError(err)
+	}
+	err = tmp.Close()
+	if err != nil {
+		return log.WrapError(err)
+	}
+
+	err = os.Rename(tmp.Name(), dstPath)
+	if err != nil {
+		return log.WrapError(err)
+	}
+	return nil
+}
+
+// evict удаляет давно использованные файлы, пока кеш превышает допустимый размер;
+// последний использованный и закреплённые файлы (ещё не отправленные части медиа-альбома) не удаляются
+func (s *Service) evict() {
+	var err error
+	result := []string{}
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"result", result,
+		)
+	}()
+
+	var entries []os.DirEntry
+	entries, err = os.ReadDir(s.directory)
+	if err != nil {
+		err = log.WrapError(err)
+		return
+	}
+
+	type cachedFile struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var (
+		files     []cachedFile
+		totalSize int64
+	)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue // файл удалён параллельно
+		}
+		files = append(files, cachedFile{
+			path:    filepath.Join(s.directory, entry.Name()),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+		totalSize += info.Size()
+	}
+
+	slices.SortFunc(files, func(a, b cachedFile) int {
+		return a.modTime.Compare(b.modTime)
+	})
+
+	for i := 0; i < len(files)-1 && totalSize > s.maxSize; i++ {
+		if s.pinned[files[i].path] > 0 {
+			continue
+		}
+		err = os.Remove(files[i].path)
+		if err != nil {
+			err = log.WrapError(err)
+			return
+		}
+		totalSize -= files[i].size
+		result = append(result, files[i].path)
+	}
+}
@@ -0,0 +1,173 @@
+package media_cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/service/media_cache/mocks"
+)
+
+func TestLocalize(t *testing.T) {
+	t.Parallel()
+
+	tg := mocks.NewTelegramRepo(t)
+	s := New(tg)
+	s.directory = t.TempDir()
+	s.maxSize = 1024 * 1024
+
+	downloadedPath := filepath.Join(t.TempDir(), "photo.jpg")
+	require.NoError(t, os.WriteFile(downloadedPath, []byte("photo"), 0o600))
+
+	remoteFile := &client.File{
+		Id:     7,
+		Remote: &client.RemoteFile{Id: "AgACAgIAAxkBAAIBPhoto", UniqueId: "AQADPhoto"},
+	}
+	tg.EXPECT().GetRemoteFile(&client.GetRemoteFileRequest{
+		RemoteFileId: "AgACAgIAAxkBAAIBPhoto",
+	}).Return(remoteFile, nil).Twice()
+	tg.EXPECT().DownloadFile(&client.DownloadFileRequest{
+		FileId:      7,
+		Priority:    1,
+		Synchronous: true,
+	}).Return(&client.File{
+		Id:     7,
+		Remote: remoteFile.Remote,
+		Local:  &client.LocalFile{Path: downloadedPath, IsDownloadingCompleted: true},
+	}, nil).Once()
+	tg.EXPECT().DeleteFile(&client.DeleteFileRequest{
+		FileId: 7,
+	}).Return(&client.Ok{}, nil).Once()
+
+	expectedPath := filepath.Join(s.directory, "AQADPhoto")
+
+	// первый раз файл скачивается, второй раз берётся из кеша
+	for range 2 {
+		content := &client.InputMessagePhoto{
+			Photo: &client.InputFileRemote{Id: "AgACAgIAAxkBAAIBPhoto"},
+			Thumbnail: &client.InputThumbnail{
+				Thumbnail: &client.InputFileRemote{Id: "AAMCAgADGQEAAgFThumb"},
+			},
+			Caption: &client.FormattedText{Text: "caption"},
+		}
+
+		ok, err := s.Localize(content)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, &client.InputFileLocal{Path: expectedPath}, content.Photo)
+		assert.Nil(t, content.Thumbnail)
+		assert.Equal(t, "caption", content.Caption.Text)
+	}
+
+	data, err := os.ReadFile(expectedPath)
+	require.NoError(t, err)
+	assert.Equal(t, "photo", string(data))
+	assert.Equal(t, 2, s.pinned[expectedPath], "файл закреплён для каждой отправки")
+}
+
+func TestLocalizeWithoutRemoteFiles(t *testing.T) {
+	t.Parallel()
+
+	tg := mocks.NewTelegramRepo(t)
+	s := New(tg)
+	s.directory = t.TempDir()
+
+	tests := []struct {
+		name    string
+		content client.InputMessageContent
+	}{
+		{
+			name: "text",
+			content: &client.InputMessageText{
+				Text: &client.FormattedText{Text: "text"},
+			},
+		},
+		{
+			name: "local_file",
+			content: &client.InputMessageDocument{
+				Document: &client.InputFileLocal{Path: "/tmp/document.pdf"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ok, err := s.Localize(test.content)
+			require.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestEvict(t *testing.T) {
+	t.Parallel()
+
+	s := New(nil)
+	s.directory = t.TempDir()
+	s.maxSize = 10
+
+	now := time.Now()
+	files := []struct {
+		name string
+		age  time.Duration
+	}{
+		{name: "oldest", age: 3 * time.Hour},
+		{name: "older", age: 2 * time.Hour},
+		{name: "newest", age: time.Hour},
+	}
+	for _, file := range files {
+		path := filepath.Join(s.directory, file.name)
+		require.NoError(t, os.WriteFile(path, []byte("123456"), 0o600))
+		modTime := now.Add(-file.age)
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	s.evict()
+
+	assert.NoFileExists(t, filepath.Join(s.directory, "oldest"))
+	assert.NoFileExists(t, filepath.Join(s.directory, "older"))
+	assert.FileExists(t, filepath.Join(s.directory, "newest"))
+}
+
+func TestEvictPinned(t *testing.T) {
+	t.Parallel()
+
+	s := New(nil)
+	s.directory = t.TempDir()
+	s.maxSize = 10
+
+	now := time.Now()
+	var contents []client.InputMessageContent
+	for i, name := range []string{"album1", "album2", "newest"} {
+		path := filepath.Join(s.directory, name)
+		require.NoError(t, os.WriteFile(path, []byte("123456"), 0o600))
+		modTime := now.Add(time.Duration(i-3) * time.Hour)
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+		s.pinned[path]++
+		contents = append(contents, &client.InputMessagePhoto{
+			Photo: &client.InputFileLocal{Path: path},
+		})
+	}
+
+	// части медиа-альбома ещё отправляются
+	s.evict()
+	assert.FileExists(t, filepath.Join(s.directory, "album1"))
+	assert.FileExists(t, filepath.Join(s.directory, "album2"))
+
+	for _, content := range contents {
+		s.Release(content)
+	}
+	assert.Empty(t, s.pinned)
+
+	s.evict()
+	assert.NoFileExists(t, filepath.Join(s.directory, "album1"))
+	assert.NoFileExists(t, filepath.Join(s.directory, "album2"))
+	assert.FileExists(t, filepath.Join(s.directory, "newest"))
+}
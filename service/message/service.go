@@ -1,9 +1,14 @@
 package message
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/zelenin/go-tdlib/client"
 
+	"github.com/comerc/budva43/app/domain"
 	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/util"
 )
 
 // Service предоставляет методы для обработки и преобразования сообщений
@@ -40,6 +45,24 @@ func (s *Service) GetFormattedText(message *client.Message) *client.FormattedTex
 		return contentByType.Caption
 	case *client.MessageVoiceNote:
 		return contentByType.Caption
+	case *client.MessagePoll:
+		return contentByType.Poll.Question
+	case *client.MessageVenue:
+		// для фильтров: название и адрес
+		return &client.FormattedText{
+			Text:     strings.TrimSpace(contentByType.Venue.Title + "\n" + contentByType.Venue.Address),
+			Entities: []*client.TextEntity{},
+		}
+	case
+		*client.MessageVideoNote,
+		*client.MessageSticker,
+		*client.MessageLocation,
+		*client.MessageContact,
+		*client.MessageDice:
+		// без подписи, но поддерживается для копирования и фильтров
+		return &client.FormattedText{
+			Entities: []*client.TextEntity{},
+		}
 	default:
 		return nil
 	}
@@ -164,20 +187,270 @@ func (s *Service) GetInputMessageContent(message *client.Message, formattedText
 			// Ttl: ,
 		}
 	case *client.MessageVoiceNote:
+		messageVoiceNote := messageContent.(*client.MessageVoiceNote)
 		return &client.InputMessageVoiceNote{
-			// TODO: support ContentModeVoiceNote
-			// VoiceNote: ,
-			// Duration: ,
-			// Waveform: ,
-			Caption: formattedText,
+			VoiceNote: &client.InputFileRemote{
+				Id: messageVoiceNote.VoiceNote.Voice.Remote.Id,
+			},
+			Duration: messageVoiceNote.VoiceNote.Duration,
+			Waveform: messageVoiceNote.VoiceNote.Waveform,
+			Caption:  formattedText,
+		}
+	case *client.MessageVideoNote:
+		messageVideoNote := messageContent.(*client.MessageVideoNote)
+		return &client.InputMessageVideoNote{
+			VideoNote: &client.InputFileRemote{
+				Id: messageVideoNote.VideoNote.Video.Remote.Id,
+			},
+			Thumbnail: getInputThumbnail(messageVideoNote.VideoNote.Thumbnail),
+			Duration:  messageVideoNote.VideoNote.Duration,
+			Length:    messageVideoNote.VideoNote.Length,
+		}
+	case *client.MessageSticker:
+		messageSticker := messageContent.(*client.MessageSticker)
+		return &client.InputMessageSticker{
+			Sticker: &client.InputFileRemote{
+				Id: messageSticker.Sticker.Sticker.Remote.Id,
+			},
+			Thumbnail: getInputThumbnail(messageSticker.Sticker.Thumbnail),
+			Width:     messageSticker.Sticker.Width,
+			Height:    messageSticker.Sticker.Height,
+			Emoji:     messageSticker.Sticker.Emoji,
+		}
+	case *client.MessagePoll:
+		messagePoll := messageContent.(*client.MessagePoll)
+		// вопрос не трансформируется: допустимы только custom emoji и не более 300 символов
+		return &client.InputMessagePoll{
+			Question: messagePoll.Poll.Question,
+			Options: func() []*client.FormattedText {
+				var options []*client.FormattedText
+				for _, option := range messagePoll.Poll.Options {
+					options = append(options, option.Text)
+				}
+				return options
+			}(),
+			IsAnonymous: messagePoll.Poll.IsAnonymous,
+			Type:        getInputPollType(messagePoll.Poll.Type),
+		}
+	case *client.MessageVenue:
+		messageVenue := messageContent.(*client.MessageVenue)
+		return &client.InputMessageVenue{
+			Venue: messageVenue.Venue,
+		}
+	case *client.MessageLocation:
+		messageLocation := messageContent.(*client.MessageLocation)
+		return &client.InputMessageLocation{
+			Location:   messageLocation.Location,
+			LivePeriod: getInputLivePeriod(messageLocation),
+			Heading:    messageLocation.Heading,
+		}
+	case *client.MessageContact:
+		messageContact := messageContent.(*client.MessageContact)
+		return &client.InputMessageContact{
+			Contact: messageContact.Contact,
+		}
+	case *client.MessageDice:
+		messageDice := messageContent.(*client.MessageDice)
+		// значение кубика не копируется - выпадет новое
+		return &client.InputMessageDice{
+			Emoji: messageDice.Emoji,
+		}
+	}
+	return nil
+}
+
+// ApplyMediaPolicy применяет к входному контенту настройки качества медиа для получателя
+func (s *Service) ApplyMediaPolicy(content client.InputMessageContent, message *client.Message, mediaPolicy *domain.MediaPolicy) {
+	if mediaPolicy == nil {
+		return
+	}
+	inputMessagePhoto, ok := content.(*client.InputMessagePhoto)
+	if !ok {
+		return
+	}
+	messagePhoto, ok := message.Content.(*client.MessagePhoto)
+	if !ok {
+		return
+	}
+	photoSize := selectPhotoSize(messagePhoto.Photo.Sizes, mediaPolicy)
+	inputMessagePhoto.Photo = &client.InputFileRemote{
+		Id: photoSize.Photo.Remote.Id,
+	}
+	inputMessagePhoto.Width = photoSize.Width
+	inputMessagePhoto.Height = photoSize.Height
+}
+
+// selectPhotoSize выбирает размер фото в пределах ограничений;
+// если ни один размер не подходит, то возвращает наименьший
+func selectPhotoSize(sizes []*client.PhotoSize, mediaPolicy *domain.MediaPolicy) *client.PhotoSize {
+	var result *client.PhotoSize
+	for _, size := range sizes {
+		if mediaPolicy.MaxSide > 0 && max(size.Width, size.Height) > mediaPolicy.MaxSide {
+			continue
+		}
+		if mediaPolicy.MaxFileSize > 0 && getFileSize(size.Photo) > mediaPolicy.MaxFileSize {
+			continue
+		}
+		if result == nil {
+			result = size
+			if mediaPolicy.PhotoSize != domain.PhotoSizeLargest {
+				break
+			}
+			continue
+		}
+		if size.Width*size.Height > result.Width*result.Height {
+			result = size
+		}
+	}
+	if result == nil {
+		return sizes[0]
+	}
+	return result
+}
+
+// getFileSize возвращает точный или ожидаемый размер файла
+func getFileSize(file *client.File) int64 {
+	if file.Size != 0 {
+		return file.Size
+	}
+	return file.ExpectedSize
+}
+
+const (
+	// maxTextLength ограничение Telegram на длину текста сообщения
+	maxTextLength = 4096
+	// maxCaptionLength ограничение Telegram на длину подписи к медиа
+	maxCaptionLength = 1024
+)
+
+// SplitOverflow отделяет от входного контента текст, превышающий ограничения Telegram:
+// длинный текст разбивается по абзацам, а длинная подпись к медиа переносится целиком;
+// возвращает части текста для отправки ответами на копию
+func (s *Service) SplitOverflow(content client.InputMessageContent) []*client.FormattedText {
+	if inputMessageText, ok := content.(*client.InputMessageText); ok {
+		if len(util.EncodeToUTF16(inputMessageText.Text.Text)) <= maxTextLength {
+			return nil
 		}
+		parts := splitFormattedText(inputMessageText.Text, maxTextLength)
+		inputMessageText.Text = parts[0]
+		return parts[1:]
+	}
+	caption := getInputCaption(content)
+	if caption == nil || *caption == nil {
+		return nil
+	}
+	if len(util.EncodeToUTF16((*caption).Text)) <= maxCaptionLength {
+		return nil
+	}
+	parts := splitFormattedText(*caption, maxTextLength)
+	*caption = &client.FormattedText{
+		Entities: []*client.TextEntity{},
+	}
+	return parts
+}
+
+// getInputCaption возвращает указатель на подпись входного контента
+func getInputCaption(content client.InputMessageContent) **client.FormattedText {
+	switch contentByType := content.(type) {
+	case *client.InputMessageAnimation:
+		return &contentByType.Caption
+	case *client.InputMessageAudio:
+		return &contentByType.Caption
+	case *client.InputMessageDocument:
+		return &contentByType.Caption
+	case *client.InputMessagePhoto:
+		return &contentByType.Caption
+	case *client.InputMessageVideo:
+		return &contentByType.Caption
+	case *client.InputMessageVoiceNote:
+		return &contentByType.Caption
 	}
 	return nil
 }
 
+// splitSeparators разделители для разбиения текста в порядке предпочтения
+var splitSeparators = [][]uint16{
+	util.EncodeToUTF16("\n\n"),
+	util.EncodeToUTF16("\n"),
+	util.EncodeToUTF16(" "),
+}
+
+// splitFormattedText разбивает текст на части не длиннее maxLength (в UTF-16),
+// по возможности на границе абзаца и вне сущностей разметки
+func splitFormattedText(formattedText *client.FormattedText, maxLength int) []*client.FormattedText {
+	text := util.EncodeToUTF16(formattedText.Text)
+	var result []*client.FormattedText
+	start := 0
+	for len(text)-start > maxLength {
+		end, next := findSplitOffset(text, formattedText.Entities, start, start+maxLength)
+		result = append(result, cutFormattedText(text, formattedText.Entities, start, end))
+		start = next
+	}
+	result = append(result, cutFormattedText(text, formattedText.Entities, start, len(text)))
+	return result
+}
+
+// findSplitOffset ищет место разбиения текста не дальше limit;
+// возвращает конец текущей части и начало следующей
+func findSplitOffset(text []uint16, entities []*client.TextEntity, start, limit int) (int, int) {
+	for _, separator := range splitSeparators {
+		for end := limit; end > start; end-- {
+			if end+len(separator) > len(text) || !slices.Equal(text[end:end+len(separator)], separator) {
+				continue
+			}
+			if isInsideEntity(entities, end) {
+				continue
+			}
+			return end, end + len(separator)
+		}
+	}
+	// подходящего разделителя нет: режем по ограничению, не разрывая суррогатную пару
+	end := limit
+	if isHighSurrogate(text[end-1]) && end-1 > start {
+		end--
+	}
+	return end, end
+}
+
+// isHighSurrogate проверяет, что код является первой половиной суррогатной пары
+func isHighSurrogate(code uint16) bool {
+	return code >= 0xD800 && code < 0xDC00
+}
+
+// isInsideEntity проверяет, что смещение попадает внутрь сущности разметки
+func isInsideEntity(entities []*client.TextEntity, offset int) bool {
+	for _, entity := range entities {
+		if int(entity.Offset) < offset && offset < int(entity.Offset+entity.Length) {
+			return true
+		}
+	}
+	return false
+}
+
+// cutFormattedText вырезает часть текста вместе с попадающими в неё сущностями разметки
+func cutFormattedText(text []uint16, entities []*client.TextEntity, start, end int) *client.FormattedText {
+	result := &client.FormattedText{
+		Text:     util.DecodeFromUTF16(text[start:end]),
+		Entities: []*client.TextEntity{},
+	}
+	for _, entity := range entities {
+		entityStart := max(int(entity.Offset), start)
+		entityEnd := min(int(entity.Offset+entity.Length), end)
+		if entityStart >= entityEnd {
+			continue
+		}
+		result.Entities = append(result.Entities, &client.TextEntity{
+			Offset: int32(entityStart - start),     //nolint:gosec
+			Length: int32(entityEnd - entityStart), //nolint:gosec
+			Type:   entity.Type,
+		})
+	}
+	return result
+}
+
 // getInputThumbnail преобразует thumbnail в входной контент
 func getInputThumbnail(thumbnail *client.Thumbnail) *client.InputThumbnail {
-	if thumbnail == nil || thumbnail.File == nil && thumbnail.File.Remote == nil {
+	if thumbnail == nil || thumbnail.File == nil || thumbnail.File.Remote == nil {
 		return nil
 	}
 	return &client.InputThumbnail{
@@ -188,3 +461,28 @@ func getInputThumbnail(thumbnail *client.Thumbnail) *client.InputThumbnail {
 		Height: thumbnail.Height,
 	}
 }
+
+// getInputPollType преобразует тип опроса во входной тип;
+// викторина без известного правильного ответа отправляется обычным опросом
+func getInputPollType(pollType client.PollType) client.PollType {
+	if quiz, ok := pollType.(*client.PollTypeQuiz); ok && quiz.CorrectOptionId < 0 {
+		return &client.PollTypeRegular{}
+	}
+	return pollType
+}
+
+// livePeriodForever период трансляции геопозиции без ограничения по времени
+const livePeriodForever = 0x7FFFFFFF
+
+// getInputLivePeriod возвращает период трансляции геопозиции для копии:
+// оставшееся время трансляции оригинала или 0 для статичной геопозиции
+func getInputLivePeriod(messageLocation *client.MessageLocation) int32 {
+	if messageLocation.LivePeriod == livePeriodForever {
+		return livePeriodForever
+	}
+	const minLivePeriod = 60
+	if messageLocation.ExpiresIn < minLivePeriod {
+		return 0
+	}
+	return messageLocation.ExpiresIn
+}
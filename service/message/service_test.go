@@ -0,0 +1,479 @@
+package message
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/domain"
+)
+
+func TestGetFormattedText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		fixture      string
+		expectedText string
+	}{
+		{
+			name:         "voice_note",
+			fixture:      "voice_note.json",
+			expectedText: "voice caption",
+		},
+		{
+			name:    "video_note",
+			fixture: "video_note.json",
+		},
+		{
+			name:    "sticker",
+			fixture: "sticker.json",
+		},
+		{
+			name:         "poll",
+			fixture:      "poll.json",
+			expectedText: "Buy #ARK?",
+		},
+		{
+			name:         "venue",
+			fixture:      "venue.json",
+			expectedText: "Red Square\nMoscow, Russia",
+		},
+		{
+			name:    "location",
+			fixture: "location.json",
+		},
+		{
+			name:    "contact",
+			fixture: "contact.json",
+		},
+		{
+			name:    "dice",
+			fixture: "dice.json",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			messageService := New()
+			message := loadMessage(t, test.fixture)
+
+			formattedText := messageService.GetFormattedText(message)
+
+			require.NotNil(t, formattedText)
+			assert.Equal(t, test.expectedText, formattedText.Text)
+		})
+	}
+}
+
+func TestGetInputMessageContent(t *testing.T) {
+	t.Parallel()
+
+	formattedText := &client.FormattedText{
+		Text:     "transformed caption",
+		Entities: []*client.TextEntity{},
+	}
+
+	tests := []struct {
+		name     string
+		fixture  string
+		expected client.InputMessageContent
+	}{
+		{
+			name:    "voice_note",
+			fixture: "voice_note.json",
+			expected: &client.InputMessageVoiceNote{
+				VoiceNote: &client.InputFileRemote{
+					Id: "AwACAgIAAxkBAAIBVoice",
+				},
+				Duration: 7,
+				Waveform: []byte{0, 0, 0, 0, 31, 31, 31, 31, 31, 31, 31, 31, 0, 0, 0},
+				Caption:  formattedText,
+			},
+		},
+		{
+			name:    "video_note",
+			fixture: "video_note.json",
+			expected: &client.InputMessageVideoNote{
+				VideoNote: &client.InputFileRemote{
+					Id: "DQACAgIAAxkBAAIBVideoNote",
+				},
+				Thumbnail: &client.InputThumbnail{
+					Thumbnail: &client.InputFileRemote{
+						Id: "AAMCAgADGQEAAgFThumb",
+					},
+					Width:  240,
+					Height: 240,
+				},
+				Duration: 12,
+				Length:   384,
+			},
+		},
+		{
+			name:    "sticker",
+			fixture: "sticker.json",
+			expected: &client.InputMessageSticker{
+				Sticker: &client.InputFileRemote{
+					Id: "CAACAgIAAxkBAAIBSticker",
+				},
+				Width:  512,
+				Height: 512,
+				Emoji:  "👍",
+			},
+		},
+		{
+			name:    "poll",
+			fixture: "poll.json",
+			expected: &client.InputMessagePoll{
+				Question: &client.FormattedText{
+					Text:     "Buy #ARK?",
+					Entities: []*client.TextEntity{},
+				},
+				Options: []*client.FormattedText{
+					{Text: "Yes", Entities: []*client.TextEntity{}},
+					{Text: "No", Entities: []*client.TextEntity{}},
+				},
+				IsAnonymous: true,
+				Type: &client.PollTypeRegular{
+					AllowMultipleAnswers: true,
+				},
+			},
+		},
+		{
+			name:    "quiz_without_correct_option",
+			fixture: "quiz.json",
+			expected: &client.InputMessagePoll{
+				Question: &client.FormattedText{
+					Text:     "2 + 2 = ?",
+					Entities: []*client.TextEntity{},
+				},
+				Options: []*client.FormattedText{
+					{Text: "4", Entities: []*client.TextEntity{}},
+					{Text: "5", Entities: []*client.TextEntity{}},
+				},
+				IsAnonymous: true,
+				Type:        &client.PollTypeRegular{},
+			},
+		},
+		{
+			name:    "venue",
+			fixture: "venue.json",
+			expected: &client.InputMessageVenue{
+				Venue: &client.Venue{
+					Location: &client.Location{
+						Latitude:  55.751244,
+						Longitude: 37.618423,
+					},
+					Title:    "Red Square",
+					Address:  "Moscow, Russia",
+					Provider: "foursquare",
+					Id:       "4b5a8e59f964a520cfc528e3",
+					Type:     "arts_entertainment/default",
+				},
+			},
+		},
+		{
+			name:    "location",
+			fixture: "location.json",
+			expected: &client.InputMessageLocation{
+				Location: &client.Location{
+					Latitude:  59.939095,
+					Longitude: 30.315868,
+				},
+			},
+		},
+		{
+			name:    "live_location",
+			fixture: "live_location.json",
+			expected: &client.InputMessageLocation{
+				Location: &client.Location{
+					Latitude:           59.939095,
+					Longitude:          30.315868,
+					HorizontalAccuracy: 15.5,
+				},
+				LivePeriod: 1800,
+				Heading:    90,
+			},
+		},
+		{
+			name:    "contact",
+			fixture: "contact.json",
+			expected: &client.InputMessageContact{
+				Contact: &client.Contact{
+					PhoneNumber: "79261112233",
+					FirstName:   "Ivan",
+					LastName:    "Petrov",
+				},
+			},
+		},
+		{
+			name:    "dice",
+			fixture: "dice.json",
+			expected: &client.InputMessageDice{
+				Emoji: "🎲",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			messageService := New()
+			message := loadMessage(t, test.fixture)
+
+			content := messageService.GetInputMessageContent(message, formattedText)
+
+			// сравниваем через JSON, т.к. распакованные из TDLib структуры содержат служебный @type
+			expected, err := json.Marshal(test.expected)
+			require.NoError(t, err)
+			actual, err := json.Marshal(content)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+		})
+	}
+}
+
+// loadMessage загружает синтетическое сообщение TDLib из testdata, см. testdata/README.md
+func loadMessage(t *testing.T, fixture string) *client.Message {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", fixture))
+	require.NoError(t, err)
+	message, err := client.UnmarshalMessage(data)
+	require.NoError(t, err)
+	return message
+}
+
+func TestApplyMediaPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		mediaPolicy    *domain.MediaPolicy
+		expectedId     string
+		expectedWidth  int32
+		expectedHeight int32
+	}{
+		{
+			name:           "without_policy",
+			expectedId:     "AgACAgIAAxkBAAIBPhotoS",
+			expectedWidth:  90,
+			expectedHeight: 60,
+		},
+		{
+			name: "smallest",
+			mediaPolicy: &domain.MediaPolicy{
+				PhotoSize: domain.PhotoSizeSmallest,
+			},
+			expectedId:     "AgACAgIAAxkBAAIBPhotoS",
+			expectedWidth:  90,
+			expectedHeight: 60,
+		},
+		{
+			name: "largest",
+			mediaPolicy: &domain.MediaPolicy{
+				PhotoSize: domain.PhotoSizeLargest,
+			},
+			expectedId:     "AgACAgIAAxkBAAIBPhotoY",
+			expectedWidth:  1280,
+			expectedHeight: 853,
+		},
+		{
+			name: "largest_with_max_side",
+			mediaPolicy: &domain.MediaPolicy{
+				PhotoSize: domain.PhotoSizeLargest,
+				MaxSide:   1000,
+			},
+			expectedId:     "AgACAgIAAxkBAAIBPhotoX",
+			expectedWidth:  800,
+			expectedHeight: 533,
+		},
+		{
+			name: "largest_with_max_file_size_by_expected_size",
+			mediaPolicy: &domain.MediaPolicy{
+				PhotoSize:   domain.PhotoSizeLargest,
+				MaxFileSize: 100000,
+			},
+			expectedId:     "AgACAgIAAxkBAAIBPhotoM",
+			expectedWidth:  320,
+			expectedHeight: 213,
+		},
+		{
+			name: "smallest_with_max_side_too_small",
+			mediaPolicy: &domain.MediaPolicy{
+				MaxSide: 10,
+			},
+			expectedId:     "AgACAgIAAxkBAAIBPhotoS",
+			expectedWidth:  90,
+			expectedHeight: 60,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			messageService := New()
+			message := loadMessage(t, "photo.json")
+			formattedText := messageService.GetFormattedText(message)
+			content := messageService.GetInputMessageContent(message, formattedText)
+
+			messageService.ApplyMediaPolicy(content, message, test.mediaPolicy)
+
+			inputMessagePhoto, ok := content.(*client.InputMessagePhoto)
+			require.True(t, ok)
+			inputFileRemote, ok := inputMessagePhoto.Photo.(*client.InputFileRemote)
+			require.True(t, ok)
+			assert.Equal(t, test.expectedId, inputFileRemote.Id)
+			assert.Equal(t, test.expectedWidth, inputMessagePhoto.Width)
+			assert.Equal(t, test.expectedHeight, inputMessagePhoto.Height)
+			assert.Equal(t, "photo caption", inputMessagePhoto.Caption.Text)
+		})
+	}
+}
+
+func TestSplitOverflow(t *testing.T) {
+	t.Parallel()
+
+	bold := &client.TextEntityTypeBold{}
+
+	tests := []struct {
+		name            string
+		content         client.InputMessageContent
+		expectedContent client.InputMessageContent
+		expectedParts   []*client.FormattedText
+	}{
+		{
+			name: "short_text",
+			content: &client.InputMessageText{
+				Text: &client.FormattedText{Text: "short", Entities: []*client.TextEntity{}},
+			},
+			expectedContent: &client.InputMessageText{
+				Text: &client.FormattedText{Text: "short", Entities: []*client.TextEntity{}},
+			},
+		},
+		{
+			name: "long_text_by_paragraph",
+			content: &client.InputMessageText{
+				Text: &client.FormattedText{
+					Text: strings.Repeat("a", 3000) + "\n\n" + strings.Repeat("b", 3000),
+					Entities: []*client.TextEntity{
+						{Offset: 2990, Length: 10, Type: bold},
+						{Offset: 5000, Length: 10, Type: bold},
+					},
+				},
+			},
+			expectedContent: &client.InputMessageText{
+				Text: &client.FormattedText{
+					Text: strings.Repeat("a", 3000),
+					Entities: []*client.TextEntity{
+						{Offset: 2990, Length: 10, Type: bold},
+					},
+				},
+			},
+			expectedParts: []*client.FormattedText{
+				{
+					Text: strings.Repeat("b", 3000),
+					Entities: []*client.TextEntity{
+						{Offset: 1998, Length: 10, Type: bold},
+					},
+				},
+			},
+		},
+		{
+			name: "paragraph_inside_entity",
+			content: &client.InputMessageText{
+				Text: &client.FormattedText{
+					Text: strings.Repeat("a", 2000) + "\n" + strings.Repeat("a", 1000) + "\n\n" + strings.Repeat("b", 3000),
+					Entities: []*client.TextEntity{
+						{Offset: 2500, Length: 1000, Type: bold},
+					},
+				},
+			},
+			expectedContent: &client.InputMessageText{
+				Text: &client.FormattedText{
+					Text:     strings.Repeat("a", 2000),
+					Entities: []*client.TextEntity{},
+				},
+			},
+			expectedParts: []*client.FormattedText{
+				{
+					Text: strings.Repeat("a", 1000) + "\n\n" + strings.Repeat("b", 3000),
+					Entities: []*client.TextEntity{
+						{Offset: 499, Length: 1000, Type: bold},
+					},
+				},
+			},
+		},
+		{
+			name: "long_text_without_separators",
+			content: &client.InputMessageText{
+				Text: &client.FormattedText{
+					Text:     strings.Repeat("a", 5000),
+					Entities: []*client.TextEntity{{Offset: 4000, Length: 200, Type: bold}},
+				},
+			},
+			expectedContent: &client.InputMessageText{
+				Text: &client.FormattedText{
+					Text:     strings.Repeat("a", 4096),
+					Entities: []*client.TextEntity{{Offset: 4000, Length: 96, Type: bold}},
+				},
+			},
+			expectedParts: []*client.FormattedText{
+				{
+					Text:     strings.Repeat("a", 904),
+					Entities: []*client.TextEntity{{Offset: 0, Length: 104, Type: bold}},
+				},
+			},
+		},
+		{
+			name: "long_caption",
+			content: &client.InputMessagePhoto{
+				Caption: &client.FormattedText{
+					Text:     strings.Repeat("c", 1500),
+					Entities: []*client.TextEntity{{Offset: 0, Length: 4, Type: bold}},
+				},
+			},
+			expectedContent: &client.InputMessagePhoto{
+				Caption: &client.FormattedText{Entities: []*client.TextEntity{}},
+			},
+			expectedParts: []*client.FormattedText{
+				{
+					Text:     strings.Repeat("c", 1500),
+					Entities: []*client.TextEntity{{Offset: 0, Length: 4, Type: bold}},
+				},
+			},
+		},
+		{
+			name: "short_caption",
+			content: &client.InputMessagePhoto{
+				Caption: &client.FormattedText{Text: "caption", Entities: []*client.TextEntity{}},
+			},
+			expectedContent: &client.InputMessagePhoto{
+				Caption: &client.FormattedText{Text: "caption", Entities: []*client.TextEntity{}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			messageService := New()
+
+			parts := messageService.SplitOverflow(test.content)
+
+			assert.Equal(t, test.expectedContent, test.content)
+			assert.Equal(t, test.expectedParts, parts)
+		})
+	}
+}
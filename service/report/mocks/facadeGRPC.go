@@ -0,0 +1,81 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	dto "github.com/comerc/budva43/app/dto/grpc/dto"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FacadeGRPC is an autogenerated mock type for the facadeGRPC type
+type FacadeGRPC struct {
+	mock.Mock
+}
+
+type FacadeGRPC_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FacadeGRPC) EXPECT() *FacadeGRPC_Expecter {
+	return &FacadeGRPC_Expecter{mock: &_m.Mock}
+}
+
+// SendMessage provides a mock function with given fields: newMessage
+func (_m *FacadeGRPC) SendMessage(newMessage *dto.NewMessage) error {
+	ret := _m.Called(newMessage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*dto.NewMessage) error); ok {
+		r0 = rf(newMessage)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FacadeGRPC_SendMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMessage'
+type FacadeGRPC_SendMessage_Call struct {
+	*mock.Call
+}
+
+// SendMessage is a helper method to define mock.On call
+//   - newMessage *dto.NewMessage
+func (_e *FacadeGRPC_Expecter) SendMessage(newMessage interface{}) *FacadeGRPC_SendMessage_Call {
+	return &FacadeGRPC_SendMessage_Call{Call: _e.mock.On("SendMessage", newMessage)}
+}
+
+func (_c *FacadeGRPC_SendMessage_Call) Run(run func(newMessage *dto.NewMessage)) *FacadeGRPC_SendMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*dto.NewMessage))
+	})
+	return _c
+}
+
+func (_c *FacadeGRPC_SendMessage_Call) Return(_a0 error) *FacadeGRPC_SendMessage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FacadeGRPC_SendMessage_Call) RunAndReturn(run func(*dto.NewMessage) error) *FacadeGRPC_SendMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFacadeGRPC creates a new instance of FacadeGRPC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFacadeGRPC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FacadeGRPC {
+	mock := &FacadeGRPC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
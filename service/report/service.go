@@ -0,0 +1,248 @@
+package report
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/dto/grpc/dto"
+	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/util"
+)
+
+//go:generate mockery --name=storageService --exported
+type storageService interface {
+	GetStats(filter *domain.StatsFilter) ([]*domain.Stats, error)
+}
+
+//go:generate mockery --name=facadeGRPC --exported
+type facadeGRPC interface {
+	SendMessage(newMessage *dto.NewMessage) error
+}
+
+// Service отправляет отчеты по статистике пересылок по расписанию config.Reports
+type Service struct {
+	log *log.Logger
+	//
+	storageService storageService
+	facadeGRPC     facadeGRPC
+}
+
+// New создает новый экземпляр сервиса отчетов
+func New(
+	storageService storageService,
+	facadeGRPC facadeGRPC,
+) *Service {
+	return &Service{
+		log: log.NewLogger(),
+		//
+		storageService: storageService,
+		facadeGRPC:     facadeGRPC,
+	}
+}
+
+// StartContext запускает отправку отчетов по расписанию (проверено при загрузке конфигурации)
+func (s *Service) StartContext(ctx context.Context) error {
+	if config.Reports.Schedule == "" || len(config.Reports.For) == 0 {
+		return nil
+	}
+	schedule, err := cron.ParseStandard(config.Reports.Schedule)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+	go s.runSchedule(ctx, schedule)
+	return nil
+}
+
+// Close останавливает сервис
+func (s *Service) Close() error {
+	return nil
+}
+
+// runSchedule отправляет отчет в каждый момент расписания
+func (s *Service) runSchedule(ctx context.Context, schedule cron.Schedule) {
+	for {
+		next := schedule.Next(time.Now())
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.send(next)
+		}
+	}
+}
+
+// send отправляет отчет за config.Reports.Period до момента to во все чаты config.Reports.For
+func (s *Service) send(to time.Time) {
+	var (
+		err    error
+		result []int64
+	)
+	defer func() {
+		s.log.ErrorOrInfo(err, "send report",
+			"to", to,
+			"result", result,
+		)
+	}()
+
+	var text string
+	text, err = s.Render(to.Add(-config.Reports.Period), to)
+	if err != nil {
+		return
+	}
+	// недоступный чат не должен лишать отчета остальные
+	var errs []error
+	for _, chatId := range config.Reports.For {
+		sendErr := s.facadeGRPC.SendMessage(&dto.NewMessage{
+			ChatId: chatId,
+			Text:   text,
+		})
+		if sendErr != nil {
+			s.log.ErrorOrDebug(sendErr, "", "chatId", chatId)
+			errs = append(errs, sendErr)
+			continue
+		}
+		result = append(result, chatId)
+	}
+	err = errors.Join(errs...)
+}
+
+// Render формирует текст отчета за период from..to по шаблону config.Reports.Template
+func (s *Service) Render(from, to time.Time) (string, error) {
+	var (
+		err  error
+		tmpl *template.Template
+		data *domain.ReportData
+		sb   strings.Builder
+	)
+
+	tmpl, err = util.NewMarkdownTemplate("report", config.Reports.Template)
+	if err != nil {
+		return "", log.WrapError(err) // внешняя ошибка
+	}
+	data, err = s.collect(from, to)
+	if err != nil {
+		return "", err
+	}
+	err = tmpl.Execute(&sb, data)
+	if err != nil {
+		return "", log.WrapError(err) // внешняя ошибка
+	}
+	return sb.String(), nil
+}
+
+// collect собирает данные отчета из почасовой статистики за период from..to
+func (s *Service) collect(from, to time.Time) (*domain.ReportData, error) {
+	stats, err := s.storageService.GetStats(&domain.StatsFilter{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		destinations = make(map[domain.ChatId]*domain.ReportDestination)
+		sources      = make(map[domain.ChatId]*domain.ReportSource)
+		rules        = make(map[domain.ForwardRuleId]*domain.ReportRule)
+		failures     = make(map[domain.ChatId]*domain.ReportFailure)
+	)
+	for _, item := range stats {
+		destination, ok := destinations[item.DstChatId]
+		if !ok {
+			destination = &domain.ReportDestination{ChatId: item.DstChatId}
+			destinations[item.DstChatId] = destination
+		}
+		destination.Viewed += item.Count
+		rule, ok := rules[item.ForwardRuleId]
+		if !ok {
+			rule = &domain.ReportRule{ForwardRuleId: item.ForwardRuleId}
+			rules[item.ForwardRuleId] = rule
+		}
+		switch item.Outcome {
+		case domain.StatsOk:
+			rule.Ok += item.Count
+			destination.Forwarded += item.Count
+			source, ok := sources[item.SrcChatId]
+			if !ok {
+				source = &domain.ReportSource{ChatId: item.SrcChatId}
+				sources[item.SrcChatId] = source
+			}
+			source.Forwarded += item.Count
+		case domain.StatsCheck:
+			rule.Check += item.Count
+			destination.Forwarded += item.Count
+		case domain.StatsOther:
+			rule.Other += item.Count
+			destination.Forwarded += item.Count
+		case domain.StatsFiltered:
+			rule.Filtered += item.Count
+		case domain.StatsDeduped:
+			rule.Deduped += item.Count
+		case domain.StatsProtected:
+			rule.Protected += item.Count
+		case domain.StatsFailed:
+			rule.Failed += item.Count
+			failure, ok := failures[item.DstChatId]
+			if !ok {
+				failure = &domain.ReportFailure{ChatId: item.DstChatId}
+				failures[item.DstChatId] = failure
+			}
+			failure.Failed += item.Count
+		}
+	}
+
+	result := &domain.ReportData{
+		From: from,
+		To:   to,
+	}
+	for _, destination := range destinations {
+		result.Forwarded += destination.Forwarded
+		result.Viewed += destination.Viewed
+		result.Destinations = append(result.Destinations, destination)
+	}
+	slices.SortFunc(result.Destinations, func(a, b *domain.ReportDestination) int {
+		return cmp.Compare(a.ChatId, b.ChatId)
+	})
+	for _, source := range sources {
+		result.TopSources = append(result.TopSources, source)
+	}
+	slices.SortFunc(result.TopSources, func(a, b *domain.ReportSource) int {
+		return cmp.Or(
+			cmp.Compare(b.Forwarded, a.Forwarded),
+			cmp.Compare(a.ChatId, b.ChatId),
+		)
+	})
+	if len(result.TopSources) > config.Reports.TopCount {
+		result.TopSources = result.TopSources[:config.Reports.TopCount]
+	}
+	for _, rule := range rules {
+		if total := rule.Ok + rule.Filtered; total > 0 {
+			rule.HitRate = float64(rule.Ok) * 100 / float64(total)
+		}
+		result.Rules = append(result.Rules, rule)
+	}
+	slices.SortFunc(result.Rules, func(a, b *domain.ReportRule) int {
+		return cmp.Compare(a.ForwardRuleId, b.ForwardRuleId)
+	})
+	for _, failure := range failures {
+		result.Failures = append(result.Failures, failure)
+	}
+	slices.SortFunc(result.Failures, func(a, b *domain.ReportFailure) int {
+		return cmp.Or(
+			cmp.Compare(b.Failed, a.Failed),
+			cmp.Compare(a.ChatId, b.ChatId),
+		)
+	})
+	return result, nil
+}
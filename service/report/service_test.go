@@ -0,0 +1,133 @@
+package report
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/dto/grpc/dto"
+	"github.com/comerc/budva43/service/report/mocks"
+)
+
+func newTestStats() []*domain.Stats {
+	hour := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []*domain.Stats{
+		{Hour: hour, ForwardRuleId: "rule1", SrcChatId: -1001, DstChatId: -1002, Outcome: domain.StatsOk, Count: 3},
+		{Hour: hour, ForwardRuleId: "rule1", SrcChatId: -1001, DstChatId: -1002, Outcome: domain.StatsFiltered, Count: 1},
+		{Hour: hour, ForwardRuleId: "rule1", SrcChatId: -1001, DstChatId: -1002, Outcome: domain.StatsProtected, Count: 1},
+		{Hour: hour, ForwardRuleId: "rule1", SrcChatId: -1001, DstChatId: -1009, Outcome: domain.StatsCheck, Count: 1},
+		{Hour: hour, ForwardRuleId: "rule2", SrcChatId: -1003, DstChatId: -1002, Outcome: domain.StatsOk, Count: 5},
+		{Hour: hour, ForwardRuleId: "rule2", SrcChatId: -1003, DstChatId: -1004, Outcome: domain.StatsFailed, Count: 2},
+	}
+}
+
+func TestRender(t *testing.T) {
+	// t.Parallel() // !! нельзя параллелить, тестирую с подменой глобальных переменных
+
+	reports := *config.Reports
+	t.Cleanup(func() {
+		*config.Reports = reports
+	})
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		template string
+		topCount int
+		expected string
+	}{
+		{
+			name:     "default",
+			template: reports.Template,
+			topCount: 1,
+			expected: "📊 *Статистика 01\\.01 00:00 – 02\\.01 00:00*\n" +
+				"Отобрал: *9* из *13*\n" +
+				"\n*Получатели*\n" +
+				"\\-1009: 1 из 1\n" +
+				"\\-1004: 0 из 2\n" +
+				"\\-1002: 8 из 10\n" +
+				"\n*Топ источников*\n" +
+				"\\-1003: 5\n" +
+				"\n*Фильтры*\n" +
+				"rule1: прошло 75% \\(3\\), отсеяно 1, защищённых пропущено 1\n" +
+				"rule2: прошло 100% \\(5\\), отсеяно 0\n" +
+				"\n*Ошибки доставки*\n" +
+				"\\-1004: 2",
+		},
+		{
+			name:     "custom",
+			template: "За *24 часа* отобрал: *{{ .Forwarded }}* из *{{ .Viewed }}* 😎",
+			topCount: 5,
+			expected: "За *24 часа* отобрал: *9* из *13* 😎",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config.Reports.Template = test.template
+			config.Reports.TopCount = test.topCount
+
+			storageService := mocks.NewStorageService(t)
+			storageService.EXPECT().GetStats(&domain.StatsFilter{From: from, To: to}).Return(newTestStats(), nil)
+
+			s := New(storageService, nil)
+			result, err := s.Render(from, to)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	// t.Parallel() // !! нельзя параллелить, тестирую с подменой глобальных переменных
+
+	reports := *config.Reports
+	t.Cleanup(func() {
+		*config.Reports = reports
+	})
+	config.Reports.Template = "{{ .Forwarded }}/{{ .Viewed }}"
+	config.Reports.Period = time.Hour
+	config.Reports.For = []domain.ChatId{-1007, -1008}
+
+	to := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	storageService := mocks.NewStorageService(t)
+	storageService.EXPECT().GetStats(&domain.StatsFilter{From: to.Add(-time.Hour), To: to}).Return(newTestStats(), nil)
+	facadeGRPC := mocks.NewFacadeGRPC(t)
+	facadeGRPC.EXPECT().SendMessage(&dto.NewMessage{ChatId: -1007, Text: "9/13"}).Return(nil).Once()
+	facadeGRPC.EXPECT().SendMessage(&dto.NewMessage{ChatId: -1008, Text: "9/13"}).Return(nil).Once()
+
+	s := New(storageService, facadeGRPC)
+	s.send(to)
+}
+
+func TestSendWithFailedChat(t *testing.T) {
+	// t.Parallel() // !! нельзя параллелить, тестирую с подменой глобальных переменных
+
+	reports := *config.Reports
+	t.Cleanup(func() {
+		*config.Reports = reports
+	})
+	config.Reports.Template = "{{ .Forwarded }}/{{ .Viewed }}"
+	config.Reports.Period = time.Hour
+	config.Reports.For = []domain.ChatId{-1007, -1008}
+
+	to := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	storageService := mocks.NewStorageService(t)
+	storageService.EXPECT().GetStats(&domain.StatsFilter{From: to.Add(-time.Hour), To: to}).Return(newTestStats(), nil)
+	facadeGRPC := mocks.NewFacadeGRPC(t)
+	// отчет отправляется в следующий чат, несмотря на ошибку в предыдущем
+	facadeGRPC.EXPECT().SendMessage(&dto.NewMessage{ChatId: -1007, Text: "9/13"}).Return(errors.New("chat not found")).Once()
+	facadeGRPC.EXPECT().SendMessage(&dto.NewMessage{ChatId: -1008, Text: "9/13"}).Return(nil).Once()
+
+	s := New(storageService, facadeGRPC)
+	s.send(to)
+}
@@ -0,0 +1,26 @@
+package engine_storage
+
+import (
+	"github.com/comerc/budva43/app/dto/storage/dto"
+)
+
+// CreateBackup создает резервную копию хранилища по запросу
+func (s *Service) CreateBackup() (*dto.Backup, error) {
+	return s.repo.Backup() // ошибка логируется в repo
+}
+
+// GetBackups возвращает резервные копии хранилища, начиная с последней
+func (s *Service) GetBackups() ([]*dto.Backup, error) {
+	var (
+		err    error
+		result []*dto.Backup
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"result", result,
+		)
+	}()
+
+	result, err = s.repo.GetBackups()
+	return result, err
+}
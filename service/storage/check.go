@@ -0,0 +1,130 @@
+package engine_storage
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/dto/storage/dto"
+	"github.com/comerc/budva43/app/util"
+)
+
+// checkPrefixes префиксы, по которым сверяются прямые и обратные связи копий
+var checkPrefixes = []string{
+	copiedMessageIdsPrefix,
+	overflowMessageIdsPrefix,
+	editDiffMessageIdsPrefix,
+	replyContextIdPrefix,
+	newMessageIdPrefix,
+	tmpMessageIdPrefix,
+}
+
+// Check находит несогласованные связи копий в одном снимке хранилища:
+// отправка копии могла не подтвердиться, а обработчики правок и удалений
+// отказываются от повторов после нескольких попыток
+func (s *Service) Check() ([]*domain.StorageIssue, error) {
+	var (
+		err    error
+		result []*domain.StorageIssue
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"result", len(result),
+		)
+	}()
+
+	var (
+		copies      = make(map[string]*domain.ChatMessage) // dstChatId:tmpMessageId -> оригинал
+		linked      = make(map[string]bool)                // dstChatId:tmpMessageId с прямой связью
+		newMessages = make(map[string]int64)               // newMsgId: dstChatId:tmpMessageId -> newMessageId
+		tmpMessages = make(map[string]int64)               // tmpMsgId: dstChatId:newMessageId -> tmpMessageId
+	)
+	fn := func(key string, record *dto.Record) {
+		prefix, id, _ := strings.Cut(key, ":")
+		chatId, messageId, _ := strings.Cut(id, ":")
+		switch prefix {
+		case copiedMessageIdsPrefix:
+			for _, chatMessage := range record.ChatMessages {
+				dstId := fmt.Sprintf("%d:%d", chatMessage.ChatId, chatMessage.MessageId)
+				copies[dstId] = &domain.ChatMessage{
+					ForwardRuleId: chatMessage.ForwardRuleId,
+					ChatId:        util.StringToInt64(chatId),
+					MessageId:     util.StringToInt64(messageId),
+				}
+				linked[dstId] = true
+			}
+		case overflowMessageIdsPrefix, editDiffMessageIdsPrefix:
+			// продолжения длинного текста и изменения текста связаны через копию
+			for _, attachedMessageId := range record.MessageIds {
+				linked[fmt.Sprintf("%s:%d", chatId, attachedMessageId)] = true
+			}
+		case replyContextIdPrefix:
+			linked[fmt.Sprintf("%s:%d", chatId, record.MessageId)] = true
+		case newMessageIdPrefix:
+			newMessages[id] = record.MessageId
+		case tmpMessageIdPrefix:
+			tmpMessages[id] = record.MessageId
+		}
+	}
+
+	err = s.repo.Scan(fn, checkPrefixes...)
+	if err != nil {
+		return nil, err
+	}
+
+	result = []*domain.StorageIssue{}
+	for dstId, source := range copies {
+		if _, ok := newMessages[dstId]; ok {
+			continue
+		}
+		dstChatId, tmpMessageId := splitChatMessageId(dstId)
+		result = append(result, &domain.StorageIssue{
+			Kind:         domain.StorageIssueCopiedWithoutNew,
+			Source:       source,
+			ChatId:       dstChatId,
+			TmpMessageId: tmpMessageId,
+		})
+	}
+	for dstId, tmpMessageId := range tmpMessages {
+		dstChatId, newMessageId := splitChatMessageId(dstId)
+		if linked[fmt.Sprintf("%d:%d", dstChatId, tmpMessageId)] {
+			continue
+		}
+		result = append(result, &domain.StorageIssue{
+			Kind:         domain.StorageIssueTmpWithoutCopied,
+			ChatId:       dstChatId,
+			TmpMessageId: tmpMessageId,
+			NewMessageId: newMessageId,
+		})
+	}
+	for dstId, newMessageId := range newMessages {
+		dstChatId, tmpMessageId := splitChatMessageId(dstId)
+		if tmpMessages[fmt.Sprintf("%d:%d", dstChatId, newMessageId)] == tmpMessageId {
+			continue
+		}
+		result = append(result, &domain.StorageIssue{
+			Kind:         domain.StorageIssueNewWithoutTmp,
+			Source:       copies[dstId],
+			ChatId:       dstChatId,
+			TmpMessageId: tmpMessageId,
+			NewMessageId: newMessageId,
+		})
+	}
+
+	slices.SortFunc(result, func(a, b *domain.StorageIssue) int {
+		return cmp.Or(
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.ChatId, b.ChatId),
+			cmp.Compare(a.TmpMessageId, b.TmpMessageId),
+		)
+	})
+	return result, nil
+}
+
+// splitChatMessageId разбирает "chatId:messageId"
+func splitChatMessageId(id string) (int64, int64) {
+	chatId, messageId, _ := strings.Cut(id, ":")
+	return util.StringToInt64(chatId), util.StringToInt64(messageId)
+}
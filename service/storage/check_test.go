@@ -0,0 +1,81 @@
+package engine_storage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/dto/storage/dto"
+	"github.com/comerc/budva43/service/storage/mocks"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	records := []struct {
+		key    string
+		record *dto.Record
+	}{
+		{"copiedMsgIds:-1001:10", &dto.Record{
+			ChatMessages: []*dto.ChatMessage{
+				{ForwardRuleId: "rule1", ChatId: -1002, MessageId: 20},
+				{ForwardRuleId: "rule1", ChatId: -1003, MessageId: 40}, // отправка не подтвердилась
+			},
+		}},
+		{"overflowMsgIds:-1002:20", &dto.Record{MessageIds: []int64{22}}},
+		{"editDiffMsgIds:-1002:20", &dto.Record{MessageIds: []int64{28}}},
+		{"replyContextId:-1002:20", &dto.Record{MessageId: 24}},
+		{"newMsgId:-1002:20", &dto.Record{MessageId: 21}},
+		{"newMsgId:-1002:22", &dto.Record{MessageId: 23}},
+		{"newMsgId:-1002:24", &dto.Record{MessageId: 25}},
+		{"newMsgId:-1002:26", &dto.Record{MessageId: 27}}, // нет tmpMsgId
+		{"newMsgId:-1002:28", &dto.Record{MessageId: 29}},
+		{"tmpMsgId:-1002:21", &dto.Record{MessageId: 20}},
+		{"tmpMsgId:-1002:23", &dto.Record{MessageId: 22}},
+		{"tmpMsgId:-1002:25", &dto.Record{MessageId: 24}},
+		{"tmpMsgId:-1002:29", &dto.Record{MessageId: 28}},
+		{"tmpMsgId:-1002:31", &dto.Record{MessageId: 30}}, // нет copiedMsgIds
+	}
+
+	repo := mocks.NewStorageRepo(t)
+	repo.EXPECT().Scan(mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything).
+		Run(func(fn func(string, *dto.Record), prefixes ...string) {
+			for _, prefix := range prefixes {
+				for _, r := range records {
+					if strings.HasPrefix(r.key, prefix+":") {
+						fn(r.key, r.record)
+					}
+				}
+			}
+		}).
+		Return(nil)
+
+	s := New(repo)
+	issues, err := s.Check()
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.StorageIssue{
+		{
+			Kind:         domain.StorageIssueCopiedWithoutNew,
+			Source:       &domain.ChatMessage{ForwardRuleId: "rule1", ChatId: -1001, MessageId: 10},
+			ChatId:       -1003,
+			TmpMessageId: 40,
+		},
+		{
+			Kind:         domain.StorageIssueNewWithoutTmp,
+			ChatId:       -1002,
+			TmpMessageId: 26,
+			NewMessageId: 27,
+		},
+		{
+			Kind:         domain.StorageIssueTmpWithoutCopied,
+			ChatId:       -1002,
+			TmpMessageId: 30,
+			NewMessageId: 31,
+		},
+	}, issues)
+}
@@ -0,0 +1,148 @@
+package engine_storage
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/dto/storage/dto"
+)
+
+// journalPrefix журнал решений "journal:chatId:messageId" по исходному сообщению
+const journalPrefix = "journal"
+
+// AddJournalRule записывает решение правила по исходному сообщению;
+// повторная запись по тому же правилу обновляет решение, сохраняя получателей
+func (s *Service) AddJournalRule(chatId, messageId int64, journalRule *domain.JournalRule) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"chatId", chatId,
+			"messageId", messageId,
+			"journalRule", journalRule,
+		)
+	}()
+
+	err = s.updateJournal(chatId, messageId, func(journal *dto.Journal) {
+		rule := getJournalRuleRecord(journal, journalRule.ForwardRuleId)
+		rule.Verdict = journalRule.Verdict
+		rule.FiltersMode = journalRule.FiltersMode
+		rule.Pattern = journalRule.Pattern
+	})
+}
+
+// AddJournalDestination записывает исход для получателя правила по исходному сообщению
+func (s *Service) AddJournalDestination(chatId, messageId int64, forwardRuleId string, destination *domain.JournalDestination) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"chatId", chatId,
+			"messageId", messageId,
+			"forwardRuleId", forwardRuleId,
+			"destination", destination,
+		)
+	}()
+
+	err = s.updateJournal(chatId, messageId, func(journal *dto.Journal) {
+		rule := getJournalRuleRecord(journal, forwardRuleId)
+		rule.Destinations = append(rule.Destinations, &dto.JournalDestination{
+			ChatId:     destination.ChatId,
+			Outcome:    destination.Outcome,
+			MessageIds: destination.MessageIds,
+			Error:      destination.Error,
+		})
+	})
+}
+
+// GetJournal возвращает журнал решений по исходному сообщению
+func (s *Service) GetJournal(chatId, messageId int64) *domain.Journal {
+	var (
+		err    error
+		record *dto.Record
+		result *domain.Journal
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"chatId", chatId,
+			"messageId", messageId,
+			"result", result,
+		)
+	}()
+
+	key := getJournalKey(chatId, messageId)
+	record, err = s.repo.Get(key)
+	if err != nil || record.Journal == nil {
+		return nil
+	}
+
+	result = newJournal(chatId, messageId, record.Journal)
+	return result
+}
+
+// updateJournal изменяет журнал решений через fn; без config.Storage.JournalRetention журнал не ведётся
+func (s *Service) updateJournal(chatId, messageId int64, fn func(journal *dto.Journal)) error {
+	if config.Storage.JournalRetention == 0 {
+		return nil
+	}
+	key := getJournalKey(chatId, messageId)
+	_, err := s.repo.GetSet(key, func(record *dto.Record) (*dto.Record, error) {
+		if record.Journal == nil {
+			record.Journal = &dto.Journal{Date: time.Now().Unix()}
+		}
+		fn(record.Journal)
+		return record, nil
+	}, config.Storage.JournalRetention)
+	return err
+}
+
+// getJournalKey формирует ключ журнала решений
+func getJournalKey(chatId, messageId int64) string {
+	return fmt.Sprintf("%s:%d:%d", journalPrefix, chatId, messageId)
+}
+
+// getJournalRuleRecord возвращает решение правила из журнала, добавляя его при отсутствии
+func getJournalRuleRecord(journal *dto.Journal, forwardRuleId string) *dto.JournalRule {
+	i := slices.IndexFunc(journal.Rules, func(rule *dto.JournalRule) bool {
+		return rule.ForwardRuleId == forwardRuleId
+	})
+	if i != -1 {
+		return journal.Rules[i]
+	}
+	rule := &dto.JournalRule{
+		ForwardRuleId: forwardRuleId,
+		Verdict:       domain.JournalEvaluated,
+	}
+	journal.Rules = append(journal.Rules, rule)
+	return rule
+}
+
+// newJournal преобразует журнал решений из записи хранилища
+func newJournal(chatId, messageId int64, journal *dto.Journal) *domain.Journal {
+	result := &domain.Journal{
+		ChatId:    chatId,
+		MessageId: messageId,
+		Date:      time.Unix(journal.Date, 0),
+		Rules:     make([]*domain.JournalRule, 0, len(journal.Rules)),
+	}
+	for _, rule := range journal.Rules {
+		journalRule := &domain.JournalRule{
+			ForwardRuleId: rule.ForwardRuleId,
+			Verdict:       rule.Verdict,
+			FiltersMode:   rule.FiltersMode,
+			Pattern:       rule.Pattern,
+			Destinations:  make([]*domain.JournalDestination, 0, len(rule.Destinations)),
+		}
+		for _, destination := range rule.Destinations {
+			journalRule.Destinations = append(journalRule.Destinations, &domain.JournalDestination{
+				ChatId:     destination.ChatId,
+				Outcome:    destination.Outcome,
+				MessageIds: destination.MessageIds,
+				Error:      destination.Error,
+			})
+		}
+		result.Rules = append(result.Rules, journalRule)
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+package engine_storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/dto/storage/dto"
+	"github.com/comerc/budva43/service/storage/mocks"
+)
+
+func TestJournal(t *testing.T) {
+	t.Parallel()
+
+	const key = "journal:-1001:10"
+
+	record := &dto.Record{}
+	repo := mocks.NewStorageRepo(t)
+	repo.EXPECT().GetSet(key, mock.Anything, config.Storage.JournalRetention).
+		RunAndReturn(func(key string, fn func(*dto.Record) (*dto.Record, error), ttl time.Duration) (*dto.Record, error) {
+			return fn(record)
+		}).
+		Times(4)
+	repo.EXPECT().Get(key).Return(record, nil).Once()
+	s := New(repo)
+
+	s.AddJournalRule(-1001, 10, &domain.JournalRule{
+		ForwardRuleId: "rule1",
+		Verdict:       domain.JournalEvaluated,
+		FiltersMode:   domain.FiltersOK,
+		Pattern:       "include: новости",
+	})
+	s.AddJournalDestination(-1001, 10, "rule1", &domain.JournalDestination{
+		ChatId:     -1002,
+		Outcome:    domain.StatsOk,
+		MessageIds: []int64{20},
+	})
+	s.AddJournalDestination(-1001, 10, "rule2", &domain.JournalDestination{
+		ChatId:  -1003,
+		Outcome: domain.StatsFailed,
+		Error:   "chat not found",
+	})
+	s.AddJournalRule(-1001, 10, &domain.JournalRule{
+		ForwardRuleId: "rule2",
+		Verdict:       domain.JournalEvaluated,
+		FiltersMode:   domain.FiltersOK,
+	})
+
+	journal := s.GetJournal(-1001, 10)
+	require.NotNil(t, journal)
+	assert.Equal(t, int64(-1001), journal.ChatId)
+	assert.Equal(t, int64(10), journal.MessageId)
+	assert.False(t, journal.Date.IsZero())
+	assert.Equal(t, []*domain.JournalRule{
+		{
+			ForwardRuleId: "rule1",
+			Verdict:       domain.JournalEvaluated,
+			FiltersMode:   domain.FiltersOK,
+			Pattern:       "include: новости",
+			Destinations: []*domain.JournalDestination{
+				{ChatId: -1002, Outcome: domain.StatsOk, MessageIds: []int64{20}},
+			},
+		},
+		{
+			ForwardRuleId: "rule2",
+			Verdict:       domain.JournalEvaluated,
+			FiltersMode:   domain.FiltersOK,
+			Destinations: []*domain.JournalDestination{
+				{ChatId: -1003, Outcome: domain.StatsFailed, Error: "chat not found"},
+			},
+		},
+	}, journal.Rules)
+}
+
+func TestGetJournal_NotFound(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewStorageRepo(t)
+	repo.EXPECT().Get("journal:-1001:10").Return(&dto.Record{}, nil)
+	s := New(repo)
+
+	assert.Nil(t, s.GetJournal(-1001, 10))
+}
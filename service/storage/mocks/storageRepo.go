@@ -2,7 +2,13 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	dto "github.com/comerc/budva43/app/dto/storage/dto"
+
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // StorageRepo is an autogenerated mock type for the storageRepo type
 type StorageRepo struct {
@@ -17,6 +23,63 @@ func (_m *StorageRepo) EXPECT() *StorageRepo_Expecter {
 	return &StorageRepo_Expecter{mock: &_m.Mock}
 }
 
+// Backup provides a mock function with no fields
+func (_m *StorageRepo) Backup() (*dto.Backup, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Backup")
+	}
+
+	var r0 *dto.Backup
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*dto.Backup, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *dto.Backup); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Backup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageRepo_Backup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Backup'
+type StorageRepo_Backup_Call struct {
+	*mock.Call
+}
+
+// Backup is a helper method to define mock.On call
+func (_e *StorageRepo_Expecter) Backup() *StorageRepo_Backup_Call {
+	return &StorageRepo_Backup_Call{Call: _e.mock.On("Backup")}
+}
+
+func (_c *StorageRepo_Backup_Call) Run(run func()) *StorageRepo_Backup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *StorageRepo_Backup_Call) Return(_a0 *dto.Backup, _a1 error) *StorageRepo_Backup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageRepo_Backup_Call) RunAndReturn(run func() (*dto.Backup, error)) *StorageRepo_Backup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: key
 func (_m *StorageRepo) Delete(key string) error {
 	ret := _m.Called(key)
@@ -63,23 +126,71 @@ func (_c *StorageRepo_Delete_Call) RunAndReturn(run func(string) error) *Storage
 	return _c
 }
 
+// DeleteBatch provides a mock function with given fields: keys
+func (_m *StorageRepo) DeleteBatch(keys []string) error {
+	ret := _m.Called(keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string) error); ok {
+		r0 = rf(keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageRepo_DeleteBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBatch'
+type StorageRepo_DeleteBatch_Call struct {
+	*mock.Call
+}
+
+// DeleteBatch is a helper method to define mock.On call
+//   - keys []string
+func (_e *StorageRepo_Expecter) DeleteBatch(keys interface{}) *StorageRepo_DeleteBatch_Call {
+	return &StorageRepo_DeleteBatch_Call{Call: _e.mock.On("DeleteBatch", keys)}
+}
+
+func (_c *StorageRepo_DeleteBatch_Call) Run(run func(keys []string)) *StorageRepo_DeleteBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *StorageRepo_DeleteBatch_Call) Return(_a0 error) *StorageRepo_DeleteBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageRepo_DeleteBatch_Call) RunAndReturn(run func([]string) error) *StorageRepo_DeleteBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function with given fields: key
-func (_m *StorageRepo) Get(key string) (string, error) {
+func (_m *StorageRepo) Get(key string) (*dto.Record, error) {
 	ret := _m.Called(key)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
 	}
 
-	var r0 string
+	var r0 *dto.Record
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (*dto.Record, error)); ok {
 		return rf(key)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
+	if rf, ok := ret.Get(0).(func(string) *dto.Record); ok {
 		r0 = rf(key)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Record)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -109,37 +220,152 @@ func (_c *StorageRepo_Get_Call) Run(run func(key string)) *StorageRepo_Get_Call
 	return _c
 }
 
-func (_c *StorageRepo_Get_Call) Return(_a0 string, _a1 error) *StorageRepo_Get_Call {
+func (_c *StorageRepo_Get_Call) Return(_a0 *dto.Record, _a1 error) *StorageRepo_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageRepo_Get_Call) RunAndReturn(run func(string) (*dto.Record, error)) *StorageRepo_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBackups provides a mock function with no fields
+func (_m *StorageRepo) GetBackups() ([]*dto.Backup, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBackups")
+	}
+
+	var r0 []*dto.Backup
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*dto.Backup, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*dto.Backup); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.Backup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageRepo_GetBackups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBackups'
+type StorageRepo_GetBackups_Call struct {
+	*mock.Call
+}
+
+// GetBackups is a helper method to define mock.On call
+func (_e *StorageRepo_Expecter) GetBackups() *StorageRepo_GetBackups_Call {
+	return &StorageRepo_GetBackups_Call{Call: _e.mock.On("GetBackups")}
+}
+
+func (_c *StorageRepo_GetBackups_Call) Run(run func()) *StorageRepo_GetBackups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *StorageRepo_GetBackups_Call) Return(_a0 []*dto.Backup, _a1 error) *StorageRepo_GetBackups_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageRepo_GetBackups_Call) RunAndReturn(run func() ([]*dto.Backup, error)) *StorageRepo_GetBackups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCounter provides a mock function with given fields: key
+func (_m *StorageRepo) GetCounter(key string) (uint64, error) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCounter")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (uint64, error)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) uint64); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageRepo_GetCounter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCounter'
+type StorageRepo_GetCounter_Call struct {
+	*mock.Call
+}
+
+// GetCounter is a helper method to define mock.On call
+//   - key string
+func (_e *StorageRepo_Expecter) GetCounter(key interface{}) *StorageRepo_GetCounter_Call {
+	return &StorageRepo_GetCounter_Call{Call: _e.mock.On("GetCounter", key)}
+}
+
+func (_c *StorageRepo_GetCounter_Call) Run(run func(key string)) *StorageRepo_GetCounter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *StorageRepo_GetCounter_Call) Return(_a0 uint64, _a1 error) *StorageRepo_GetCounter_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *StorageRepo_Get_Call) RunAndReturn(run func(string) (string, error)) *StorageRepo_Get_Call {
+func (_c *StorageRepo_GetCounter_Call) RunAndReturn(run func(string) (uint64, error)) *StorageRepo_GetCounter_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSet provides a mock function with given fields: key, fn
-func (_m *StorageRepo) GetSet(key string, fn func(string) (string, error)) (string, error) {
-	ret := _m.Called(key, fn)
+// GetSet provides a mock function with given fields: key, fn, ttl
+func (_m *StorageRepo) GetSet(key string, fn func(*dto.Record) (*dto.Record, error), ttl time.Duration) (*dto.Record, error) {
+	ret := _m.Called(key, fn, ttl)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetSet")
 	}
 
-	var r0 string
+	var r0 *dto.Record
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, func(string) (string, error)) (string, error)); ok {
-		return rf(key, fn)
+	if rf, ok := ret.Get(0).(func(string, func(*dto.Record) (*dto.Record, error), time.Duration) (*dto.Record, error)); ok {
+		return rf(key, fn, ttl)
 	}
-	if rf, ok := ret.Get(0).(func(string, func(string) (string, error)) string); ok {
-		r0 = rf(key, fn)
+	if rf, ok := ret.Get(0).(func(string, func(*dto.Record) (*dto.Record, error), time.Duration) *dto.Record); ok {
+		r0 = rf(key, fn, ttl)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Record)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, func(string) (string, error)) error); ok {
-		r1 = rf(key, fn)
+	if rf, ok := ret.Get(1).(func(string, func(*dto.Record) (*dto.Record, error), time.Duration) error); ok {
+		r1 = rf(key, fn, ttl)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -154,24 +380,82 @@ type StorageRepo_GetSet_Call struct {
 
 // GetSet is a helper method to define mock.On call
 //   - key string
-//   - fn func(string)(string , error)
-func (_e *StorageRepo_Expecter) GetSet(key interface{}, fn interface{}) *StorageRepo_GetSet_Call {
-	return &StorageRepo_GetSet_Call{Call: _e.mock.On("GetSet", key, fn)}
+//   - fn func(*dto.Record)(*dto.Record , error)
+//   - ttl time.Duration
+func (_e *StorageRepo_Expecter) GetSet(key interface{}, fn interface{}, ttl interface{}) *StorageRepo_GetSet_Call {
+	return &StorageRepo_GetSet_Call{Call: _e.mock.On("GetSet", key, fn, ttl)}
+}
+
+func (_c *StorageRepo_GetSet_Call) Run(run func(key string, fn func(*dto.Record) (*dto.Record, error), ttl time.Duration)) *StorageRepo_GetSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(func(*dto.Record) (*dto.Record, error)), args[2].(time.Duration))
+	})
+	return _c
 }
 
-func (_c *StorageRepo_GetSet_Call) Run(run func(key string, fn func(string) (string, error))) *StorageRepo_GetSet_Call {
+func (_c *StorageRepo_GetSet_Call) Return(_a0 *dto.Record, _a1 error) *StorageRepo_GetSet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageRepo_GetSet_Call) RunAndReturn(run func(string, func(*dto.Record) (*dto.Record, error), time.Duration) (*dto.Record, error)) *StorageRepo_GetSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsage provides a mock function with no fields
+func (_m *StorageRepo) GetUsage() ([]*dto.Usage, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsage")
+	}
+
+	var r0 []*dto.Usage
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*dto.Usage, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*dto.Usage); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.Usage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageRepo_GetUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsage'
+type StorageRepo_GetUsage_Call struct {
+	*mock.Call
+}
+
+// GetUsage is a helper method to define mock.On call
+func (_e *StorageRepo_Expecter) GetUsage() *StorageRepo_GetUsage_Call {
+	return &StorageRepo_GetUsage_Call{Call: _e.mock.On("GetUsage")}
+}
+
+func (_c *StorageRepo_GetUsage_Call) Run(run func()) *StorageRepo_GetUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(func(string) (string, error)))
+		run()
 	})
 	return _c
 }
 
-func (_c *StorageRepo_GetSet_Call) Return(_a0 string, _a1 error) *StorageRepo_GetSet_Call {
+func (_c *StorageRepo_GetUsage_Call) Return(_a0 []*dto.Usage, _a1 error) *StorageRepo_GetUsage_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *StorageRepo_GetSet_Call) RunAndReturn(run func(string, func(string) (string, error)) (string, error)) *StorageRepo_GetSet_Call {
+func (_c *StorageRepo_GetUsage_Call) RunAndReturn(run func() ([]*dto.Usage, error)) *StorageRepo_GetUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -232,17 +516,126 @@ func (_c *StorageRepo_Increment_Call) RunAndReturn(run func(string) (uint64, err
 	return _c
 }
 
-// Set provides a mock function with given fields: key, value
-func (_m *StorageRepo) Set(key string, value string) error {
-	ret := _m.Called(key, value)
+// Scan provides a mock function with given fields: fn, prefixes
+func (_m *StorageRepo) Scan(fn func(string, *dto.Record), prefixes ...string) error {
+	_va := make([]interface{}, len(prefixes))
+	for _i := range prefixes {
+		_va[_i] = prefixes[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, fn)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Scan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(string, *dto.Record), ...string) error); ok {
+		r0 = rf(fn, prefixes...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageRepo_Scan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Scan'
+type StorageRepo_Scan_Call struct {
+	*mock.Call
+}
+
+// Scan is a helper method to define mock.On call
+//   - fn func(string , *dto.Record)
+//   - prefixes ...string
+func (_e *StorageRepo_Expecter) Scan(fn interface{}, prefixes ...interface{}) *StorageRepo_Scan_Call {
+	return &StorageRepo_Scan_Call{Call: _e.mock.On("Scan",
+		append([]interface{}{fn}, prefixes...)...)}
+}
+
+func (_c *StorageRepo_Scan_Call) Run(run func(fn func(string, *dto.Record), prefixes ...string)) *StorageRepo_Scan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(func(string, *dto.Record)), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *StorageRepo_Scan_Call) Return(_a0 error) *StorageRepo_Scan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageRepo_Scan_Call) RunAndReturn(run func(func(string, *dto.Record), ...string) error) *StorageRepo_Scan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScanCounters provides a mock function with given fields: fn, from, to
+func (_m *StorageRepo) ScanCounters(fn func(string, uint64), from string, to string) error {
+	ret := _m.Called(fn, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScanCounters")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(string, uint64), string, string) error); ok {
+		r0 = rf(fn, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageRepo_ScanCounters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScanCounters'
+type StorageRepo_ScanCounters_Call struct {
+	*mock.Call
+}
+
+// ScanCounters is a helper method to define mock.On call
+//   - fn func(string , uint64)
+//   - from string
+//   - to string
+func (_e *StorageRepo_Expecter) ScanCounters(fn interface{}, from interface{}, to interface{}) *StorageRepo_ScanCounters_Call {
+	return &StorageRepo_ScanCounters_Call{Call: _e.mock.On("ScanCounters", fn, from, to)}
+}
+
+func (_c *StorageRepo_ScanCounters_Call) Run(run func(fn func(string, uint64), from string, to string)) *StorageRepo_ScanCounters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(func(string, uint64)), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *StorageRepo_ScanCounters_Call) Return(_a0 error) *StorageRepo_ScanCounters_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageRepo_ScanCounters_Call) RunAndReturn(run func(func(string, uint64), string, string) error) *StorageRepo_ScanCounters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function with given fields: key, record, ttl
+func (_m *StorageRepo) Set(key string, record *dto.Record, ttl time.Duration) error {
+	ret := _m.Called(key, record, ttl)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Set")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(key, value)
+	if rf, ok := ret.Get(0).(func(string, *dto.Record, time.Duration) error); ok {
+		r0 = rf(key, record, ttl)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -257,14 +650,15 @@ type StorageRepo_Set_Call struct {
 
 // Set is a helper method to define mock.On call
 //   - key string
-//   - value string
-func (_e *StorageRepo_Expecter) Set(key interface{}, value interface{}) *StorageRepo_Set_Call {
-	return &StorageRepo_Set_Call{Call: _e.mock.On("Set", key, value)}
+//   - record *dto.Record
+//   - ttl time.Duration
+func (_e *StorageRepo_Expecter) Set(key interface{}, record interface{}, ttl interface{}) *StorageRepo_Set_Call {
+	return &StorageRepo_Set_Call{Call: _e.mock.On("Set", key, record, ttl)}
 }
 
-func (_c *StorageRepo_Set_Call) Run(run func(key string, value string)) *StorageRepo_Set_Call {
+func (_c *StorageRepo_Set_Call) Run(run func(key string, record *dto.Record, ttl time.Duration)) *StorageRepo_Set_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].(*dto.Record), args[2].(time.Duration))
 	})
 	return _c
 }
@@ -274,7 +668,7 @@ func (_c *StorageRepo_Set_Call) Return(_a0 error) *StorageRepo_Set_Call {
 	return _c
 }
 
-func (_c *StorageRepo_Set_Call) RunAndReturn(run func(string, string) error) *StorageRepo_Set_Call {
+func (_c *StorageRepo_Set_Call) RunAndReturn(run func(string, *dto.Record, time.Duration) error) *StorageRepo_Set_Call {
 	_c.Call.Return(run)
 	return _c
 }
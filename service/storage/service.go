@@ -2,29 +2,50 @@ package engine_storage
 
 import (
 	"fmt"
-	"strings"
+	"slices"
+	"time"
 
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/dto/storage/dto"
 	"github.com/comerc/budva43/app/log"
 	"github.com/comerc/budva43/app/util"
 )
 
 const (
 	// Префиксы ключей для хранения в BadgerDB
-	copiedMessageIdsPrefix  = "copiedMsgIds"
-	newMessageIdPrefix      = "newMsgId"
-	tmpMessageIdPrefix      = "tmpMsgId"
-	viewedMessagesPrefix    = "viewedMsgs"
-	forwardedMessagesPrefix = "forwardedMsgs"
-	answerMessageIdPrefix   = "answerMsgId"
+	copiedMessageIdsPrefix   = "copiedMsgIds"
+	newMessageIdPrefix       = "newMsgId"
+	tmpMessageIdPrefix       = "tmpMsgId"
+	viewedMessagesPrefix     = "viewedMsgs"
+	forwardedMessagesPrefix  = "forwardedMsgs"
+	protectedMessagesPrefix  = "protectedMsgs"
+	answerMessageIdPrefix    = "answerMsgId"
+	revisionMessageIdsPrefix = "revisionMsgIds"
+	textSnapshotPrefix       = "textSnapshot"
+	albumMessageIdsPrefix    = "albumMsgIds"
+	overflowMessageIdsPrefix = "overflowMsgIds"
+	editDiffMessageIdsPrefix = "editDiffMsgIds"
+	forumTopicIdPrefix       = "forumTopicId"
+	replyContextIdPrefix     = "replyContextId"
+	originMessageIdPrefix    = "originMsgId"
+	tombstonePrefix          = "tombstone"
 )
 
 //go:generate mockery --name=storageRepo --exported
 type storageRepo interface {
-	GetSet(key string, fn func(val string) (string, error)) (string, error)
-	Set(key, value string) error
-	Get(key string) (string, error)
+	GetSet(key string, fn func(record *dto.Record) (*dto.Record, error), ttl time.Duration) (*dto.Record, error)
+	Set(key string, record *dto.Record, ttl time.Duration) error
+	Get(key string) (*dto.Record, error)
 	Delete(key string) error
+	DeleteBatch(keys []string) error
 	Increment(key string) (uint64, error)
+	GetCounter(key string) (uint64, error)
+	Scan(fn func(key string, record *dto.Record), prefixes ...string) error
+	ScanCounters(fn func(key string, value uint64), from, to string) error
+	GetUsage() ([]*dto.Usage, error)
+	Backup() (*dto.Backup, error)
+	GetBackups() ([]*dto.Backup, error)
 }
 
 // Service предоставляет методы для хранения данных, специфичных для engine
@@ -32,6 +53,8 @@ type Service struct {
 	log *log.Logger
 	//
 	repo storageRepo
+	//
+	orphans map[string]bool // ключи-сироты прошлой очистки
 }
 
 // New создает новый экземпляр сервиса хранения данных
@@ -43,56 +66,45 @@ func New(repo storageRepo) *Service {
 	}
 }
 
-// SetCopiedMessageId сохраняет связь между оригинальным и скопированным сообщением
-func (s *Service) SetCopiedMessageId(chatId, messageId int64, toChatMessageId string) {
+// SetCopiedMessageId сохраняет связь между оригинальным и скопированным сообщением;
+// копия по тому же правилу в тот же чат заменяется
+func (s *Service) SetCopiedMessageId(chatId, messageId int64, toChatMessage *domain.ChatMessage) {
 	var (
 		err    error
-		val    string
-		result []string
+		record *dto.Record
 	)
 	defer func() {
 		s.log.ErrorOrDebug(err, "",
 			"chatId", chatId,
 			"messageId", messageId,
-			"toChatMessageId", toChatMessageId,
-			"result", result,
+			"toChatMessage", toChatMessage,
+			"record", record,
 		)
 	}()
 
-	lastPos := strings.LastIndex(toChatMessageId, ":")
-	prefix := toChatMessageId[:lastPos+1]
-
-	fn := func(val string) (string, error) {
-		var ss []string
-		if val != "" {
-			// workaround https://stackoverflow.com/questions/28330908/how-to-string-split-an-empty-string-in-go
-			ss = strings.Split(val, ",")
-		}
-		isFound := false
-		for i, s := range ss {
-			if strings.HasPrefix(s, prefix) {
-				isFound = true
-				ss[i] = toChatMessageId
-				break
-			}
+	fn := func(record *dto.Record) (*dto.Record, error) {
+		i := slices.IndexFunc(record.ChatMessages, func(chatMessage *dto.ChatMessage) bool {
+			return chatMessage.ForwardRuleId == toChatMessage.ForwardRuleId &&
+				chatMessage.ChatId == toChatMessage.ChatId
+		})
+		if i == -1 {
+			record.ChatMessages = append(record.ChatMessages, newChatMessageRecord(toChatMessage))
+		} else {
+			record.ChatMessages[i] = newChatMessageRecord(toChatMessage)
 		}
-		if !isFound {
-			ss = append(ss, toChatMessageId)
-		}
-		return strings.Join(ss, ","), nil
+		return record, nil
 	}
 
 	key := fmt.Sprintf("%s:%d:%d", copiedMessageIdsPrefix, chatId, messageId)
-	val, err = s.repo.GetSet(key, fn)
-	result = strings.Split(val, ",")
+	record, err = s.repo.GetSet(key, fn, s.getSourceRetention(chatId))
 }
 
-// GetCopiedMessageIds получает идентификаторы скопированных сообщений по Id оригинала
-func (s *Service) GetCopiedMessageIds(chatId, messageId int64) []string {
+// GetCopiedMessageIds получает скопированные сообщения по Id оригинала
+func (s *Service) GetCopiedMessageIds(chatId, messageId int64) []*domain.ChatMessage {
 	var (
 		err    error
-		val    string
-		result []string
+		record *dto.Record
+		result []*domain.ChatMessage
 	)
 	defer func() {
 		s.log.ErrorOrDebug(err, "",
@@ -103,17 +115,37 @@ func (s *Service) GetCopiedMessageIds(chatId, messageId int64) []string {
 	}()
 
 	key := fmt.Sprintf("%s:%d:%d", copiedMessageIdsPrefix, chatId, messageId)
-	val, err = s.repo.Get(key)
+	record, err = s.repo.Get(key)
 	if err != nil {
 		return nil
 	}
 
-	if val != "" {
-		// workaround https://stackoverflow.com/questions/28330908/how-to-string-split-an-empty-string-in-go
-		result = strings.Split(val, ",")
+	result = newChatMessages(record.ChatMessages)
+	return result
+}
+
+// DeleteCopiedMessageId удаляет одну копию из связей оригинального сообщения
+func (s *Service) DeleteCopiedMessageId(chatId, messageId int64, toChatMessage *domain.ChatMessage) {
+	var (
+		err    error
+		record *dto.Record
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"chatId", chatId,
+			"messageId", messageId,
+			"toChatMessage", toChatMessage,
+			"record", record,
+		)
+	}()
+
+	fn := func(record *dto.Record) (*dto.Record, error) {
+		record.ChatMessages = slices.DeleteFunc(record.ChatMessages, isChatMessage(toChatMessage))
+		return record, nil
 	}
 
-	return result
+	key := fmt.Sprintf("%s:%d:%d", copiedMessageIdsPrefix, chatId, messageId)
+	record, err = s.repo.GetSet(key, fn, s.getSourceRetention(chatId))
 }
 
 // DeleteCopiedMessageIds удаляет связь между оригинальным и скопированными сообщениями
@@ -130,6 +162,95 @@ func (s *Service) DeleteCopiedMessageIds(chatId, messageId int64) {
 	err = s.repo.Delete(key)
 }
 
+// AddRevisionMessageId добавляет копию в цепочку редакций оригинального сообщения
+func (s *Service) AddRevisionMessageId(chatId, messageId int64, toChatMessage *domain.ChatMessage) {
+	var (
+		err    error
+		record *dto.Record
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"chatId", chatId,
+			"messageId", messageId,
+			"toChatMessage", toChatMessage,
+			"record", record,
+		)
+	}()
+
+	fn := func(record *dto.Record) (*dto.Record, error) {
+		if !slices.ContainsFunc(record.ChatMessages, isChatMessage(toChatMessage)) {
+			record.ChatMessages = append(record.ChatMessages, newChatMessageRecord(toChatMessage))
+		}
+		return record, nil
+	}
+
+	key := fmt.Sprintf("%s:%d:%d", revisionMessageIdsPrefix, chatId, messageId)
+	record, err = s.repo.GetSet(key, fn, s.getSourceRetention(chatId))
+}
+
+// GetRevisionMessageIds получает цепочку редакций (все копии) по Id оригинала
+func (s *Service) GetRevisionMessageIds(chatId, messageId int64) []*domain.ChatMessage {
+	var (
+		err    error
+		record *dto.Record
+		result []*domain.ChatMessage
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"chatId", chatId,
+			"messageId", messageId,
+			"result", result,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", revisionMessageIdsPrefix, chatId, messageId)
+	record, err = s.repo.Get(key)
+	if err != nil {
+		return nil
+	}
+
+	result = newChatMessages(record.ChatMessages)
+	return result
+}
+
+// DeleteRevisionMessageId удаляет копию из цепочки редакций оригинального сообщения
+func (s *Service) DeleteRevisionMessageId(chatId, messageId int64, toChatMessage *domain.ChatMessage) {
+	var (
+		err    error
+		record *dto.Record
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"chatId", chatId,
+			"messageId", messageId,
+			"toChatMessage", toChatMessage,
+			"record", record,
+		)
+	}()
+
+	fn := func(record *dto.Record) (*dto.Record, error) {
+		record.ChatMessages = slices.DeleteFunc(record.ChatMessages, isChatMessage(toChatMessage))
+		return record, nil
+	}
+
+	key := fmt.Sprintf("%s:%d:%d", revisionMessageIdsPrefix, chatId, messageId)
+	record, err = s.repo.GetSet(key, fn, s.getSourceRetention(chatId))
+}
+
+// DeleteRevisionMessageIds удаляет цепочку редакций оригинального сообщения
+func (s *Service) DeleteRevisionMessageIds(chatId, messageId int64) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"chatId", chatId,
+			"messageId", messageId,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", revisionMessageIdsPrefix, chatId, messageId)
+	err = s.repo.Delete(key)
+}
+
 // SetNewMessageId сохраняет соответствие между временным и постоянным Id сообщения
 func (s *Service) SetNewMessageId(chatId, tmpMessageId, newMessageId int64) {
 	var err error
@@ -142,14 +263,14 @@ func (s *Service) SetNewMessageId(chatId, tmpMessageId, newMessageId int64) {
 	}()
 
 	key := fmt.Sprintf("%s:%d:%d", newMessageIdPrefix, chatId, tmpMessageId)
-	err = s.repo.Set(key, fmt.Sprintf("%d", newMessageId))
+	err = s.repo.Set(key, &dto.Record{MessageId: newMessageId}, s.getMaxRetention())
 }
 
 // GetNewMessageId получает постоянный Id сообщения по временному
 func (s *Service) GetNewMessageId(chatId, tmpMessageId int64) int64 {
 	var (
 		err    error
-		val    string
+		record *dto.Record
 		result int64
 	)
 	defer func() {
@@ -161,12 +282,12 @@ func (s *Service) GetNewMessageId(chatId, tmpMessageId int64) int64 {
 	}()
 
 	key := fmt.Sprintf("%s:%d:%d", newMessageIdPrefix, chatId, tmpMessageId)
-	val, err = s.repo.Get(key)
+	record, err = s.repo.Get(key)
 	if err != nil {
 		return 0
 	}
 
-	result = util.ConvertToInt[int64](val)
+	result = record.MessageId
 	return result
 }
 
@@ -196,14 +317,14 @@ func (s *Service) SetTmpMessageId(chatId, newMessageId, tmpMessageId int64) {
 	}()
 
 	key := fmt.Sprintf("%s:%d:%d", tmpMessageIdPrefix, chatId, newMessageId)
-	err = s.repo.Set(key, fmt.Sprintf("%d", tmpMessageId))
+	err = s.repo.Set(key, &dto.Record{MessageId: tmpMessageId}, s.getMaxRetention())
 }
 
 // GetTmpMessageId получает временный Id сообщения по постоянному
 func (s *Service) GetTmpMessageId(chatId, newMessageId int64) int64 {
 	var (
 		err    error
-		val    string
+		record *dto.Record
 		result int64
 	)
 	defer func() {
@@ -215,12 +336,12 @@ func (s *Service) GetTmpMessageId(chatId, newMessageId int64) int64 {
 	}()
 
 	key := fmt.Sprintf("%s:%d:%d", tmpMessageIdPrefix, chatId, newMessageId)
-	val, err = s.repo.Get(key)
+	record, err = s.repo.Get(key)
 	if err != nil {
 		return 0
 	}
 
-	result = util.ConvertToInt[int64](val)
+	result = record.MessageId
 	return result
 }
 
@@ -263,7 +384,7 @@ func (s *Service) IncrementViewedMessages(toChatId int64, date string) {
 func (s *Service) GetViewedMessages(toChatId int64, date string) int64 {
 	var (
 		err    error
-		val    string
+		val    uint64
 		result int64
 	)
 	defer func() {
@@ -275,12 +396,12 @@ func (s *Service) GetViewedMessages(toChatId int64, date string) int64 {
 	}()
 
 	key := fmt.Sprintf("%s:%d:%s", viewedMessagesPrefix, toChatId, date)
-	val, err = s.repo.Get(key)
-	if err != nil || val == "" {
+	val, err = s.repo.GetCounter(key)
+	if err != nil {
 		return 0
 	}
 
-	result = util.ConvertToInt[int64](val)
+	result = int64(val)
 	return result
 }
 
@@ -309,7 +430,7 @@ func (s *Service) IncrementForwardedMessages(toChatId int64, date string) {
 func (s *Service) GetForwardedMessages(toChatId int64, date string) int64 {
 	var (
 		err    error
-		val    string
+		val    uint64
 		result int64
 	)
 	defer func() {
@@ -324,12 +445,63 @@ func (s *Service) GetForwardedMessages(toChatId int64, date string) int64 {
 		date = util.GetCurrentDate()
 	}
 	key := fmt.Sprintf("%s:%d:%s", forwardedMessagesPrefix, toChatId, date)
-	val, err = s.repo.Get(key)
-	if err != nil || val == "" {
+	val, err = s.repo.GetCounter(key)
+	if err != nil {
 		return 0
 	}
 
-	result = util.ConvertToInt[int64](val)
+	result = int64(val)
+	return result
+}
+
+// IncrementProtectedMessages увеличивает счетчик защищённых сообщений по политике fallback
+func (s *Service) IncrementProtectedMessages(toChatId int64, fallback string, date string) {
+	var (
+		err    error
+		result uint64
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"toChatId", toChatId,
+			"fallback", fallback,
+			"date", date,
+			"result", result,
+		)
+	}()
+
+	if date == "" { // внешняя date нужна для тестирования
+		date = util.GetCurrentDate()
+	}
+	key := fmt.Sprintf("%s:%d:%s:%s", protectedMessagesPrefix, toChatId, fallback, date)
+	result, err = s.repo.Increment(key)
+}
+
+// GetProtectedMessages получает количество защищённых сообщений по политике fallback
+func (s *Service) GetProtectedMessages(toChatId int64, fallback string, date string) int64 {
+	var (
+		err    error
+		val    uint64
+		result int64
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"toChatId", toChatId,
+			"fallback", fallback,
+			"date", date,
+			"result", result,
+		)
+	}()
+
+	if date == "" { // внешняя date нужна для тестирования
+		date = util.GetCurrentDate()
+	}
+	key := fmt.Sprintf("%s:%d:%s:%s", protectedMessagesPrefix, toChatId, fallback, date)
+	val, err = s.repo.GetCounter(key)
+	if err != nil {
+		return 0
+	}
+
+	result = int64(val)
 	return result
 }
 
@@ -345,16 +517,18 @@ func (s *Service) SetAnswerMessageId(dstChatId, tmpMessageId, chatId, messageId
 		)
 	}()
 
-	fromChatMessageId := fmt.Sprintf("%d:%d", chatId, messageId)
 	key := fmt.Sprintf("%s:%d:%d", answerMessageIdPrefix, dstChatId, tmpMessageId)
-	err = s.repo.Set(key, fromChatMessageId)
+	err = s.repo.Set(key, &dto.Record{
+		ChatMessages: []*dto.ChatMessage{{ChatId: chatId, MessageId: messageId}},
+	}, s.getMaxRetention())
 }
 
-// GetAnswerMessageId возвращает идентификатор сообщения ответа
-func (s *Service) GetAnswerMessageId(dstChatId, tmpMessageId int64) string {
+// GetAnswerMessageId возвращает исходное сообщение, на которое отвечает копия
+func (s *Service) GetAnswerMessageId(dstChatId, tmpMessageId int64) *domain.ChatMessage {
 	var (
 		err    error
-		result string
+		record *dto.Record
+		result *domain.ChatMessage
 	)
 	defer func() {
 		s.log.ErrorOrDebug(err, "",
@@ -365,12 +539,13 @@ func (s *Service) GetAnswerMessageId(dstChatId, tmpMessageId int64) string {
 	}()
 
 	key := fmt.Sprintf("%s:%d:%d", answerMessageIdPrefix, dstChatId, tmpMessageId)
-	result, err = s.repo.Get(key)
-	if err != nil {
-		return ""
+	record, err = s.repo.Get(key)
+	if err != nil || len(record.ChatMessages) == 0 {
+		return nil
 	}
 
-	return result // fromChatMessageId
+	result = newChatMessage(record.ChatMessages[0])
+	return result
 }
 
 // DeleteAnswerMessageId удаляет идентификатор сообщения ответа
@@ -386,3 +561,513 @@ func (s *Service) DeleteAnswerMessageId(dstChatId, tmpMessageId int64) {
 	key := fmt.Sprintf("%s:%d:%d", answerMessageIdPrefix, dstChatId, tmpMessageId)
 	err = s.repo.Delete(key)
 }
+
+// SetTextSnapshot сохраняет снимок текста исходного сообщения
+func (s *Service) SetTextSnapshot(chatId, messageId int64, text string) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"chatId", chatId,
+			"messageId", messageId,
+			"len(text)", len(text),
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", textSnapshotPrefix, chatId, messageId)
+	err = s.repo.Set(key, &dto.Record{Text: text}, s.getSourceRetention(chatId))
+}
+
+// GetTextSnapshot возвращает снимок текста исходного сообщения
+func (s *Service) GetTextSnapshot(chatId, messageId int64) (string, bool) {
+	var (
+		err    error
+		record *dto.Record
+		result string
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"chatId", chatId,
+			"messageId", messageId,
+			"len(result)", len(result),
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", textSnapshotPrefix, chatId, messageId)
+	record, err = s.repo.Get(key)
+	if err != nil {
+		return "", false
+	}
+
+	result = record.Text
+	return result, true
+}
+
+// DeleteTextSnapshot удаляет снимок текста исходного сообщения
+func (s *Service) DeleteTextSnapshot(chatId, messageId int64) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"chatId", chatId,
+			"messageId", messageId,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", textSnapshotPrefix, chatId, messageId)
+	err = s.repo.Delete(key)
+}
+
+// SetMediaAlbumMessageIds сохраняет состав медиа-альбома для каждого его сообщения;
+// первое сообщение альбома несёт подпись и ссылки на источник
+func (s *Service) SetMediaAlbumMessageIds(chatId int64, messageIds []int64) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"chatId", chatId,
+			"messageIds", messageIds,
+		)
+	}()
+
+	record := &dto.Record{MessageIds: messageIds}
+	for _, messageId := range messageIds {
+		key := fmt.Sprintf("%s:%d:%d", albumMessageIdsPrefix, chatId, messageId)
+		err = s.repo.Set(key, record, s.getSourceRetention(chatId))
+		if err != nil {
+			return
+		}
+	}
+}
+
+// GetMediaAlbumMessageIds возвращает состав медиа-альбома по одному из его сообщений
+func (s *Service) GetMediaAlbumMessageIds(chatId, messageId int64) []int64 {
+	var (
+		err    error
+		record *dto.Record
+		result []int64
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"chatId", chatId,
+			"messageId", messageId,
+			"result", result,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", albumMessageIdsPrefix, chatId, messageId)
+	record, err = s.repo.Get(key)
+	if err != nil {
+		return nil
+	}
+
+	result = record.MessageIds
+	return result
+}
+
+// DeleteMediaAlbumMessageIds удаляет состав медиа-альбома для сообщения
+func (s *Service) DeleteMediaAlbumMessageIds(chatId, messageId int64) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"chatId", chatId,
+			"messageId", messageId,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", albumMessageIdsPrefix, chatId, messageId)
+	err = s.repo.Delete(key)
+}
+
+// SetOverflowMessageIds сохраняет временные идентификаторы ответов с продолжением текста копии
+func (s *Service) SetOverflowMessageIds(dstChatId, tmpMessageId int64, overflowTmpMessageIds []int64) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"tmpMessageId", tmpMessageId,
+			"overflowTmpMessageIds", overflowTmpMessageIds,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", overflowMessageIdsPrefix, dstChatId, tmpMessageId)
+	err = s.repo.Set(key, &dto.Record{MessageIds: overflowTmpMessageIds}, s.getMaxRetention())
+}
+
+// GetOverflowMessageIds возвращает временные идентификаторы ответов с продолжением текста копии
+func (s *Service) GetOverflowMessageIds(dstChatId, tmpMessageId int64) []int64 {
+	var (
+		err    error
+		record *dto.Record
+		result []int64
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"tmpMessageId", tmpMessageId,
+			"result", result,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", overflowMessageIdsPrefix, dstChatId, tmpMessageId)
+	record, err = s.repo.Get(key)
+	if err != nil {
+		return nil
+	}
+
+	result = record.MessageIds
+	return result
+}
+
+// DeleteOverflowMessageIds удаляет связь копии с ответами с продолжением текста
+func (s *Service) DeleteOverflowMessageIds(dstChatId, tmpMessageId int64) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"tmpMessageId", tmpMessageId,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", overflowMessageIdsPrefix, dstChatId, tmpMessageId)
+	err = s.repo.Delete(key)
+}
+
+// AddEditDiffMessageIds добавляет временные идентификаторы ответов с изменениями текста копии
+func (s *Service) AddEditDiffMessageIds(dstChatId, tmpMessageId int64, editDiffTmpMessageIds []int64) {
+	var (
+		err    error
+		record *dto.Record
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"tmpMessageId", tmpMessageId,
+			"editDiffTmpMessageIds", editDiffTmpMessageIds,
+			"record", record,
+		)
+	}()
+
+	fn := func(record *dto.Record) (*dto.Record, error) {
+		record.MessageIds = append(record.MessageIds, editDiffTmpMessageIds...)
+		return record, nil
+	}
+
+	key := fmt.Sprintf("%s:%d:%d", editDiffMessageIdsPrefix, dstChatId, tmpMessageId)
+	record, err = s.repo.GetSet(key, fn, s.getMaxRetention())
+}
+
+// GetEditDiffMessageIds возвращает временные идентификаторы ответов с изменениями текста копии
+func (s *Service) GetEditDiffMessageIds(dstChatId, tmpMessageId int64) []int64 {
+	var (
+		err    error
+		record *dto.Record
+		result []int64
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"tmpMessageId", tmpMessageId,
+			"result", result,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", editDiffMessageIdsPrefix, dstChatId, tmpMessageId)
+	record, err = s.repo.Get(key)
+	if err != nil {
+		return nil
+	}
+
+	result = record.MessageIds
+	return result
+}
+
+// DeleteEditDiffMessageIds удаляет связь копии с ответами с изменениями текста
+func (s *Service) DeleteEditDiffMessageIds(dstChatId, tmpMessageId int64) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"tmpMessageId", tmpMessageId,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", editDiffMessageIdsPrefix, dstChatId, tmpMessageId)
+	err = s.repo.Delete(key)
+}
+
+// SetForumTopicId сохраняет тему форума получателя, созданную для источника
+func (s *Service) SetForumTopicId(dstChatId, srcChatId, messageThreadId int64) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"srcChatId", srcChatId,
+			"messageThreadId", messageThreadId,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", forumTopicIdPrefix, dstChatId, srcChatId)
+	err = s.repo.Set(key, &dto.Record{MessageId: messageThreadId}, 0) // тема форума нужна, пока действует правило
+}
+
+// GetForumTopicId возвращает тему форума получателя, созданную для источника
+func (s *Service) GetForumTopicId(dstChatId, srcChatId int64) int64 {
+	var (
+		err    error
+		record *dto.Record
+		result int64
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"srcChatId", srcChatId,
+			"result", result,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", forumTopicIdPrefix, dstChatId, srcChatId)
+	record, err = s.repo.Get(key)
+	if err != nil {
+		return 0
+	}
+
+	result = record.MessageId
+	return result
+}
+
+// SetReplyContextMessageId сохраняет временный идентификатор сообщения с контекстом ответа,
+// отправленного перед пересланным сообщением
+func (s *Service) SetReplyContextMessageId(dstChatId, tmpMessageId, contextTmpMessageId int64) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"tmpMessageId", tmpMessageId,
+			"contextTmpMessageId", contextTmpMessageId,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", replyContextIdPrefix, dstChatId, tmpMessageId)
+	err = s.repo.Set(key, &dto.Record{MessageId: contextTmpMessageId}, s.getMaxRetention())
+}
+
+// GetReplyContextMessageId возвращает временный идентификатор сообщения с контекстом ответа
+func (s *Service) GetReplyContextMessageId(dstChatId, tmpMessageId int64) int64 {
+	var (
+		err    error
+		record *dto.Record
+		result int64
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"tmpMessageId", tmpMessageId,
+			"result", result,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", replyContextIdPrefix, dstChatId, tmpMessageId)
+	record, err = s.repo.Get(key)
+	if err != nil {
+		return 0
+	}
+
+	result = record.MessageId
+	return result
+}
+
+// DeleteReplyContextMessageId удаляет связь пересланного сообщения с контекстом ответа
+func (s *Service) DeleteReplyContextMessageId(dstChatId, tmpMessageId int64) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"tmpMessageId", tmpMessageId,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", replyContextIdPrefix, dstChatId, tmpMessageId)
+	err = s.repo.Delete(key)
+}
+
+// SetOriginMessageId сохраняет обратную связь сообщения в получателе с исходным сообщением;
+// до успешной отправки связь хранится по временному идентификатору, см. MoveOriginMessageId
+func (s *Service) SetOriginMessageId(dstChatId, dstMessageId int64, forwardRuleId string, chatId, messageId int64) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"dstMessageId", dstMessageId,
+			"forwardRuleId", forwardRuleId,
+			"chatId", chatId,
+			"messageId", messageId,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", originMessageIdPrefix, dstChatId, dstMessageId)
+	err = s.repo.Set(key, &dto.Record{
+		ChatMessages: []*dto.ChatMessage{{ForwardRuleId: forwardRuleId, ChatId: chatId, MessageId: messageId}},
+	}, s.getMaxRetention())
+}
+
+// GetOriginMessageId возвращает исходное сообщение для сообщения в получателе
+func (s *Service) GetOriginMessageId(dstChatId, dstMessageId int64) *domain.ChatMessage {
+	var (
+		err    error
+		record *dto.Record
+		result *domain.ChatMessage
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"dstMessageId", dstMessageId,
+			"result", result,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", originMessageIdPrefix, dstChatId, dstMessageId)
+	record, err = s.repo.Get(key)
+	if err != nil || len(record.ChatMessages) == 0 {
+		return nil
+	}
+
+	result = newChatMessage(record.ChatMessages[0])
+	return result
+}
+
+// MoveOriginMessageId переносит обратную связь с временного идентификатора на постоянный
+func (s *Service) MoveOriginMessageId(dstChatId, tmpMessageId, newMessageId int64) {
+	var (
+		err    error
+		record *dto.Record
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"tmpMessageId", tmpMessageId,
+			"newMessageId", newMessageId,
+			"record", record,
+		)
+	}()
+
+	tmpKey := fmt.Sprintf("%s:%d:%d", originMessageIdPrefix, dstChatId, tmpMessageId)
+	record, err = s.repo.Get(tmpKey)
+	if err != nil {
+		return
+	}
+	key := fmt.Sprintf("%s:%d:%d", originMessageIdPrefix, dstChatId, newMessageId)
+	err = s.repo.Set(key, record, s.getMaxRetention())
+	if err != nil {
+		return
+	}
+	err = s.repo.Delete(tmpKey)
+}
+
+// DeleteOriginMessageId удаляет обратную связь сообщения в получателе с исходным сообщением
+func (s *Service) DeleteOriginMessageId(dstChatId, dstMessageId int64) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"dstMessageId", dstMessageId,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", originMessageIdPrefix, dstChatId, dstMessageId)
+	err = s.repo.Delete(key)
+}
+
+// SetTombstone отмечает копию, помеченную вместо удаления вслед за оригиналом:
+// обратная связь такой копии с оригиналом не считается осиротевшей, см. sweep
+func (s *Service) SetTombstone(dstChatId, newMessageId int64) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+			"newMessageId", newMessageId,
+		)
+	}()
+
+	key := fmt.Sprintf("%s:%d:%d", tombstonePrefix, dstChatId, newMessageId)
+	err = s.repo.Set(key, &dto.Record{}, s.getMaxRetention())
+}
+
+// getRetention возвращает срок хранения связей сообщений правила
+func getRetention(forwardRule *domain.ForwardRule) time.Duration {
+	if forwardRule.Retention > 0 {
+		return forwardRule.Retention
+	}
+	return config.Storage.Retention
+}
+
+// getSourceRetention возвращает срок хранения ключей источника:
+// наибольший среди его правил, т.к. ключи источника общие для всех его правил
+func (s *Service) getSourceRetention(chatId int64) time.Duration {
+	return maxRetention(func(forwardRule *domain.ForwardRule) bool {
+		return forwardRule.From == chatId
+	})
+}
+
+// getMaxRetention возвращает срок хранения ключей получателей: наибольший среди всех правил,
+// чтобы ключи получателей не истекали раньше ключей источников; осиротевшие ключи удаляет sweep
+func (s *Service) getMaxRetention() time.Duration {
+	return maxRetention(func(*domain.ForwardRule) bool {
+		return true
+	})
+}
+
+// maxRetention возвращает наибольший срок хранения среди подходящих правил (0 - бессрочно)
+func maxRetention(isMatch func(*domain.ForwardRule) bool) time.Duration {
+	engineConfig := config.Engine
+	var result time.Duration
+	isFound := false
+	for _, forwardRule := range engineConfig.ForwardRules {
+		if !isMatch(forwardRule) {
+			continue
+		}
+		retention := getRetention(forwardRule)
+		if retention == 0 {
+			return 0
+		}
+		isFound = true
+		result = max(result, retention)
+	}
+	if !isFound {
+		return config.Storage.Retention
+	}
+	return result
+}
+
+// newChatMessage преобразует ссылку на сообщение из записи хранилища
+func newChatMessage(chatMessage *dto.ChatMessage) *domain.ChatMessage {
+	return &domain.ChatMessage{
+		ForwardRuleId: chatMessage.ForwardRuleId,
+		ChatId:        chatMessage.ChatId,
+		MessageId:     chatMessage.MessageId,
+	}
+}
+
+// newChatMessages преобразует ссылки на сообщения из записи хранилища
+func newChatMessages(chatMessages []*dto.ChatMessage) []*domain.ChatMessage {
+	result := make([]*domain.ChatMessage, 0, len(chatMessages))
+	for _, chatMessage := range chatMessages {
+		result = append(result, newChatMessage(chatMessage))
+	}
+	return result
+}
+
+// newChatMessageRecord преобразует ссылку на сообщение для записи в хранилище
+func newChatMessageRecord(chatMessage *domain.ChatMessage) *dto.ChatMessage {
+	return &dto.ChatMessage{
+		ForwardRuleId: chatMessage.ForwardRuleId,
+		ChatId:        chatMessage.ChatId,
+		MessageId:     chatMessage.MessageId,
+	}
+}
+
+// isChatMessage возвращает условие совпадения записи со ссылкой на сообщение
+func isChatMessage(chatMessage *domain.ChatMessage) func(*dto.ChatMessage) bool {
+	return func(item *dto.ChatMessage) bool {
+		return item.ForwardRuleId == chatMessage.ForwardRuleId &&
+			item.ChatId == chatMessage.ChatId &&
+			item.MessageId == chatMessage.MessageId
+	}
+}
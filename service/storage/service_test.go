@@ -0,0 +1,36 @@
+package engine_storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/domain"
+)
+
+func TestRetention(t *testing.T) {
+	// t.Parallel() // !! нельзя параллелить, тестирую с подменой глобальных переменных
+
+	engineConfig, retention := config.Engine, config.Storage.Retention
+	t.Cleanup(func() {
+		config.Engine, config.Storage.Retention = engineConfig, retention
+	})
+	config.Storage.Retention = 24 * time.Hour
+	config.Engine = &domain.EngineConfig{
+		ForwardRules: map[domain.ForwardRuleId]*domain.ForwardRule{
+			"Rule1": {Id: "Rule1", From: -1001, Retention: 720 * time.Hour},
+			"Rule2": {Id: "Rule2", From: -1001},
+			"Rule3": {Id: "Rule3", From: -1003, Retention: 48 * time.Hour},
+		},
+	}
+
+	s := New(nil)
+	// ключи источника общие для его правил: побеждает наибольший срок
+	assert.Equal(t, 720*time.Hour, s.getSourceRetention(-1001))
+	assert.Equal(t, 48*time.Hour, s.getSourceRetention(-1003))
+	// источник без правил хранится по умолчанию
+	assert.Equal(t, 24*time.Hour, s.getSourceRetention(-1005))
+	assert.Equal(t, 720*time.Hour, s.getMaxRetention())
+}
@@ -0,0 +1,222 @@
+package engine_storage
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/util"
+)
+
+const (
+	// statsPrefix счетчики "stats:hour:srcChatId:dstChatId:outcome:forwardRuleId";
+	// час идёт первым, чтобы выборка за период была диапазоном ключей
+	statsPrefix = "stats"
+	// statsHourLayout час в UTC; строки сравниваются в том же порядке, что и время
+	statsHourLayout = "2006010215"
+)
+
+// IncrementStats увеличивает счетчик исхода обработки сообщения правилом за текущий час
+func (s *Service) IncrementStats(forwardRuleId string, srcChatId, dstChatId int64, outcome domain.StatsOutcome) {
+	var (
+		err    error
+		result uint64
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"forwardRuleId", forwardRuleId,
+			"srcChatId", srcChatId,
+			"dstChatId", dstChatId,
+			"outcome", outcome,
+			"result", result,
+		)
+	}()
+
+	key := getStatsKey(time.Now(), srcChatId, dstChatId, outcome, forwardRuleId)
+	result, err = s.repo.Increment(key)
+}
+
+// GetStats возвращает почасовые счетчики за период с отбором по измерениям
+func (s *Service) GetStats(filter *domain.StatsFilter) ([]*domain.Stats, error) {
+	var (
+		err    error
+		result []*domain.Stats
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"filter", filter,
+			"result", len(result),
+		)
+	}()
+
+	result = []*domain.Stats{}
+	err = s.scanStats(filter, func(stats *domain.Stats) {
+		result = append(result, stats)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetTopStats суммирует счетчики за период по измерению groupBy
+// и возвращает limit наибольших (0 - все)
+func (s *Service) GetTopStats(filter *domain.StatsFilter, groupBy domain.StatsGroupBy, limit int) ([]*domain.Stats, error) {
+	var (
+		err    error
+		result []*domain.Stats
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"filter", filter,
+			"groupBy", groupBy,
+			"limit", limit,
+			"result", len(result),
+		)
+	}()
+
+	var getGroup func(stats *domain.Stats) *domain.Stats
+	switch groupBy {
+	case domain.StatsGroupByRule:
+		getGroup = func(stats *domain.Stats) *domain.Stats {
+			return &domain.Stats{ForwardRuleId: stats.ForwardRuleId}
+		}
+	case domain.StatsGroupBySource:
+		getGroup = func(stats *domain.Stats) *domain.Stats {
+			return &domain.Stats{SrcChatId: stats.SrcChatId}
+		}
+	case domain.StatsGroupByDestination:
+		getGroup = func(stats *domain.Stats) *domain.Stats {
+			return &domain.Stats{DstChatId: stats.DstChatId}
+		}
+	case domain.StatsGroupByOutcome:
+		getGroup = func(stats *domain.Stats) *domain.Stats {
+			return &domain.Stats{Outcome: stats.Outcome}
+		}
+	default:
+		err = log.NewError("unknown stats group",
+			"groupBy", groupBy,
+		)
+		return nil, err
+	}
+
+	groups := make(map[domain.Stats]*domain.Stats)
+	err = s.scanStats(filter, func(stats *domain.Stats) {
+		group := getGroup(stats)
+		total, ok := groups[*group]
+		if !ok {
+			total = group
+			groups[*group] = total
+		}
+		total.Count += stats.Count
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result = make([]*domain.Stats, 0, len(groups))
+	for _, total := range groups {
+		result = append(result, total)
+	}
+	slices.SortFunc(result, func(a, b *domain.Stats) int {
+		return cmp.Or(
+			cmp.Compare(b.Count, a.Count),
+			cmp.Compare(a.ForwardRuleId, b.ForwardRuleId),
+			cmp.Compare(a.SrcChatId, b.SrcChatId),
+			cmp.Compare(a.DstChatId, b.DstChatId),
+			cmp.Compare(a.Outcome, b.Outcome),
+		)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// scanStats обходит счетчики за период filter.From..filter.To, подходящие под отбор
+func (s *Service) scanStats(filter *domain.StatsFilter, fn func(stats *domain.Stats)) error {
+	from := statsPrefix + ":"
+	if !filter.From.IsZero() {
+		from += formatStatsHour(filter.From)
+	}
+	to := filter.To
+	if to.IsZero() {
+		to = time.Now().Add(time.Hour)
+	}
+	return s.repo.ScanCounters(func(key string, value uint64) {
+		stats, err := parseStatsKey(key)
+		if err != nil {
+			s.log.ErrorOrDebug(err, "skip stats")
+			return
+		}
+		if filter.ForwardRuleId != "" && filter.ForwardRuleId != stats.ForwardRuleId ||
+			filter.SrcChatId != 0 && filter.SrcChatId != stats.SrcChatId ||
+			filter.DstChatId != 0 && filter.DstChatId != stats.DstChatId ||
+			filter.Outcome != "" && filter.Outcome != stats.Outcome {
+			return
+		}
+		stats.Count = int64(value)
+		fn(stats)
+	}, from, statsPrefix+":"+formatStatsHour(to))
+}
+
+// sweepStats удаляет счетчики старше config.Storage.StatsRetention
+func (s *Service) sweepStats() {
+	var (
+		err  error
+		keys []string
+	)
+	defer func() {
+		s.log.ErrorOrInfo(err, "sweep stats",
+			"result", len(keys),
+		)
+	}()
+
+	from := statsPrefix + ":"
+	to := from + formatStatsHour(time.Now().Add(-config.Storage.StatsRetention))
+	err = s.repo.ScanCounters(func(key string, _ uint64) {
+		keys = append(keys, key)
+	}, from, to)
+	if err != nil {
+		return
+	}
+	if len(keys) > 0 {
+		err = s.repo.DeleteBatch(keys)
+	}
+}
+
+// getStatsKey формирует ключ счетчика за час t
+func getStatsKey(t time.Time, srcChatId, dstChatId int64, outcome domain.StatsOutcome, forwardRuleId string) string {
+	return fmt.Sprintf("%s:%s:%d:%d:%s:%s", statsPrefix, formatStatsHour(t), srcChatId, dstChatId, outcome, forwardRuleId)
+}
+
+// formatStatsHour форматирует час t для ключа счетчика
+func formatStatsHour(t time.Time) string {
+	return t.UTC().Format(statsHourLayout)
+}
+
+// parseStatsKey разбирает ключ счетчика
+func parseStatsKey(key string) (*domain.Stats, error) {
+	a := strings.SplitN(key, ":", 6)
+	if len(a) != 6 {
+		return nil, log.NewError("invalid stats key",
+			"key", key,
+		)
+	}
+	hour, err := time.ParseInLocation(statsHourLayout, a[1], time.UTC)
+	if err != nil {
+		return nil, log.WrapError(err) // внешняя ошибка
+	}
+	return &domain.Stats{
+		Hour:          hour,
+		SrcChatId:     util.StringToInt64(a[2]),
+		DstChatId:     util.StringToInt64(a[3]),
+		Outcome:       a[4],
+		ForwardRuleId: a[5],
+	}, nil
+}
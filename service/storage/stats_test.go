@@ -0,0 +1,144 @@
+package engine_storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/service/storage/mocks"
+)
+
+func newTestStatsRepo(t *testing.T, from, to string) *mocks.StorageRepo {
+	counters := []struct {
+		key   string
+		value uint64
+	}{
+		{"stats:2025010100:-1001:-1002:ok:rule1", 3},
+		{"stats:2025010100:-1001:-1002:filtered:rule1", 5},
+		{"stats:2025010101:-1001:-1003:ok:rule2", 4},
+		{"stats:2025010101:-1004:-1002:failed:rule3", 1},
+		{"stats:2025010101:broken", 1},
+	}
+	repo := mocks.NewStorageRepo(t)
+	repo.EXPECT().ScanCounters(mock.Anything, from, to).
+		Run(func(fn func(string, uint64), from, to string) {
+			for _, c := range counters {
+				if c.key >= from && c.key < to {
+					fn(c.key, c.value)
+				}
+			}
+		}).
+		Return(nil)
+	return repo
+}
+
+func TestGetStats(t *testing.T) {
+	t.Parallel()
+
+	repo := newTestStatsRepo(t, "stats:2025010100", "stats:2025010102")
+	s := New(repo)
+
+	result, err := s.GetStats(&domain.StatsFilter{
+		From:      time.Date(2025, 1, 1, 0, 30, 0, 0, time.UTC),
+		To:        time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC),
+		SrcChatId: -1001,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.Stats{
+		{
+			Hour:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			ForwardRuleId: "rule1",
+			SrcChatId:     -1001,
+			DstChatId:     -1002,
+			Outcome:       domain.StatsOk,
+			Count:         3,
+		},
+		{
+			Hour:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			ForwardRuleId: "rule1",
+			SrcChatId:     -1001,
+			DstChatId:     -1002,
+			Outcome:       domain.StatsFiltered,
+			Count:         5,
+		},
+		{
+			Hour:          time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC),
+			ForwardRuleId: "rule2",
+			SrcChatId:     -1001,
+			DstChatId:     -1003,
+			Outcome:       domain.StatsOk,
+			Count:         4,
+		},
+	}, result)
+}
+
+func TestGetTopStats(t *testing.T) {
+	t.Parallel()
+
+	filter := &domain.StatsFilter{
+		From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name     string
+		groupBy  domain.StatsGroupBy
+		outcome  domain.StatsOutcome
+		limit    int
+		expected []*domain.Stats
+	}{
+		{
+			name:    "source",
+			groupBy: domain.StatsGroupBySource,
+			expected: []*domain.Stats{
+				{SrcChatId: -1001, Count: 12},
+				{SrcChatId: -1004, Count: 1},
+			},
+		},
+		{
+			name:    "destination_ok_limit",
+			groupBy: domain.StatsGroupByDestination,
+			outcome: domain.StatsOk,
+			limit:   1,
+			expected: []*domain.Stats{
+				{DstChatId: -1003, Count: 4},
+			},
+		},
+		{
+			name:    "rule",
+			groupBy: domain.StatsGroupByRule,
+			expected: []*domain.Stats{
+				{ForwardRuleId: "rule1", Count: 8},
+				{ForwardRuleId: "rule2", Count: 4},
+				{ForwardRuleId: "rule3", Count: 1},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := newTestStatsRepo(t, "stats:2025010100", "stats:2025010200")
+			s := New(repo)
+
+			filter := *filter
+			filter.Outcome = test.outcome
+			result, err := s.GetTopStats(&filter, test.groupBy, test.limit)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestGetTopStats_UnknownGroup(t *testing.T) {
+	t.Parallel()
+
+	s := New(mocks.NewStorageRepo(t))
+	_, err := s.GetTopStats(&domain.StatsFilter{}, "hour", 10)
+	require.Error(t, err)
+}
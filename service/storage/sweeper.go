@@ -0,0 +1,164 @@
+package engine_storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/dto/storage/dto"
+)
+
+// sweepPrefixes префиксы связей сообщений в порядке обхода:
+// сначала ключи-владельцы, затем зависимые от них ключи
+var sweepPrefixes = []string{
+	copiedMessageIdsPrefix,
+	revisionMessageIdsPrefix,
+	textSnapshotPrefix,
+	albumMessageIdsPrefix,
+	overflowMessageIdsPrefix,
+	editDiffMessageIdsPrefix,
+	replyContextIdPrefix,
+	answerMessageIdPrefix,
+	newMessageIdPrefix,
+	tmpMessageIdPrefix,
+	tombstonePrefix,
+	originMessageIdPrefix,
+}
+
+// StartContext запускает периодическую очистку осиротевших ключей
+func (s *Service) StartContext(ctx context.Context) error {
+	if config.Storage.SweepInterval > 0 {
+		go s.runSweeper(ctx)
+	}
+	return nil
+}
+
+// Close останавливает сервис
+func (s *Service) Close() error {
+	return nil
+}
+
+// runSweeper периодически удаляет осиротевшие ключи и устаревшую статистику
+func (s *Service) runSweeper(ctx context.Context) {
+	ticker := time.NewTicker(config.Storage.SweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweep()
+			if config.Storage.StatsRetention > 0 {
+				s.sweepStats()
+			}
+		}
+	}
+}
+
+// sweep удаляет ключи, владельцы которых удалены или истекли по сроку хранения
+// (например, newMsgId и tmpMsgId копии, когда истёк copiedMsgIds оригинала);
+// ключ удаляется, только если остался сиротой с прошлой очистки,
+// т.к. зависимые ключи могут записываться раньше владельцев
+func (s *Service) sweep() {
+	var (
+		err    error
+		result = make(map[string]int) // prefix -> количество удалённых ключей
+	)
+	defer func() {
+		s.log.ErrorOrInfo(err, "sweep",
+			"result", result,
+		)
+	}()
+
+	var (
+		sources     = make(map[string]bool) // chatId:messageId оригиналов с копиями
+		tmpMessages = make(map[string]bool) // dstChatId:tmpMessageId действующих копий
+		newMessages = make(map[string]bool) // dstChatId:newMessageId действующих копий
+		tombstones  = make(map[string]bool) // dstChatId:newMessageId помеченных копий
+		orphans     = make(map[string]bool)
+		keys        []string
+	)
+	fn := func(key string, record *dto.Record) {
+		prefix, id, _ := strings.Cut(key, ":")
+		chatId, _, _ := strings.Cut(id, ":")
+		isOrphan := false
+		switch prefix {
+		case copiedMessageIdsPrefix:
+			sources[id] = true
+			for _, chatMessage := range record.ChatMessages {
+				tmpMessages[fmt.Sprintf("%d:%d", chatMessage.ChatId, chatMessage.MessageId)] = true
+			}
+		case revisionMessageIdsPrefix:
+			isOrphan = !sources[id]
+			if !isOrphan {
+				for _, chatMessage := range record.ChatMessages {
+					tmpMessages[fmt.Sprintf("%d:%d", chatMessage.ChatId, chatMessage.MessageId)] = true
+				}
+			}
+		case textSnapshotPrefix, albumMessageIdsPrefix:
+			isOrphan = !sources[id]
+		case overflowMessageIdsPrefix, editDiffMessageIdsPrefix:
+			isOrphan = !tmpMessages[id]
+			if !isOrphan {
+				for _, messageId := range record.MessageIds {
+					tmpMessages[fmt.Sprintf("%s:%d", chatId, messageId)] = true
+				}
+			}
+		case replyContextIdPrefix:
+			isOrphan = !tmpMessages[id]
+			if !isOrphan {
+				tmpMessages[fmt.Sprintf("%s:%d", chatId, record.MessageId)] = true
+			}
+		case answerMessageIdPrefix:
+			isOrphan = !tmpMessages[id]
+		case newMessageIdPrefix:
+			isOrphan = !tmpMessages[id]
+			if !isOrphan {
+				newMessages[fmt.Sprintf("%s:%d", chatId, record.MessageId)] = true
+			}
+		case tmpMessageIdPrefix:
+			isOrphan = !newMessages[id]
+		case tombstonePrefix:
+			tombstones[id] = true // помеченная копия осталась в получателе, ключ истекает по сроку хранения
+		case originMessageIdPrefix:
+			// до успешной отправки связь хранится по временному идентификатору
+			isOrphan = !newMessages[id] && !tmpMessages[id] && !tombstones[id]
+		}
+		if !isOrphan {
+			return
+		}
+		orphans[key] = true
+		if s.orphans[key] {
+			keys = append(keys, key)
+			result[prefix]++
+		}
+	}
+
+	err = s.repo.Scan(fn, sweepPrefixes...)
+	if err != nil {
+		return
+	}
+	s.orphans = orphans
+
+	if len(keys) > 0 {
+		err = s.repo.DeleteBatch(keys)
+	}
+}
+
+// GetUsage возвращает количество ключей и занимаемое место по префиксам ключей
+func (s *Service) GetUsage() []*dto.Usage {
+	var (
+		err    error
+		result []*dto.Usage
+	)
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"result", result,
+		)
+	}()
+
+	result, err = s.repo.GetUsage()
+	return result
+}
@@ -0,0 +1,69 @@
+package engine_storage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/comerc/budva43/app/dto/storage/dto"
+	"github.com/comerc/budva43/service/storage/mocks"
+)
+
+func TestSweep(t *testing.T) {
+	t.Parallel()
+
+	records := []struct {
+		key    string
+		record *dto.Record
+	}{
+		{"copiedMsgIds:-1001:10", &dto.Record{
+			ChatMessages: []*dto.ChatMessage{{ChatId: -1002, MessageId: 20}},
+		}},
+		{"textSnapshot:-1001:10", &dto.Record{Text: "text"}},
+		{"textSnapshot:-1001:11", &dto.Record{Text: "text"}}, // оригинал истёк
+		{"overflowMsgIds:-1002:20", &dto.Record{MessageIds: []int64{22}}},
+		{"editDiffMsgIds:-1002:20", &dto.Record{MessageIds: []int64{24}}},
+		{"editDiffMsgIds:-1002:30", &dto.Record{MessageIds: []int64{32}}}, // копия истекла
+		{"newMsgId:-1002:20", &dto.Record{MessageId: 21}},
+		{"newMsgId:-1002:22", &dto.Record{MessageId: 23}},
+		{"newMsgId:-1002:24", &dto.Record{MessageId: 25}},
+		{"newMsgId:-1002:30", &dto.Record{MessageId: 31}}, // копия истекла
+		{"tmpMsgId:-1002:21", &dto.Record{MessageId: 20}},
+		{"tmpMsgId:-1002:23", &dto.Record{MessageId: 22}},
+		{"tmpMsgId:-1002:25", &dto.Record{MessageId: 24}},
+		{"tmpMsgId:-1002:31", &dto.Record{MessageId: 30}},
+		{"originMsgId:-1002:21", &dto.Record{}},
+		{"originMsgId:-1002:31", &dto.Record{}},
+		{"tombstone:-1002:41", &dto.Record{}},
+		{"originMsgId:-1002:41", &dto.Record{}}, // оригинал удалён, копия помечена
+	}
+
+	repo := mocks.NewStorageRepo(t)
+	repo.EXPECT().Scan(mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(fn func(string, *dto.Record), prefixes ...string) {
+			// записи обходятся в порядке префиксов
+			for _, prefix := range prefixes {
+				for _, r := range records {
+					if strings.HasPrefix(r.key, prefix+":") {
+						fn(r.key, r.record)
+					}
+				}
+			}
+		}).
+		Return(nil)
+	repo.EXPECT().DeleteBatch([]string{
+		"textSnapshot:-1001:11",
+		"editDiffMsgIds:-1002:30",
+		"newMsgId:-1002:30",
+		"tmpMsgId:-1002:31",
+		"originMsgId:-1002:31",
+	}).Return(nil).Once()
+
+	s := New(repo)
+	// первая очистка только запоминает сирот
+	s.sweep()
+	s.sweep()
+}
@@ -0,0 +1,432 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/comerc/budva43/app/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StorageService is an autogenerated mock type for the storageService type
+type StorageService struct {
+	mock.Mock
+}
+
+type StorageService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *StorageService) EXPECT() *StorageService_Expecter {
+	return &StorageService_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function with no fields
+func (_m *StorageService) Check() ([]*domain.StorageIssue, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 []*domain.StorageIssue
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*domain.StorageIssue, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*domain.StorageIssue); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.StorageIssue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageService_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type StorageService_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+func (_e *StorageService_Expecter) Check() *StorageService_Check_Call {
+	return &StorageService_Check_Call{Call: _e.mock.On("Check")}
+}
+
+func (_c *StorageService_Check_Call) Run(run func()) *StorageService_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *StorageService_Check_Call) Return(_a0 []*domain.StorageIssue, _a1 error) *StorageService_Check_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageService_Check_Call) RunAndReturn(run func() ([]*domain.StorageIssue, error)) *StorageService_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCopiedMessageId provides a mock function with given fields: chatId, messageId, toChatMessage
+func (_m *StorageService) DeleteCopiedMessageId(chatId int64, messageId int64, toChatMessage *domain.ChatMessage) {
+	_m.Called(chatId, messageId, toChatMessage)
+}
+
+// StorageService_DeleteCopiedMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCopiedMessageId'
+type StorageService_DeleteCopiedMessageId_Call struct {
+	*mock.Call
+}
+
+// DeleteCopiedMessageId is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+//   - toChatMessage *domain.ChatMessage
+func (_e *StorageService_Expecter) DeleteCopiedMessageId(chatId interface{}, messageId interface{}, toChatMessage interface{}) *StorageService_DeleteCopiedMessageId_Call {
+	return &StorageService_DeleteCopiedMessageId_Call{Call: _e.mock.On("DeleteCopiedMessageId", chatId, messageId, toChatMessage)}
+}
+
+func (_c *StorageService_DeleteCopiedMessageId_Call) Run(run func(chatId int64, messageId int64, toChatMessage *domain.ChatMessage)) *StorageService_DeleteCopiedMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(*domain.ChatMessage))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteCopiedMessageId_Call) Return() *StorageService_DeleteCopiedMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteCopiedMessageId_Call) RunAndReturn(run func(int64, int64, *domain.ChatMessage)) *StorageService_DeleteCopiedMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteNewMessageId provides a mock function with given fields: chatId, tmpMessageId
+func (_m *StorageService) DeleteNewMessageId(chatId int64, tmpMessageId int64) {
+	_m.Called(chatId, tmpMessageId)
+}
+
+// StorageService_DeleteNewMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNewMessageId'
+type StorageService_DeleteNewMessageId_Call struct {
+	*mock.Call
+}
+
+// DeleteNewMessageId is a helper method to define mock.On call
+//   - chatId int64
+//   - tmpMessageId int64
+func (_e *StorageService_Expecter) DeleteNewMessageId(chatId interface{}, tmpMessageId interface{}) *StorageService_DeleteNewMessageId_Call {
+	return &StorageService_DeleteNewMessageId_Call{Call: _e.mock.On("DeleteNewMessageId", chatId, tmpMessageId)}
+}
+
+func (_c *StorageService_DeleteNewMessageId_Call) Run(run func(chatId int64, tmpMessageId int64)) *StorageService_DeleteNewMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteNewMessageId_Call) Return() *StorageService_DeleteNewMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteNewMessageId_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteNewMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteOriginMessageId provides a mock function with given fields: dstChatId, dstMessageId
+func (_m *StorageService) DeleteOriginMessageId(dstChatId int64, dstMessageId int64) {
+	_m.Called(dstChatId, dstMessageId)
+}
+
+// StorageService_DeleteOriginMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOriginMessageId'
+type StorageService_DeleteOriginMessageId_Call struct {
+	*mock.Call
+}
+
+// DeleteOriginMessageId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - dstMessageId int64
+func (_e *StorageService_Expecter) DeleteOriginMessageId(dstChatId interface{}, dstMessageId interface{}) *StorageService_DeleteOriginMessageId_Call {
+	return &StorageService_DeleteOriginMessageId_Call{Call: _e.mock.On("DeleteOriginMessageId", dstChatId, dstMessageId)}
+}
+
+func (_c *StorageService_DeleteOriginMessageId_Call) Run(run func(dstChatId int64, dstMessageId int64)) *StorageService_DeleteOriginMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteOriginMessageId_Call) Return() *StorageService_DeleteOriginMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteOriginMessageId_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteOriginMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteRevisionMessageId provides a mock function with given fields: chatId, messageId, toChatMessage
+func (_m *StorageService) DeleteRevisionMessageId(chatId int64, messageId int64, toChatMessage *domain.ChatMessage) {
+	_m.Called(chatId, messageId, toChatMessage)
+}
+
+// StorageService_DeleteRevisionMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRevisionMessageId'
+type StorageService_DeleteRevisionMessageId_Call struct {
+	*mock.Call
+}
+
+// DeleteRevisionMessageId is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+//   - toChatMessage *domain.ChatMessage
+func (_e *StorageService_Expecter) DeleteRevisionMessageId(chatId interface{}, messageId interface{}, toChatMessage interface{}) *StorageService_DeleteRevisionMessageId_Call {
+	return &StorageService_DeleteRevisionMessageId_Call{Call: _e.mock.On("DeleteRevisionMessageId", chatId, messageId, toChatMessage)}
+}
+
+func (_c *StorageService_DeleteRevisionMessageId_Call) Run(run func(chatId int64, messageId int64, toChatMessage *domain.ChatMessage)) *StorageService_DeleteRevisionMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(*domain.ChatMessage))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteRevisionMessageId_Call) Return() *StorageService_DeleteRevisionMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteRevisionMessageId_Call) RunAndReturn(run func(int64, int64, *domain.ChatMessage)) *StorageService_DeleteRevisionMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteTmpMessageId provides a mock function with given fields: chatId, newMessageId
+func (_m *StorageService) DeleteTmpMessageId(chatId int64, newMessageId int64) {
+	_m.Called(chatId, newMessageId)
+}
+
+// StorageService_DeleteTmpMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTmpMessageId'
+type StorageService_DeleteTmpMessageId_Call struct {
+	*mock.Call
+}
+
+// DeleteTmpMessageId is a helper method to define mock.On call
+//   - chatId int64
+//   - newMessageId int64
+func (_e *StorageService_Expecter) DeleteTmpMessageId(chatId interface{}, newMessageId interface{}) *StorageService_DeleteTmpMessageId_Call {
+	return &StorageService_DeleteTmpMessageId_Call{Call: _e.mock.On("DeleteTmpMessageId", chatId, newMessageId)}
+}
+
+func (_c *StorageService_DeleteTmpMessageId_Call) Run(run func(chatId int64, newMessageId int64)) *StorageService_DeleteTmpMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_DeleteTmpMessageId_Call) Return() *StorageService_DeleteTmpMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_DeleteTmpMessageId_Call) RunAndReturn(run func(int64, int64)) *StorageService_DeleteTmpMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetCopiedMessageIds provides a mock function with given fields: chatId, messageId
+func (_m *StorageService) GetCopiedMessageIds(chatId int64, messageId int64) []*domain.ChatMessage {
+	ret := _m.Called(chatId, messageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCopiedMessageIds")
+	}
+
+	var r0 []*domain.ChatMessage
+	if rf, ok := ret.Get(0).(func(int64, int64) []*domain.ChatMessage); ok {
+		r0 = rf(chatId, messageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ChatMessage)
+		}
+	}
+
+	return r0
+}
+
+// StorageService_GetCopiedMessageIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCopiedMessageIds'
+type StorageService_GetCopiedMessageIds_Call struct {
+	*mock.Call
+}
+
+// GetCopiedMessageIds is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+func (_e *StorageService_Expecter) GetCopiedMessageIds(chatId interface{}, messageId interface{}) *StorageService_GetCopiedMessageIds_Call {
+	return &StorageService_GetCopiedMessageIds_Call{Call: _e.mock.On("GetCopiedMessageIds", chatId, messageId)}
+}
+
+func (_c *StorageService_GetCopiedMessageIds_Call) Run(run func(chatId int64, messageId int64)) *StorageService_GetCopiedMessageIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetCopiedMessageIds_Call) Return(_a0 []*domain.ChatMessage) *StorageService_GetCopiedMessageIds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetCopiedMessageIds_Call) RunAndReturn(run func(int64, int64) []*domain.ChatMessage) *StorageService_GetCopiedMessageIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOriginMessageId provides a mock function with given fields: dstChatId, dstMessageId
+func (_m *StorageService) GetOriginMessageId(dstChatId int64, dstMessageId int64) *domain.ChatMessage {
+	ret := _m.Called(dstChatId, dstMessageId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOriginMessageId")
+	}
+
+	var r0 *domain.ChatMessage
+	if rf, ok := ret.Get(0).(func(int64, int64) *domain.ChatMessage); ok {
+		r0 = rf(dstChatId, dstMessageId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ChatMessage)
+		}
+	}
+
+	return r0
+}
+
+// StorageService_GetOriginMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOriginMessageId'
+type StorageService_GetOriginMessageId_Call struct {
+	*mock.Call
+}
+
+// GetOriginMessageId is a helper method to define mock.On call
+//   - dstChatId int64
+//   - dstMessageId int64
+func (_e *StorageService_Expecter) GetOriginMessageId(dstChatId interface{}, dstMessageId interface{}) *StorageService_GetOriginMessageId_Call {
+	return &StorageService_GetOriginMessageId_Call{Call: _e.mock.On("GetOriginMessageId", dstChatId, dstMessageId)}
+}
+
+func (_c *StorageService_GetOriginMessageId_Call) Run(run func(dstChatId int64, dstMessageId int64)) *StorageService_GetOriginMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_GetOriginMessageId_Call) Return(_a0 *domain.ChatMessage) *StorageService_GetOriginMessageId_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetOriginMessageId_Call) RunAndReturn(run func(int64, int64) *domain.ChatMessage) *StorageService_GetOriginMessageId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCopiedMessageId provides a mock function with given fields: chatId, messageId, toChatMessage
+func (_m *StorageService) SetCopiedMessageId(chatId int64, messageId int64, toChatMessage *domain.ChatMessage) {
+	_m.Called(chatId, messageId, toChatMessage)
+}
+
+// StorageService_SetCopiedMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCopiedMessageId'
+type StorageService_SetCopiedMessageId_Call struct {
+	*mock.Call
+}
+
+// SetCopiedMessageId is a helper method to define mock.On call
+//   - chatId int64
+//   - messageId int64
+//   - toChatMessage *domain.ChatMessage
+func (_e *StorageService_Expecter) SetCopiedMessageId(chatId interface{}, messageId interface{}, toChatMessage interface{}) *StorageService_SetCopiedMessageId_Call {
+	return &StorageService_SetCopiedMessageId_Call{Call: _e.mock.On("SetCopiedMessageId", chatId, messageId, toChatMessage)}
+}
+
+func (_c *StorageService_SetCopiedMessageId_Call) Run(run func(chatId int64, messageId int64, toChatMessage *domain.ChatMessage)) *StorageService_SetCopiedMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(*domain.ChatMessage))
+	})
+	return _c
+}
+
+func (_c *StorageService_SetCopiedMessageId_Call) Return() *StorageService_SetCopiedMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_SetCopiedMessageId_Call) RunAndReturn(run func(int64, int64, *domain.ChatMessage)) *StorageService_SetCopiedMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetTmpMessageId provides a mock function with given fields: chatId, newMessageId, tmpMessageId
+func (_m *StorageService) SetTmpMessageId(chatId int64, newMessageId int64, tmpMessageId int64) {
+	_m.Called(chatId, newMessageId, tmpMessageId)
+}
+
+// StorageService_SetTmpMessageId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTmpMessageId'
+type StorageService_SetTmpMessageId_Call struct {
+	*mock.Call
+}
+
+// SetTmpMessageId is a helper method to define mock.On call
+//   - chatId int64
+//   - newMessageId int64
+//   - tmpMessageId int64
+func (_e *StorageService_Expecter) SetTmpMessageId(chatId interface{}, newMessageId interface{}, tmpMessageId interface{}) *StorageService_SetTmpMessageId_Call {
+	return &StorageService_SetTmpMessageId_Call{Call: _e.mock.On("SetTmpMessageId", chatId, newMessageId, tmpMessageId)}
+}
+
+func (_c *StorageService_SetTmpMessageId_Call) Run(run func(chatId int64, newMessageId int64, tmpMessageId int64)) *StorageService_SetTmpMessageId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *StorageService_SetTmpMessageId_Call) Return() *StorageService_SetTmpMessageId_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *StorageService_SetTmpMessageId_Call) RunAndReturn(run func(int64, int64, int64)) *StorageService_SetTmpMessageId_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewStorageService creates a new instance of StorageService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStorageService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StorageService {
+	mock := &StorageService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,184 @@
+package storage_check
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/log"
+)
+
+//go:generate mockery --name=telegramRepo --exported
+type telegramRepo interface {
+	// tdlibClient methods
+	GetMessage(*client.GetMessageRequest) (*client.Message, error)
+}
+
+//go:generate mockery --name=storageService --exported
+type storageService interface {
+	Check() ([]*domain.StorageIssue, error)
+	SetCopiedMessageId(chatId, messageId int64, toChatMessage *domain.ChatMessage)
+	GetCopiedMessageIds(chatId, messageId int64) []*domain.ChatMessage
+	DeleteCopiedMessageId(chatId, messageId int64, toChatMessage *domain.ChatMessage)
+	DeleteRevisionMessageId(chatId, messageId int64, toChatMessage *domain.ChatMessage)
+	DeleteNewMessageId(chatId, tmpMessageId int64)
+	SetTmpMessageId(chatId, newMessageId, tmpMessageId int64)
+	DeleteTmpMessageId(chatId, newMessageId int64)
+	GetOriginMessageId(dstChatId, dstMessageId int64) *domain.ChatMessage
+	DeleteOriginMessageId(dstChatId, dstMessageId int64)
+}
+
+// Service проверяет согласованность связей сообщений и исправляет их по данным Telegram
+type Service struct {
+	log *log.Logger
+	//
+	telegramRepo   telegramRepo
+	storageService storageService
+}
+
+// New создает новый экземпляр сервиса проверки хранилища
+func New(
+	telegramRepo telegramRepo,
+	storageService storageService,
+) *Service {
+	return &Service{
+		log: log.NewLogger(),
+		//
+		telegramRepo:   telegramRepo,
+		storageService: storageService,
+	}
+}
+
+// Run находит несогласованные связи; при isRepair исправляет их, сверяясь с Telegram,
+// а при isDryRun только заполняет, какое исправление было бы выполнено
+func (s *Service) Run(isRepair, isDryRun bool) ([]*domain.StorageIssue, error) {
+	var (
+		err    error
+		result []*domain.StorageIssue
+	)
+	defer func() {
+		s.log.ErrorOrInfo(err, "storage check",
+			"isRepair", isRepair,
+			"isDryRun", isDryRun,
+			"result", len(result),
+		)
+	}()
+
+	result, err = s.storageService.Check()
+	if err != nil || !isRepair {
+		return result, err
+	}
+
+	for _, issue := range result {
+		err = s.repair(issue, isDryRun)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// repair выбирает исправление по наличию сообщения-копии и, если не isDryRun, выполняет его
+func (s *Service) repair(issue *domain.StorageIssue, isDryRun bool) error {
+	switch issue.Kind {
+	case domain.StorageIssueCopiedWithoutNew:
+		// пока сообщение не отправлено, TDLib отдаёт его по временному Id
+		isExist, err := s.isMessageExist(issue.ChatId, issue.TmpMessageId)
+		if err != nil {
+			return err
+		}
+		if isExist {
+			issue.Repair = domain.StorageRepairKeep
+			return nil
+		}
+		issue.Repair = domain.StorageRepairDelete
+		if isDryRun {
+			return nil
+		}
+		toChatMessage := &domain.ChatMessage{
+			ForwardRuleId: issue.Source.ForwardRuleId,
+			ChatId:        issue.ChatId,
+			MessageId:     issue.TmpMessageId,
+		}
+		s.storageService.DeleteCopiedMessageId(issue.Source.ChatId, issue.Source.MessageId, toChatMessage)
+		s.storageService.DeleteRevisionMessageId(issue.Source.ChatId, issue.Source.MessageId, toChatMessage)
+		s.storageService.DeleteOriginMessageId(issue.ChatId, issue.TmpMessageId)
+	case domain.StorageIssueTmpWithoutCopied:
+		isExist, err := s.isMessageExist(issue.ChatId, issue.NewMessageId)
+		if err != nil {
+			return err
+		}
+		if !isExist {
+			issue.Repair = domain.StorageRepairDelete
+			if isDryRun {
+				return nil
+			}
+			s.storageService.DeleteTmpMessageId(issue.ChatId, issue.NewMessageId)
+			s.storageService.DeleteNewMessageId(issue.ChatId, issue.TmpMessageId)
+			s.storageService.DeleteOriginMessageId(issue.ChatId, issue.NewMessageId)
+			return nil
+		}
+		// прямую связь можно восстановить по обратному индексу оригиналов
+		issue.Source = s.storageService.GetOriginMessageId(issue.ChatId, issue.NewMessageId)
+		if issue.Source == nil || s.hasCopy(issue.Source, issue.ChatId) {
+			// другую копию по тому же правилу в тот же чат не затираем
+			issue.Repair = domain.StorageRepairKeep
+			return nil
+		}
+		issue.Repair = domain.StorageRepairRestore
+		if isDryRun {
+			return nil
+		}
+		s.storageService.SetCopiedMessageId(issue.Source.ChatId, issue.Source.MessageId, &domain.ChatMessage{
+			ForwardRuleId: issue.Source.ForwardRuleId,
+			ChatId:        issue.ChatId,
+			MessageId:     issue.TmpMessageId,
+		})
+	case domain.StorageIssueNewWithoutTmp:
+		isExist, err := s.isMessageExist(issue.ChatId, issue.NewMessageId)
+		if err != nil {
+			return err
+		}
+		if isExist {
+			issue.Repair = domain.StorageRepairRestore
+			if isDryRun {
+				return nil
+			}
+			s.storageService.SetTmpMessageId(issue.ChatId, issue.NewMessageId, issue.TmpMessageId)
+			return nil
+		}
+		issue.Repair = domain.StorageRepairDelete
+		if isDryRun {
+			return nil
+		}
+		s.storageService.DeleteNewMessageId(issue.ChatId, issue.TmpMessageId)
+	}
+	return nil
+}
+
+// hasCopy проверяет, есть ли у оригинала копия по тому же правилу в чат получателя
+func (s *Service) hasCopy(source *domain.ChatMessage, dstChatId int64) bool {
+	toChatMessages := s.storageService.GetCopiedMessageIds(source.ChatId, source.MessageId)
+	return slices.ContainsFunc(toChatMessages, func(toChatMessage *domain.ChatMessage) bool {
+		return toChatMessage.ForwardRuleId == source.ForwardRuleId && toChatMessage.ChatId == dstChatId
+	})
+}
+
+// isMessageExist проверяет наличие сообщения в Telegram; только ответ "не найдено"
+// считается отсутствием, иначе (сеть, доступ к чату) связь нельзя трогать
+func (s *Service) isMessageExist(chatId, messageId int64) (bool, error) {
+	_, err := s.telegramRepo.GetMessage(&client.GetMessageRequest{
+		ChatId:    chatId,
+		MessageId: messageId,
+	})
+	if err == nil {
+		return true, nil
+	}
+	var responseError client.ResponseError
+	if errors.As(err, &responseError) && responseError.Err.Code == 404 {
+		return false, nil
+	}
+	return false, err
+}
@@ -0,0 +1,197 @@
+package storage_check
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/service/storage_check/mocks"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	notFound := client.ResponseError{Err: &client.Error{Code: 404, Message: "Message not found"}}
+	source := &domain.ChatMessage{ForwardRuleId: "rule1", ChatId: -1001, MessageId: 10}
+
+	tests := []struct {
+		name     string
+		issue    *domain.StorageIssue
+		isDryRun bool
+		setup    func(telegramRepo *mocks.TelegramRepo, storageService *mocks.StorageService)
+		repair   domain.StorageRepair
+		isError  bool
+	}{
+		{
+			name: "copied_without_new_delete",
+			issue: &domain.StorageIssue{
+				Kind:         domain.StorageIssueCopiedWithoutNew,
+				Source:       source,
+				ChatId:       -1002,
+				TmpMessageId: 20,
+			},
+			setup: func(telegramRepo *mocks.TelegramRepo, storageService *mocks.StorageService) {
+				telegramRepo.EXPECT().GetMessage(&client.GetMessageRequest{ChatId: -1002, MessageId: 20}).
+					Return(nil, notFound)
+				toChatMessage := &domain.ChatMessage{ForwardRuleId: "rule1", ChatId: -1002, MessageId: 20}
+				storageService.EXPECT().DeleteCopiedMessageId(int64(-1001), int64(10), toChatMessage).Once()
+				storageService.EXPECT().DeleteRevisionMessageId(int64(-1001), int64(10), toChatMessage).Once()
+				storageService.EXPECT().DeleteOriginMessageId(int64(-1002), int64(20)).Once()
+			},
+			repair: domain.StorageRepairDelete,
+		},
+		{
+			name: "copied_without_new_dry_run",
+			issue: &domain.StorageIssue{
+				Kind:         domain.StorageIssueCopiedWithoutNew,
+				Source:       source,
+				ChatId:       -1002,
+				TmpMessageId: 20,
+			},
+			isDryRun: true,
+			setup: func(telegramRepo *mocks.TelegramRepo, storageService *mocks.StorageService) {
+				telegramRepo.EXPECT().GetMessage(&client.GetMessageRequest{ChatId: -1002, MessageId: 20}).
+					Return(nil, notFound)
+			},
+			repair: domain.StorageRepairDelete,
+		},
+		{
+			name: "copied_without_new_network_error",
+			issue: &domain.StorageIssue{
+				Kind:         domain.StorageIssueCopiedWithoutNew,
+				Source:       source,
+				ChatId:       -1002,
+				TmpMessageId: 20,
+			},
+			setup: func(telegramRepo *mocks.TelegramRepo, storageService *mocks.StorageService) {
+				telegramRepo.EXPECT().GetMessage(&client.GetMessageRequest{ChatId: -1002, MessageId: 20}).
+					Return(nil, errors.New("network error"))
+			},
+			isError: true,
+		},
+		{
+			name: "tmp_without_copied_restore",
+			issue: &domain.StorageIssue{
+				Kind:         domain.StorageIssueTmpWithoutCopied,
+				ChatId:       -1002,
+				TmpMessageId: 20,
+				NewMessageId: 21,
+			},
+			setup: func(telegramRepo *mocks.TelegramRepo, storageService *mocks.StorageService) {
+				telegramRepo.EXPECT().GetMessage(&client.GetMessageRequest{ChatId: -1002, MessageId: 21}).
+					Return(&client.Message{}, nil)
+				storageService.EXPECT().GetOriginMessageId(int64(-1002), int64(21)).Return(source)
+				storageService.EXPECT().GetCopiedMessageIds(int64(-1001), int64(10)).Return(nil)
+				storageService.EXPECT().SetCopiedMessageId(int64(-1001), int64(10),
+					&domain.ChatMessage{ForwardRuleId: "rule1", ChatId: -1002, MessageId: 20}).Once()
+			},
+			repair: domain.StorageRepairRestore,
+		},
+		{
+			name: "tmp_without_copied_keep_other_copy",
+			issue: &domain.StorageIssue{
+				Kind:         domain.StorageIssueTmpWithoutCopied,
+				ChatId:       -1002,
+				TmpMessageId: 20,
+				NewMessageId: 21,
+			},
+			setup: func(telegramRepo *mocks.TelegramRepo, storageService *mocks.StorageService) {
+				telegramRepo.EXPECT().GetMessage(&client.GetMessageRequest{ChatId: -1002, MessageId: 21}).
+					Return(&client.Message{}, nil)
+				storageService.EXPECT().GetOriginMessageId(int64(-1002), int64(21)).Return(source)
+				storageService.EXPECT().GetCopiedMessageIds(int64(-1001), int64(10)).Return([]*domain.ChatMessage{
+					{ForwardRuleId: "rule1", ChatId: -1002, MessageId: 30},
+				})
+			},
+			repair: domain.StorageRepairKeep,
+		},
+		{
+			name: "tmp_without_copied_delete",
+			issue: &domain.StorageIssue{
+				Kind:         domain.StorageIssueTmpWithoutCopied,
+				ChatId:       -1002,
+				TmpMessageId: 20,
+				NewMessageId: 21,
+			},
+			setup: func(telegramRepo *mocks.TelegramRepo, storageService *mocks.StorageService) {
+				telegramRepo.EXPECT().GetMessage(&client.GetMessageRequest{ChatId: -1002, MessageId: 21}).
+					Return(nil, notFound)
+				storageService.EXPECT().DeleteTmpMessageId(int64(-1002), int64(21)).Once()
+				storageService.EXPECT().DeleteNewMessageId(int64(-1002), int64(20)).Once()
+				storageService.EXPECT().DeleteOriginMessageId(int64(-1002), int64(21)).Once()
+			},
+			repair: domain.StorageRepairDelete,
+		},
+		{
+			name: "new_without_tmp_restore",
+			issue: &domain.StorageIssue{
+				Kind:         domain.StorageIssueNewWithoutTmp,
+				ChatId:       -1002,
+				TmpMessageId: 20,
+				NewMessageId: 21,
+			},
+			setup: func(telegramRepo *mocks.TelegramRepo, storageService *mocks.StorageService) {
+				telegramRepo.EXPECT().GetMessage(&client.GetMessageRequest{ChatId: -1002, MessageId: 21}).
+					Return(&client.Message{}, nil)
+				storageService.EXPECT().SetTmpMessageId(int64(-1002), int64(21), int64(20)).Once()
+			},
+			repair: domain.StorageRepairRestore,
+		},
+		{
+			name: "new_without_tmp_delete",
+			issue: &domain.StorageIssue{
+				Kind:         domain.StorageIssueNewWithoutTmp,
+				ChatId:       -1002,
+				TmpMessageId: 20,
+				NewMessageId: 21,
+			},
+			setup: func(telegramRepo *mocks.TelegramRepo, storageService *mocks.StorageService) {
+				telegramRepo.EXPECT().GetMessage(&client.GetMessageRequest{ChatId: -1002, MessageId: 21}).
+					Return(nil, notFound)
+				storageService.EXPECT().DeleteNewMessageId(int64(-1002), int64(20)).Once()
+			},
+			repair: domain.StorageRepairDelete,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			telegramRepo := mocks.NewTelegramRepo(t)
+			storageService := mocks.NewStorageService(t)
+			storageService.EXPECT().Check().Return([]*domain.StorageIssue{test.issue}, nil)
+			test.setup(telegramRepo, storageService)
+
+			s := New(telegramRepo, storageService)
+			issues, err := s.Run(true, test.isDryRun)
+			if test.isError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, issues, 1)
+			assert.Equal(t, test.repair, issues[0].Repair)
+		})
+	}
+}
+
+func TestRun_WithoutRepair(t *testing.T) {
+	t.Parallel()
+
+	issues := []*domain.StorageIssue{
+		{Kind: domain.StorageIssueNewWithoutTmp, ChatId: -1002, TmpMessageId: 20, NewMessageId: 21},
+	}
+	storageService := mocks.NewStorageService(t)
+	storageService.EXPECT().Check().Return(issues, nil)
+
+	s := New(nil, storageService)
+	result, err := s.Run(false, false)
+	require.NoError(t, err)
+	assert.Equal(t, issues, result)
+	assert.Empty(t, result[0].Repair)
+}
@@ -2,7 +2,10 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	domain "github.com/comerc/budva43/app/domain"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // StorageService is an autogenerated mock type for the storageService type
 type StorageService struct {
@@ -18,19 +21,19 @@ func (_m *StorageService) EXPECT() *StorageService_Expecter {
 }
 
 // GetCopiedMessageIds provides a mock function with given fields: chatId, messageId
-func (_m *StorageService) GetCopiedMessageIds(chatId int64, messageId int64) []string {
+func (_m *StorageService) GetCopiedMessageIds(chatId int64, messageId int64) []*domain.ChatMessage {
 	ret := _m.Called(chatId, messageId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCopiedMessageIds")
 	}
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func(int64, int64) []string); ok {
+	var r0 []*domain.ChatMessage
+	if rf, ok := ret.Get(0).(func(int64, int64) []*domain.ChatMessage); ok {
 		r0 = rf(chatId, messageId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]*domain.ChatMessage)
 		}
 	}
 
@@ -56,12 +59,12 @@ func (_c *StorageService_GetCopiedMessageIds_Call) Run(run func(chatId int64, me
 	return _c
 }
 
-func (_c *StorageService_GetCopiedMessageIds_Call) Return(_a0 []string) *StorageService_GetCopiedMessageIds_Call {
+func (_c *StorageService_GetCopiedMessageIds_Call) Return(_a0 []*domain.ChatMessage) *StorageService_GetCopiedMessageIds_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *StorageService_GetCopiedMessageIds_Call) RunAndReturn(run func(int64, int64) []string) *StorageService_GetCopiedMessageIds_Call {
+func (_c *StorageService_GetCopiedMessageIds_Call) RunAndReturn(run func(int64, int64) []*domain.ChatMessage) *StorageService_GetCopiedMessageIds_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1,6 +1,7 @@
 package transform
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"slices"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/comerc/budva43/app/domain"
 	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/trace"
 	"github.com/comerc/budva43/app/util"
 )
 
@@ -27,7 +29,7 @@ type telegramRepo interface {
 //go:generate mockery --name=storageService --exported
 type storageService interface {
 	GetNewMessageId(chatId, tmpMessageId int64) int64
-	GetCopiedMessageIds(chatId, messageId int64) []string
+	GetCopiedMessageIds(chatId, messageId int64) []*domain.ChatMessage
 }
 
 //go:generate mockery --name=messageService --exported
@@ -67,11 +69,13 @@ func New(
 }
 
 // Transform преобразует содержимое сообщения
-func (s *Service) Transform(formattedText *client.FormattedText, withSources bool,
+func (s *Service) Transform(ctx context.Context, formattedText *client.FormattedText, withSources bool,
 	src *client.Message, dstChatId, prevMessageId int64, engineConfig *domain.EngineConfig,
 ) {
+	ctx, end := trace.Start(ctx, "transform", "srcChatId", src.ChatId, "dstChatId", dstChatId)
 	defer func() {
-		s.log.ErrorOrDebug(nil, "",
+		end(nil)
+		s.log.ErrorOrDebugContext(ctx, nil, "",
 			"withSources", withSources,
 			"srcChatId", src.ChatId,
 			"srcId", src.Id,
@@ -145,6 +149,209 @@ func (s *Service) AddNextLink(formattedText *client.FormattedText,
 	s.addText(formattedText, text)
 }
 
+// AddRevisionMark добавляет метку редакции сообщения
+func (s *Service) AddRevisionMark(formattedText *client.FormattedText,
+	revision int, forwardRule *domain.ForwardRule,
+) {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"revision", revision,
+			"forwardRuleId", forwardRule.Id,
+		)
+	}()
+
+	if forwardRule.Revisions == nil || !forwardRule.Revisions.Run {
+		err = log.NewError("forwardRule.Revisions is not run")
+		return
+	}
+
+	revisionTitle := forwardRule.Revisions.Title
+	if revisionTitle == "" {
+		revisionTitle = domain.REVISION_TITLE
+	}
+
+	text := fmt.Sprintf(revisionTitle, revision)
+	s.addText(formattedText, text)
+}
+
+// AddProtectedContentNote добавляет пометку о защищённом содержимом согласно forwardRule.Fallback
+func (s *Service) AddProtectedContentNote(formattedText *client.FormattedText, forwardRule *domain.ForwardRule) {
+	switch forwardRule.Fallback {
+	case domain.FallbackNote, domain.FallbackCheck:
+		s.addText(formattedText, domain.PROTECTED_CONTENT_NOTE)
+	}
+}
+
+// AddTombstone помечает текст копии удалённого оригинала согласно forwardRule.Tombstone
+func (s *Service) AddTombstone(formattedText *client.FormattedText, forwardRule *domain.ForwardRule) {
+	var err error
+	mode := domain.TombstoneMarker
+	title := domain.TOMBSTONE_TITLE
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"forwardRuleId", forwardRule.Id,
+			"mode", mode,
+		)
+	}()
+
+	if forwardRule.Tombstone != nil {
+		if forwardRule.Tombstone.Mode != "" {
+			mode = forwardRule.Tombstone.Mode
+		}
+		if forwardRule.Tombstone.Title != "" {
+			title = forwardRule.Tombstone.Title
+		}
+	}
+
+	if mode == domain.TombstoneStrike {
+		length := int32(len(util.EncodeToUTF16(formattedText.Text))) //nolint:gosec
+		if length == 0 {
+			return
+		}
+		formattedText.Entities = append(formattedText.Entities, &client.TextEntity{
+			Offset: 0,
+			Length: length,
+			Type:   &client.TextEntityTypeStrikethrough{},
+		})
+		return
+	}
+
+	var parsedText *client.FormattedText
+	parsedText, err = s.telegramRepo.ParseTextEntities(&client.ParseTextEntitiesRequest{
+		Text: title,
+		ParseMode: &client.TextParseModeMarkdown{
+			Version: 2,
+		},
+	})
+	if err != nil {
+		return
+	}
+	if formattedText.Text != "" {
+		parsedText.Text += "\n\n"
+	}
+	offset := int32(len(util.EncodeToUTF16(parsedText.Text))) //nolint:gosec
+	for _, entity := range formattedText.Entities {
+		entity.Offset += offset
+	}
+	formattedText.Text = parsedText.Text + formattedText.Text
+	formattedText.Entities = append(parsedText.Entities, formattedText.Entities...)
+}
+
+// AddReplyContext добавляет перед текстом контекст ответа согласно forwardRule.ReplyContext:
+// заголовок (ссылкой на исходное сообщение, если она доступна) и цитату
+func (s *Service) AddReplyContext(formattedText *client.FormattedText,
+	quote, link string, forwardRule *domain.ForwardRule,
+) {
+	var err error
+	mode := domain.ReplyContextQuote
+	title := domain.REPLY_CONTEXT_TITLE
+	length := domain.REPLY_CONTEXT_LENGTH
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"forwardRuleId", forwardRule.Id,
+			"mode", mode,
+			"link", link,
+		)
+	}()
+
+	if forwardRule.ReplyContext != nil {
+		if forwardRule.ReplyContext.Mode != "" {
+			mode = forwardRule.ReplyContext.Mode
+		}
+		if forwardRule.ReplyContext.Title != "" {
+			title = forwardRule.ReplyContext.Title
+		}
+		if forwardRule.ReplyContext.Length > 0 {
+			length = forwardRule.ReplyContext.Length
+		}
+	}
+
+	if mode == domain.ReplyContextLink && link != "" {
+		quote = ""
+	}
+	text := title
+	if link != "" {
+		text = fmt.Sprintf("[%s](%s)", title, link)
+	}
+
+	var parsedText *client.FormattedText
+	parsedText, err = s.telegramRepo.ParseTextEntities(&client.ParseTextEntitiesRequest{
+		Text: text,
+		ParseMode: &client.TextParseModeMarkdown{
+			Version: 2,
+		},
+	})
+	if err != nil {
+		return
+	}
+	quote = truncateText(quote, length)
+	if quote != "" {
+		parsedText.Text += "\n"
+		parsedText.Entities = append(parsedText.Entities, &client.TextEntity{
+			Offset: int32(len(util.EncodeToUTF16(parsedText.Text))), //nolint:gosec
+			Length: int32(len(util.EncodeToUTF16(quote))),           //nolint:gosec
+			Type:   &client.TextEntityTypeBlockQuote{},
+		})
+		parsedText.Text += quote
+	}
+	if formattedText.Text != "" {
+		parsedText.Text += "\n\n"
+	}
+	offset := int32(len(util.EncodeToUTF16(parsedText.Text))) //nolint:gosec
+	for _, entity := range formattedText.Entities {
+		entity.Offset += offset
+	}
+	formattedText.Text = parsedText.Text + formattedText.Text
+	formattedText.Entities = append(parsedText.Entities, formattedText.Entities...)
+}
+
+// truncateText обрезает текст до length символов, отмечая обрезку многоточием
+func truncateText(text string, length int) string {
+	text = strings.TrimSpace(text)
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:length])) + "…"
+}
+
+// FormatEditDiff формирует ответ с изменениями текста между редакциями оригинала
+func (s *Service) FormatEditDiff(oldText, newText string,
+	dstChatId int64, engineConfig *domain.EngineConfig,
+) *client.FormattedText {
+	var err error
+	defer func() {
+		s.log.ErrorOrDebug(err, "",
+			"dstChatId", dstChatId,
+		)
+	}()
+
+	destination := engineConfig.Destinations[dstChatId]
+	if destination == nil || destination.EditDiff == nil || !destination.EditDiff.Run {
+		err = log.NewError("destination.EditDiff is not run")
+		return nil
+	}
+
+	diffParts := util.DiffWords(oldText, newText)
+	isChanged := slices.ContainsFunc(diffParts, func(diffPart util.DiffPart) bool {
+		return diffPart.Op != util.DiffEqual
+	})
+	if !isChanged {
+		return nil
+	}
+
+	title := destination.EditDiff.Title
+	if title == "" {
+		title = domain.EDIT_DIFF_TITLE
+	}
+
+	formattedText := &client.FormattedText{}
+	s.addText(formattedText, title)
+	s.addDiffParts(formattedText, diffParts)
+	return formattedText
+}
+
 // translate переводит текст сообщения
 func (s *Service) translate(formattedText *client.FormattedText,
 	srcChatId, dstChatId int64, engineConfig *domain.EngineConfig,
@@ -548,6 +755,42 @@ func (s *Service) addText(formattedText *client.FormattedText, text string) {
 	formattedText.Entities = append(formattedText.Entities, parsedText.Entities...)
 }
 
+// addDiffParts добавляет изменения к formattedText:
+// удалённое - зачёркнутым, добавленное - подчёркнутым, неизменное - сокращённо
+func (s *Service) addDiffParts(formattedText *client.FormattedText, diffParts []util.DiffPart) {
+	offset := int32(len(util.EncodeToUTF16(formattedText.Text))) //nolint:gosec
+	if offset > 0 {
+		formattedText.Text += "\n\n"
+		offset += 2
+	}
+	for i, diffPart := range diffParts {
+		if i > 0 {
+			formattedText.Text += " "
+			offset++
+		}
+		text := diffPart.Text
+		var entityType client.TextEntityType
+		switch diffPart.Op {
+		case util.DiffEqual:
+			text = compactDiffText(text, i == 0, i == len(diffParts)-1)
+		case util.DiffDelete:
+			entityType = &client.TextEntityTypeStrikethrough{}
+		case util.DiffInsert:
+			entityType = &client.TextEntityTypeUnderline{}
+		}
+		length := int32(len(util.EncodeToUTF16(text))) //nolint:gosec
+		if entityType != nil {
+			formattedText.Entities = append(formattedText.Entities, &client.TextEntity{
+				Offset: offset,
+				Length: length,
+				Type:   entityType,
+			})
+		}
+		formattedText.Text += text
+		offset += length
+	}
+}
+
 // applyReplacements применяет замены replacements к formattedText
 func (s *Service) applyReplacements(formattedText *client.FormattedText, replacements []*replacement) {
 	markdownReplacements := []*replacement{}
@@ -630,12 +873,11 @@ func (s *Service) getMessageByLink(url string) *client.Message {
 func (s *Service) getMyselfLink(src *client.Message, dstChatId int64) (string, error) {
 	var err error
 
-	toChatMessageIds := s.storageService.GetCopiedMessageIds(src.ChatId, src.Id)
+	toChatMessages := s.storageService.GetCopiedMessageIds(src.ChatId, src.Id)
 	var tmpMessageId int64 = 0
-	for _, toChatMessageId := range toChatMessageIds {
-		a := strings.Split(toChatMessageId, ":")
-		if util.ConvertToInt[int64](a[1]) == dstChatId {
-			tmpMessageId = util.ConvertToInt[int64](a[2])
+	for _, toChatMessage := range toChatMessages {
+		if toChatMessage.ChatId == dstChatId {
+			tmpMessageId = toChatMessage.MessageId
 			break
 		}
 	}
@@ -779,3 +1021,27 @@ func replaceTextUTF16(text string, startOffset, endOffset int32, newText string)
 
 	return util.DecodeFromUTF16(newUTF16)
 }
+
+// diffContextWords кол-во неизменных слов, сохраняемых рядом с изменениями
+const diffContextWords = 3
+
+// compactDiffText сокращает неизменный фрагмент до слов рядом с изменениями
+func compactDiffText(text string, isFirst, isLast bool) string {
+	words := strings.Fields(text)
+	head := diffContextWords
+	tail := diffContextWords
+	if isFirst {
+		head = 0
+	}
+	if isLast {
+		tail = 0
+	}
+	if len(words) <= head+tail {
+		return text
+	}
+	var result []string
+	result = append(result, words[:head]...)
+	result = append(result, "…")
+	result = append(result, words[len(words)-tail:]...)
+	return strings.Join(result, " ")
+}
@@ -1,8 +1,10 @@
 package transform
 
 import (
+	"context"
 	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,6 +16,7 @@ import (
 	"github.com/comerc/budva43/app/engine_config"
 	"github.com/comerc/budva43/app/log"
 	"github.com/comerc/budva43/app/testing/spylog"
+	"github.com/comerc/budva43/app/util"
 	"github.com/comerc/budva43/service/transform/mocks"
 )
 
@@ -211,7 +214,7 @@ func Test(t *testing.T) {
 			t.Parallel()
 
 			transformService := test.setup(t)
-			transformService.Transform(test.formattedText, test.withSources, test.src, test.dstChatId, test.prevMessageId, config.Engine)
+			transformService.Transform(context.Background(), test.formattedText, test.withSources, test.src, test.dstChatId, test.prevMessageId, config.Engine)
 
 			assert.Equal(t, test.expectedText, test.formattedText.Text)
 			assert.Equal(t, test.expectedEntities, test.formattedText.Entities)
@@ -790,7 +793,7 @@ func Test_replaceMyselfLinks(t *testing.T) {
 						Id:     123,
 					},
 				}, nil)
-				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]string{})
+				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]*domain.ChatMessage{})
 				return New(telegramRepo, storageService, nil)
 			},
 		},
@@ -829,8 +832,8 @@ func Test_replaceMyselfLinks(t *testing.T) {
 						Id:     123,
 					},
 				}, nil)
-				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]string{
-					"rule1:-10119:789", // другое назначение (не dstChatId -10114)
+				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]*domain.ChatMessage{
+					{ForwardRuleId: "rule1", ChatId: -10119, MessageId: 789}, // другое назначение (не dstChatId -10114)
 				})
 				return New(telegramRepo, storageService, nil)
 			},
@@ -870,8 +873,8 @@ func Test_replaceMyselfLinks(t *testing.T) {
 						Id:     123,
 					},
 				}, nil)
-				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]string{
-					"rule1:-10114:789",
+				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]*domain.ChatMessage{
+					{ForwardRuleId: "rule1", ChatId: -10114, MessageId: 789},
 				})
 				storageService.EXPECT().GetNewMessageId(int64(-10114), int64(789)).Return(int64(0))
 				return New(telegramRepo, storageService, nil)
@@ -912,8 +915,8 @@ func Test_replaceMyselfLinks(t *testing.T) {
 						Id:     123,
 					},
 				}, nil)
-				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]string{
-					"rule1:-10114:789",
+				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]*domain.ChatMessage{
+					{ForwardRuleId: "rule1", ChatId: -10114, MessageId: 789},
 				})
 				storageService.EXPECT().GetNewMessageId(int64(-10114), int64(789)).Return(int64(456))
 				telegramRepo.EXPECT().GetMessageLink(&client.GetMessageLinkRequest{
@@ -960,8 +963,8 @@ func Test_replaceMyselfLinks(t *testing.T) {
 						Id:     123,
 					},
 				}, nil)
-				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]string{
-					"rule1:-10114:789",
+				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]*domain.ChatMessage{
+					{ForwardRuleId: "rule1", ChatId: -10114, MessageId: 789},
 				})
 				storageService.EXPECT().GetNewMessageId(int64(-10114), int64(789)).Return(int64(456))
 				telegramRepo.EXPECT().GetMessageLink(&client.GetMessageLinkRequest{
@@ -1014,8 +1017,8 @@ func Test_replaceMyselfLinks(t *testing.T) {
 						Id:     123,
 					},
 				}, nil)
-				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]string{
-					"rule1:-10114:789",
+				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]*domain.ChatMessage{
+					{ForwardRuleId: "rule1", ChatId: -10114, MessageId: 789},
 				})
 				storageService.EXPECT().GetNewMessageId(int64(-10114), int64(789)).Return(int64(456))
 				telegramRepo.EXPECT().GetMessageLink(&client.GetMessageLinkRequest{
@@ -1065,7 +1068,7 @@ func Test_replaceMyselfLinks(t *testing.T) {
 						Id:     123,
 					},
 				}, nil)
-				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]string{})
+				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]*domain.ChatMessage{})
 				telegramRepo.EXPECT().ParseTextEntities(&client.ParseTextEntitiesRequest{
 					Text: domain.DELETED_LINK,
 					ParseMode: &client.TextParseModeMarkdown{
@@ -1119,8 +1122,8 @@ func Test_replaceMyselfLinks(t *testing.T) {
 						Id:     123,
 					},
 				}, nil)
-				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]string{
-					"rule1:-10114:789",
+				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]*domain.ChatMessage{
+					{ForwardRuleId: "rule1", ChatId: -10114, MessageId: 789},
 				})
 				storageService.EXPECT().GetNewMessageId(int64(-10114), int64(789)).Return(int64(456))
 				telegramRepo.EXPECT().GetMessageLink(&client.GetMessageLinkRequest{
@@ -1245,8 +1248,8 @@ func Test_replaceMyselfLinks(t *testing.T) {
 						Id:     123,
 					},
 				}, nil)
-				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]string{
-					"rule1:-10114:789",
+				storageService.EXPECT().GetCopiedMessageIds(int64(-10100), int64(123)).Return([]*domain.ChatMessage{
+					{ForwardRuleId: "rule1", ChatId: -10114, MessageId: 789},
 				})
 				storageService.EXPECT().GetNewMessageId(int64(-10114), int64(789)).Return(int64(456))
 				telegramRepo.EXPECT().GetMessageLink(&client.GetMessageLinkRequest{
@@ -3204,3 +3207,502 @@ func TestAddNextLink(t *testing.T) {
 		})
 	}
 }
+
+func TestAddRevisionMark(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		revision      int
+		forwardRule   *domain.ForwardRule
+		markdownText  string
+		parsedText    string
+		expectedText  string
+		expectedError error
+	}{
+		{
+			name:     "default_title",
+			revision: 2,
+			forwardRule: &domain.ForwardRule{
+				Id:        "Rule1",
+				Revisions: &domain.Revisions{Run: true},
+			},
+			markdownText: "Revision \\#2",
+			parsedText:   "Revision #2",
+			expectedText: "test message\n\nRevision #2",
+		},
+		{
+			name:     "custom_title",
+			revision: 3,
+			forwardRule: &domain.ForwardRule{
+				Id: "Rule1",
+				Revisions: &domain.Revisions{
+					Run:   true,
+					Title: "Редакция %d",
+				},
+			},
+			markdownText: "Редакция 3",
+			parsedText:   "Редакция 3",
+			expectedText: "test message\n\nРедакция 3",
+		},
+		{
+			name:     "revisions_is_nil",
+			revision: 2,
+			forwardRule: &domain.ForwardRule{
+				Id: "Rule1",
+			},
+			expectedText:  "test message",
+			expectedError: log.NewError("forwardRule.Revisions is not run"),
+		},
+		{
+			name:     "revisions_is_not_run",
+			revision: 2,
+			forwardRule: &domain.ForwardRule{
+				Id:        "Rule1",
+				Revisions: &domain.Revisions{Run: false},
+			},
+			expectedText:  "test message",
+			expectedError: log.NewError("forwardRule.Revisions is not run"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			telegramRepo := mocks.NewTelegramRepo(t)
+			if test.markdownText != "" {
+				telegramRepo.EXPECT().ParseTextEntities(&client.ParseTextEntitiesRequest{
+					Text: test.markdownText,
+					ParseMode: &client.TextParseModeMarkdown{
+						Version: 2,
+					},
+				}).Return(&client.FormattedText{
+					Text:     test.parsedText,
+					Entities: []*client.TextEntity{},
+				}, nil)
+			}
+
+			var transformService *Service
+			spylogHandler := spylog.GetHandler(t.Name(), func() {
+				transformService = New(telegramRepo, nil, nil)
+			})
+
+			formattedText := &client.FormattedText{
+				Text:     "test message",
+				Entities: []*client.TextEntity{},
+			}
+			transformService.AddRevisionMark(formattedText, test.revision, test.forwardRule)
+
+			if test.expectedError != nil {
+				records := spylogHandler.GetRecords()
+				require.Equal(t, 1, len(records))
+				assert.Equal(t, test.expectedError.Error(), records[0].Message)
+			}
+
+			assert.Equal(t, test.expectedText, formattedText.Text)
+		})
+	}
+}
+
+func TestFormatEditDiff(t *testing.T) {
+	t.Parallel()
+
+	const dstChatId = int64(-10121)
+
+	tests := []struct {
+		name             string
+		oldText          string
+		newText          string
+		destination      *domain.Destination
+		markdownText     string
+		expectedText     string
+		expectedEntities []*client.TextEntity
+		expectedError    error
+	}{
+		{
+			name:    "default_title",
+			oldText: "one two three four five six seven eight nine",
+			newText: "one two three four 5 six seven eight nine",
+			destination: &domain.Destination{
+				EditDiff: &domain.EditDiff{Run: true},
+			},
+			markdownText: "*Edited:*",
+			expectedText: "Edited:\n\n… two three four five 5 six seven eight …",
+			expectedEntities: []*client.TextEntity{
+				{Offset: 0, Length: 7, Type: &client.TextEntityTypeBold{}},
+				{Offset: 26, Length: 4, Type: &client.TextEntityTypeStrikethrough{}},
+				{Offset: 31, Length: 1, Type: &client.TextEntityTypeUnderline{}},
+			},
+		},
+		{
+			name:    "custom_title",
+			oldText: "привет",
+			newText: "привет мир",
+			destination: &domain.Destination{
+				EditDiff: &domain.EditDiff{
+					Run:   true,
+					Title: "*Правка:*",
+				},
+			},
+			markdownText: "*Правка:*",
+			expectedText: "Правка:\n\nпривет мир",
+			expectedEntities: []*client.TextEntity{
+				{Offset: 0, Length: 7, Type: &client.TextEntityTypeBold{}},
+				{Offset: 16, Length: 3, Type: &client.TextEntityTypeUnderline{}},
+			},
+		},
+		{
+			name:    "without_changes",
+			oldText: "same text",
+			newText: "same  text",
+			destination: &domain.Destination{
+				EditDiff: &domain.EditDiff{Run: true},
+			},
+		},
+		{
+			name:          "destination_is_nil",
+			oldText:       "old",
+			newText:       "new",
+			expectedError: log.NewError("destination.EditDiff is not run"),
+		},
+		{
+			name:    "edit_diff_is_not_run",
+			oldText: "old",
+			newText: "new",
+			destination: &domain.Destination{
+				EditDiff: &domain.EditDiff{Run: false},
+			},
+			expectedError: log.NewError("destination.EditDiff is not run"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			telegramRepo := mocks.NewTelegramRepo(t)
+			if test.markdownText != "" {
+				parsedText := strings.Trim(test.markdownText, "*")
+				telegramRepo.EXPECT().ParseTextEntities(&client.ParseTextEntitiesRequest{
+					Text: test.markdownText,
+					ParseMode: &client.TextParseModeMarkdown{
+						Version: 2,
+					},
+				}).Return(&client.FormattedText{
+					Text: parsedText,
+					Entities: []*client.TextEntity{
+						{
+							Offset: 0,
+							Length: int32(len(util.EncodeToUTF16(parsedText))), //nolint:gosec
+							Type:   &client.TextEntityTypeBold{},
+						},
+					},
+				}, nil)
+			}
+
+			var transformService *Service
+			spylogHandler := spylog.GetHandler(t.Name(), func() {
+				transformService = New(telegramRepo, nil, nil)
+			})
+
+			engineConfig := &domain.EngineConfig{
+				Destinations: map[domain.ChatId]*domain.Destination{},
+			}
+			if test.destination != nil {
+				engineConfig.Destinations[dstChatId] = test.destination
+			}
+			formattedText := transformService.FormatEditDiff(test.oldText, test.newText, dstChatId, engineConfig)
+
+			if test.expectedError != nil {
+				records := spylogHandler.GetRecords()
+				require.Equal(t, 1, len(records))
+				assert.Equal(t, test.expectedError.Error(), records[0].Message)
+			}
+
+			if test.expectedText == "" {
+				assert.Nil(t, formattedText)
+				return
+			}
+			require.NotNil(t, formattedText)
+			assert.Equal(t, test.expectedText, formattedText.Text)
+			assert.Equal(t, test.expectedEntities, formattedText.Entities)
+		})
+	}
+}
+
+func TestAddProtectedContentNote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		fallback     domain.FallbackPolicy
+		expectedText string
+	}{
+		{
+			name:         "fallback_note",
+			fallback:     domain.FallbackNote,
+			expectedText: "test message\n\n[protected content]",
+		},
+		{
+			name:         "fallback_check",
+			fallback:     domain.FallbackCheck,
+			expectedText: "test message\n\n[protected content]",
+		},
+		{
+			name:         "fallback_text",
+			fallback:     domain.FallbackText,
+			expectedText: "test message",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			telegramRepo := mocks.NewTelegramRepo(t)
+			if test.fallback != domain.FallbackText {
+				telegramRepo.EXPECT().ParseTextEntities(&client.ParseTextEntitiesRequest{
+					Text: domain.PROTECTED_CONTENT_NOTE,
+					ParseMode: &client.TextParseModeMarkdown{
+						Version: 2,
+					},
+				}).Return(&client.FormattedText{
+					Text:     "[protected content]",
+					Entities: []*client.TextEntity{},
+				}, nil)
+			}
+
+			transformService := New(telegramRepo, nil, nil)
+
+			formattedText := &client.FormattedText{
+				Text:     "test message",
+				Entities: []*client.TextEntity{},
+			}
+			forwardRule := &domain.ForwardRule{
+				Id:       "Rule1",
+				Fallback: test.fallback,
+			}
+			transformService.AddProtectedContentNote(formattedText, forwardRule)
+
+			assert.Equal(t, test.expectedText, formattedText.Text)
+		})
+	}
+}
+
+func TestAddTombstone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		text             string
+		tombstone        *domain.Tombstone
+		markdownText     string
+		parsedText       string
+		expectedText     string
+		expectedEntities []*client.TextEntity
+	}{
+		{
+			name:         "default_marker",
+			text:         "test message",
+			markdownText: domain.TOMBSTONE_TITLE,
+			parsedText:   "🗑 deleted by source",
+			expectedText: "🗑 deleted by source\n\ntest message",
+			expectedEntities: []*client.TextEntity{
+				{Offset: 0, Length: 3, Type: &client.TextEntityTypeItalic{}},
+				{Offset: 22, Length: 4, Type: &client.TextEntityTypeBold{}},
+			},
+		},
+		{
+			name: "custom_marker_with_empty_text",
+			tombstone: &domain.Tombstone{
+				Title: "*Удалено*",
+			},
+			markdownText: "*Удалено*",
+			parsedText:   "Удалено",
+			expectedText: "Удалено",
+			expectedEntities: []*client.TextEntity{
+				{Offset: 0, Length: 3, Type: &client.TextEntityTypeItalic{}},
+			},
+		},
+		{
+			name: "strike",
+			text: "test message",
+			tombstone: &domain.Tombstone{
+				Mode: domain.TombstoneStrike,
+			},
+			expectedText: "test message",
+			expectedEntities: []*client.TextEntity{
+				{Offset: 0, Length: 4, Type: &client.TextEntityTypeBold{}},
+				{Offset: 0, Length: 12, Type: &client.TextEntityTypeStrikethrough{}},
+			},
+		},
+		{
+			name: "strike_with_empty_text",
+			tombstone: &domain.Tombstone{
+				Mode: domain.TombstoneStrike,
+			},
+			expectedText:     "",
+			expectedEntities: []*client.TextEntity{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			telegramRepo := mocks.NewTelegramRepo(t)
+			if test.markdownText != "" {
+				telegramRepo.EXPECT().ParseTextEntities(&client.ParseTextEntitiesRequest{
+					Text: test.markdownText,
+					ParseMode: &client.TextParseModeMarkdown{
+						Version: 2,
+					},
+				}).Return(&client.FormattedText{
+					Text: test.parsedText,
+					Entities: []*client.TextEntity{
+						{Offset: 0, Length: 3, Type: &client.TextEntityTypeItalic{}},
+					},
+				}, nil)
+			}
+
+			transformService := New(telegramRepo, nil, nil)
+
+			formattedText := &client.FormattedText{
+				Text:     test.text,
+				Entities: []*client.TextEntity{},
+			}
+			if test.text != "" {
+				formattedText.Entities = append(formattedText.Entities,
+					&client.TextEntity{Offset: 0, Length: 4, Type: &client.TextEntityTypeBold{}})
+			}
+			forwardRule := &domain.ForwardRule{
+				Id:        "Rule1",
+				Tombstone: test.tombstone,
+			}
+			transformService.AddTombstone(formattedText, forwardRule)
+
+			assert.Equal(t, test.expectedText, formattedText.Text)
+			assert.Equal(t, test.expectedEntities, formattedText.Entities)
+		})
+	}
+}
+
+func TestAddReplyContext(t *testing.T) {
+	t.Parallel()
+
+	const link = "https://t.me/c/1/2"
+
+	tests := []struct {
+		name             string
+		text             string
+		quote            string
+		link             string
+		replyContext     *domain.ReplyContext
+		markdownText     string
+		parsedText       string
+		expectedText     string
+		expectedEntities []*client.TextEntity
+	}{
+		{
+			name:         "quote_with_link",
+			text:         "test message",
+			quote:        "parent",
+			link:         link,
+			markdownText: "[" + domain.REPLY_CONTEXT_TITLE + "](" + link + ")",
+			parsedText:   "↩️ in reply to",
+			expectedText: "↩️ in reply to\nparent\n\ntest message",
+			expectedEntities: []*client.TextEntity{
+				{Offset: 0, Length: 3, Type: &client.TextEntityTypeItalic{}},
+				{Offset: 15, Length: 6, Type: &client.TextEntityTypeBlockQuote{}},
+				{Offset: 23, Length: 4, Type: &client.TextEntityTypeBold{}},
+			},
+		},
+		{
+			name:  "link",
+			text:  "test message",
+			quote: "parent",
+			link:  link,
+			replyContext: &domain.ReplyContext{
+				Mode:  domain.ReplyContextLink,
+				Title: "Ответ",
+			},
+			markdownText: "[Ответ](" + link + ")",
+			parsedText:   "Ответ",
+			expectedText: "Ответ\n\ntest message",
+			expectedEntities: []*client.TextEntity{
+				{Offset: 0, Length: 3, Type: &client.TextEntityTypeItalic{}},
+				{Offset: 7, Length: 4, Type: &client.TextEntityTypeBold{}},
+			},
+		},
+		{
+			name:  "link_without_link",
+			text:  "test message",
+			quote: "parent",
+			replyContext: &domain.ReplyContext{
+				Mode:  domain.ReplyContextLink,
+				Title: "Ответ",
+			},
+			markdownText: "Ответ",
+			parsedText:   "Ответ",
+			expectedText: "Ответ\nparent\n\ntest message",
+			expectedEntities: []*client.TextEntity{
+				{Offset: 0, Length: 3, Type: &client.TextEntityTypeItalic{}},
+				{Offset: 6, Length: 6, Type: &client.TextEntityTypeBlockQuote{}},
+				{Offset: 14, Length: 4, Type: &client.TextEntityTypeBold{}},
+			},
+		},
+		{
+			name:  "truncated_quote_with_empty_text",
+			quote: " long parent message ",
+			replyContext: &domain.ReplyContext{
+				Length: 4,
+			},
+			markdownText: domain.REPLY_CONTEXT_TITLE,
+			parsedText:   "↩️ in reply to",
+			expectedText: "↩️ in reply to\nlong…",
+			expectedEntities: []*client.TextEntity{
+				{Offset: 0, Length: 3, Type: &client.TextEntityTypeItalic{}},
+				{Offset: 15, Length: 5, Type: &client.TextEntityTypeBlockQuote{}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			telegramRepo := mocks.NewTelegramRepo(t)
+			telegramRepo.EXPECT().ParseTextEntities(&client.ParseTextEntitiesRequest{
+				Text: test.markdownText,
+				ParseMode: &client.TextParseModeMarkdown{
+					Version: 2,
+				},
+			}).Return(&client.FormattedText{
+				Text: test.parsedText,
+				Entities: []*client.TextEntity{
+					{Offset: 0, Length: 3, Type: &client.TextEntityTypeItalic{}},
+				},
+			}, nil)
+
+			transformService := New(telegramRepo, nil, nil)
+
+			formattedText := &client.FormattedText{
+				Text:     test.text,
+				Entities: []*client.TextEntity{},
+			}
+			if test.text != "" {
+				formattedText.Entities = append(formattedText.Entities,
+					&client.TextEntity{Offset: 0, Length: 4, Type: &client.TextEntityTypeBold{}})
+			}
+			forwardRule := &domain.ForwardRule{
+				Id:           "Rule1",
+				ReplyContext: test.replyContext,
+			}
+			transformService.AddReplyContext(formattedText, test.quote, test.link, forwardRule)
+
+			assert.Equal(t, test.expectedText, formattedText.Text)
+			assert.Equal(t, test.expectedEntities, formattedText.Entities)
+		})
+	}
+}
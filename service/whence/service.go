@@ -0,0 +1,95 @@
+package whence
+
+import (
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/log"
+)
+
+//go:generate mockery --name=telegramRepo --exported
+type telegramRepo interface {
+	// tdlibClient methods
+	GetMessageLinkInfo(*client.GetMessageLinkInfoRequest) (*client.MessageLinkInfo, error)
+	GetMessageLink(*client.GetMessageLinkRequest) (*client.MessageLink, error)
+}
+
+//go:generate mockery --name=storageService --exported
+type storageService interface {
+	GetOriginMessageId(dstChatId, dstMessageId int64) *domain.ChatMessage
+}
+
+// Service определяет происхождение сообщений в получателях (для модерации и жалоб)
+type Service struct {
+	log *log.Logger
+	//
+	telegramRepo   telegramRepo
+	storageService storageService
+}
+
+// New создает новый экземпляр сервиса происхождения сообщений
+func New(
+	telegramRepo telegramRepo,
+	storageService storageService,
+) *Service {
+	return &Service{
+		log: log.NewLogger(),
+		//
+		telegramRepo:   telegramRepo,
+		storageService: storageService,
+	}
+}
+
+// Lookup возвращает исходное сообщение для сообщения в получателе по ссылке на него
+func (s *Service) Lookup(link string) (*domain.Whence, error) {
+	messageLinkInfo, err := s.telegramRepo.GetMessageLinkInfo(&client.GetMessageLinkInfoRequest{
+		Url: link,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dst := messageLinkInfo.Message
+	if dst == nil {
+		return nil, log.NewError("message not found by link", "link", link)
+	}
+
+	result := &domain.Whence{}
+	if fromMessage := s.storageService.GetOriginMessageId(dst.ChatId, dst.Id); fromMessage != nil {
+		result.ForwardRuleId = fromMessage.ForwardRuleId
+		result.ChatId = fromMessage.ChatId
+		result.MessageId = fromMessage.MessageId
+	} else if origin := getForwardOrigin(dst); origin != nil {
+		// пересланное без сохранения связи сообщение содержит сведения об оригинале
+		result.ChatId = origin.ChatId
+		result.MessageId = origin.MessageId
+	} else {
+		return nil, log.NewError("origin not found",
+			"chatId", dst.ChatId,
+			"messageId", dst.Id,
+		)
+	}
+
+	// ссылки недоступны для сообщений из приватных чатов и обычных групп
+	messageLink, err := s.telegramRepo.GetMessageLink(&client.GetMessageLinkRequest{
+		ChatId:    result.ChatId,
+		MessageId: result.MessageId,
+	})
+	s.log.ErrorOrDebug(err, "",
+		"chatId", result.ChatId,
+		"messageId", result.MessageId,
+	)
+	if err == nil {
+		result.Link = messageLink.Link
+	}
+
+	return result, nil
+}
+
+// getForwardOrigin возвращает канал-оригинал пересланного сообщения
+func getForwardOrigin(message *client.Message) *client.MessageOriginChannel {
+	if message.ForwardInfo == nil {
+		return nil
+	}
+	origin, _ := message.ForwardInfo.Origin.(*client.MessageOriginChannel)
+	return origin
+}
@@ -0,0 +1,94 @@
+package whence
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/service/whence/mocks"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	const link = "https://t.me/c/2/20"
+
+	tests := []struct {
+		name     string
+		dst      *client.Message
+		origin   *domain.ChatMessage
+		srcLink  string
+		expected *domain.Whence
+		isError  bool
+	}{
+		{
+			name:    "indexed",
+			dst:     &client.Message{Id: 20, ChatId: -1002},
+			origin:  &domain.ChatMessage{ForwardRuleId: "Rule1", ChatId: -1001, MessageId: 10},
+			srcLink: "https://t.me/c/1/10",
+			expected: &domain.Whence{
+				ForwardRuleId: "Rule1",
+				ChatId:        -1001,
+				MessageId:     10,
+				Link:          "https://t.me/c/1/10",
+			},
+		},
+		{
+			name: "forward_info",
+			dst: &client.Message{
+				Id:     20,
+				ChatId: -1002,
+				ForwardInfo: &client.MessageForwardInfo{
+					Origin: &client.MessageOriginChannel{ChatId: -1001, MessageId: 10},
+				},
+			},
+			expected: &domain.Whence{
+				ChatId:    -1001,
+				MessageId: 10,
+			},
+		},
+		{
+			name:    "not_found",
+			dst:     &client.Message{Id: 20, ChatId: -1002},
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			telegramRepo := mocks.NewTelegramRepo(t)
+			storageService := mocks.NewStorageService(t)
+
+			telegramRepo.EXPECT().GetMessageLinkInfo(&client.GetMessageLinkInfoRequest{
+				Url: link,
+			}).Return(&client.MessageLinkInfo{Message: test.dst}, nil)
+			storageService.EXPECT().GetOriginMessageId(test.dst.ChatId, test.dst.Id).Return(test.origin)
+			if test.expected != nil {
+				call := telegramRepo.EXPECT().GetMessageLink(&client.GetMessageLinkRequest{
+					ChatId:    test.expected.ChatId,
+					MessageId: test.expected.MessageId,
+				})
+				if test.srcLink != "" {
+					call.Return(&client.MessageLink{Link: test.srcLink}, nil)
+				} else {
+					call.Return(nil, errors.New("message links are unavailable"))
+				}
+			}
+
+			s := New(telegramRepo, storageService)
+			result, err := s.Lookup(link)
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
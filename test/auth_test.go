@@ -104,6 +104,9 @@ func TestAuth(t *testing.T) {
 		telegramRepo,
 		termRepo,
 		authService,
+		nil,
+		nil,
+		nil,
 	).WithPhoneNumber("")
 	err = termTransport.StartContext(ctx, cancel)
 	require.NoError(t, err)
@@ -21,6 +21,63 @@ func (_m *FacadeGRPC) EXPECT() *FacadeGRPC_Expecter {
 	return &FacadeGRPC_Expecter{mock: &_m.Mock}
 }
 
+// CreateBackup provides a mock function with no fields
+func (_m *FacadeGRPC) CreateBackup() (*dto.Backup, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBackup")
+	}
+
+	var r0 *dto.Backup
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*dto.Backup, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *dto.Backup); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Backup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FacadeGRPC_CreateBackup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBackup'
+type FacadeGRPC_CreateBackup_Call struct {
+	*mock.Call
+}
+
+// CreateBackup is a helper method to define mock.On call
+func (_e *FacadeGRPC_Expecter) CreateBackup() *FacadeGRPC_CreateBackup_Call {
+	return &FacadeGRPC_CreateBackup_Call{Call: _e.mock.On("CreateBackup")}
+}
+
+func (_c *FacadeGRPC_CreateBackup_Call) Run(run func()) *FacadeGRPC_CreateBackup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *FacadeGRPC_CreateBackup_Call) Return(_a0 *dto.Backup, _a1 error) *FacadeGRPC_CreateBackup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FacadeGRPC_CreateBackup_Call) RunAndReturn(run func() (*dto.Backup, error)) *FacadeGRPC_CreateBackup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteMessages provides a mock function with given fields: chatId, messageIds
 func (_m *FacadeGRPC) DeleteMessages(chatId int64, messageIds []int64) error {
 	ret := _m.Called(chatId, messageIds)
@@ -115,6 +172,63 @@ func (_c *FacadeGRPC_ForwardMessage_Call) RunAndReturn(run func(int64, int64) er
 	return _c
 }
 
+// GetBackups provides a mock function with no fields
+func (_m *FacadeGRPC) GetBackups() ([]*dto.Backup, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBackups")
+	}
+
+	var r0 []*dto.Backup
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*dto.Backup, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*dto.Backup); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.Backup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FacadeGRPC_GetBackups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBackups'
+type FacadeGRPC_GetBackups_Call struct {
+	*mock.Call
+}
+
+// GetBackups is a helper method to define mock.On call
+func (_e *FacadeGRPC_Expecter) GetBackups() *FacadeGRPC_GetBackups_Call {
+	return &FacadeGRPC_GetBackups_Call{Call: _e.mock.On("GetBackups")}
+}
+
+func (_c *FacadeGRPC_GetBackups_Call) Run(run func()) *FacadeGRPC_GetBackups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *FacadeGRPC_GetBackups_Call) Return(_a0 []*dto.Backup, _a1 error) *FacadeGRPC_GetBackups_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FacadeGRPC_GetBackups_Call) RunAndReturn(run func() ([]*dto.Backup, error)) *FacadeGRPC_GetBackups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetChatHistory provides a mock function with given fields: chatId, fromMessageId, offset, limit
 func (_m *FacadeGRPC) GetChatHistory(chatId int64, fromMessageId int64, offset int32, limit int32) ([]*dto.Message, error) {
 	ret := _m.Called(chatId, fromMessageId, offset, limit)
@@ -176,6 +290,64 @@ func (_c *FacadeGRPC_GetChatHistory_Call) RunAndReturn(run func(int64, int64, in
 	return _c
 }
 
+// GetExplain provides a mock function with given fields: link
+func (_m *FacadeGRPC) GetExplain(link string) (*dto.Journal, error) {
+	ret := _m.Called(link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExplain")
+	}
+
+	var r0 *dto.Journal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*dto.Journal, error)); ok {
+		return rf(link)
+	}
+	if rf, ok := ret.Get(0).(func(string) *dto.Journal); ok {
+		r0 = rf(link)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Journal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FacadeGRPC_GetExplain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExplain'
+type FacadeGRPC_GetExplain_Call struct {
+	*mock.Call
+}
+
+// GetExplain is a helper method to define mock.On call
+//   - link string
+func (_e *FacadeGRPC_Expecter) GetExplain(link interface{}) *FacadeGRPC_GetExplain_Call {
+	return &FacadeGRPC_GetExplain_Call{Call: _e.mock.On("GetExplain", link)}
+}
+
+func (_c *FacadeGRPC_GetExplain_Call) Run(run func(link string)) *FacadeGRPC_GetExplain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *FacadeGRPC_GetExplain_Call) Return(_a0 *dto.Journal, _a1 error) *FacadeGRPC_GetExplain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FacadeGRPC_GetExplain_Call) RunAndReturn(run func(string) (*dto.Journal, error)) *FacadeGRPC_GetExplain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetMessage provides a mock function with given fields: chatId, messageId
 func (_m *FacadeGRPC) GetMessage(chatId int64, messageId int64) (*dto.Message, error) {
 	ret := _m.Called(chatId, messageId)
@@ -409,6 +581,182 @@ func (_c *FacadeGRPC_GetMessages_Call) RunAndReturn(run func(int64, []int64) ([]
 	return _c
 }
 
+// GetStats provides a mock function with given fields: filter
+func (_m *FacadeGRPC) GetStats(filter *dto.StatsFilter) ([]*dto.Stats, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
+	}
+
+	var r0 []*dto.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*dto.StatsFilter) ([]*dto.Stats, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*dto.StatsFilter) []*dto.Stats); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*dto.StatsFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FacadeGRPC_GetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStats'
+type FacadeGRPC_GetStats_Call struct {
+	*mock.Call
+}
+
+// GetStats is a helper method to define mock.On call
+//   - filter *dto.StatsFilter
+func (_e *FacadeGRPC_Expecter) GetStats(filter interface{}) *FacadeGRPC_GetStats_Call {
+	return &FacadeGRPC_GetStats_Call{Call: _e.mock.On("GetStats", filter)}
+}
+
+func (_c *FacadeGRPC_GetStats_Call) Run(run func(filter *dto.StatsFilter)) *FacadeGRPC_GetStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*dto.StatsFilter))
+	})
+	return _c
+}
+
+func (_c *FacadeGRPC_GetStats_Call) Return(_a0 []*dto.Stats, _a1 error) *FacadeGRPC_GetStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FacadeGRPC_GetStats_Call) RunAndReturn(run func(*dto.StatsFilter) ([]*dto.Stats, error)) *FacadeGRPC_GetStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTopStats provides a mock function with given fields: filter, groupBy, limit
+func (_m *FacadeGRPC) GetTopStats(filter *dto.StatsFilter, groupBy string, limit int) ([]*dto.Stats, error) {
+	ret := _m.Called(filter, groupBy, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTopStats")
+	}
+
+	var r0 []*dto.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*dto.StatsFilter, string, int) ([]*dto.Stats, error)); ok {
+		return rf(filter, groupBy, limit)
+	}
+	if rf, ok := ret.Get(0).(func(*dto.StatsFilter, string, int) []*dto.Stats); ok {
+		r0 = rf(filter, groupBy, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*dto.StatsFilter, string, int) error); ok {
+		r1 = rf(filter, groupBy, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FacadeGRPC_GetTopStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTopStats'
+type FacadeGRPC_GetTopStats_Call struct {
+	*mock.Call
+}
+
+// GetTopStats is a helper method to define mock.On call
+//   - filter *dto.StatsFilter
+//   - groupBy string
+//   - limit int
+func (_e *FacadeGRPC_Expecter) GetTopStats(filter interface{}, groupBy interface{}, limit interface{}) *FacadeGRPC_GetTopStats_Call {
+	return &FacadeGRPC_GetTopStats_Call{Call: _e.mock.On("GetTopStats", filter, groupBy, limit)}
+}
+
+func (_c *FacadeGRPC_GetTopStats_Call) Run(run func(filter *dto.StatsFilter, groupBy string, limit int)) *FacadeGRPC_GetTopStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*dto.StatsFilter), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *FacadeGRPC_GetTopStats_Call) Return(_a0 []*dto.Stats, _a1 error) *FacadeGRPC_GetTopStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FacadeGRPC_GetTopStats_Call) RunAndReturn(run func(*dto.StatsFilter, string, int) ([]*dto.Stats, error)) *FacadeGRPC_GetTopStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWhence provides a mock function with given fields: link
+func (_m *FacadeGRPC) GetWhence(link string) (*dto.Whence, error) {
+	ret := _m.Called(link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWhence")
+	}
+
+	var r0 *dto.Whence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*dto.Whence, error)); ok {
+		return rf(link)
+	}
+	if rf, ok := ret.Get(0).(func(string) *dto.Whence); ok {
+		r0 = rf(link)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Whence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FacadeGRPC_GetWhence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWhence'
+type FacadeGRPC_GetWhence_Call struct {
+	*mock.Call
+}
+
+// GetWhence is a helper method to define mock.On call
+//   - link string
+func (_e *FacadeGRPC_Expecter) GetWhence(link interface{}) *FacadeGRPC_GetWhence_Call {
+	return &FacadeGRPC_GetWhence_Call{Call: _e.mock.On("GetWhence", link)}
+}
+
+func (_c *FacadeGRPC_GetWhence_Call) Run(run func(link string)) *FacadeGRPC_GetWhence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *FacadeGRPC_GetWhence_Call) Return(_a0 *dto.Whence, _a1 error) *FacadeGRPC_GetWhence_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FacadeGRPC_GetWhence_Call) RunAndReturn(run func(string) (*dto.Whence, error)) *FacadeGRPC_GetWhence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SendMessage provides a mock function with given fields: newMessage
 func (_m *FacadeGRPC) SendMessage(newMessage *dto.NewMessage) error {
 	ret := _m.Called(newMessage)
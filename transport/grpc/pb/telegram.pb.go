@@ -801,6 +801,913 @@ func (x *GetMessageLinkInfoRequest) GetLink() string {
 	return ""
 }
 
+type GetWhenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          string                 `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWhenceRequest) Reset() {
+	*x = GetWhenceRequest{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWhenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWhenceRequest) ProtoMessage() {}
+
+func (x *GetWhenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWhenceRequest.ProtoReflect.Descriptor instead.
+func (*GetWhenceRequest) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetWhenceRequest) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+type WhenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ForwardRuleId string                 `protobuf:"bytes,1,opt,name=forward_rule_id,json=forwardRuleId,proto3" json:"forward_rule_id,omitempty"`
+	ChatId        int64                  `protobuf:"varint,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     int64                  `protobuf:"varint,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Link          string                 `protobuf:"bytes,4,opt,name=link,proto3" json:"link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WhenceResponse) Reset() {
+	*x = WhenceResponse{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WhenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhenceResponse) ProtoMessage() {}
+
+func (x *WhenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhenceResponse.ProtoReflect.Descriptor instead.
+func (*WhenceResponse) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WhenceResponse) GetForwardRuleId() string {
+	if x != nil {
+		return x.ForwardRuleId
+	}
+	return ""
+}
+
+func (x *WhenceResponse) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *WhenceResponse) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *WhenceResponse) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+type GetExplainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          string                 `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"` // ссылка на исходное сообщение
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExplainRequest) Reset() {
+	*x = GetExplainRequest{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExplainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExplainRequest) ProtoMessage() {}
+
+func (x *GetExplainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExplainRequest.ProtoReflect.Descriptor instead.
+func (*GetExplainRequest) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetExplainRequest) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+// JournalDestination исход для получателя: ok | check | other | filtered | failed | deduped
+type JournalDestination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Outcome       string                 `protobuf:"bytes,2,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	MessageIds    []int64                `protobuf:"varint,3,rep,packed,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JournalDestination) Reset() {
+	*x = JournalDestination{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JournalDestination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JournalDestination) ProtoMessage() {}
+
+func (x *JournalDestination) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JournalDestination.ProtoReflect.Descriptor instead.
+func (*JournalDestination) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *JournalDestination) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *JournalDestination) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *JournalDestination) GetMessageIds() []int64 {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+func (x *JournalDestination) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// JournalRule решение правила: evaluated | other_thread | protected
+type JournalRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ForwardRuleId string                 `protobuf:"bytes,1,opt,name=forward_rule_id,json=forwardRuleId,proto3" json:"forward_rule_id,omitempty"`
+	Verdict       string                 `protobuf:"bytes,2,opt,name=verdict,proto3" json:"verdict,omitempty"`
+	FiltersMode   string                 `protobuf:"bytes,3,opt,name=filters_mode,json=filtersMode,proto3" json:"filters_mode,omitempty"`
+	Pattern       string                 `protobuf:"bytes,4,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Destinations  []*JournalDestination  `protobuf:"bytes,5,rep,name=destinations,proto3" json:"destinations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JournalRule) Reset() {
+	*x = JournalRule{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JournalRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JournalRule) ProtoMessage() {}
+
+func (x *JournalRule) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JournalRule.ProtoReflect.Descriptor instead.
+func (*JournalRule) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *JournalRule) GetForwardRuleId() string {
+	if x != nil {
+		return x.ForwardRuleId
+	}
+	return ""
+}
+
+func (x *JournalRule) GetVerdict() string {
+	if x != nil {
+		return x.Verdict
+	}
+	return ""
+}
+
+func (x *JournalRule) GetFiltersMode() string {
+	if x != nil {
+		return x.FiltersMode
+	}
+	return ""
+}
+
+func (x *JournalRule) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *JournalRule) GetDestinations() []*JournalDestination {
+	if x != nil {
+		return x.Destinations
+	}
+	return nil
+}
+
+type ExplainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     int64                  `protobuf:"varint,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Date          int64                  `protobuf:"varint,3,opt,name=date,proto3" json:"date,omitempty"` // unix time
+	Rules         []*JournalRule         `protobuf:"bytes,4,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainResponse) Reset() {
+	*x = ExplainResponse{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainResponse) ProtoMessage() {}
+
+func (x *ExplainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainResponse.ProtoReflect.Descriptor instead.
+func (*ExplainResponse) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ExplainResponse) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *ExplainResponse) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *ExplainResponse) GetDate() int64 {
+	if x != nil {
+		return x.Date
+	}
+	return 0
+}
+
+func (x *ExplainResponse) GetRules() []*JournalRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type Backup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // unix time
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Backup) Reset() {
+	*x = Backup{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Backup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Backup) ProtoMessage() {}
+
+func (x *Backup) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Backup.ProtoReflect.Descriptor instead.
+func (*Backup) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Backup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Backup) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Backup) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type CreateBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBackupRequest) Reset() {
+	*x = CreateBackupRequest{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBackupRequest) ProtoMessage() {}
+
+func (x *CreateBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBackupRequest.ProtoReflect.Descriptor instead.
+func (*CreateBackupRequest) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{22}
+}
+
+type BackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backup        *Backup                `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupResponse) Reset() {
+	*x = BackupResponse{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupResponse) ProtoMessage() {}
+
+func (x *BackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupResponse.ProtoReflect.Descriptor instead.
+func (*BackupResponse) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BackupResponse) GetBackup() *Backup {
+	if x != nil {
+		return x.Backup
+	}
+	return nil
+}
+
+type GetBackupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBackupsRequest) Reset() {
+	*x = GetBackupsRequest{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBackupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupsRequest) ProtoMessage() {}
+
+func (x *GetBackupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackupsRequest.ProtoReflect.Descriptor instead.
+func (*GetBackupsRequest) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{24}
+}
+
+type BackupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backups       []*Backup              `protobuf:"bytes,1,rep,name=backups,proto3" json:"backups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupsResponse) Reset() {
+	*x = BackupsResponse{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupsResponse) ProtoMessage() {}
+
+func (x *BackupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupsResponse.ProtoReflect.Descriptor instead.
+func (*BackupsResponse) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *BackupsResponse) GetBackups() []*Backup {
+	if x != nil {
+		return x.Backups
+	}
+	return nil
+}
+
+// StatsFilter пустые поля - без отбора
+type StatsFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          int64                  `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"` // unix time, включительно
+	To            int64                  `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`     // unix time, не включительно; 0 - до текущего часа включительно
+	ForwardRuleId string                 `protobuf:"bytes,3,opt,name=forward_rule_id,json=forwardRuleId,proto3" json:"forward_rule_id,omitempty"`
+	SrcChatId     int64                  `protobuf:"varint,4,opt,name=src_chat_id,json=srcChatId,proto3" json:"src_chat_id,omitempty"`
+	DstChatId     int64                  `protobuf:"varint,5,opt,name=dst_chat_id,json=dstChatId,proto3" json:"dst_chat_id,omitempty"`
+	Outcome       string                 `protobuf:"bytes,6,opt,name=outcome,proto3" json:"outcome,omitempty"` // ok | check | other | filtered | failed | deduped | protected
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsFilter) Reset() {
+	*x = StatsFilter{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsFilter) ProtoMessage() {}
+
+func (x *StatsFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsFilter.ProtoReflect.Descriptor instead.
+func (*StatsFilter) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *StatsFilter) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *StatsFilter) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *StatsFilter) GetForwardRuleId() string {
+	if x != nil {
+		return x.ForwardRuleId
+	}
+	return ""
+}
+
+func (x *StatsFilter) GetSrcChatId() int64 {
+	if x != nil {
+		return x.SrcChatId
+	}
+	return 0
+}
+
+func (x *StatsFilter) GetDstChatId() int64 {
+	if x != nil {
+		return x.DstChatId
+	}
+	return 0
+}
+
+func (x *StatsFilter) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+type Stats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hour          int64                  `protobuf:"varint,1,opt,name=hour,proto3" json:"hour,omitempty"` // unix time начала часа
+	ForwardRuleId string                 `protobuf:"bytes,2,opt,name=forward_rule_id,json=forwardRuleId,proto3" json:"forward_rule_id,omitempty"`
+	SrcChatId     int64                  `protobuf:"varint,3,opt,name=src_chat_id,json=srcChatId,proto3" json:"src_chat_id,omitempty"`
+	DstChatId     int64                  `protobuf:"varint,4,opt,name=dst_chat_id,json=dstChatId,proto3" json:"dst_chat_id,omitempty"`
+	Outcome       string                 `protobuf:"bytes,5,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Count         int64                  `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Stats) GetHour() int64 {
+	if x != nil {
+		return x.Hour
+	}
+	return 0
+}
+
+func (x *Stats) GetForwardRuleId() string {
+	if x != nil {
+		return x.ForwardRuleId
+	}
+	return ""
+}
+
+func (x *Stats) GetSrcChatId() int64 {
+	if x != nil {
+		return x.SrcChatId
+	}
+	return 0
+}
+
+func (x *Stats) GetDstChatId() int64 {
+	if x != nil {
+		return x.DstChatId
+	}
+	return 0
+}
+
+func (x *Stats) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *Stats) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *StatsFilter           `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetStatsRequest) GetFilter() *StatsFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type GetTopStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *StatsFilter           `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	GroupBy       string                 `protobuf:"bytes,2,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"` // rule | source | destination | outcome
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                   // 0 - все
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopStatsRequest) Reset() {
+	*x = GetTopStatsRequest{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopStatsRequest) ProtoMessage() {}
+
+func (x *GetTopStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetTopStatsRequest) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetTopStatsRequest) GetFilter() *StatsFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *GetTopStatsRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+func (x *GetTopStatsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type StatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         []*Stats               `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsResponse) Reset() {
+	*x = StatsResponse{}
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponse) ProtoMessage() {}
+
+func (x *StatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StatsResponse) GetStats() []*Stats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
 type EmptyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -809,7 +1716,7 @@ type EmptyResponse struct {
 
 func (x *EmptyResponse) Reset() {
 	*x = EmptyResponse{}
-	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[15]
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -821,7 +1728,7 @@ func (x *EmptyResponse) String() string {
 func (*EmptyResponse) ProtoMessage() {}
 
 func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[15]
+	mi := &file_transport_grpc_pb_telegram_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -834,7 +1741,7 @@ func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmptyResponse.ProtoReflect.Descriptor instead.
 func (*EmptyResponse) Descriptor() ([]byte, []int) {
-	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{15}
+	return file_transport_grpc_pb_telegram_proto_rawDescGZIP(), []int{31}
 }
 
 var File_transport_grpc_pb_telegram_proto protoreflect.FileDescriptor
@@ -893,8 +1800,71 @@ const file_transport_grpc_pb_telegram_proto_rawDesc = "" +
 	"\x13MessageLinkResponse\x12\x12\n" +
 	"\x04link\x18\x01 \x01(\tR\x04link\"/\n" +
 	"\x19GetMessageLinkInfoRequest\x12\x12\n" +
-	"\x04link\x18\x01 \x01(\tR\x04link\"\x0f\n" +
-	"\rEmptyResponse2\x92\x05\n" +
+	"\x04link\x18\x01 \x01(\tR\x04link\"&\n" +
+	"\x10GetWhenceRequest\x12\x12\n" +
+	"\x04link\x18\x01 \x01(\tR\x04link\"\x84\x01\n" +
+	"\x0eWhenceResponse\x12&\n" +
+	"\x0fforward_rule_id\x18\x01 \x01(\tR\rforwardRuleId\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\x03R\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x03 \x01(\x03R\tmessageId\x12\x12\n" +
+	"\x04link\x18\x04 \x01(\tR\x04link\"'\n" +
+	"\x11GetExplainRequest\x12\x12\n" +
+	"\x04link\x18\x01 \x01(\tR\x04link\"~\n" +
+	"\x12JournalDestination\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x18\n" +
+	"\aoutcome\x18\x02 \x01(\tR\aoutcome\x12\x1f\n" +
+	"\vmessage_ids\x18\x03 \x03(\x03R\n" +
+	"messageIds\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xc8\x01\n" +
+	"\vJournalRule\x12&\n" +
+	"\x0fforward_rule_id\x18\x01 \x01(\tR\rforwardRuleId\x12\x18\n" +
+	"\averdict\x18\x02 \x01(\tR\averdict\x12!\n" +
+	"\ffilters_mode\x18\x03 \x01(\tR\vfiltersMode\x12\x18\n" +
+	"\apattern\x18\x04 \x01(\tR\apattern\x12:\n" +
+	"\fdestinations\x18\x05 \x03(\v2\x16.pb.JournalDestinationR\fdestinations\"\x84\x01\n" +
+	"\x0fExplainResponse\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\x03R\tmessageId\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\x03R\x04date\x12%\n" +
+	"\x05rules\x18\x04 \x03(\v2\x0f.pb.JournalRuleR\x05rules\"O\n" +
+	"\x06Backup\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\"\x15\n" +
+	"\x13CreateBackupRequest\"4\n" +
+	"\x0eBackupResponse\x12\"\n" +
+	"\x06backup\x18\x01 \x01(\v2\n" +
+	".pb.BackupR\x06backup\"\x13\n" +
+	"\x11GetBackupsRequest\"7\n" +
+	"\x0fBackupsResponse\x12$\n" +
+	"\abackups\x18\x01 \x03(\v2\n" +
+	".pb.BackupR\abackups\"\xb3\x01\n" +
+	"\vStatsFilter\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x03R\x02to\x12&\n" +
+	"\x0fforward_rule_id\x18\x03 \x01(\tR\rforwardRuleId\x12\x1e\n" +
+	"\vsrc_chat_id\x18\x04 \x01(\x03R\tsrcChatId\x12\x1e\n" +
+	"\vdst_chat_id\x18\x05 \x01(\x03R\tdstChatId\x12\x18\n" +
+	"\aoutcome\x18\x06 \x01(\tR\aoutcome\"\xb3\x01\n" +
+	"\x05Stats\x12\x12\n" +
+	"\x04hour\x18\x01 \x01(\x03R\x04hour\x12&\n" +
+	"\x0fforward_rule_id\x18\x02 \x01(\tR\rforwardRuleId\x12\x1e\n" +
+	"\vsrc_chat_id\x18\x03 \x01(\x03R\tsrcChatId\x12\x1e\n" +
+	"\vdst_chat_id\x18\x04 \x01(\x03R\tdstChatId\x12\x18\n" +
+	"\aoutcome\x18\x05 \x01(\tR\aoutcome\x12\x14\n" +
+	"\x05count\x18\x06 \x01(\x03R\x05count\":\n" +
+	"\x0fGetStatsRequest\x12'\n" +
+	"\x06filter\x18\x01 \x01(\v2\x0f.pb.StatsFilterR\x06filter\"n\n" +
+	"\x12GetTopStatsRequest\x12'\n" +
+	"\x06filter\x18\x01 \x01(\v2\x0f.pb.StatsFilterR\x06filter\x12\x19\n" +
+	"\bgroup_by\x18\x02 \x01(\tR\agroupBy\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"0\n" +
+	"\rStatsResponse\x12\x1f\n" +
+	"\x05stats\x18\x01 \x03(\v2\t.pb.StatsR\x05stats\"\x0f\n" +
+	"\rEmptyResponse2\xe8\a\n" +
 	"\n" +
 	"FacadeGRPC\x12;\n" +
 	"\vGetMessages\x12\x16.pb.GetMessagesRequest\x1a\x14.pb.MessagesResponse\x12A\n" +
@@ -907,7 +1877,15 @@ const file_transport_grpc_pb_telegram_proto_rawDesc = "" +
 	"\rUpdateMessage\x12\x18.pb.UpdateMessageRequest\x1a\x11.pb.EmptyResponse\x12>\n" +
 	"\x0eDeleteMessages\x12\x19.pb.DeleteMessagesRequest\x1a\x11.pb.EmptyResponse\x12D\n" +
 	"\x0eGetMessageLink\x12\x19.pb.GetMessageLinkRequest\x1a\x17.pb.MessageLinkResponse\x12H\n" +
-	"\x12GetMessageLinkInfo\x12\x1d.pb.GetMessageLinkInfoRequest\x1a\x13.pb.MessageResponseB-Z+github.com/comerc/budva43/transport/grpc/pbb\x06proto3"
+	"\x12GetMessageLinkInfo\x12\x1d.pb.GetMessageLinkInfoRequest\x1a\x13.pb.MessageResponse\x125\n" +
+	"\tGetWhence\x12\x14.pb.GetWhenceRequest\x1a\x12.pb.WhenceResponse\x128\n" +
+	"\n" +
+	"GetExplain\x12\x15.pb.GetExplainRequest\x1a\x13.pb.ExplainResponse\x12;\n" +
+	"\fCreateBackup\x12\x17.pb.CreateBackupRequest\x1a\x12.pb.BackupResponse\x128\n" +
+	"\n" +
+	"GetBackups\x12\x15.pb.GetBackupsRequest\x1a\x13.pb.BackupsResponse\x122\n" +
+	"\bGetStats\x12\x13.pb.GetStatsRequest\x1a\x11.pb.StatsResponse\x128\n" +
+	"\vGetTopStats\x12\x16.pb.GetTopStatsRequest\x1a\x11.pb.StatsResponseB-Z+github.com/comerc/budva43/transport/grpc/pbb\x06proto3"
 
 var (
 	file_transport_grpc_pb_telegram_proto_rawDescOnce sync.Once
@@ -921,7 +1899,7 @@ func file_transport_grpc_pb_telegram_proto_rawDescGZIP() []byte {
 	return file_transport_grpc_pb_telegram_proto_rawDescData
 }
 
-var file_transport_grpc_pb_telegram_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_transport_grpc_pb_telegram_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_transport_grpc_pb_telegram_proto_goTypes = []any{
 	(*NewMessage)(nil),                // 0: pb.NewMessage
 	(*Message)(nil),                   // 1: pb.Message
@@ -938,7 +1916,23 @@ var file_transport_grpc_pb_telegram_proto_goTypes = []any{
 	(*GetMessageLinkRequest)(nil),     // 12: pb.GetMessageLinkRequest
 	(*MessageLinkResponse)(nil),       // 13: pb.MessageLinkResponse
 	(*GetMessageLinkInfoRequest)(nil), // 14: pb.GetMessageLinkInfoRequest
-	(*EmptyResponse)(nil),             // 15: pb.EmptyResponse
+	(*GetWhenceRequest)(nil),          // 15: pb.GetWhenceRequest
+	(*WhenceResponse)(nil),            // 16: pb.WhenceResponse
+	(*GetExplainRequest)(nil),         // 17: pb.GetExplainRequest
+	(*JournalDestination)(nil),        // 18: pb.JournalDestination
+	(*JournalRule)(nil),               // 19: pb.JournalRule
+	(*ExplainResponse)(nil),           // 20: pb.ExplainResponse
+	(*Backup)(nil),                    // 21: pb.Backup
+	(*CreateBackupRequest)(nil),       // 22: pb.CreateBackupRequest
+	(*BackupResponse)(nil),            // 23: pb.BackupResponse
+	(*GetBackupsRequest)(nil),         // 24: pb.GetBackupsRequest
+	(*BackupsResponse)(nil),           // 25: pb.BackupsResponse
+	(*StatsFilter)(nil),               // 26: pb.StatsFilter
+	(*Stats)(nil),                     // 27: pb.Stats
+	(*GetStatsRequest)(nil),           // 28: pb.GetStatsRequest
+	(*GetTopStatsRequest)(nil),        // 29: pb.GetTopStatsRequest
+	(*StatsResponse)(nil),             // 30: pb.StatsResponse
+	(*EmptyResponse)(nil),             // 31: pb.EmptyResponse
 }
 var file_transport_grpc_pb_telegram_proto_depIdxs = []int32{
 	1,  // 0: pb.MessagesResponse.messages:type_name -> pb.Message
@@ -946,31 +1940,50 @@ var file_transport_grpc_pb_telegram_proto_depIdxs = []int32{
 	0,  // 2: pb.SendMessageAlbumRequest.new_messages:type_name -> pb.NewMessage
 	1,  // 3: pb.MessageResponse.message:type_name -> pb.Message
 	1,  // 4: pb.UpdateMessageRequest.message:type_name -> pb.Message
-	2,  // 5: pb.FacadeGRPC.GetMessages:input_type -> pb.GetMessagesRequest
-	3,  // 6: pb.FacadeGRPC.GetChatHistory:input_type -> pb.GetChatHistoryRequest
-	5,  // 7: pb.FacadeGRPC.SendMessage:input_type -> pb.SendMessageRequest
-	6,  // 8: pb.FacadeGRPC.SendMessageAlbum:input_type -> pb.SendMessageAlbumRequest
-	7,  // 9: pb.FacadeGRPC.ForwardMessage:input_type -> pb.ForwardMessageRequest
-	9,  // 10: pb.FacadeGRPC.GetMessage:input_type -> pb.GetMessageRequest
-	10, // 11: pb.FacadeGRPC.UpdateMessage:input_type -> pb.UpdateMessageRequest
-	11, // 12: pb.FacadeGRPC.DeleteMessages:input_type -> pb.DeleteMessagesRequest
-	12, // 13: pb.FacadeGRPC.GetMessageLink:input_type -> pb.GetMessageLinkRequest
-	14, // 14: pb.FacadeGRPC.GetMessageLinkInfo:input_type -> pb.GetMessageLinkInfoRequest
-	4,  // 15: pb.FacadeGRPC.GetMessages:output_type -> pb.MessagesResponse
-	4,  // 16: pb.FacadeGRPC.GetChatHistory:output_type -> pb.MessagesResponse
-	15, // 17: pb.FacadeGRPC.SendMessage:output_type -> pb.EmptyResponse
-	15, // 18: pb.FacadeGRPC.SendMessageAlbum:output_type -> pb.EmptyResponse
-	15, // 19: pb.FacadeGRPC.ForwardMessage:output_type -> pb.EmptyResponse
-	8,  // 20: pb.FacadeGRPC.GetMessage:output_type -> pb.MessageResponse
-	15, // 21: pb.FacadeGRPC.UpdateMessage:output_type -> pb.EmptyResponse
-	15, // 22: pb.FacadeGRPC.DeleteMessages:output_type -> pb.EmptyResponse
-	13, // 23: pb.FacadeGRPC.GetMessageLink:output_type -> pb.MessageLinkResponse
-	8,  // 24: pb.FacadeGRPC.GetMessageLinkInfo:output_type -> pb.MessageResponse
-	15, // [15:25] is the sub-list for method output_type
-	5,  // [5:15] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	18, // 5: pb.JournalRule.destinations:type_name -> pb.JournalDestination
+	19, // 6: pb.ExplainResponse.rules:type_name -> pb.JournalRule
+	21, // 7: pb.BackupResponse.backup:type_name -> pb.Backup
+	21, // 8: pb.BackupsResponse.backups:type_name -> pb.Backup
+	26, // 9: pb.GetStatsRequest.filter:type_name -> pb.StatsFilter
+	26, // 10: pb.GetTopStatsRequest.filter:type_name -> pb.StatsFilter
+	27, // 11: pb.StatsResponse.stats:type_name -> pb.Stats
+	2,  // 12: pb.FacadeGRPC.GetMessages:input_type -> pb.GetMessagesRequest
+	3,  // 13: pb.FacadeGRPC.GetChatHistory:input_type -> pb.GetChatHistoryRequest
+	5,  // 14: pb.FacadeGRPC.SendMessage:input_type -> pb.SendMessageRequest
+	6,  // 15: pb.FacadeGRPC.SendMessageAlbum:input_type -> pb.SendMessageAlbumRequest
+	7,  // 16: pb.FacadeGRPC.ForwardMessage:input_type -> pb.ForwardMessageRequest
+	9,  // 17: pb.FacadeGRPC.GetMessage:input_type -> pb.GetMessageRequest
+	10, // 18: pb.FacadeGRPC.UpdateMessage:input_type -> pb.UpdateMessageRequest
+	11, // 19: pb.FacadeGRPC.DeleteMessages:input_type -> pb.DeleteMessagesRequest
+	12, // 20: pb.FacadeGRPC.GetMessageLink:input_type -> pb.GetMessageLinkRequest
+	14, // 21: pb.FacadeGRPC.GetMessageLinkInfo:input_type -> pb.GetMessageLinkInfoRequest
+	15, // 22: pb.FacadeGRPC.GetWhence:input_type -> pb.GetWhenceRequest
+	17, // 23: pb.FacadeGRPC.GetExplain:input_type -> pb.GetExplainRequest
+	22, // 24: pb.FacadeGRPC.CreateBackup:input_type -> pb.CreateBackupRequest
+	24, // 25: pb.FacadeGRPC.GetBackups:input_type -> pb.GetBackupsRequest
+	28, // 26: pb.FacadeGRPC.GetStats:input_type -> pb.GetStatsRequest
+	29, // 27: pb.FacadeGRPC.GetTopStats:input_type -> pb.GetTopStatsRequest
+	4,  // 28: pb.FacadeGRPC.GetMessages:output_type -> pb.MessagesResponse
+	4,  // 29: pb.FacadeGRPC.GetChatHistory:output_type -> pb.MessagesResponse
+	31, // 30: pb.FacadeGRPC.SendMessage:output_type -> pb.EmptyResponse
+	31, // 31: pb.FacadeGRPC.SendMessageAlbum:output_type -> pb.EmptyResponse
+	31, // 32: pb.FacadeGRPC.ForwardMessage:output_type -> pb.EmptyResponse
+	8,  // 33: pb.FacadeGRPC.GetMessage:output_type -> pb.MessageResponse
+	31, // 34: pb.FacadeGRPC.UpdateMessage:output_type -> pb.EmptyResponse
+	31, // 35: pb.FacadeGRPC.DeleteMessages:output_type -> pb.EmptyResponse
+	13, // 36: pb.FacadeGRPC.GetMessageLink:output_type -> pb.MessageLinkResponse
+	8,  // 37: pb.FacadeGRPC.GetMessageLinkInfo:output_type -> pb.MessageResponse
+	16, // 38: pb.FacadeGRPC.GetWhence:output_type -> pb.WhenceResponse
+	20, // 39: pb.FacadeGRPC.GetExplain:output_type -> pb.ExplainResponse
+	23, // 40: pb.FacadeGRPC.CreateBackup:output_type -> pb.BackupResponse
+	25, // 41: pb.FacadeGRPC.GetBackups:output_type -> pb.BackupsResponse
+	30, // 42: pb.FacadeGRPC.GetStats:output_type -> pb.StatsResponse
+	30, // 43: pb.FacadeGRPC.GetTopStats:output_type -> pb.StatsResponse
+	28, // [28:44] is the sub-list for method output_type
+	12, // [12:28] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_transport_grpc_pb_telegram_proto_init() }
@@ -984,7 +1997,7 @@ func file_transport_grpc_pb_telegram_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_transport_grpc_pb_telegram_proto_rawDesc), len(file_transport_grpc_pb_telegram_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -29,6 +29,12 @@ const (
 	FacadeGRPC_DeleteMessages_FullMethodName     = "/pb.FacadeGRPC/DeleteMessages"
 	FacadeGRPC_GetMessageLink_FullMethodName     = "/pb.FacadeGRPC/GetMessageLink"
 	FacadeGRPC_GetMessageLinkInfo_FullMethodName = "/pb.FacadeGRPC/GetMessageLinkInfo"
+	FacadeGRPC_GetWhence_FullMethodName          = "/pb.FacadeGRPC/GetWhence"
+	FacadeGRPC_GetExplain_FullMethodName         = "/pb.FacadeGRPC/GetExplain"
+	FacadeGRPC_CreateBackup_FullMethodName       = "/pb.FacadeGRPC/CreateBackup"
+	FacadeGRPC_GetBackups_FullMethodName         = "/pb.FacadeGRPC/GetBackups"
+	FacadeGRPC_GetStats_FullMethodName           = "/pb.FacadeGRPC/GetStats"
+	FacadeGRPC_GetTopStats_FullMethodName        = "/pb.FacadeGRPC/GetTopStats"
 )
 
 // FacadeGRPCClient is the client API for FacadeGRPC service.
@@ -45,6 +51,12 @@ type FacadeGRPCClient interface {
 	DeleteMessages(ctx context.Context, in *DeleteMessagesRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	GetMessageLink(ctx context.Context, in *GetMessageLinkRequest, opts ...grpc.CallOption) (*MessageLinkResponse, error)
 	GetMessageLinkInfo(ctx context.Context, in *GetMessageLinkInfoRequest, opts ...grpc.CallOption) (*MessageResponse, error)
+	GetWhence(ctx context.Context, in *GetWhenceRequest, opts ...grpc.CallOption) (*WhenceResponse, error)
+	GetExplain(ctx context.Context, in *GetExplainRequest, opts ...grpc.CallOption) (*ExplainResponse, error)
+	CreateBackup(ctx context.Context, in *CreateBackupRequest, opts ...grpc.CallOption) (*BackupResponse, error)
+	GetBackups(ctx context.Context, in *GetBackupsRequest, opts ...grpc.CallOption) (*BackupsResponse, error)
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	GetTopStats(ctx context.Context, in *GetTopStatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
 }
 
 type facadeGRPCClient struct {
@@ -155,6 +167,66 @@ func (c *facadeGRPCClient) GetMessageLinkInfo(ctx context.Context, in *GetMessag
 	return out, nil
 }
 
+func (c *facadeGRPCClient) GetWhence(ctx context.Context, in *GetWhenceRequest, opts ...grpc.CallOption) (*WhenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WhenceResponse)
+	err := c.cc.Invoke(ctx, FacadeGRPC_GetWhence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *facadeGRPCClient) GetExplain(ctx context.Context, in *GetExplainRequest, opts ...grpc.CallOption) (*ExplainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExplainResponse)
+	err := c.cc.Invoke(ctx, FacadeGRPC_GetExplain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *facadeGRPCClient) CreateBackup(ctx context.Context, in *CreateBackupRequest, opts ...grpc.CallOption) (*BackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BackupResponse)
+	err := c.cc.Invoke(ctx, FacadeGRPC_CreateBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *facadeGRPCClient) GetBackups(ctx context.Context, in *GetBackupsRequest, opts ...grpc.CallOption) (*BackupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BackupsResponse)
+	err := c.cc.Invoke(ctx, FacadeGRPC_GetBackups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *facadeGRPCClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, FacadeGRPC_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *facadeGRPCClient) GetTopStats(ctx context.Context, in *GetTopStatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, FacadeGRPC_GetTopStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FacadeGRPCServer is the server API for FacadeGRPC service.
 // All implementations must embed UnimplementedFacadeGRPCServer
 // for forward compatibility.
@@ -169,6 +241,12 @@ type FacadeGRPCServer interface {
 	DeleteMessages(context.Context, *DeleteMessagesRequest) (*EmptyResponse, error)
 	GetMessageLink(context.Context, *GetMessageLinkRequest) (*MessageLinkResponse, error)
 	GetMessageLinkInfo(context.Context, *GetMessageLinkInfoRequest) (*MessageResponse, error)
+	GetWhence(context.Context, *GetWhenceRequest) (*WhenceResponse, error)
+	GetExplain(context.Context, *GetExplainRequest) (*ExplainResponse, error)
+	CreateBackup(context.Context, *CreateBackupRequest) (*BackupResponse, error)
+	GetBackups(context.Context, *GetBackupsRequest) (*BackupsResponse, error)
+	GetStats(context.Context, *GetStatsRequest) (*StatsResponse, error)
+	GetTopStats(context.Context, *GetTopStatsRequest) (*StatsResponse, error)
 	mustEmbedUnimplementedFacadeGRPCServer()
 }
 
@@ -209,6 +287,24 @@ func (UnimplementedFacadeGRPCServer) GetMessageLink(context.Context, *GetMessage
 func (UnimplementedFacadeGRPCServer) GetMessageLinkInfo(context.Context, *GetMessageLinkInfoRequest) (*MessageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMessageLinkInfo not implemented")
 }
+func (UnimplementedFacadeGRPCServer) GetWhence(context.Context, *GetWhenceRequest) (*WhenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWhence not implemented")
+}
+func (UnimplementedFacadeGRPCServer) GetExplain(context.Context, *GetExplainRequest) (*ExplainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExplain not implemented")
+}
+func (UnimplementedFacadeGRPCServer) CreateBackup(context.Context, *CreateBackupRequest) (*BackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBackup not implemented")
+}
+func (UnimplementedFacadeGRPCServer) GetBackups(context.Context, *GetBackupsRequest) (*BackupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBackups not implemented")
+}
+func (UnimplementedFacadeGRPCServer) GetStats(context.Context, *GetStatsRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedFacadeGRPCServer) GetTopStats(context.Context, *GetTopStatsRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopStats not implemented")
+}
 func (UnimplementedFacadeGRPCServer) mustEmbedUnimplementedFacadeGRPCServer() {}
 func (UnimplementedFacadeGRPCServer) testEmbeddedByValue()                    {}
 
@@ -410,6 +506,114 @@ func _FacadeGRPC_GetMessageLinkInfo_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FacadeGRPC_GetWhence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWhenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FacadeGRPCServer).GetWhence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FacadeGRPC_GetWhence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FacadeGRPCServer).GetWhence(ctx, req.(*GetWhenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FacadeGRPC_GetExplain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExplainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FacadeGRPCServer).GetExplain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FacadeGRPC_GetExplain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FacadeGRPCServer).GetExplain(ctx, req.(*GetExplainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FacadeGRPC_CreateBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FacadeGRPCServer).CreateBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FacadeGRPC_CreateBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FacadeGRPCServer).CreateBackup(ctx, req.(*CreateBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FacadeGRPC_GetBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FacadeGRPCServer).GetBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FacadeGRPC_GetBackups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FacadeGRPCServer).GetBackups(ctx, req.(*GetBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FacadeGRPC_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FacadeGRPCServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FacadeGRPC_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FacadeGRPCServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FacadeGRPC_GetTopStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FacadeGRPCServer).GetTopStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FacadeGRPC_GetTopStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FacadeGRPCServer).GetTopStats(ctx, req.(*GetTopStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FacadeGRPC_ServiceDesc is the grpc.ServiceDesc for FacadeGRPC service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -457,6 +661,30 @@ var FacadeGRPC_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMessageLinkInfo",
 			Handler:    _FacadeGRPC_GetMessageLinkInfo_Handler,
 		},
+		{
+			MethodName: "GetWhence",
+			Handler:    _FacadeGRPC_GetWhence_Handler,
+		},
+		{
+			MethodName: "GetExplain",
+			Handler:    _FacadeGRPC_GetExplain_Handler,
+		},
+		{
+			MethodName: "CreateBackup",
+			Handler:    _FacadeGRPC_CreateBackup_Handler,
+		},
+		{
+			MethodName: "GetBackups",
+			Handler:    _FacadeGRPC_GetBackups_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _FacadeGRPC_GetStats_Handler,
+		},
+		{
+			MethodName: "GetTopStats",
+			Handler:    _FacadeGRPC_GetTopStats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "transport/grpc/pb/telegram.proto",
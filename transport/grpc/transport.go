@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -37,6 +38,12 @@ type facadeGRPC interface {
 	DeleteMessages(chatId int64, messageIds []int64) error
 	GetMessageLink(chatId int64, messageId int64) (string, error)
 	GetMessageLinkInfo(link string) (*dto.Message, error)
+	GetWhence(link string) (*dto.Whence, error)
+	GetExplain(link string) (*dto.Journal, error)
+	CreateBackup() (*dto.Backup, error)
+	GetBackups() ([]*dto.Backup, error)
+	GetStats(filter *dto.StatsFilter) ([]*dto.Stats, error)
+	GetTopStats(filter *dto.StatsFilter, groupBy string, limit int) ([]*dto.Stats, error)
 }
 
 type Transport struct {
@@ -46,6 +53,7 @@ type Transport struct {
 	facade facadeGRPC
 	server *grpc.Server
 	lis    net.Listener
+	port   string
 }
 
 func New(facade facadeGRPC) *Transport {
@@ -53,14 +61,21 @@ func New(facade facadeGRPC) *Transport {
 		log: log.NewLogger(),
 		//
 		facade: facade,
+		port:   config.Grpc.Port,
 	}
 }
 
+// WithPort задаёт порт вместо config.Grpc.Port (для запуска из engine рядом с facade)
+func (t *Transport) WithPort(port string) *Transport {
+	t.port = port
+	return t
+}
+
 func (t *Transport) Start() error {
-	addr := net.JoinHostPort(config.Grpc.Host, config.Grpc.Port)
+	addr := net.JoinHostPort(config.Grpc.Host, t.port)
 	if !util.IsPortFree(addr) {
 		return log.NewError(
-			fmt.Sprintf("port is busy -> task kill-port -- %s", config.Grpc.Port),
+			fmt.Sprintf("port is busy -> task kill-port -- %s", t.port),
 			"addr", addr,
 		)
 	}
@@ -244,3 +259,162 @@ func (t *Transport) GetMessageLinkInfo(ctx context.Context, req *pb.GetMessageLi
 		Forward: res.Forward,
 	}}, nil
 }
+
+func (t *Transport) GetWhence(ctx context.Context, req *pb.GetWhenceRequest) (*pb.WhenceResponse, error) {
+	var err error
+
+	var res *dto.Whence
+	res, err = t.facade.GetWhence(req.Link)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &pb.WhenceResponse{
+		ForwardRuleId: res.ForwardRuleId,
+		ChatId:        res.ChatId,
+		MessageId:     res.MessageId,
+		Link:          res.Link,
+	}, nil
+}
+
+func (t *Transport) GetExplain(ctx context.Context, req *pb.GetExplainRequest) (*pb.ExplainResponse, error) {
+	var err error
+
+	var res *dto.Journal
+	res, err = t.facade.GetExplain(req.Link)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	rules := make([]*pb.JournalRule, 0, len(res.Rules))
+	for _, rule := range res.Rules {
+		destinations := make([]*pb.JournalDestination, 0, len(rule.Destinations))
+		for _, destination := range rule.Destinations {
+			destinations = append(destinations, &pb.JournalDestination{
+				ChatId:     destination.ChatId,
+				Outcome:    destination.Outcome,
+				MessageIds: destination.MessageIds,
+				Error:      destination.Error,
+			})
+		}
+		rules = append(rules, &pb.JournalRule{
+			ForwardRuleId: rule.ForwardRuleId,
+			Verdict:       rule.Verdict,
+			FiltersMode:   rule.FiltersMode,
+			Pattern:       rule.Pattern,
+			Destinations:  destinations,
+		})
+	}
+	return &pb.ExplainResponse{
+		ChatId:    res.ChatId,
+		MessageId: res.MessageId,
+		Date:      res.Date.Unix(),
+		Rules:     rules,
+	}, nil
+}
+
+func (t *Transport) CreateBackup(ctx context.Context, req *pb.CreateBackupRequest) (*pb.BackupResponse, error) {
+	var err error
+
+	var res *dto.Backup
+	res, err = t.facade.CreateBackup()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.BackupResponse{
+		Backup: mapBackup(res),
+	}, nil
+}
+
+func (t *Transport) GetBackups(ctx context.Context, req *pb.GetBackupsRequest) (*pb.BackupsResponse, error) {
+	var err error
+
+	var res []*dto.Backup
+	res, err = t.facade.GetBackups()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	backups := make([]*pb.Backup, 0, len(res))
+	for _, backup := range res {
+		backups = append(backups, mapBackup(backup))
+	}
+	return &pb.BackupsResponse{
+		Backups: backups,
+	}, nil
+}
+
+// mapBackup преобразует dto.Backup в pb.Backup
+func mapBackup(backup *dto.Backup) *pb.Backup {
+	return &pb.Backup{
+		Name:      backup.Name,
+		Size:      backup.Size,
+		CreatedAt: backup.CreatedAt.Unix(),
+	}
+}
+
+func (t *Transport) GetStats(ctx context.Context, req *pb.GetStatsRequest) (*pb.StatsResponse, error) {
+	var err error
+
+	var res []*dto.Stats
+	res, err = t.facade.GetStats(mapStatsFilter(req.Filter))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.StatsResponse{
+		Stats: mapStats(res),
+	}, nil
+}
+
+func (t *Transport) GetTopStats(ctx context.Context, req *pb.GetTopStatsRequest) (*pb.StatsResponse, error) {
+	var err error
+
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit is negative")
+	}
+
+	var res []*dto.Stats
+	res, err = t.facade.GetTopStats(mapStatsFilter(req.Filter), req.GroupBy, int(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.StatsResponse{
+		Stats: mapStats(res),
+	}, nil
+}
+
+// mapStatsFilter преобразует pb.StatsFilter в dto.StatsFilter; nil - без отбора
+func mapStatsFilter(filter *pb.StatsFilter) *dto.StatsFilter {
+	result := &dto.StatsFilter{}
+	if filter == nil {
+		return result
+	}
+	if filter.From != 0 {
+		result.From = time.Unix(filter.From, 0)
+	}
+	if filter.To != 0 {
+		result.To = time.Unix(filter.To, 0)
+	}
+	result.ForwardRuleId = filter.ForwardRuleId
+	result.SrcChatId = filter.SrcChatId
+	result.DstChatId = filter.DstChatId
+	result.Outcome = filter.Outcome
+	return result
+}
+
+// mapStats преобразует []*dto.Stats в []*pb.Stats
+func mapStats(stats []*dto.Stats) []*pb.Stats {
+	result := make([]*pb.Stats, 0, len(stats))
+	for _, item := range stats {
+		var hour int64
+		if !item.Hour.IsZero() {
+			hour = item.Hour.Unix()
+		}
+		result = append(result, &pb.Stats{
+			Hour:          hour,
+			ForwardRuleId: item.ForwardRuleId,
+			SrcChatId:     item.SrcChatId,
+			DstChatId:     item.DstChatId,
+			Outcome:       item.Outcome,
+			Count:         item.Count,
+		})
+	}
+	return result
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/test/bufconn"
 
@@ -236,3 +238,109 @@ func TestGetChatHistory(t *testing.T) {
 	assert.Equal(t, "message 2", resp.Messages[1].Text)
 	assert.True(t, resp.Messages[1].Forward)
 }
+
+func TestGetWhence(t *testing.T) {
+	t.Parallel()
+
+	facade := mocks.NewFacadeGRPC(t)
+	link := "https://t.me/c/1/2"
+	whence := &dto.Whence{ForwardRuleId: "rule1", ChatId: -1003, MessageId: 4, Link: "https://t.me/c/3/4"}
+	facade.EXPECT().GetWhence(link).Return(whence, nil)
+
+	conn, cleanup := startTestGRPCServer(t, facade)
+	t.Cleanup(cleanup)
+	client := pb.NewFacadeGRPCClient(conn)
+
+	resp, err := client.GetWhence(context.Background(), &pb.GetWhenceRequest{Link: link})
+	assert.NoError(t, err)
+	assert.Equal(t, "rule1", resp.ForwardRuleId)
+	assert.Equal(t, int64(-1003), resp.ChatId)
+	assert.Equal(t, int64(4), resp.MessageId)
+	assert.Equal(t, "https://t.me/c/3/4", resp.Link)
+}
+
+func TestGetExplain(t *testing.T) {
+	t.Parallel()
+
+	facade := mocks.NewFacadeGRPC(t)
+	link := "https://t.me/c/1/2"
+	date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	journal := &dto.Journal{
+		ChatId:    -1001,
+		MessageId: 2,
+		Date:      date,
+		Rules: []*dto.JournalRule{
+			{
+				ForwardRuleId: "rule1",
+				Verdict:       "evaluated",
+				FiltersMode:   "ok",
+				Pattern:       "include: новости",
+				Destinations: []*dto.JournalDestination{
+					{ChatId: -1002, Outcome: "ok", MessageIds: []int64{10}},
+				},
+			},
+		},
+	}
+	facade.EXPECT().GetExplain(link).Return(journal, nil)
+
+	conn, cleanup := startTestGRPCServer(t, facade)
+	t.Cleanup(cleanup)
+	client := pb.NewFacadeGRPCClient(conn)
+
+	resp, err := client.GetExplain(context.Background(), &pb.GetExplainRequest{Link: link})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1001), resp.ChatId)
+	assert.Equal(t, int64(2), resp.MessageId)
+	assert.Equal(t, date.Unix(), resp.Date)
+	require.Len(t, resp.Rules, 1)
+	assert.Equal(t, "rule1", resp.Rules[0].ForwardRuleId)
+	assert.Equal(t, "include: новости", resp.Rules[0].Pattern)
+	require.Len(t, resp.Rules[0].Destinations, 1)
+	assert.Equal(t, int64(-1002), resp.Rules[0].Destinations[0].ChatId)
+	assert.Equal(t, []int64{10}, resp.Rules[0].Destinations[0].MessageIds)
+}
+
+func TestCreateBackup(t *testing.T) {
+	t.Parallel()
+
+	facade := mocks.NewFacadeGRPC(t)
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	backup := &dto.Backup{Name: "backup-20250101-000000.000.bak.gz", Size: 1024, CreatedAt: createdAt}
+	facade.EXPECT().CreateBackup().Return(backup, nil)
+
+	conn, cleanup := startTestGRPCServer(t, facade)
+	t.Cleanup(cleanup)
+	client := pb.NewFacadeGRPCClient(conn)
+
+	resp, err := client.CreateBackup(context.Background(), &pb.CreateBackupRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "backup-20250101-000000.000.bak.gz", resp.Backup.Name)
+	assert.Equal(t, int64(1024), resp.Backup.Size)
+	assert.Equal(t, createdAt.Unix(), resp.Backup.CreatedAt)
+}
+
+func TestGetStats(t *testing.T) {
+	t.Parallel()
+
+	facade := mocks.NewFacadeGRPC(t)
+	hour := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	facade.EXPECT().GetStats(&dto.StatsFilter{From: time.Unix(hour.Unix(), 0), SrcChatId: -1001}).
+		Return([]*dto.Stats{
+			{Hour: hour, ForwardRuleId: "rule1", SrcChatId: -1001, DstChatId: -1002, Outcome: "ok", Count: 3},
+		}, nil)
+
+	conn, cleanup := startTestGRPCServer(t, facade)
+	t.Cleanup(cleanup)
+	client := pb.NewFacadeGRPCClient(conn)
+
+	resp, err := client.GetStats(context.Background(), &pb.GetStatsRequest{
+		Filter: &pb.StatsFilter{From: hour.Unix(), SrcChatId: -1001},
+	})
+	assert.NoError(t, err)
+	require.Len(t, resp.Stats, 1)
+	assert.Equal(t, hour.Unix(), resp.Stats[0].Hour)
+	assert.Equal(t, "rule1", resp.Stats[0].ForwardRuleId)
+	assert.Equal(t, int64(-1002), resp.Stats[0].DstChatId)
+	assert.Equal(t, "ok", resp.Stats[0].Outcome)
+	assert.Equal(t, int64(3), resp.Stats[0].Count)
+}
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/log"
+	"github.com/comerc/budva43/app/metrics"
+	"github.com/comerc/budva43/app/util"
+)
+
+// Transport отдаёт метрики Prometheus по HTTP
+type Transport struct {
+	log *log.Logger
+	//
+	server *http.Server
+	port   string
+}
+
+// New создает новый экземпляр транспорта метрик
+func New() *Transport {
+	return &Transport{
+		log: log.NewLogger(),
+		//
+		port: config.Metrics.Port,
+	}
+}
+
+// WithPort задаёт порт вместо config.Metrics.Port (для запуска из engine рядом с facade)
+func (t *Transport) WithPort(port string) *Transport {
+	t.port = port
+	return t
+}
+
+// Start запускает HTTP-сервер метрик
+func (t *Transport) Start() error {
+	addr := net.JoinHostPort(config.Metrics.Host, t.port)
+	if !util.IsPortFree(addr) {
+		return log.NewError(
+			fmt.Sprintf("port is busy -> task kill-port -- %s", t.port),
+			"addr", addr,
+		)
+	}
+
+	t.server = &http.Server{
+		Addr:              addr,
+		Handler:           newHandler(),
+		ReadHeaderTimeout: config.Web.ReadTimeout,
+	}
+
+	go t.runServer()
+
+	return nil
+}
+
+// Close останавливает HTTP-сервер метрик
+func (t *Transport) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), config.Web.ShutdownTimeout)
+	defer cancel()
+
+	err := t.server.Shutdown(ctx)
+	if err != nil {
+		return log.WrapError(err) // внешняя ошибка
+	}
+
+	return nil
+}
+
+func (t *Transport) runServer() {
+	var err error
+	defer func() {
+		t.log.ErrorOrDebug(err, "", "addr", t.server.Addr)
+	}()
+
+	err = t.server.ListenAndServe()
+
+	if errors.Is(err, http.ErrServerClosed) {
+		err = nil
+	}
+}
+
+// newHandler возвращает маршрутизатор с единственным маршрутом /metrics
+func newHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	return mux
+}
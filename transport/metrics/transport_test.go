@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zelenin/go-tdlib/client"
+
+	"github.com/comerc/budva43/app/metrics"
+)
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	metrics.AddUpdate(&client.UpdateNewMessage{})
+	metrics.AddForward("rule1", -1002, "ok")
+	metrics.ObserveTdlibCall("SendMessage", time.Now(), client.ResponseError{Err: &client.Error{Code: 429}})
+	metrics.StartSend(-1002, 20, int32(time.Now().Unix()))
+	metrics.FinishSend(-1002, 20)
+
+	server := httptest.NewServer(newHandler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close() //nolint:errcheck
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	for _, expected := range []string{
+		`budva43_updates_total{type="updateNewMessage"}`,
+		`budva43_forwards_total{destination="-1002",outcome="ok",rule="rule1"}`,
+		`budva43_tdlib_errors_total{code="429",method="SendMessage"}`,
+		`budva43_tdlib_call_duration_seconds_count{method="SendMessage"}`,
+		`budva43_forward_latency_seconds_count 1`,
+		`budva43_queue_length`,
+		`budva43_media_albums_pending`,
+	} {
+		assert.Contains(t, string(body), expected)
+	}
+}
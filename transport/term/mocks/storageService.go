@@ -0,0 +1,196 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	dto "github.com/comerc/budva43/app/dto/storage/dto"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StorageService is an autogenerated mock type for the storageService type
+type StorageService struct {
+	mock.Mock
+}
+
+type StorageService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *StorageService) EXPECT() *StorageService_Expecter {
+	return &StorageService_Expecter{mock: &_m.Mock}
+}
+
+// CreateBackup provides a mock function with no fields
+func (_m *StorageService) CreateBackup() (*dto.Backup, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBackup")
+	}
+
+	var r0 *dto.Backup
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*dto.Backup, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *dto.Backup); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Backup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageService_CreateBackup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBackup'
+type StorageService_CreateBackup_Call struct {
+	*mock.Call
+}
+
+// CreateBackup is a helper method to define mock.On call
+func (_e *StorageService_Expecter) CreateBackup() *StorageService_CreateBackup_Call {
+	return &StorageService_CreateBackup_Call{Call: _e.mock.On("CreateBackup")}
+}
+
+func (_c *StorageService_CreateBackup_Call) Run(run func()) *StorageService_CreateBackup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *StorageService_CreateBackup_Call) Return(_a0 *dto.Backup, _a1 error) *StorageService_CreateBackup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageService_CreateBackup_Call) RunAndReturn(run func() (*dto.Backup, error)) *StorageService_CreateBackup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBackups provides a mock function with no fields
+func (_m *StorageService) GetBackups() ([]*dto.Backup, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBackups")
+	}
+
+	var r0 []*dto.Backup
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*dto.Backup, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*dto.Backup); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.Backup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageService_GetBackups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBackups'
+type StorageService_GetBackups_Call struct {
+	*mock.Call
+}
+
+// GetBackups is a helper method to define mock.On call
+func (_e *StorageService_Expecter) GetBackups() *StorageService_GetBackups_Call {
+	return &StorageService_GetBackups_Call{Call: _e.mock.On("GetBackups")}
+}
+
+func (_c *StorageService_GetBackups_Call) Run(run func()) *StorageService_GetBackups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *StorageService_GetBackups_Call) Return(_a0 []*dto.Backup, _a1 error) *StorageService_GetBackups_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageService_GetBackups_Call) RunAndReturn(run func() ([]*dto.Backup, error)) *StorageService_GetBackups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsage provides a mock function with no fields
+func (_m *StorageService) GetUsage() []*dto.Usage {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsage")
+	}
+
+	var r0 []*dto.Usage
+	if rf, ok := ret.Get(0).(func() []*dto.Usage); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.Usage)
+		}
+	}
+
+	return r0
+}
+
+// StorageService_GetUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsage'
+type StorageService_GetUsage_Call struct {
+	*mock.Call
+}
+
+// GetUsage is a helper method to define mock.On call
+func (_e *StorageService_Expecter) GetUsage() *StorageService_GetUsage_Call {
+	return &StorageService_GetUsage_Call{Call: _e.mock.On("GetUsage")}
+}
+
+func (_c *StorageService_GetUsage_Call) Run(run func()) *StorageService_GetUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *StorageService_GetUsage_Call) Return(_a0 []*dto.Usage) *StorageService_GetUsage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageService_GetUsage_Call) RunAndReturn(run func() []*dto.Usage) *StorageService_GetUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewStorageService creates a new instance of StorageService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStorageService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StorageService {
+	mock := &StorageService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
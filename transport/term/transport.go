@@ -2,11 +2,15 @@ package term
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/zelenin/go-tdlib/client"
 
 	"github.com/comerc/budva43/app/config"
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/dto/storage/dto"
 	"github.com/comerc/budva43/app/log"
 	"github.com/comerc/budva43/app/util"
 )
@@ -34,18 +38,38 @@ type authService interface {
 	GetStatus() string
 }
 
+//go:generate mockery --name=whenceService --exported
+type whenceService interface {
+	Lookup(link string) (*domain.Whence, error)
+}
+
+//go:generate mockery --name=explainService --exported
+type explainService interface {
+	Explain(link string) (*domain.Journal, error)
+}
+
+//go:generate mockery --name=storageService --exported
+type storageService interface {
+	GetUsage() []*dto.Usage
+	CreateBackup() (*dto.Backup, error)
+	GetBackups() ([]*dto.Backup, error)
+}
+
 // Transport представляет терминальный интерфейс
 type Transport struct {
 	log *log.Logger
 	//
-	telegramRepo  telegramRepo
-	termRepo      termRepo
-	authService   authService
-	authStateChan chan client.AuthorizationState
-	commands      []command
-	commandMap    map[string]*command
-	shutdown      func()
-	phoneNumber   string
+	telegramRepo   telegramRepo
+	termRepo       termRepo
+	authService    authService
+	whenceService  whenceService
+	explainService explainService
+	storageService storageService
+	authStateChan  chan client.AuthorizationState
+	commands       []command
+	commandMap     map[string]*command
+	shutdown       func()
+	phoneNumber    string
 }
 
 // command представляет команду терминала
@@ -60,16 +84,22 @@ func New(
 	telegramRepo telegramRepo,
 	termRepo termRepo,
 	authService authService,
+	whenceService whenceService,
+	explainService explainService,
+	storageService storageService,
 ) *Transport {
 	term := &Transport{
 		log: log.NewLogger(),
 		//
-		telegramRepo:  telegramRepo,
-		termRepo:      termRepo,
-		authService:   authService,
-		authStateChan: make(chan client.AuthorizationState, 10),
-		commands:      []command{},
-		phoneNumber:   config.Telegram.PhoneNumber,
+		telegramRepo:   telegramRepo,
+		termRepo:       termRepo,
+		authService:    authService,
+		whenceService:  whenceService,
+		explainService: explainService,
+		storageService: storageService,
+		authStateChan:  make(chan client.AuthorizationState, 10),
+		commands:       []command{},
+		phoneNumber:    config.Telegram.PhoneNumber,
 	}
 
 	// Регистрация команд
@@ -153,6 +183,36 @@ func (t *Transport) registerCommands() {
 			handler:     t.handleExit,
 		},
 	}
+	// без хранилища (в facade) происхождение сообщений недоступно
+	if t.whenceService != nil {
+		t.commands = append(t.commands, command{
+			name:        "whence",
+			description: "Найти источник сообщения получателя: whence <link>",
+			handler:     t.handleWhence,
+		})
+	}
+	if t.explainService != nil {
+		t.commands = append(t.commands, command{
+			name:        "explain",
+			description: "Объяснить решения по исходному сообщению: explain <link>",
+			handler:     t.handleExplain,
+		})
+	}
+	if t.storageService != nil {
+		t.commands = append(t.commands, command{
+			name:        "usage",
+			description: "Показать использование хранилища по префиксам ключей",
+			handler:     t.handleUsage,
+		}, command{
+			name:        "backup",
+			description: "Создать резервную копию хранилища",
+			handler:     t.handleBackup,
+		}, command{
+			name:        "backups",
+			description: "Показать резервные копии хранилища",
+			handler:     t.handleBackups,
+		})
+	}
 
 	t.commandMap = make(map[string]*command)
 	for _, cmd := range t.commands {
@@ -204,6 +264,97 @@ func (t *Transport) handleExit(args []string) {
 	t.shutdown()
 }
 
+// handleWhence обрабатывает команду whence
+func (t *Transport) handleWhence(args []string) {
+	if len(args) != 1 {
+		t.termRepo.Println("Использование: whence <link>")
+		return
+	}
+	whence, err := t.whenceService.Lookup(args[0])
+	if err != nil {
+		t.termRepo.Printf("Источник не найден: %s\n", err)
+		return
+	}
+	t.termRepo.Printf("Правило: %s\n", whence.ForwardRuleId)
+	t.termRepo.Printf("Источник: %d\n", whence.ChatId)
+	t.termRepo.Printf("Сообщение: %d\n", whence.MessageId)
+	if whence.Link != "" {
+		t.termRepo.Printf("Ссылка: %s\n", whence.Link)
+	}
+}
+
+// handleExplain обрабатывает команду explain
+func (t *Transport) handleExplain(args []string) {
+	if len(args) != 1 {
+		t.termRepo.Println("Использование: explain <link>")
+		return
+	}
+	journal, err := t.explainService.Explain(args[0])
+	if err != nil {
+		t.termRepo.Printf("Журнал не найден: %s\n", err)
+		return
+	}
+	t.termRepo.Printf("Источник: %d\n", journal.ChatId)
+	t.termRepo.Printf("Сообщение: %d\n", journal.MessageId)
+	t.termRepo.Printf("Время: %s\n", journal.Date.Format(time.DateTime))
+	for _, rule := range journal.Rules {
+		line := fmt.Sprintf("Правило %s: %s", rule.ForwardRuleId, rule.Verdict)
+		if rule.FiltersMode != "" {
+			line += ", " + rule.FiltersMode
+		}
+		if rule.Pattern != "" {
+			line += " (" + rule.Pattern + ")"
+		}
+		t.termRepo.Printf("%s\n", line)
+		for _, destination := range rule.Destinations {
+			line := fmt.Sprintf("  -> %d: %s %v", destination.ChatId, destination.Outcome, destination.MessageIds)
+			if destination.Error != "" {
+				line += " " + destination.Error
+			}
+			t.termRepo.Printf("%s\n", line)
+		}
+	}
+}
+
+// handleUsage обрабатывает команду usage
+func (t *Transport) handleUsage(args []string) {
+	usage := t.storageService.GetUsage()
+	if len(usage) == 0 {
+		t.termRepo.Println("Хранилище пусто")
+		return
+	}
+	t.termRepo.Printf("%-16s %10s %12s\n", "Префикс", "Ключей", "Байт")
+	for _, u := range usage {
+		t.termRepo.Printf("%-16s %10d %12d\n", u.Prefix, u.Keys, u.Size)
+	}
+}
+
+// handleBackup обрабатывает команду backup
+func (t *Transport) handleBackup(args []string) {
+	backup, err := t.storageService.CreateBackup()
+	if err != nil {
+		t.termRepo.Printf("Ошибка создания резервной копии: %s\n", err)
+		return
+	}
+	t.termRepo.Printf("Создана резервная копия: %s (%d байт)\n", backup.Name, backup.Size)
+}
+
+// handleBackups обрабатывает команду backups
+func (t *Transport) handleBackups(args []string) {
+	backups, err := t.storageService.GetBackups()
+	if err != nil {
+		t.termRepo.Printf("Ошибка получения резервных копий: %s\n", err)
+		return
+	}
+	if len(backups) == 0 {
+		t.termRepo.Println("Резервных копий нет")
+		return
+	}
+	for _, backup := range backups {
+		t.termRepo.Printf("%-40s %12d  %s\n", backup.Name, backup.Size, backup.CreatedAt.Format(time.DateTime))
+	}
+}
+
 // processAuth обрабатывает состояние авторизации
 func (t *Transport) processAuth(state client.AuthorizationState) {
 	var err error
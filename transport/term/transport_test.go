@@ -2,6 +2,7 @@ package term
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"testing/synctest"
 	"time"
@@ -9,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/zelenin/go-tdlib/client"
 
+	"github.com/comerc/budva43/app/domain"
+	"github.com/comerc/budva43/app/dto/storage/dto"
 	"github.com/comerc/budva43/transport/term/mocks"
 )
 
@@ -39,6 +42,9 @@ func Test(t *testing.T) {
 			telegramRepo,
 			termRepo,
 			authService,
+			nil,
+			nil,
+			nil,
 		)
 		termTransport.shutdown = cancel
 
@@ -90,7 +96,7 @@ func TestProcessAuth_WaitPassword(t *testing.T) {
 			termRepo := mocks.NewTermRepo(t)
 			authService := mocks.NewAuthService(t)
 
-			transport := New(nil, termRepo, authService)
+			transport := New(nil, termRepo, authService, nil, nil, nil)
 
 			// Создаем состояние ожидания пароля
 			passwordState := &client.AuthorizationStateWaitPassword{
@@ -118,3 +124,259 @@ func TestProcessAuth_WaitPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleWhence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		args  []string
+		setup func(termRepo *mocks.TermRepo, whenceService *mocks.WhenceService)
+	}{
+		{
+			name: "found",
+			args: []string{"https://t.me/c/1/2"},
+			setup: func(termRepo *mocks.TermRepo, whenceService *mocks.WhenceService) {
+				whenceService.EXPECT().Lookup("https://t.me/c/1/2").Return(&domain.Whence{
+					ForwardRuleId: "rule1",
+					ChatId:        -1003,
+					MessageId:     4,
+					Link:          "https://t.me/c/3/4",
+				}, nil)
+				termRepo.EXPECT().Printf("Правило: %s\n", "rule1").Once()
+				termRepo.EXPECT().Printf("Источник: %d\n", int64(-1003)).Once()
+				termRepo.EXPECT().Printf("Сообщение: %d\n", int64(4)).Once()
+				termRepo.EXPECT().Printf("Ссылка: %s\n", "https://t.me/c/3/4").Once()
+			},
+		},
+		{
+			name: "not_found",
+			args: []string{"https://t.me/c/1/2"},
+			setup: func(termRepo *mocks.TermRepo, whenceService *mocks.WhenceService) {
+				err := errors.New("origin not found")
+				whenceService.EXPECT().Lookup("https://t.me/c/1/2").Return(nil, err)
+				termRepo.EXPECT().Printf("Источник не найден: %s\n", err).Once()
+			},
+		},
+		{
+			name: "usage",
+			args: nil,
+			setup: func(termRepo *mocks.TermRepo, whenceService *mocks.WhenceService) {
+				termRepo.EXPECT().Println("Использование: whence <link>").Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			termRepo := mocks.NewTermRepo(t)
+			whenceService := mocks.NewWhenceService(t)
+			test.setup(termRepo, whenceService)
+
+			transport := New(nil, termRepo, nil, whenceService, nil, nil)
+			transport.handleWhence(test.args)
+		})
+	}
+}
+
+func TestHandleExplain(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2025, 1, 2, 3, 4, 5, 0, time.Local)
+
+	tests := []struct {
+		name  string
+		args  []string
+		setup func(termRepo *mocks.TermRepo, explainService *mocks.ExplainService)
+	}{
+		{
+			name: "found",
+			args: []string{"https://t.me/c/1/2"},
+			setup: func(termRepo *mocks.TermRepo, explainService *mocks.ExplainService) {
+				explainService.EXPECT().Explain("https://t.me/c/1/2").Return(&domain.Journal{
+					ChatId:    -1001,
+					MessageId: 2,
+					Date:      date,
+					Rules: []*domain.JournalRule{
+						{
+							ForwardRuleId: "rule1",
+							Verdict:       domain.JournalEvaluated,
+							FiltersMode:   domain.FiltersOK,
+							Pattern:       "include: новости",
+							Destinations: []*domain.JournalDestination{
+								{ChatId: -1002, Outcome: "ok", MessageIds: []int64{10}},
+								{ChatId: -1003, Outcome: "error", Error: "chat not found"},
+							},
+						},
+						{
+							ForwardRuleId: "rule2",
+							Verdict:       domain.JournalOtherThread,
+						},
+					},
+				}, nil)
+				termRepo.EXPECT().Printf("Источник: %d\n", int64(-1001)).Once()
+				termRepo.EXPECT().Printf("Сообщение: %d\n", int64(2)).Once()
+				termRepo.EXPECT().Printf("Время: %s\n", "2025-01-02 03:04:05").Once()
+				termRepo.EXPECT().Printf("%s\n", "Правило rule1: evaluated, ok (include: новости)").Once()
+				termRepo.EXPECT().Printf("%s\n", "  -> -1002: ok [10]").Once()
+				termRepo.EXPECT().Printf("%s\n", "  -> -1003: error [] chat not found").Once()
+				termRepo.EXPECT().Printf("%s\n", "Правило rule2: other_thread").Once()
+			},
+		},
+		{
+			name: "not_found",
+			args: []string{"https://t.me/c/1/2"},
+			setup: func(termRepo *mocks.TermRepo, explainService *mocks.ExplainService) {
+				err := errors.New("journal not found")
+				explainService.EXPECT().Explain("https://t.me/c/1/2").Return(nil, err)
+				termRepo.EXPECT().Printf("Журнал не найден: %s\n", err).Once()
+			},
+		},
+		{
+			name: "usage",
+			args: nil,
+			setup: func(termRepo *mocks.TermRepo, explainService *mocks.ExplainService) {
+				termRepo.EXPECT().Println("Использование: explain <link>").Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			termRepo := mocks.NewTermRepo(t)
+			explainService := mocks.NewExplainService(t)
+			test.setup(termRepo, explainService)
+
+			transport := New(nil, termRepo, nil, nil, explainService, nil)
+			transport.handleExplain(test.args)
+		})
+	}
+}
+
+func TestHandleUsage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		setup func(termRepo *mocks.TermRepo, storageService *mocks.StorageService)
+	}{
+		{
+			name: "usage",
+			setup: func(termRepo *mocks.TermRepo, storageService *mocks.StorageService) {
+				storageService.EXPECT().GetUsage().Return([]*dto.Usage{
+					{Prefix: "copiedMsgIds", Keys: 2, Size: 128},
+				})
+				termRepo.EXPECT().Printf("%-16s %10s %12s\n", "Префикс", "Ключей", "Байт").Once()
+				termRepo.EXPECT().Printf("%-16s %10d %12d\n", "copiedMsgIds", int64(2), int64(128)).Once()
+			},
+		},
+		{
+			name: "empty",
+			setup: func(termRepo *mocks.TermRepo, storageService *mocks.StorageService) {
+				storageService.EXPECT().GetUsage().Return(nil)
+				termRepo.EXPECT().Println("Хранилище пусто").Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			termRepo := mocks.NewTermRepo(t)
+			storageService := mocks.NewStorageService(t)
+			test.setup(termRepo, storageService)
+
+			transport := New(nil, termRepo, nil, nil, nil, storageService)
+			transport.handleUsage(nil)
+		})
+	}
+}
+
+func TestHandleBackup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		setup func(termRepo *mocks.TermRepo, storageService *mocks.StorageService)
+	}{
+		{
+			name: "created",
+			setup: func(termRepo *mocks.TermRepo, storageService *mocks.StorageService) {
+				storageService.EXPECT().CreateBackup().Return(&dto.Backup{
+					Name: "backup-20250101-000000.000.bak.gz",
+					Size: 1024,
+				}, nil)
+				termRepo.EXPECT().Printf("Создана резервная копия: %s (%d байт)\n",
+					"backup-20250101-000000.000.bak.gz", int64(1024)).Once()
+			},
+		},
+		{
+			name: "error",
+			setup: func(termRepo *mocks.TermRepo, storageService *mocks.StorageService) {
+				err := errors.New("no space left on device")
+				storageService.EXPECT().CreateBackup().Return(nil, err)
+				termRepo.EXPECT().Printf("Ошибка создания резервной копии: %s\n", err).Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			termRepo := mocks.NewTermRepo(t)
+			storageService := mocks.NewStorageService(t)
+			test.setup(termRepo, storageService)
+
+			transport := New(nil, termRepo, nil, nil, nil, storageService)
+			transport.handleBackup(nil)
+		})
+	}
+}
+
+func TestHandleBackups(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		setup func(termRepo *mocks.TermRepo, storageService *mocks.StorageService)
+	}{
+		{
+			name: "list",
+			setup: func(termRepo *mocks.TermRepo, storageService *mocks.StorageService) {
+				storageService.EXPECT().GetBackups().Return([]*dto.Backup{
+					{Name: "backup-20250101-000000.000.bak", Size: 2048, CreatedAt: createdAt},
+				}, nil)
+				termRepo.EXPECT().Printf("%-40s %12d  %s\n",
+					"backup-20250101-000000.000.bak", int64(2048), "2025-01-01 00:00:00").Once()
+			},
+		},
+		{
+			name: "empty",
+			setup: func(termRepo *mocks.TermRepo, storageService *mocks.StorageService) {
+				storageService.EXPECT().GetBackups().Return([]*dto.Backup{}, nil)
+				termRepo.EXPECT().Println("Резервных копий нет").Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			termRepo := mocks.NewTermRepo(t)
+			storageService := mocks.NewStorageService(t)
+			test.setup(termRepo, storageService)
+
+			transport := New(nil, termRepo, nil, nil, nil, storageService)
+			transport.handleBackups(nil)
+		})
+	}
+}
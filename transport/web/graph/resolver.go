@@ -10,6 +10,9 @@ import "github.com/comerc/budva43/app/dto/gql/dto"
 
 type facadeGQL interface {
 	GetStatus() (*dto.Status, error)
+	GetStats(filter *dto.StatsFilter) ([]*dto.Stats, error)
+	GetTopStats(filter *dto.StatsFilter, groupBy string, limit int) ([]*dto.Stats, error)
+	GetExplain(link string) (*dto.Journal, error)
 }
 
 type Resolver struct {
@@ -31,6 +31,21 @@ func (r *queryResolver) Chats(ctx context.Context) ([]*dto.Chat, error) {
 	panic(fmt.Errorf("not implemented: Chats - chats"))
 }
 
+// Stats is the resolver for the stats field.
+func (r *queryResolver) Stats(ctx context.Context, filter *dto.StatsFilter) ([]*dto.Stats, error) {
+	return r.Facade.GetStats(filter)
+}
+
+// TopStats is the resolver for the topStats field.
+func (r *queryResolver) TopStats(ctx context.Context, filter *dto.StatsFilter, groupBy string, limit int) ([]*dto.Stats, error) {
+	return r.Facade.GetTopStats(filter, groupBy, limit)
+}
+
+// Explain is the resolver for the explain field.
+func (r *queryResolver) Explain(ctx context.Context, link string) (*dto.Journal, error) {
+	return r.Facade.GetExplain(link)
+}
+
 // Chat returns ChatResolver implementation.
 func (r *Resolver) Chat() ChatResolver { return &chatResolver{r} }
 
@@ -20,6 +20,122 @@ func (_m *FacadeGQL) EXPECT() *FacadeGQL_Expecter {
 	return &FacadeGQL_Expecter{mock: &_m.Mock}
 }
 
+// GetExplain provides a mock function with given fields: link
+func (_m *FacadeGQL) GetExplain(link string) (*dto.Journal, error) {
+	ret := _m.Called(link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExplain")
+	}
+
+	var r0 *dto.Journal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*dto.Journal, error)); ok {
+		return rf(link)
+	}
+	if rf, ok := ret.Get(0).(func(string) *dto.Journal); ok {
+		r0 = rf(link)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Journal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FacadeGQL_GetExplain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExplain'
+type FacadeGQL_GetExplain_Call struct {
+	*mock.Call
+}
+
+// GetExplain is a helper method to define mock.On call
+//   - link string
+func (_e *FacadeGQL_Expecter) GetExplain(link interface{}) *FacadeGQL_GetExplain_Call {
+	return &FacadeGQL_GetExplain_Call{Call: _e.mock.On("GetExplain", link)}
+}
+
+func (_c *FacadeGQL_GetExplain_Call) Run(run func(link string)) *FacadeGQL_GetExplain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *FacadeGQL_GetExplain_Call) Return(_a0 *dto.Journal, _a1 error) *FacadeGQL_GetExplain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FacadeGQL_GetExplain_Call) RunAndReturn(run func(string) (*dto.Journal, error)) *FacadeGQL_GetExplain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStats provides a mock function with given fields: filter
+func (_m *FacadeGQL) GetStats(filter *dto.StatsFilter) ([]*dto.Stats, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
+	}
+
+	var r0 []*dto.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*dto.StatsFilter) ([]*dto.Stats, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*dto.StatsFilter) []*dto.Stats); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*dto.StatsFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FacadeGQL_GetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStats'
+type FacadeGQL_GetStats_Call struct {
+	*mock.Call
+}
+
+// GetStats is a helper method to define mock.On call
+//   - filter *dto.StatsFilter
+func (_e *FacadeGQL_Expecter) GetStats(filter interface{}) *FacadeGQL_GetStats_Call {
+	return &FacadeGQL_GetStats_Call{Call: _e.mock.On("GetStats", filter)}
+}
+
+func (_c *FacadeGQL_GetStats_Call) Run(run func(filter *dto.StatsFilter)) *FacadeGQL_GetStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*dto.StatsFilter))
+	})
+	return _c
+}
+
+func (_c *FacadeGQL_GetStats_Call) Return(_a0 []*dto.Stats, _a1 error) *FacadeGQL_GetStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FacadeGQL_GetStats_Call) RunAndReturn(run func(*dto.StatsFilter) ([]*dto.Stats, error)) *FacadeGQL_GetStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetStatus provides a mock function with no fields
 func (_m *FacadeGQL) GetStatus() (*dto.Status, error) {
 	ret := _m.Called()
@@ -77,6 +193,66 @@ func (_c *FacadeGQL_GetStatus_Call) RunAndReturn(run func() (*dto.Status, error)
 	return _c
 }
 
+// GetTopStats provides a mock function with given fields: filter, groupBy, limit
+func (_m *FacadeGQL) GetTopStats(filter *dto.StatsFilter, groupBy string, limit int) ([]*dto.Stats, error) {
+	ret := _m.Called(filter, groupBy, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTopStats")
+	}
+
+	var r0 []*dto.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*dto.StatsFilter, string, int) ([]*dto.Stats, error)); ok {
+		return rf(filter, groupBy, limit)
+	}
+	if rf, ok := ret.Get(0).(func(*dto.StatsFilter, string, int) []*dto.Stats); ok {
+		r0 = rf(filter, groupBy, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*dto.StatsFilter, string, int) error); ok {
+		r1 = rf(filter, groupBy, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FacadeGQL_GetTopStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTopStats'
+type FacadeGQL_GetTopStats_Call struct {
+	*mock.Call
+}
+
+// GetTopStats is a helper method to define mock.On call
+//   - filter *dto.StatsFilter
+//   - groupBy string
+//   - limit int
+func (_e *FacadeGQL_Expecter) GetTopStats(filter interface{}, groupBy interface{}, limit interface{}) *FacadeGQL_GetTopStats_Call {
+	return &FacadeGQL_GetTopStats_Call{Call: _e.mock.On("GetTopStats", filter, groupBy, limit)}
+}
+
+func (_c *FacadeGQL_GetTopStats_Call) Run(run func(filter *dto.StatsFilter, groupBy string, limit int)) *FacadeGQL_GetTopStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*dto.StatsFilter), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *FacadeGQL_GetTopStats_Call) Return(_a0 []*dto.Stats, _a1 error) *FacadeGQL_GetTopStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FacadeGQL_GetTopStats_Call) RunAndReturn(run func(*dto.StatsFilter, string, int) ([]*dto.Stats, error)) *FacadeGQL_GetTopStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewFacadeGQL creates a new instance of FacadeGQL. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewFacadeGQL(t interface {
@@ -33,6 +33,9 @@ type authService interface {
 //go:generate mockery --name=facadeGQL --exported
 type facadeGQL interface {
 	GetStatus() (*dto.Status, error)
+	GetStats(filter *dto.StatsFilter) ([]*dto.Stats, error)
+	GetTopStats(filter *dto.StatsFilter, groupBy string, limit int) ([]*dto.Stats, error)
+	GetExplain(link string) (*dto.Journal, error)
 }
 
 // Transport представляет HTTP маршрутизатор для API
@@ -43,6 +46,7 @@ type Transport struct {
 	facadeGQL   facadeGQL
 	authState   client.AuthorizationState
 	server      *http.Server
+	port        string
 }
 
 // New создает новый экземпляр HTTP маршрутизатора
@@ -55,9 +59,16 @@ func New(
 		//
 		authService: authService,
 		facadeGQL:   facadeGQL,
+		port:        config.Web.Port,
 	}
 }
 
+// WithPort задаёт порт вместо config.Web.Port (для запуска из engine рядом с facade)
+func (t *Transport) WithPort(port string) *Transport {
+	t.port = port
+	return t
+}
+
 // logMiddleware добавляет логирование времени выполнения запросов
 func (t *Transport) logMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -78,10 +89,10 @@ func (t *Transport) logMiddleware(next http.Handler) http.Handler {
 func (t *Transport) StartContext(ctx context.Context, shutdown func()) error {
 	_ = shutdown // пока не используется
 
-	addr := net.JoinHostPort(config.Web.Host, config.Web.Port)
+	addr := net.JoinHostPort(config.Web.Host, t.port)
 	if !util.IsPortFree(addr) {
 		return log.NewError(
-			fmt.Sprintf("port is busy -> task kill-port -- %s", config.Grpc.Port),
+			fmt.Sprintf("port is busy -> task kill-port -- %s", t.port),
 			"addr", addr,
 		)
 	}
@@ -130,7 +141,7 @@ func (t *Transport) createServer() {
 
 	// Настраиваем HTTP-сервер
 	t.server = &http.Server{
-		Addr:         net.JoinHostPort(config.Web.Host, config.Web.Port),
+		Addr:         net.JoinHostPort(config.Web.Host, t.port),
 		Handler:      handler,
 		ReadTimeout:  config.Web.ReadTimeout,
 		WriteTimeout: config.Web.WriteTimeout,